@@ -0,0 +1,360 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package baseline checks the security configuration of a zone against a set of checks.
+//
+// A Linter reads the TLS, HTTPS, HSTS, cipher, WAF and security level settings of a zone into a State and runs its
+// checks on it. Each failed check gives a Finding with a severity and the update call that fixes it. DefaultChecks
+// returns the checks the Linter starts with; AddCheck adds more.
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/networking-go-sdk/firewallapiv1"
+	"github.com/IBM/networking-go-sdk/sslcertificateapiv1"
+	"github.com/IBM/networking-go-sdk/wafapiv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+)
+
+// Constants associated with the Check.Severity property.
+const (
+	Check_Severity_Critical = "critical"
+	Check_Severity_High     = "high"
+	Check_Severity_Low      = "low"
+	Check_Severity_Medium   = "medium"
+)
+
+// severityRanks orders the severities, the most severe first.
+var severityRanks = map[string]int{
+	Check_Severity_Critical: 0,
+	Check_Severity_High:     1,
+	Check_Severity_Medium:   2,
+	Check_Severity_Low:      3,
+}
+
+// State : The configuration of a zone that the checks look at. The fields of the settings that could not be read are
+// nil, and Errors tells why.
+type State struct {
+	// The min_tls_version, always_use_https, security_header, ciphers and opportunistic_encryption settings.
+	Settings zonessettingsv1.ZoneSettingsProfile
+
+	// The SSL mode: off, flexible, full or strict.
+	SslMode *string
+
+	// Whether only TLS 1.2 and later are accepted: on or off.
+	Tls12Only *string
+
+	// Whether TLS 1.3 is enabled: on, off or zrt.
+	Tls13 *string
+
+	// Whether the WAF is enabled: on or off.
+	Waf *string
+
+	// One of the firewallapiv1.SetSecurityLevelSettingOptions_Value constants.
+	SecurityLevel *string
+
+	// The errors of the settings that could not be read, by setting name.
+	Errors map[string]error
+}
+
+// Require returns nil when the settings are set, and else an error telling which setting is missing and why.
+func (state *State) Require(settings ...string) error {
+	for _, setting := range settings {
+		if err, ok := state.Errors[setting]; ok {
+			return fmt.Errorf("%s could not be read: %w", setting, err)
+		}
+	}
+	return nil
+}
+
+// Fix : The update call that fixes a finding.
+type Fix struct {
+	// The client method to call, such as 'zonessettingsv1.UpdateMinTlsVersion'.
+	Method string
+
+	// The options of the call, such as a *zonessettingsv1.UpdateMinTlsVersionOptions.
+	Options interface{}
+
+	// Make the call with the clients of a linter.
+	Apply func(ctx context.Context, linter *Linter) error
+}
+
+func (fix *Fix) String() string {
+	return fix.Method
+}
+
+// Check : A rule that the configuration of a zone should follow.
+type Check struct {
+	// A unique ID, such as 'min-tls-version'.
+	ID string
+
+	Description string
+
+	// One of the Check_Severity constants.
+	Severity string
+
+	// Evaluate returns nil when the state passes the check, or a finding with a message and a fix when it does not.
+	// It returns an error when the state lacks a setting it needs.
+	Evaluate func(state *State) (finding *Finding, err error)
+}
+
+// Finding : A check that a zone failed.
+type Finding struct {
+	// The ID of the check.
+	Check string
+
+	// The severity of the check.
+	Severity string
+
+	// What is wrong.
+	Message string
+
+	// The update call that fixes it. Nil when there is no single call to make.
+	Fix *Fix
+}
+
+func (finding Finding) String() string {
+	if finding.Fix == nil {
+		return fmt.Sprintf("[%s] %s: %s", finding.Severity, finding.Check, finding.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s (fix: %s)", finding.Severity, finding.Check, finding.Message, finding.Fix)
+}
+
+// Skipped : A check that could not be evaluated.
+type Skipped struct {
+	Check string
+
+	Err error
+}
+
+// Report : The results of the checks of a zone.
+type Report struct {
+	// The findings, the most severe first.
+	Findings []Finding
+
+	// The number of checks that passed.
+	Passed int
+
+	Skipped []Skipped
+}
+
+func (report *Report) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d passed, %d failed, %d skipped\n", report.Passed, len(report.Findings), len(report.Skipped))
+	for _, finding := range report.Findings {
+		fmt.Fprintf(&builder, "  %s\n", finding)
+	}
+	for _, skipped := range report.Skipped {
+		fmt.Fprintf(&builder, "  skipped %s: %s\n", skipped.Check, skipped.Err)
+	}
+	return builder.String()
+}
+
+// Linter : Checks the configuration of a zone. Each client is optional; the settings of a missing client are not
+// read, and the checks that need them are skipped.
+type Linter struct {
+	ZonesSettings *zonessettingsv1.ZonesSettingsV1
+
+	SslCertificate *sslcertificateapiv1.SslCertificateApiV1
+
+	Waf *wafapiv1.WafApiV1
+
+	Firewall *firewallapiv1.FirewallApiV1
+
+	Checks []Check
+}
+
+// NewLinter : Instantiate Linter with the default checks
+func NewLinter(zonesSettings *zonessettingsv1.ZonesSettingsV1, sslCertificate *sslcertificateapiv1.SslCertificateApiV1, waf *wafapiv1.WafApiV1, firewall *firewallapiv1.FirewallApiV1) (linter *Linter, err error) {
+	if zonesSettings == nil && sslCertificate == nil && waf == nil && firewall == nil {
+		err = fmt.Errorf("at least one client is required")
+		return
+	}
+	linter = &Linter{
+		ZonesSettings:  zonesSettings,
+		SslCertificate: sslCertificate,
+		Waf:            waf,
+		Firewall:       firewall,
+		Checks:         DefaultChecks(),
+	}
+	return
+}
+
+// AddCheck adds a check to the linter.
+func (linter *Linter) AddCheck(check Check) error {
+	if check.ID == "" || check.Evaluate == nil {
+		return fmt.Errorf("checks need an ID and an Evaluate function")
+	}
+	if _, ok := severityRanks[check.Severity]; !ok {
+		return fmt.Errorf("check %s has an unknown severity %q", check.ID, check.Severity)
+	}
+	for _, existing := range linter.Checks {
+		if existing.ID == check.ID {
+			return fmt.Errorf("check %s already exists", check.ID)
+		}
+	}
+	linter.Checks = append(linter.Checks, check)
+	return nil
+}
+
+// ReadState : Read the configuration of the zone
+// Read the settings that the checks look at. The settings that cannot be read are recorded in the errors of the
+// state rather than failing the read.
+func (linter *Linter) ReadState() (state *State) {
+	return linter.ReadStateWithContext(context.Background())
+}
+
+// ReadStateWithContext is an alternate form of the ReadState method which supports a Context parameter
+func (linter *Linter) ReadStateWithContext(ctx context.Context) (state *State) {
+	state = &State{Errors: map[string]error{}}
+	record := func(setting string, err error) {
+		if err != nil {
+			state.Errors[setting] = err
+		}
+	}
+
+	if settings := linter.ZonesSettings; settings != nil {
+		minTlsVersion, _, err := settings.GetMinTlsVersionWithContext(ctx, settings.NewGetMinTlsVersionOptions())
+		if record("min_tls_version", err); err == nil && minTlsVersion.Result != nil {
+			state.Settings.MinTlsVersion = minTlsVersion.Result.Value
+		}
+		alwaysUseHttps, _, err := settings.GetAlwaysUseHttpsWithContext(ctx, settings.NewGetAlwaysUseHttpsOptions())
+		if record("always_use_https", err); err == nil && alwaysUseHttps.Result != nil {
+			state.Settings.AlwaysUseHttps = alwaysUseHttps.Result.Value
+		}
+		securityHeader, _, err := settings.GetSecurityHeaderWithContext(ctx, settings.NewGetSecurityHeaderOptions())
+		if record("security_header", err); err == nil && securityHeader.Result != nil && securityHeader.Result.Value != nil {
+			state.Settings.SecurityHeader = &zonessettingsv1.SecurityHeaderSettingValue{}
+			if hsts := securityHeader.Result.Value.StrictTransportSecurity; hsts != nil {
+				state.Settings.SecurityHeader.StrictTransportSecurity = &zonessettingsv1.SecurityHeaderSettingValueStrictTransportSecurity{
+					Enabled:           hsts.Enabled,
+					MaxAge:            hsts.MaxAge,
+					IncludeSubdomains: hsts.IncludeSubdomains,
+					Preload:           hsts.Preload,
+					Nosniff:           hsts.Nosniff,
+				}
+			}
+		}
+		ciphers, _, err := settings.GetCiphersWithContext(ctx, settings.NewGetCiphersOptions())
+		if record("ciphers", err); err == nil && ciphers.Result != nil {
+			state.Settings.Ciphers = append([]string{}, ciphers.Result.Value...)
+		}
+		opportunisticEncryption, _, err := settings.GetOpportunisticEncryptionWithContext(ctx, settings.NewGetOpportunisticEncryptionOptions())
+		if record("opportunistic_encryption", err); err == nil && opportunisticEncryption.Result != nil {
+			state.Settings.OpportunisticEncryption = opportunisticEncryption.Result.Value
+		}
+	} else {
+		for _, setting := range []string{"min_tls_version", "always_use_https", "security_header", "ciphers", "opportunistic_encryption"} {
+			record(setting, errNoClient)
+		}
+	}
+
+	if ssl := linter.SslCertificate; ssl != nil {
+		sslSetting, _, err := ssl.GetSslSettingWithContext(ctx, ssl.NewGetSslSettingOptions())
+		if record("ssl", err); err == nil && sslSetting.Result != nil {
+			state.SslMode = sslSetting.Result.Value
+		}
+		tls12, _, err := ssl.GetTls12SettingWithContext(ctx, ssl.NewGetTls12SettingOptions())
+		if record("tls_1_2_only", err); err == nil && tls12.Result != nil {
+			state.Tls12Only = tls12.Result.Value
+		}
+		tls13, _, err := ssl.GetTls13SettingWithContext(ctx, ssl.NewGetTls13SettingOptions())
+		if record("tls_1_3", err); err == nil && tls13.Result != nil {
+			state.Tls13 = tls13.Result.Value
+		}
+	} else {
+		record("ssl", errNoClient)
+		record("tls_1_2_only", errNoClient)
+		record("tls_1_3", errNoClient)
+	}
+
+	if linter.Waf != nil {
+		waf, _, err := linter.Waf.GetWafSettingsWithContext(ctx, linter.Waf.NewGetWafSettingsOptions())
+		if record("waf", err); err == nil && waf.Result != nil {
+			state.Waf = waf.Result.Value
+		}
+	} else {
+		record("waf", errNoClient)
+	}
+
+	if linter.Firewall != nil {
+		securityLevel, _, err := linter.Firewall.GetSecurityLevelSettingWithContext(ctx, linter.Firewall.NewGetSecurityLevelSettingOptions())
+		if record("security_level", err); err == nil && securityLevel.Result != nil {
+			state.SecurityLevel = securityLevel.Result.Value
+		}
+	} else {
+		record("security_level", errNoClient)
+	}
+	return
+}
+
+// Evaluate runs the checks of the linter on a state.
+func (linter *Linter) Evaluate(state *State) (report *Report) {
+	report = &Report{}
+	for _, check := range linter.Checks {
+		finding, err := check.Evaluate(state)
+		switch {
+		case err != nil:
+			report.Skipped = append(report.Skipped, Skipped{Check: check.ID, Err: err})
+		case finding == nil:
+			report.Passed++
+		default:
+			finding.Check = check.ID
+			finding.Severity = check.Severity
+			report.Findings = append(report.Findings, *finding)
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return severityRanks[report.Findings[i].Severity] < severityRanks[report.Findings[j].Severity]
+	})
+	return
+}
+
+// Lint : Check the configuration of the zone
+// Read the configuration of the zone and run the checks of the linter on it.
+func (linter *Linter) Lint() (report *Report) {
+	return linter.LintWithContext(context.Background())
+}
+
+// LintWithContext is an alternate form of the Lint method which supports a Context parameter
+func (linter *Linter) LintWithContext(ctx context.Context) (report *Report) {
+	return linter.Evaluate(linter.ReadStateWithContext(ctx))
+}
+
+// Fix : Fix findings
+// Make the update call of each finding that has a fix, in order, and stop at the first error.
+func (linter *Linter) Fix(findings []Finding) (fixed int, err error) {
+	return linter.FixWithContext(context.Background(), findings)
+}
+
+// FixWithContext is an alternate form of the Fix method which supports a Context parameter
+func (linter *Linter) FixWithContext(ctx context.Context, findings []Finding) (fixed int, err error) {
+	for _, finding := range findings {
+		if finding.Fix == nil {
+			continue
+		}
+		if err = finding.Fix.Apply(ctx, linter); err != nil {
+			err = fmt.Errorf("fixing %s with %s: %w", finding.Check, finding.Fix, err)
+			return
+		}
+		fixed++
+	}
+	return
+}
@@ -0,0 +1,201 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseline_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/baseline"
+	"github.com/IBM/networking-go-sdk/firewallapiv1"
+	"github.com/IBM/networking-go-sdk/sslcertificateapiv1"
+	"github.com/IBM/networking-go-sdk/wafapiv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeZone keeps the settings of a zone by the last segment of their path.
+type fakeZone struct {
+	sync.Mutex
+	values  map[string]interface{}
+	updates []string
+	missing map[string]bool
+}
+
+func (api *fakeZone) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	name := path.Base(req.URL.Path)
+	res.Header().Set("Content-type", "application/json")
+	if api.missing[name] {
+		res.WriteHeader(403)
+		fmt.Fprint(res, `{"success": false, "errors": [{"code": 1000, "message": "not entitled"}], "messages": []}`)
+		return
+	}
+	if req.Method == "PATCH" {
+		var body map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		api.values[name] = body["value"]
+		encoded, _ := json.Marshal(body["value"])
+		api.updates = append(api.updates, fmt.Sprintf("%s %s", name, encoded))
+	}
+	result := map[string]interface{}{"id": name, "value": api.values[name], "editable": true, "modified_on": "2024-01-01T00:00:00Z"}
+	json.NewEncoder(res).Encode(map[string]interface{}{"success": true, "errors": []string{}, "messages": []string{}, "result": result})
+}
+
+func newFakeZone() *fakeZone {
+	return &fakeZone{
+		values: map[string]interface{}{
+			"min_tls_version":          "1.0",
+			"always_use_https":         "off",
+			"ciphers":                  []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA", "DES-CBC3-SHA"},
+			"opportunistic_encryption": "on",
+			"security_header": map[string]interface{}{"strict_transport_security": map[string]interface{}{
+				"enabled": true, "max_age": 3600, "include_subdomains": false, "preload": true, "nosniff": false,
+			}},
+			"ssl":            "flexible",
+			"tls_1_2_only":   "off",
+			"tls_1_3":        "on",
+			"waf":            "off",
+			"security_level": "medium",
+		},
+		missing: map[string]bool{},
+	}
+}
+
+var _ = Describe(`Linter`, func() {
+	var (
+		api    *fakeZone
+		server *httptest.Server
+		linter *baseline.Linter
+	)
+
+	BeforeEach(func() {
+		api = newFakeZone()
+		server = httptest.NewServer(api)
+		zonesSettings, err := zonessettingsv1.NewZonesSettingsV1(&zonessettingsv1.ZonesSettingsV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		sslCertificate, err := sslcertificateapiv1.NewSslCertificateApiV1(&sslcertificateapiv1.SslCertificateApiV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		waf, err := wafapiv1.NewWafApiV1(&wafapiv1.WafApiV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneID: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		firewall, err := firewallapiv1.NewFirewallApiV1(&firewallapiv1.FirewallApiV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		linter, err = baseline.NewLinter(zonesSettings, sslCertificate, waf, firewall)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Reports the failed checks with their fix, the most severe first`, func() {
+		report := linter.Lint()
+		Expect(report.Skipped).To(BeEmpty())
+		Expect(report.Passed).To(Equal(3))
+		Expect(report.String()).To(Equal("3 passed, 6 failed, 0 skipped\n" +
+			"  [high] min-tls-version: the minimum TLS version is 1.0 (fix: zonessettingsv1.UpdateMinTlsVersion)\n" +
+			"  [high] ssl-mode: the SSL mode is flexible; strict also needs a valid certificate on the origin (fix: sslcertificateapiv1.ChangeSslSetting)\n" +
+			"  [high] waf: the WAF is off (fix: wafapiv1.UpdateWafSettings)\n" +
+			"  [medium] always-use-https: HTTP requests are not redirected to HTTPS (fix: zonessettingsv1.UpdateAlwaysUseHttps)\n" +
+			"  [medium] hsts: HSTS does not include the subdomains; the HSTS max age is 3600 seconds (fix: zonessettingsv1.UpdateSecurityHeader)\n" +
+			"  [medium] ciphers: weak ciphers are enabled: AES128-SHA, DES-CBC3-SHA (fix: zonessettingsv1.UpdateCiphers)\n"))
+
+		options, ok := report.Findings[0].Fix.Options.(*zonessettingsv1.UpdateMinTlsVersionOptions)
+		Expect(ok).To(BeTrue())
+		Expect(*options.Value).To(Equal("1.2"))
+	})
+
+	It(`Applies the fixes`, func() {
+		fixed, err := linter.Fix(linter.Lint().Findings)
+		Expect(err).To(BeNil())
+		Expect(fixed).To(Equal(6))
+		Expect(api.updates).To(ConsistOf(
+			`min_tls_version "1.2"`,
+			`ssl "strict"`,
+			`waf "on"`,
+			`always_use_https "on"`,
+			`security_header {"strict_transport_security":{"enabled":true,"include_subdomains":true,"max_age":31536000,"nosniff":false,"preload":true}}`,
+			`ciphers ["ECDHE-RSA-AES128-GCM-SHA256"]`,
+		))
+
+		report := linter.Lint()
+		Expect(report.Findings).To(BeEmpty())
+		Expect(report.Passed).To(Equal(9))
+	})
+
+	It(`Accepts TLS 1.2 only in place of the minimum TLS version`, func() {
+		api.values["tls_1_2_only"] = "on"
+		for _, finding := range linter.Lint().Findings {
+			Expect(finding.Check).ToNot(Equal("min-tls-version"))
+		}
+	})
+
+	It(`Skips the checks of the settings it cannot read`, func() {
+		api.missing["waf"] = true
+		linter.Firewall = nil
+		report := linter.Lint()
+		Expect(report.Skipped).To(HaveLen(2))
+		Expect(report.Skipped[0].Check).To(Equal("waf"))
+		Expect(report.Skipped[0].Err.Error()).To(HavePrefix("waf could not be read: "))
+		Expect(report.Skipped[1].Check).To(Equal("security-level"))
+		Expect(report.Skipped[1].Err).To(MatchError("security_level could not be read: the linter has no client for it"))
+	})
+
+	It(`Runs added checks`, func() {
+		Expect(linter.AddCheck(baseline.Check{ID: "waf", Severity: baseline.Check_Severity_Low, Evaluate: func(*baseline.State) (*baseline.Finding, error) {
+			return nil, nil
+		}})).To(MatchError("check waf already exists"))
+		Expect(linter.AddCheck(baseline.Check{ID: "strict-ssl", Severity: "urgent", Evaluate: func(*baseline.State) (*baseline.Finding, error) {
+			return nil, nil
+		}})).To(MatchError(`check strict-ssl has an unknown severity "urgent"`))
+
+		err := linter.AddCheck(baseline.Check{
+			ID:       "strict-ssl",
+			Severity: baseline.Check_Severity_Critical,
+			Evaluate: func(state *baseline.State) (finding *baseline.Finding, err error) {
+				if err = state.Require("ssl"); err != nil || *state.SslMode == "strict" {
+					return
+				}
+				return &baseline.Finding{Message: "the SSL mode is not strict"}, nil
+			},
+		})
+		Expect(err).To(BeNil())
+		report := linter.Lint()
+		Expect(report.Findings[0].String()).To(Equal("[critical] strict-ssl: the SSL mode is not strict"))
+
+		fixed, err := linter.Fix(report.Findings[:1])
+		Expect(err).To(BeNil())
+		Expect(fixed).To(Equal(0))
+	})
+})
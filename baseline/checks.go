@@ -0,0 +1,361 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/firewallapiv1"
+	"github.com/IBM/networking-go-sdk/sslcertificateapiv1"
+	"github.com/IBM/networking-go-sdk/wafapiv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+)
+
+// MinHstsMaxAge is the shortest HSTS max age, in seconds, that the hsts check accepts: six months.
+const MinHstsMaxAge = 15552000
+
+// hstsMaxAge is the max age that the fix of the hsts check sets: a year.
+const hstsMaxAge = 31536000
+
+// WeakCiphers are the ciphers that the ciphers check rejects: 3DES, and the suites without forward secrecy.
+var WeakCiphers = []string{
+	zonessettingsv1.UpdateCiphersOptions_Value_DesCbc3Sha,
+	zonessettingsv1.UpdateCiphersOptions_Value_Aes128GcmSha256,
+	zonessettingsv1.UpdateCiphersOptions_Value_Aes128Sha,
+	zonessettingsv1.UpdateCiphersOptions_Value_Aes128Sha256,
+	zonessettingsv1.UpdateCiphersOptions_Value_Aes256GcmSha384,
+	zonessettingsv1.UpdateCiphersOptions_Value_Aes256Sha,
+	zonessettingsv1.UpdateCiphersOptions_Value_Aes256Sha256,
+}
+
+// strongCiphers are the ciphers that the fix of the ciphers check sets when no cipher of the zone is left.
+var strongCiphers = []string{
+	zonessettingsv1.UpdateCiphersOptions_Value_EcdheEcdsaAes128GcmSha256,
+	zonessettingsv1.UpdateCiphersOptions_Value_EcdheEcdsaAes256GcmSha384,
+	zonessettingsv1.UpdateCiphersOptions_Value_EcdheEcdsaChacha20Poly1305,
+	zonessettingsv1.UpdateCiphersOptions_Value_EcdheRsaAes128GcmSha256,
+	zonessettingsv1.UpdateCiphersOptions_Value_EcdheRsaAes256GcmSha384,
+	zonessettingsv1.UpdateCiphersOptions_Value_EcdheRsaChacha20Poly1305,
+}
+
+var errNoClient = fmt.Errorf("the linter has no client for it")
+
+// DefaultChecks returns the checks that a new linter starts with.
+func DefaultChecks() []Check {
+	return []Check{
+		{
+			ID:          "min-tls-version",
+			Description: "Clients must use TLS 1.2 or later.",
+			Severity:    Check_Severity_High,
+			Evaluate:    checkMinTlsVersion,
+		},
+		{
+			ID:          "ssl-mode",
+			Description: "Traffic to the origin must be encrypted: the SSL mode must be full or strict.",
+			Severity:    Check_Severity_High,
+			Evaluate:    checkSslMode,
+		},
+		{
+			ID:          "waf",
+			Description: "The WAF must be on.",
+			Severity:    Check_Severity_High,
+			Evaluate:    checkWaf,
+		},
+		{
+			ID:          "always-use-https",
+			Description: "HTTP requests must be redirected to HTTPS.",
+			Severity:    Check_Severity_Medium,
+			Evaluate:    checkAlwaysUseHttps,
+		},
+		{
+			ID:          "hsts",
+			Description: "HSTS must be on for the subdomains too, with a max age of six months or more.",
+			Severity:    Check_Severity_Medium,
+			Evaluate:    checkHsts,
+		},
+		{
+			ID:          "ciphers",
+			Description: "The ciphers must not include 3DES or suites without forward secrecy. The default ciphers are not checked.",
+			Severity:    Check_Severity_Medium,
+			Evaluate:    checkCiphers,
+		},
+		{
+			ID:          "security-level",
+			Description: "The security level must not be essentially off.",
+			Severity:    Check_Severity_Medium,
+			Evaluate:    checkSecurityLevel,
+		},
+		{
+			ID:          "tls-1-3",
+			Description: "TLS 1.3 should be on.",
+			Severity:    Check_Severity_Low,
+			Evaluate:    checkTls13,
+		},
+		{
+			ID:          "opportunistic-encryption",
+			Description: "Opportunistic encryption should be on.",
+			Severity:    Check_Severity_Low,
+			Evaluate:    checkOpportunisticEncryption,
+		},
+	}
+}
+
+func checkMinTlsVersion(state *State) (finding *Finding, err error) {
+	if err = state.Require("min_tls_version"); err != nil {
+		return
+	}
+	// The TLS 1.2 only setting is enough when it can be read.
+	if state.Tls12Only != nil && *state.Tls12Only == sslcertificateapiv1.ChangeTls12SettingOptions_Value_On {
+		return
+	}
+	version := core.StringNilMapper(state.Settings.MinTlsVersion)
+	if version >= "1.2" {
+		return
+	}
+	options := (&zonessettingsv1.ZonesSettingsV1{}).NewUpdateMinTlsVersionOptions().SetValue("1.2")
+	finding = &Finding{
+		Message: fmt.Sprintf("the minimum TLS version is %s", version),
+		Fix: &Fix{Method: "zonessettingsv1.UpdateMinTlsVersion", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.ZonesSettings == nil {
+				return errNoClient
+			}
+			_, _, err := linter.ZonesSettings.UpdateMinTlsVersionWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkSslMode(state *State) (finding *Finding, err error) {
+	if err = state.Require("ssl"); err != nil {
+		return
+	}
+	mode := core.StringNilMapper(state.SslMode)
+	if mode == sslcertificateapiv1.ChangeSslSettingOptions_Value_Full || mode == sslcertificateapiv1.ChangeSslSettingOptions_Value_Strict {
+		return
+	}
+	options := (&sslcertificateapiv1.SslCertificateApiV1{}).NewChangeSslSettingOptions().SetValue(sslcertificateapiv1.ChangeSslSettingOptions_Value_Strict)
+	finding = &Finding{
+		Message: fmt.Sprintf("the SSL mode is %s; strict also needs a valid certificate on the origin", mode),
+		Fix: &Fix{Method: "sslcertificateapiv1.ChangeSslSetting", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.SslCertificate == nil {
+				return errNoClient
+			}
+			_, _, err := linter.SslCertificate.ChangeSslSettingWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkWaf(state *State) (finding *Finding, err error) {
+	if err = state.Require("waf"); err != nil {
+		return
+	}
+	if core.StringNilMapper(state.Waf) == wafapiv1.UpdateWafSettingsOptions_Value_On {
+		return
+	}
+	options := (&wafapiv1.WafApiV1{}).NewUpdateWafSettingsOptions().SetValue(wafapiv1.UpdateWafSettingsOptions_Value_On)
+	finding = &Finding{
+		Message: "the WAF is off",
+		Fix: &Fix{Method: "wafapiv1.UpdateWafSettings", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.Waf == nil {
+				return errNoClient
+			}
+			_, _, err := linter.Waf.UpdateWafSettingsWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkAlwaysUseHttps(state *State) (finding *Finding, err error) {
+	if err = state.Require("always_use_https"); err != nil {
+		return
+	}
+	if core.StringNilMapper(state.Settings.AlwaysUseHttps) == zonessettingsv1.UpdateAlwaysUseHttpsOptions_Value_On {
+		return
+	}
+	options := (&zonessettingsv1.ZonesSettingsV1{}).NewUpdateAlwaysUseHttpsOptions().SetValue(zonessettingsv1.UpdateAlwaysUseHttpsOptions_Value_On)
+	finding = &Finding{
+		Message: "HTTP requests are not redirected to HTTPS",
+		Fix: &Fix{Method: "zonessettingsv1.UpdateAlwaysUseHttps", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.ZonesSettings == nil {
+				return errNoClient
+			}
+			_, _, err := linter.ZonesSettings.UpdateAlwaysUseHttpsWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkHsts(state *State) (finding *Finding, err error) {
+	if err = state.Require("security_header"); err != nil {
+		return
+	}
+	hsts := &zonessettingsv1.SecurityHeaderSettingValueStrictTransportSecurity{}
+	if state.Settings.SecurityHeader != nil && state.Settings.SecurityHeader.StrictTransportSecurity != nil {
+		hsts = state.Settings.SecurityHeader.StrictTransportSecurity
+	}
+	enabled := hsts.Enabled != nil && *hsts.Enabled
+	includeSubdomains := hsts.IncludeSubdomains != nil && *hsts.IncludeSubdomains
+	var maxAge int64
+	if hsts.MaxAge != nil {
+		maxAge = *hsts.MaxAge
+	}
+	var problems []string
+	if !enabled {
+		problems = append(problems, "HSTS is off")
+	}
+	if enabled && !includeSubdomains {
+		problems = append(problems, "HSTS does not include the subdomains")
+	}
+	if enabled && maxAge < MinHstsMaxAge {
+		problems = append(problems, fmt.Sprintf("the HSTS max age is %d seconds", maxAge))
+	}
+	if len(problems) == 0 {
+		return
+	}
+	if maxAge < MinHstsMaxAge {
+		maxAge = hstsMaxAge
+	}
+	// The fix keeps the preload setting, which is hard to undo, and the nosniff setting, which is not checked.
+	value := &zonessettingsv1.SecurityHeaderSettingValue{
+		StrictTransportSecurity: &zonessettingsv1.SecurityHeaderSettingValueStrictTransportSecurity{
+			Enabled:           core.BoolPtr(true),
+			MaxAge:            core.Int64Ptr(maxAge),
+			IncludeSubdomains: core.BoolPtr(true),
+			Preload:           core.BoolPtr(hsts.Preload != nil && *hsts.Preload),
+			Nosniff:           core.BoolPtr(hsts.Nosniff != nil && *hsts.Nosniff),
+		},
+	}
+	options := (&zonessettingsv1.ZonesSettingsV1{}).NewUpdateSecurityHeaderOptions().SetValue(value)
+	finding = &Finding{
+		Message: strings.Join(problems, "; "),
+		Fix: &Fix{Method: "zonessettingsv1.UpdateSecurityHeader", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.ZonesSettings == nil {
+				return errNoClient
+			}
+			_, _, err := linter.ZonesSettings.UpdateSecurityHeaderWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkCiphers(state *State) (finding *Finding, err error) {
+	if err = state.Require("ciphers"); err != nil {
+		return
+	}
+	weak := map[string]bool{}
+	for _, cipher := range WeakCiphers {
+		weak[cipher] = true
+	}
+	var found, kept []string
+	for _, cipher := range state.Settings.Ciphers {
+		if weak[cipher] {
+			found = append(found, cipher)
+		} else {
+			kept = append(kept, cipher)
+		}
+	}
+	if len(found) == 0 {
+		return
+	}
+	if len(kept) == 0 {
+		kept = strongCiphers
+	}
+	options := (&zonessettingsv1.ZonesSettingsV1{}).NewUpdateCiphersOptions().SetValue(kept)
+	finding = &Finding{
+		Message: fmt.Sprintf("weak ciphers are enabled: %s", strings.Join(found, ", ")),
+		Fix: &Fix{Method: "zonessettingsv1.UpdateCiphers", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.ZonesSettings == nil {
+				return errNoClient
+			}
+			_, _, err := linter.ZonesSettings.UpdateCiphersWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkSecurityLevel(state *State) (finding *Finding, err error) {
+	if err = state.Require("security_level"); err != nil {
+		return
+	}
+	if core.StringNilMapper(state.SecurityLevel) != firewallapiv1.SetSecurityLevelSettingOptions_Value_EssentiallyOff {
+		return
+	}
+	options := (&firewallapiv1.FirewallApiV1{}).NewSetSecurityLevelSettingOptions().SetValue(firewallapiv1.SetSecurityLevelSettingOptions_Value_Medium)
+	finding = &Finding{
+		Message: "the security level is essentially off",
+		Fix: &Fix{Method: "firewallapiv1.SetSecurityLevelSetting", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.Firewall == nil {
+				return errNoClient
+			}
+			_, _, err := linter.Firewall.SetSecurityLevelSettingWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkTls13(state *State) (finding *Finding, err error) {
+	if err = state.Require("tls_1_3"); err != nil {
+		return
+	}
+	if core.StringNilMapper(state.Tls13) != sslcertificateapiv1.ChangeTls13SettingOptions_Value_Off {
+		return
+	}
+	options := (&sslcertificateapiv1.SslCertificateApiV1{}).NewChangeTls13SettingOptions().SetValue(sslcertificateapiv1.ChangeTls13SettingOptions_Value_On)
+	finding = &Finding{
+		Message: "TLS 1.3 is off",
+		Fix: &Fix{Method: "sslcertificateapiv1.ChangeTls13Setting", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.SslCertificate == nil {
+				return errNoClient
+			}
+			_, _, err := linter.SslCertificate.ChangeTls13SettingWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
+
+func checkOpportunisticEncryption(state *State) (finding *Finding, err error) {
+	if err = state.Require("opportunistic_encryption"); err != nil {
+		return
+	}
+	if core.StringNilMapper(state.Settings.OpportunisticEncryption) == zonessettingsv1.UpdateOpportunisticEncryptionOptions_Value_On {
+		return
+	}
+	options := (&zonessettingsv1.ZonesSettingsV1{}).NewUpdateOpportunisticEncryptionOptions().SetValue(zonessettingsv1.UpdateOpportunisticEncryptionOptions_Value_On)
+	finding = &Finding{
+		Message: "opportunistic encryption is off",
+		Fix: &Fix{Method: "zonessettingsv1.UpdateOpportunisticEncryption", Options: options, Apply: func(ctx context.Context, linter *Linter) error {
+			if linter.ZonesSettings == nil {
+				return errNoClient
+			}
+			_, _, err := linter.ZonesSettings.UpdateOpportunisticEncryptionWithContext(ctx, options)
+			return err
+		}},
+	}
+	return
+}
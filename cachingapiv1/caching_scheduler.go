@@ -0,0 +1,699 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cachingapiv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/common"
+)
+
+// DefaultScheduleRetryInterval is the wait before a failed restore is tried again.
+const DefaultScheduleRetryInterval = time.Minute
+
+// CachingProfile : Caching settings of a zone. The nil settings are left as they are.
+type CachingProfile struct {
+	// on/off value, one of the UpdateDevelopmentModeOptions_Value constants.
+	DevelopmentMode *string `json:"development_mode,omitempty"`
+
+	// cache level, one of the UpdateCacheLevelOptions_Value constants.
+	CacheLevel *string `json:"cache_level,omitempty"`
+
+	// browser cache ttl in seconds.
+	BrowserCacheTTL *int64 `json:"browser_cache_ttl,omitempty"`
+}
+
+// ScheduledRestore : The settings a zone had before a profile was applied, and when to restore them.
+type ScheduledRestore struct {
+	// The ID of the schedule.
+	ID string `json:"id"`
+
+	// The CRN and ID of the zone.
+	Crn    string `json:"crn"`
+	ZoneID string `json:"zone_id"`
+
+	// The applied profile.
+	Applied CachingProfile `json:"applied"`
+
+	// The settings to restore, those of the applied profile as they were before.
+	Previous CachingProfile `json:"previous"`
+
+	// When to restore the settings.
+	Until time.Time `json:"until"`
+}
+
+// Remaining returns the time until the settings are restored, 0 when it is overdue.
+func (restore *ScheduledRestore) Remaining() time.Duration {
+	remaining := time.Until(restore.Until)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// developmentModeTimeRemaining : The part of the development mode response that the generated
+// DeveopmentModeResponse does not read.
+type developmentModeTimeRemaining struct {
+	Result *struct {
+		// seconds until development mode turns itself off, 0 when it is off.
+		TimeRemaining *int64 `json:"time_remaining,omitempty"`
+	} `json:"result,omitempty"`
+}
+
+// GetDevelopmentModeTimeRemaining : Get the time until development mode turns itself off
+// Get the time until development mode turns itself off, 0 when it is off.
+func (cachingApi *CachingApiV1) GetDevelopmentModeTimeRemaining(getDevelopmentModeOptions *GetDevelopmentModeOptions) (remaining time.Duration, response *core.DetailedResponse, err error) {
+	return cachingApi.GetDevelopmentModeTimeRemainingWithContext(context.Background(), getDevelopmentModeOptions)
+}
+
+// GetDevelopmentModeTimeRemainingWithContext is an alternate form of the GetDevelopmentModeTimeRemaining method which supports a Context parameter
+func (cachingApi *CachingApiV1) GetDevelopmentModeTimeRemainingWithContext(ctx context.Context, getDevelopmentModeOptions *GetDevelopmentModeOptions) (remaining time.Duration, response *core.DetailedResponse, err error) {
+	err = core.ValidateStruct(getDevelopmentModeOptions, "getDevelopmentModeOptions")
+	if err != nil {
+		return
+	}
+
+	pathParamsMap := map[string]string{
+		"crn":     *cachingApi.Crn,
+		"zone_id": *cachingApi.ZoneID,
+	}
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = cachingApi.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(cachingApi.Service.Options.URL, `/v1/{crn}/zones/{zone_id}/settings/development_mode`, pathParamsMap)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range getDevelopmentModeOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	sdkHeaders := common.GetSdkHeaders("caching_api", "V1", "GetDevelopmentModeTimeRemaining")
+	for headerName, headerValue := range sdkHeaders {
+		builder.AddHeader(headerName, headerValue)
+	}
+	builder.AddHeader("Accept", "application/json")
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var result developmentModeTimeRemaining
+	response, err = cachingApi.Service.Request(request, &result)
+	if err != nil {
+		return
+	}
+	if result.Result != nil && result.Result.TimeRemaining != nil {
+		remaining = time.Duration(*result.Result.TimeRemaining) * time.Second
+	}
+	return
+}
+
+// ScheduleStore : Where a CachingScheduler keeps its pending restores, so that another process can resume them after
+// a restart. A store can be shared by the schedulers of several zones.
+type ScheduleStore interface {
+	// Save adds or replaces a restore.
+	Save(restore *ScheduledRestore) error
+
+	// Delete removes a restore, if present.
+	Delete(id string) error
+
+	// List returns all the restores.
+	List() ([]*ScheduledRestore, error)
+}
+
+// MemoryScheduleStore : A ScheduleStore that keeps the restores in memory, for the restores that need not survive
+// the process.
+type MemoryScheduleStore struct {
+	mutex    sync.Mutex
+	restores map[string]ScheduledRestore
+}
+
+// NewMemoryScheduleStore : Instantiate MemoryScheduleStore
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{restores: map[string]ScheduledRestore{}}
+}
+
+// Save adds or replaces a restore.
+func (store *MemoryScheduleStore) Save(restore *ScheduledRestore) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.restores[restore.ID] = *restore
+	return nil
+}
+
+// Delete removes a restore, if present.
+func (store *MemoryScheduleStore) Delete(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	delete(store.restores, id)
+	return nil
+}
+
+// List returns all the restores, by restore time.
+func (store *MemoryScheduleStore) List() (restores []*ScheduledRestore, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	for _, restore := range store.restores {
+		restore := restore
+		restores = append(restores, &restore)
+	}
+	sortRestores(restores)
+	return
+}
+
+// FileScheduleStore : A ScheduleStore that keeps the restores in a JSON file. The file is replaced as a whole on each
+// change, so that it is never left half written.
+type FileScheduleStore struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// NewFileScheduleStore : Instantiate FileScheduleStore
+func NewFileScheduleStore(path string) *FileScheduleStore {
+	return &FileScheduleStore{path: path}
+}
+
+// Save adds or replaces a restore.
+func (store *FileScheduleStore) Save(restore *ScheduledRestore) error {
+	return store.update(func(restores map[string]*ScheduledRestore) {
+		restores[restore.ID] = restore
+	})
+}
+
+// Delete removes a restore, if present.
+func (store *FileScheduleStore) Delete(id string) error {
+	return store.update(func(restores map[string]*ScheduledRestore) {
+		delete(restores, id)
+	})
+}
+
+// List returns all the restores, by restore time. A missing file holds no restores.
+func (store *FileScheduleStore) List() (restores []*ScheduledRestore, err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.read()
+}
+
+func (store *FileScheduleStore) read() (restores []*ScheduledRestore, err error) {
+	buffer, err := os.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(buffer, &restores); err != nil {
+		err = fmt.Errorf("the schedule file %s cannot be parsed: %w", store.path, err)
+	}
+	return
+}
+
+// update reads the restores, changes them and writes them to a temporary file that then replaces the file.
+func (store *FileScheduleStore) update(change func(restores map[string]*ScheduledRestore)) (err error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	list, err := store.read()
+	if err != nil {
+		return
+	}
+	restores := map[string]*ScheduledRestore{}
+	for _, restore := range list {
+		restores[restore.ID] = restore
+	}
+	change(restores)
+	list = nil
+	for _, restore := range restores {
+		list = append(list, restore)
+	}
+	sortRestores(list)
+	if list == nil {
+		list = []*ScheduledRestore{}
+	}
+	buffer, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return
+	}
+	file, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(buffer)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+	return os.Rename(file.Name(), store.path)
+}
+
+func sortRestores(restores []*ScheduledRestore) {
+	sort.Slice(restores, func(i, j int) bool {
+		if !restores[i].Until.Equal(restores[j].Until) {
+			return restores[i].Until.Before(restores[j].Until)
+		}
+		return restores[i].ID < restores[j].ID
+	})
+}
+
+// CachingSchedulerOptions : The CachingScheduler options.
+type CachingSchedulerOptions struct {
+	// Where to keep the pending restores. Defaults to a MemoryScheduleStore.
+	Store ScheduleStore
+
+	// The wait before a failed restore is tried again. Defaults to DefaultScheduleRetryInterval.
+	RetryInterval time.Duration
+
+	// Called after each restore made when its time comes, with the error if it failed. Optional.
+	OnRestore func(restore *ScheduledRestore, err error)
+}
+
+// CachingScheduler : Applies caching profiles to a zone for a time window, and restores the previous settings
+// afterwards. The pending restores are saved to a store before the profile is applied, and a scheduler created after
+// a restart takes them over with Resume. A scheduler can be used from several goroutines.
+type CachingScheduler struct {
+	cachingApi *CachingApiV1
+	options    CachingSchedulerOptions
+
+	mutex  sync.Mutex
+	timers map[string]*time.Timer
+
+	// restoring holds the restores being written back, so that a timer and Restore never write the same one.
+	restoring map[string]bool
+
+	// applying serializes Apply from the check of the pending restores to the write of the profile.
+	applying sync.Mutex
+}
+
+// NewCachingScheduler : Instantiate CachingScheduler
+func (cachingApi *CachingApiV1) NewCachingScheduler(cachingSchedulerOptions *CachingSchedulerOptions) (scheduler *CachingScheduler, err error) {
+	options := CachingSchedulerOptions{}
+	if cachingSchedulerOptions != nil {
+		options = *cachingSchedulerOptions
+	}
+	if options.RetryInterval < 0 {
+		err = fmt.Errorf("the retry interval cannot be negative")
+		return
+	}
+	if options.Store == nil {
+		options.Store = NewMemoryScheduleStore()
+	}
+	if options.RetryInterval == 0 {
+		options.RetryInterval = DefaultScheduleRetryInterval
+	}
+	scheduler = &CachingScheduler{
+		cachingApi: cachingApi,
+		options:    options,
+		timers:     map[string]*time.Timer{},
+		restoring:  map[string]bool{},
+	}
+	return
+}
+
+// Apply : Apply a caching profile for a time window
+// Read the settings of the zone that the profile changes, save them as a restore due after the duration, then apply
+// the profile. The settings are restored when the duration has passed, or earlier with Restore. The settings of a
+// profile cannot be scheduled while a restore of the same settings is pending; if applying the profile fails, the
+// settings already changed are restored at once. When restoring them fails too, the restore is returned with the
+// error and stays pending, to be tried again after RetryInterval.
+func (scheduler *CachingScheduler) Apply(profile *CachingProfile, duration time.Duration) (restore *ScheduledRestore, err error) {
+	return scheduler.ApplyWithContext(context.Background(), profile, duration)
+}
+
+// ApplyWithContext is an alternate form of the Apply method which supports a Context parameter
+func (scheduler *CachingScheduler) ApplyWithContext(ctx context.Context, profile *CachingProfile, duration time.Duration) (restore *ScheduledRestore, err error) {
+	err = core.ValidateNotNil(profile, "profile cannot be nil")
+	if err != nil {
+		return
+	}
+	if profile.DevelopmentMode == nil && profile.CacheLevel == nil && profile.BrowserCacheTTL == nil {
+		err = fmt.Errorf("the caching profile changes no setting")
+		return
+	}
+	if duration <= 0 {
+		err = fmt.Errorf("the duration of a caching profile must be positive")
+		return
+	}
+	scheduler.applying.Lock()
+	defer scheduler.applying.Unlock()
+	pending, err := scheduler.Pending()
+	if err != nil {
+		return
+	}
+	for _, other := range pending {
+		if overlaps(profile, &other.Applied) {
+			err = fmt.Errorf("the zone %s already has a pending restore %s of the same settings", other.ZoneID, other.ID)
+			return
+		}
+	}
+
+	id, err := newScheduleID()
+	if err != nil {
+		return
+	}
+	restore = &ScheduledRestore{
+		ID:      id,
+		Crn:     core.StringNilMapper(scheduler.cachingApi.Crn),
+		ZoneID:  core.StringNilMapper(scheduler.cachingApi.ZoneID),
+		Applied: *profile,
+		Until:   time.Now().Add(duration),
+	}
+	restore.Previous, err = scheduler.read(ctx, profile)
+	if err != nil {
+		restore = nil
+		return
+	}
+	if err = scheduler.options.Store.Save(restore); err != nil {
+		restore = nil
+		return
+	}
+	if rolledBack, writeErr := scheduler.write(ctx, profile, restore.Previous); writeErr != nil {
+		err = writeErr
+		if !rolledBack {
+			scheduler.arm(restore, scheduler.options.RetryInterval)
+			return
+		}
+		if deleteErr := scheduler.options.Store.Delete(restore.ID); deleteErr != nil {
+			err = fmt.Errorf("%s, and removing the restore failed: %w", err.Error(), deleteErr)
+		}
+		restore = nil
+		return
+	}
+	scheduler.arm(restore, duration)
+	return
+}
+
+// Pending returns the pending restores of the zone, by restore time.
+func (scheduler *CachingScheduler) Pending() (pending []*ScheduledRestore, err error) {
+	restores, err := scheduler.options.Store.List()
+	if err != nil {
+		return
+	}
+	for _, restore := range restores {
+		if restore.Crn == core.StringNilMapper(scheduler.cachingApi.Crn) && restore.ZoneID == core.StringNilMapper(scheduler.cachingApi.ZoneID) {
+			pending = append(pending, restore)
+		}
+	}
+	return
+}
+
+// Resume : Take over the pending restores of the zone
+// Restore at once the settings whose time has passed, as when the process was down, and schedule the other restores.
+// Call it once after creating a scheduler on a store that outlives the process. The error tells the restores that
+// failed; they are tried again after RetryInterval.
+func (scheduler *CachingScheduler) Resume() (err error) {
+	return scheduler.ResumeWithContext(context.Background())
+}
+
+// ResumeWithContext is an alternate form of the Resume method which supports a Context parameter
+func (scheduler *CachingScheduler) ResumeWithContext(ctx context.Context) (err error) {
+	pending, err := scheduler.Pending()
+	if err != nil {
+		return
+	}
+	var failed []string
+	var first error
+	for _, restore := range pending {
+		if remaining := restore.Remaining(); remaining > 0 {
+			scheduler.arm(restore, remaining)
+			continue
+		}
+		current, busy, restoreErr := scheduler.take(restore.ID)
+		if busy || (restoreErr == nil && current == nil) {
+			continue
+		}
+		if restoreErr == nil {
+			restoreErr = scheduler.restore(ctx, current)
+			scheduler.release(restore.ID)
+		}
+		if restoreErr != nil {
+			failed = append(failed, restore.ID)
+			if first == nil {
+				first = restoreErr
+			}
+			scheduler.arm(restore, scheduler.options.RetryInterval)
+		}
+	}
+	if len(failed) > 0 {
+		err = fmt.Errorf("%d overdue restores failed, the first %s: %w", len(failed), failed[0], first)
+	}
+	return
+}
+
+// Restore : Restore the settings of a pending restore now
+// Restore the settings before their time, as when a development session ends early. A restore that fails is tried
+// again after RetryInterval. A restore cannot be restored while its timer is restoring it.
+func (scheduler *CachingScheduler) Restore(id string) (err error) {
+	return scheduler.RestoreWithContext(context.Background(), id)
+}
+
+// RestoreWithContext is an alternate form of the Restore method which supports a Context parameter
+func (scheduler *CachingScheduler) RestoreWithContext(ctx context.Context, id string) (err error) {
+	restore, busy, err := scheduler.take(id)
+	if err != nil {
+		return
+	}
+	if busy {
+		return fmt.Errorf("the restore %s of the zone %s is already in progress", id, core.StringNilMapper(scheduler.cachingApi.ZoneID))
+	}
+	if restore == nil {
+		return fmt.Errorf("the zone %s has no pending restore %s", core.StringNilMapper(scheduler.cachingApi.ZoneID), id)
+	}
+	err = scheduler.restore(ctx, restore)
+	scheduler.release(id)
+	if err != nil {
+		scheduler.arm(restore, scheduler.options.RetryInterval)
+	}
+	return
+}
+
+// Stop stops the timers of the scheduler without restoring any setting. The restores stay in the store, for a
+// scheduler to resume.
+func (scheduler *CachingScheduler) Stop() {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	for id, timer := range scheduler.timers {
+		timer.Stop()
+		delete(scheduler.timers, id)
+	}
+}
+
+// arm schedules a restore, replacing its timer if any.
+func (scheduler *CachingScheduler) arm(restore *ScheduledRestore, wait time.Duration) {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	if timer := scheduler.timers[restore.ID]; timer != nil {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(wait, func() {
+		scheduler.mutex.Lock()
+		if scheduler.timers[restore.ID] != timer {
+			scheduler.mutex.Unlock()
+			return
+		}
+		delete(scheduler.timers, restore.ID)
+		scheduler.mutex.Unlock()
+
+		current, busy, err := scheduler.take(restore.ID)
+		if busy || (err == nil && current == nil) {
+			return
+		}
+		if err == nil {
+			err = scheduler.restore(context.Background(), current)
+			scheduler.release(restore.ID)
+		}
+		if err != nil {
+			scheduler.arm(restore, scheduler.options.RetryInterval)
+		}
+		if scheduler.options.OnRestore != nil {
+			scheduler.options.OnRestore(restore, err)
+		}
+	})
+	scheduler.timers[restore.ID] = timer
+}
+
+// take marks a restore as being restored and stops its timer, then reads it from the store. It returns busy when the
+// restore is already being restored, and a nil restore when it is no longer pending. Unless it returns busy or an
+// error, release must be called once the restore is written back.
+func (scheduler *CachingScheduler) take(id string) (restore *ScheduledRestore, busy bool, err error) {
+	scheduler.mutex.Lock()
+	if scheduler.restoring[id] {
+		scheduler.mutex.Unlock()
+		busy = true
+		return
+	}
+	scheduler.restoring[id] = true
+	if timer := scheduler.timers[id]; timer != nil {
+		timer.Stop()
+		delete(scheduler.timers, id)
+	}
+	scheduler.mutex.Unlock()
+
+	pending, err := scheduler.Pending()
+	for _, other := range pending {
+		if other.ID == id {
+			restore = other
+		}
+	}
+	if err != nil || restore == nil {
+		scheduler.release(id)
+	}
+	return
+}
+
+func (scheduler *CachingScheduler) release(id string) {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	delete(scheduler.restoring, id)
+}
+
+// restore writes back the previous settings of a restore and removes it from the store.
+func (scheduler *CachingScheduler) restore(ctx context.Context, restore *ScheduledRestore) (err error) {
+	if _, err = scheduler.write(ctx, &restore.Previous, CachingProfile{}); err != nil {
+		return
+	}
+	return scheduler.options.Store.Delete(restore.ID)
+}
+
+// read returns the current values of the settings a profile changes.
+func (scheduler *CachingScheduler) read(ctx context.Context, profile *CachingProfile) (current CachingProfile, err error) {
+	cachingApi := scheduler.cachingApi
+	if profile.DevelopmentMode != nil {
+		var result *DeveopmentModeResponse
+		result, _, err = cachingApi.GetDevelopmentModeWithContext(ctx, cachingApi.NewGetDevelopmentModeOptions())
+		if err == nil && result.Result == nil {
+			err = fmt.Errorf("the response has no result")
+		}
+		if err != nil {
+			err = fmt.Errorf("reading the development mode: %w", err)
+			return
+		}
+		current.DevelopmentMode = result.Result.Value
+	}
+	if profile.CacheLevel != nil {
+		var result *CacheLevelResponse
+		result, _, err = cachingApi.GetCacheLevelWithContext(ctx, cachingApi.NewGetCacheLevelOptions())
+		if err == nil && result.Result == nil {
+			err = fmt.Errorf("the response has no result")
+		}
+		if err != nil {
+			err = fmt.Errorf("reading the cache level: %w", err)
+			return
+		}
+		current.CacheLevel = result.Result.Value
+	}
+	if profile.BrowserCacheTTL != nil {
+		var result *BrowserTTLResponse
+		result, _, err = cachingApi.GetBrowserCacheTTLWithContext(ctx, cachingApi.NewGetBrowserCacheTtlOptions())
+		if err == nil && result.Result == nil {
+			err = fmt.Errorf("the response has no result")
+		}
+		if err != nil {
+			err = fmt.Errorf("reading the browser cache ttl: %w", err)
+			return
+		}
+		current.BrowserCacheTTL = result.Result.Value
+	}
+	return
+}
+
+// write applies the settings of a profile, in order. When a setting fails and rollback holds the previous values,
+// the settings already applied are set back to them, in reverse order; rolledBack is false when that fails too.
+func (scheduler *CachingScheduler) write(ctx context.Context, profile *CachingProfile, rollback CachingProfile) (rolledBack bool, err error) {
+	cachingApi := scheduler.cachingApi
+	var applied []func() error
+	setDevelopmentMode := func(value *string) error {
+		_, _, err := cachingApi.UpdateDevelopmentModeWithContext(ctx, cachingApi.NewUpdateDevelopmentModeOptions().SetValue(*value))
+		if err != nil {
+			return fmt.Errorf("updating the development mode: %w", err)
+		}
+		return nil
+	}
+	setCacheLevel := func(value *string) error {
+		_, _, err := cachingApi.UpdateCacheLevelWithContext(ctx, cachingApi.NewUpdateCacheLevelOptions().SetValue(*value))
+		if err != nil {
+			return fmt.Errorf("updating the cache level: %w", err)
+		}
+		return nil
+	}
+	setBrowserCacheTTL := func(value *int64) error {
+		_, _, err := cachingApi.UpdateBrowserCacheTTLWithContext(ctx, cachingApi.NewUpdateBrowserCacheTtlOptions().SetValue(*value))
+		if err != nil {
+			return fmt.Errorf("updating the browser cache ttl: %w", err)
+		}
+		return nil
+	}
+
+	if profile.DevelopmentMode != nil {
+		if err = setDevelopmentMode(profile.DevelopmentMode); err == nil && rollback.DevelopmentMode != nil {
+			applied = append(applied, func() error { return setDevelopmentMode(rollback.DevelopmentMode) })
+		}
+	}
+	if err == nil && profile.CacheLevel != nil {
+		if err = setCacheLevel(profile.CacheLevel); err == nil && rollback.CacheLevel != nil {
+			applied = append(applied, func() error { return setCacheLevel(rollback.CacheLevel) })
+		}
+	}
+	if err == nil && profile.BrowserCacheTTL != nil {
+		if err = setBrowserCacheTTL(profile.BrowserCacheTTL); err == nil && rollback.BrowserCacheTTL != nil {
+			applied = append(applied, func() error { return setBrowserCacheTTL(rollback.BrowserCacheTTL) })
+		}
+	}
+	if err != nil {
+		rolledBack = true
+		for i := len(applied) - 1; i >= 0; i-- {
+			if rollbackErr := applied[i](); rollbackErr != nil {
+				err = fmt.Errorf("%s, and rolling back failed: %w", err.Error(), rollbackErr)
+				rolledBack = false
+				break
+			}
+		}
+	}
+	return
+}
+
+// overlaps returns true when two profiles change a same setting.
+func overlaps(profile *CachingProfile, other *CachingProfile) bool {
+	return (profile.DevelopmentMode != nil && other.DevelopmentMode != nil) ||
+		(profile.CacheLevel != nil && other.CacheLevel != nil) ||
+		(profile.BrowserCacheTTL != nil && other.BrowserCacheTTL != nil)
+}
+
+func newScheduleID() (string, error) {
+	buffer := make([]byte, 8)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,338 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cachingapiv1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/cachingapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCachingSettings serves the development mode, cache level and browser cache ttl settings of a zone, and
+// records their updates as "setting value". It answers 500 to the updates of the failing setting and, when limit is
+// positive, to the updates after the first limit ones. It answers the missing setting without result, and delays
+// each update by delay.
+type fakeCachingSettings struct {
+	sync.Mutex
+	settings map[string]interface{}
+	updates  []string
+	failing  string
+	limit    int
+	missing  string
+	delay    time.Duration
+}
+
+func (api *fakeCachingSettings) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	if req.Method == "PATCH" {
+		api.Lock()
+		delay := api.delay
+		api.Unlock()
+		time.Sleep(delay)
+	}
+	api.Lock()
+	defer api.Unlock()
+
+	setting := path.Base(req.URL.Path)
+	res.Header().Set("Content-type", "application/json")
+	if req.Method == "PATCH" {
+		var body map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		api.updates = append(api.updates, fmt.Sprintf("%s %v", setting, body["value"]))
+		if setting == api.failing || (api.limit > 0 && len(api.updates) > api.limit) {
+			res.WriteHeader(500)
+			fmt.Fprint(res, `{"success": false, "errors": [["internal error"]], "messages": []}`)
+			return
+		}
+		api.settings[setting] = body["value"]
+	}
+	result := map[string]interface{}{"id": setting, "value": api.settings[setting], "editable": true, "modified_on": "2025-01-01T00:00:00Z"}
+	if setting == "development_mode" && api.settings[setting] == "on" {
+		result["time_remaining"] = 10800
+	}
+	if setting == api.missing {
+		result = nil
+	}
+	body, _ := json.Marshal(map[string]interface{}{"success": true, "errors": [][]string{}, "messages": [][]string{}, "result": result})
+	res.Write(body)
+}
+
+func (api *fakeCachingSettings) fail(setting string, limit int) {
+	api.Lock()
+	defer api.Unlock()
+	api.failing, api.limit = setting, limit
+}
+
+func (api *fakeCachingSettings) get(setting string) interface{} {
+	api.Lock()
+	defer api.Unlock()
+	return api.settings[setting]
+}
+
+var _ = Describe(`CachingScheduler`, func() {
+	var (
+		api     *fakeCachingSettings
+		server  *httptest.Server
+		service *cachingapiv1.CachingApiV1
+		dir     string
+	)
+
+	devMode := &cachingapiv1.CachingProfile{
+		DevelopmentMode: core.StringPtr(cachingapiv1.UpdateDevelopmentModeOptions_Value_On),
+		CacheLevel:      core.StringPtr(cachingapiv1.UpdateCacheLevelOptions_Value_Basic),
+		BrowserCacheTTL: core.Int64Ptr(0),
+	}
+
+	BeforeEach(func() {
+		api = &fakeCachingSettings{settings: map[string]interface{}{
+			"development_mode":  "off",
+			"cache_level":       "aggressive",
+			"browser_cache_ttl": float64(14400),
+		}}
+		server = httptest.NewServer(api)
+		var err error
+		service, err = cachingapiv1.NewCachingApiV1(&cachingapiv1.CachingApiV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Crn:           core.StringPtr("crn"),
+			ZoneID:        core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		dir, err = os.MkdirTemp("", "caching-schedule")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	It(`Applies a profile and restores the previous settings after the window`, func() {
+		restored := make(chan error, 1)
+		scheduler, err := service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{
+			OnRestore: func(restore *cachingapiv1.ScheduledRestore, err error) { restored <- err },
+		})
+		Expect(err).To(BeNil())
+
+		restore, err := scheduler.Apply(devMode, 100*time.Millisecond)
+		Expect(err).To(BeNil())
+		Expect(restore.ZoneID).To(Equal("zone"))
+		Expect(restore.Previous).To(Equal(cachingapiv1.CachingProfile{
+			DevelopmentMode: core.StringPtr("off"),
+			CacheLevel:      core.StringPtr("aggressive"),
+			BrowserCacheTTL: core.Int64Ptr(14400),
+		}))
+		Expect(restore.Remaining()).To(BeNumerically(">", 0))
+		Expect(api.get("development_mode")).To(Equal("on"))
+
+		remaining, _, err := service.GetDevelopmentModeTimeRemaining(service.NewGetDevelopmentModeOptions())
+		Expect(err).To(BeNil())
+		Expect(remaining).To(Equal(3 * time.Hour))
+
+		_, err = scheduler.Apply(&cachingapiv1.CachingProfile{CacheLevel: core.StringPtr("simplified")}, time.Hour)
+		Expect(err).To(MatchError("the zone zone already has a pending restore " + restore.ID + " of the same settings"))
+
+		Eventually(restored).Should(Receive(BeNil()))
+		Expect(api.updates).To(Equal([]string{
+			"development_mode on", "cache_level basic", "browser_cache_ttl 0",
+			"development_mode off", "cache_level aggressive", "browser_cache_ttl 14400",
+		}))
+		pending, err := scheduler.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(BeEmpty())
+
+		remaining, _, err = service.GetDevelopmentModeTimeRemaining(service.NewGetDevelopmentModeOptions())
+		Expect(err).To(BeNil())
+		Expect(remaining).To(BeZero())
+	})
+
+	It(`Resumes the pending restores from a file after a restart`, func() {
+		store := cachingapiv1.NewFileScheduleStore(filepath.Join(dir, "schedule.json"))
+		first, err := service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{Store: store})
+		Expect(err).To(BeNil())
+		restore, err := first.Apply(&cachingapiv1.CachingProfile{DevelopmentMode: core.StringPtr("on")}, time.Hour)
+		Expect(err).To(BeNil())
+		first.Stop()
+
+		overdue := &cachingapiv1.ScheduledRestore{ID: "overdue", Crn: "crn", ZoneID: "zone", Until: time.Now().Add(-time.Minute),
+			Applied: cachingapiv1.CachingProfile{CacheLevel: core.StringPtr("basic")}, Previous: cachingapiv1.CachingProfile{CacheLevel: core.StringPtr("simplified")}}
+		other := &cachingapiv1.ScheduledRestore{ID: "other", Crn: "crn", ZoneID: "other-zone", Until: time.Now().Add(-time.Minute),
+			Previous: cachingapiv1.CachingProfile{CacheLevel: core.StringPtr("basic")}}
+		Expect(store.Save(overdue)).To(Succeed())
+		Expect(store.Save(other)).To(Succeed())
+
+		second, err := service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{Store: cachingapiv1.NewFileScheduleStore(filepath.Join(dir, "schedule.json"))})
+		Expect(err).To(BeNil())
+		defer second.Stop()
+		Expect(second.Resume()).To(Succeed())
+		Expect(api.get("cache_level")).To(Equal("simplified"))
+		Expect(api.get("development_mode")).To(Equal("on"))
+		pending, err := second.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(1))
+		Expect(pending[0].ID).To(Equal(restore.ID))
+		Expect(pending[0].Until.Equal(restore.Until)).To(BeTrue())
+
+		Expect(second.Restore(restore.ID)).To(Succeed())
+		Expect(api.get("development_mode")).To(Equal("off"))
+		Expect(second.Restore(restore.ID)).To(MatchError("the zone zone has no pending restore " + restore.ID))
+		all, err := store.List()
+		Expect(err).To(BeNil())
+		Expect(all).To(HaveLen(1))
+		Expect(all[0].ID).To(Equal("other"))
+	})
+
+	It(`Rolls back the settings already applied when a setting fails`, func() {
+		api.failing = "browser_cache_ttl"
+		store := cachingapiv1.NewMemoryScheduleStore()
+		scheduler, err := service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{Store: store})
+		Expect(err).To(BeNil())
+		restore, err := scheduler.Apply(devMode, time.Hour)
+		Expect(restore).To(BeNil())
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("updating the browser cache ttl: "))
+		Expect(api.updates).To(Equal([]string{
+			"development_mode on", "cache_level basic", "browser_cache_ttl 0",
+			"cache_level aggressive", "development_mode off",
+		}))
+		all, err := store.List()
+		Expect(err).To(BeNil())
+		Expect(all).To(BeEmpty())
+	})
+
+	It(`Keeps the restores that fail pending and tries them again`, func() {
+		restored := make(chan error, 10)
+		store := cachingapiv1.NewMemoryScheduleStore()
+		scheduler, err := service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{
+			Store:         store,
+			RetryInterval: 50 * time.Millisecond,
+			OnRestore:     func(restore *cachingapiv1.ScheduledRestore, err error) { restored <- err },
+		})
+		Expect(err).To(BeNil())
+		defer scheduler.Stop()
+
+		api.fail("", 2)
+		restore, err := scheduler.Apply(devMode, time.Hour)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(", and rolling back failed: updating the cache level: "))
+		Expect(restore).ToNot(BeNil())
+		all, err := store.List()
+		Expect(err).To(BeNil())
+		Expect(all).To(HaveLen(1))
+		api.fail("", 0)
+		Eventually(restored).Should(Receive(BeNil()))
+		Expect(api.get("development_mode")).To(Equal("off"))
+		Expect(api.get("cache_level")).To(Equal("aggressive"))
+
+		restore, err = scheduler.Apply(&cachingapiv1.CachingProfile{CacheLevel: core.StringPtr("basic")}, time.Hour)
+		Expect(err).To(BeNil())
+		api.fail("cache_level", 0)
+		Expect(scheduler.Restore(restore.ID)).ToNot(Succeed())
+		pending, err := scheduler.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(1))
+		api.fail("", 0)
+		Eventually(restored).Should(Receive(BeNil()))
+		Expect(api.get("cache_level")).To(Equal("aggressive"))
+	})
+
+	It(`Applies one of two concurrent profiles of the same settings`, func() {
+		scheduler, err := service.NewCachingScheduler(nil)
+		Expect(err).To(BeNil())
+		defer scheduler.Stop()
+
+		var wait sync.WaitGroup
+		restores := make([]*cachingapiv1.ScheduledRestore, 2)
+		errs := make([]error, 2)
+		for i, level := range []string{"basic", "simplified"} {
+			wait.Add(1)
+			go func(i int, level string) {
+				defer wait.Done()
+				restores[i], errs[i] = scheduler.Apply(&cachingapiv1.CachingProfile{CacheLevel: core.StringPtr(level)}, time.Hour)
+			}(i, level)
+		}
+		wait.Wait()
+
+		applied := 0
+		if errs[1] == nil {
+			applied = 1
+		}
+		Expect(errs[applied]).To(BeNil())
+		Expect(errs[1-applied]).To(MatchError(HaveSuffix(" of the same settings")))
+		Expect(*restores[applied].Previous.CacheLevel).To(Equal("aggressive"))
+		pending, err := scheduler.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(1))
+	})
+
+	It(`Restores a restore once when Restore races its timer`, func() {
+		restored := make(chan error, 1)
+		scheduler, err := service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{
+			OnRestore: func(restore *cachingapiv1.ScheduledRestore, err error) { restored <- err },
+		})
+		Expect(err).To(BeNil())
+		defer scheduler.Stop()
+
+		restore, err := scheduler.Apply(&cachingapiv1.CachingProfile{CacheLevel: core.StringPtr("basic")}, 10*time.Millisecond)
+		Expect(err).To(BeNil())
+		api.Lock()
+		api.delay = 200 * time.Millisecond
+		api.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		Expect(scheduler.Restore(restore.ID)).To(MatchError("the restore " + restore.ID + " of the zone zone is already in progress"))
+		Eventually(restored).Should(Receive(BeNil()))
+		Expect(scheduler.Restore(restore.ID)).To(MatchError("the zone zone has no pending restore " + restore.ID))
+		api.Lock()
+		defer api.Unlock()
+		Expect(api.updates).To(Equal([]string{"cache_level basic", "cache_level aggressive"}))
+	})
+
+	It(`Reports a setting read without result`, func() {
+		scheduler, err := service.NewCachingScheduler(nil)
+		Expect(err).To(BeNil())
+		api.missing = "cache_level"
+		_, err = scheduler.Apply(devMode, time.Hour)
+		Expect(err).To(MatchError("reading the cache level: the response has no result"))
+		pending, err := scheduler.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(BeEmpty())
+	})
+
+	It(`Validates the profile`, func() {
+		scheduler, err := service.NewCachingScheduler(nil)
+		Expect(err).To(BeNil())
+		_, err = scheduler.Apply(nil, time.Hour)
+		Expect(err).ToNot(BeNil())
+		_, err = scheduler.Apply(&cachingapiv1.CachingProfile{}, time.Hour)
+		Expect(err).To(MatchError("the caching profile changes no setting"))
+		_, err = scheduler.Apply(devMode, 0)
+		Expect(err).To(MatchError("the duration of a caching profile must be positive"))
+		_, err = service.NewCachingScheduler(&cachingapiv1.CachingSchedulerOptions{RetryInterval: -time.Second})
+		Expect(err).To(MatchError("the retry interval cannot be negative"))
+	})
+})
@@ -0,0 +1,423 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cachingapiv1
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// The defaults of PurgeQueueOptions. A purge call carries at most DefaultPurgeBatchSize URLs, cache tags or hosts;
+// the pace is a conservative share of the per-minute call limit of the purge API.
+const (
+	DefaultPurgeBatchSize         = 30
+	DefaultPurgeRequestsPerMinute = 60
+	DefaultPurgeBurst             = 5
+	DefaultPurgeMaxRetries        = 3
+	DefaultPurgeRetryInterval     = time.Second
+	DefaultPurgeMaxRetryInterval  = 30 * time.Second
+)
+
+// Constants associated with the PurgeResult.Kind property.
+const (
+	PurgeResult_Kind_CacheTag = "cache_tag"
+	PurgeResult_Kind_Host     = "host"
+	PurgeResult_Kind_URL      = "url"
+)
+
+// maxSitemapDepth is how deep AddSitemap follows sitemap indexes.
+const maxSitemapDepth = 3
+
+// PurgeQueueOptions : The PurgeQueue options. The zero value of a field stands for its default.
+type PurgeQueueOptions struct {
+	// The number of items a purge call carries.
+	BatchSize int
+
+	// The number of purge calls per minute.
+	RequestsPerMinute int
+
+	// The number of purge calls that may be made at once before the pace applies.
+	Burst int
+
+	// The number of times a call is retried after a rate limit, a server error or a network error,
+	// DefaultPurgeMaxRetries when nil. Zero turns the retries off.
+	MaxRetries *int64
+
+	// The wait before the first retry, doubled for each retry up to MaxRetryInterval. A longer Retry-After header
+	// of the response takes precedence.
+	RetryInterval time.Duration
+
+	MaxRetryInterval time.Duration
+}
+
+// PurgeResult : The outcome of the purge of one item.
+type PurgeResult struct {
+	// One of the PurgeResult_Kind constants.
+	Kind string
+
+	// The URL, cache tag or host.
+	Value string
+
+	// The ID of the purge that included the item.
+	PurgeID string
+
+	// The number of calls made for the batch of the item.
+	Attempts int
+
+	// Why the item could not be purged.
+	Err error
+}
+
+// PurgeReport : The outcome of a flush, one result per item in the order the items were added, URLs first.
+type PurgeReport struct {
+	Results []PurgeResult
+
+	// The number of purge calls made, retries included.
+	Calls int
+}
+
+// Failed returns the results of the items that could not be purged.
+func (report *PurgeReport) Failed() (failed []PurgeResult) {
+	for _, result := range report.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return
+}
+
+// PurgeQueue : A queue of URLs, cache tags and hosts to purge from the cache of a zone. The queue dedupes the items
+// added to it, and a flush purges them in batches the API accepts, at a steady pace, with retries. A queue can be
+// used from several goroutines.
+type PurgeQueue struct {
+	cachingApi *CachingApiV1
+	options    PurgeQueueOptions
+	bucket     *tokenBucket
+
+	mutex   sync.Mutex
+	pending map[string][]string
+	seen    map[string]bool
+}
+
+// NewPurgeQueue : Instantiate PurgeQueue
+func (cachingApi *CachingApiV1) NewPurgeQueue(purgeQueueOptions *PurgeQueueOptions) (queue *PurgeQueue, err error) {
+	options := PurgeQueueOptions{}
+	if purgeQueueOptions != nil {
+		options = *purgeQueueOptions
+	}
+	if options.BatchSize < 0 || options.RequestsPerMinute < 0 || options.Burst < 0 || (options.MaxRetries != nil && *options.MaxRetries < 0) || options.RetryInterval < 0 || options.MaxRetryInterval < 0 {
+		err = fmt.Errorf("purge queue options cannot be negative")
+		return
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = DefaultPurgeBatchSize
+	}
+	if options.RequestsPerMinute == 0 {
+		options.RequestsPerMinute = DefaultPurgeRequestsPerMinute
+	}
+	if options.Burst == 0 {
+		options.Burst = DefaultPurgeBurst
+	}
+	maxRetries := int64(DefaultPurgeMaxRetries)
+	if options.MaxRetries != nil {
+		maxRetries = *options.MaxRetries
+	}
+	options.MaxRetries = &maxRetries
+	if options.RetryInterval == 0 {
+		options.RetryInterval = DefaultPurgeRetryInterval
+	}
+	if options.MaxRetryInterval == 0 {
+		options.MaxRetryInterval = DefaultPurgeMaxRetryInterval
+	}
+	queue = &PurgeQueue{
+		cachingApi: cachingApi,
+		options:    options,
+		bucket:     newTokenBucket(time.Minute/time.Duration(options.RequestsPerMinute), options.Burst),
+		pending:    map[string][]string{},
+		seen:       map[string]bool{},
+	}
+	return
+}
+
+// add queues the items of a kind that are not queued yet, and returns how many were queued.
+func (queue *PurgeQueue) add(kind string, values []string, key func(value string) string) (added int) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || queue.seen[kind+" "+key(value)] {
+			continue
+		}
+		queue.seen[kind+" "+key(value)] = true
+		queue.pending[kind] = append(queue.pending[kind], value)
+		added++
+	}
+	return
+}
+
+func sameString(value string) string {
+	return value
+}
+
+// AddUrls queues URLs to purge, and returns how many were not queued yet.
+func (queue *PurgeQueue) AddUrls(urls ...string) int {
+	return queue.add(PurgeResult_Kind_URL, urls, sameString)
+}
+
+// AddCacheTags queues cache tags to purge, and returns how many were not queued yet.
+func (queue *PurgeQueue) AddCacheTags(tags ...string) int {
+	return queue.add(PurgeResult_Kind_CacheTag, tags, sameString)
+}
+
+// AddHosts queues host names to purge, and returns how many were not queued yet. Host names are compared without
+// regard to case.
+func (queue *PurgeQueue) AddHosts(hosts ...string) int {
+	return queue.add(PurgeResult_Kind_Host, hosts, strings.ToLower)
+}
+
+// AddChangedFiles queues the URLs of changed files, as returned by a function that maps a file to its URLs, such as
+// public/index.html to https://www.example.com/ and https://www.example.com/index.html. It returns how many URLs were
+// not queued yet.
+func (queue *PurgeQueue) AddChangedFiles(files []string, urls func(file string) []string) (added int) {
+	for _, file := range files {
+		added += queue.AddUrls(urls(file)...)
+	}
+	return
+}
+
+// sitemap is a sitemap or a sitemap index.
+type sitemap struct {
+	URLs     []sitemapLocation `xml:"url"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+// AddSitemap queues the URLs of a sitemap, and returns how many were not queued yet. The sitemaps that a sitemap
+// index lists are read with the fetch function, a few levels deep; without a fetch function, a sitemap index is an
+// error.
+func (queue *PurgeQueue) AddSitemap(reader io.Reader, fetch func(loc string) (io.ReadCloser, error)) (added int, err error) {
+	return queue.addSitemap(reader, fetch, 0)
+}
+
+func (queue *PurgeQueue) addSitemap(reader io.Reader, fetch func(loc string) (io.ReadCloser, error), depth int) (added int, err error) {
+	var document sitemap
+	if err = xml.NewDecoder(reader).Decode(&document); err != nil {
+		err = fmt.Errorf("the sitemap cannot be parsed: %w", err)
+		return
+	}
+	for _, location := range document.URLs {
+		added += queue.AddUrls(location.Loc)
+	}
+	if len(document.Sitemaps) == 0 {
+		return
+	}
+	if fetch == nil || depth >= maxSitemapDepth {
+		err = fmt.Errorf("the sitemap index lists %d sitemaps that cannot be fetched", len(document.Sitemaps))
+		return
+	}
+	for _, location := range document.Sitemaps {
+		loc := strings.TrimSpace(location.Loc)
+		nested, fetchErr := fetch(loc)
+		if fetchErr != nil {
+			err = fmt.Errorf("fetching the sitemap %s failed: %w", loc, fetchErr)
+			return
+		}
+		count, nestedErr := queue.addSitemap(nested, fetch, depth+1)
+		nested.Close()
+		added += count
+		if nestedErr != nil {
+			err = nestedErr
+			return
+		}
+	}
+	return
+}
+
+// Len returns the number of items waiting for a flush.
+func (queue *PurgeQueue) Len() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	return len(queue.pending[PurgeResult_Kind_URL]) + len(queue.pending[PurgeResult_Kind_CacheTag]) + len(queue.pending[PurgeResult_Kind_Host])
+}
+
+// Flush : Purge the queued items
+// Purge the queued URLs, then the cache tags, then the hosts, in batches of BatchSize items, waiting for the pace
+// between calls. A call that hits a rate limit, a server error or a network error is retried with backoff. When the
+// API rejects a batch, as when one of its URLs is invalid, the batch is split in halves until the rejected items are
+// found, so that the other items are still purged. The queue is empty afterwards, and the items can be added again.
+// The error tells how many items could not be purged.
+func (queue *PurgeQueue) Flush() (report *PurgeReport, err error) {
+	return queue.FlushWithContext(context.Background())
+}
+
+// FlushWithContext is an alternate form of the Flush method which supports a Context parameter
+func (queue *PurgeQueue) FlushWithContext(ctx context.Context) (report *PurgeReport, err error) {
+	queue.mutex.Lock()
+	pending := queue.pending
+	queue.pending = map[string][]string{}
+	queue.seen = map[string]bool{}
+	queue.mutex.Unlock()
+
+	report = &PurgeReport{}
+	for _, kind := range []string{PurgeResult_Kind_URL, PurgeResult_Kind_CacheTag, PurgeResult_Kind_Host} {
+		values := pending[kind]
+		for start := 0; start < len(values); start += queue.options.BatchSize {
+			end := start + queue.options.BatchSize
+			if end > len(values) {
+				end = len(values)
+			}
+			queue.purge(ctx, kind, values[start:end], report)
+		}
+	}
+
+	failed := report.Failed()
+	if len(failed) > 0 {
+		err = fmt.Errorf("%d of %d purge items failed, the first: %s %s: %w", len(failed), len(report.Results), failed[0].Kind, failed[0].Value, failed[0].Err)
+	}
+	return
+}
+
+// purge purges one batch, with retries, and splits it when the API rejects it.
+func (queue *PurgeQueue) purge(ctx context.Context, kind string, values []string, report *PurgeReport) {
+	var id string
+	var err error
+	var response *core.DetailedResponse
+	attempts := 0
+	for {
+		if err = queue.bucket.wait(ctx); err != nil {
+			break
+		}
+		attempts++
+		report.Calls++
+		id, response, err = queue.call(ctx, kind, values)
+		if err == nil || !retryable(response) || int64(attempts) > *queue.options.MaxRetries {
+			break
+		}
+		if err = sleep(ctx, queue.backoff(attempts, response)); err != nil {
+			break
+		}
+	}
+	if err != nil && len(values) > 1 && response != nil && response.StatusCode == http.StatusBadRequest {
+		half := len(values) / 2
+		queue.purge(ctx, kind, values[:half], report)
+		queue.purge(ctx, kind, values[half:], report)
+		return
+	}
+	for _, value := range values {
+		report.Results = append(report.Results, PurgeResult{Kind: kind, Value: value, PurgeID: id, Attempts: attempts, Err: err})
+	}
+}
+
+// call makes the purge call of a batch, and returns the ID of the purge.
+func (queue *PurgeQueue) call(ctx context.Context, kind string, values []string) (id string, response *core.DetailedResponse, err error) {
+	cachingApi := queue.cachingApi
+	var result *PurgeAllResponse
+	switch kind {
+	case PurgeResult_Kind_URL:
+		result, response, err = cachingApi.PurgeByUrlsWithContext(ctx, cachingApi.NewPurgeByUrlsOptions().SetFiles(values))
+	case PurgeResult_Kind_CacheTag:
+		result, response, err = cachingApi.PurgeByCacheTagsWithContext(ctx, cachingApi.NewPurgeByCacheTagsOptions().SetTags(values))
+	case PurgeResult_Kind_Host:
+		result, response, err = cachingApi.PurgeByHostsWithContext(ctx, cachingApi.NewPurgeByHostsOptions().SetHosts(values))
+	}
+	if err == nil && result != nil && result.Result != nil {
+		id = core.StringNilMapper(result.Result.ID)
+	}
+	return
+}
+
+// retryable returns true for the failures worth a retry: rate limits, server errors and network errors.
+func retryable(response *core.DetailedResponse) bool {
+	return response == nil || response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+}
+
+// backoff returns the wait before a retry: RetryInterval doubled for each attempt up to MaxRetryInterval, or the
+// Retry-After header of the response when it is longer.
+func (queue *PurgeQueue) backoff(attempts int, response *core.DetailedResponse) time.Duration {
+	wait := queue.options.RetryInterval
+	for i := 1; i < attempts && wait < queue.options.MaxRetryInterval; i++ {
+		wait *= 2
+	}
+	if wait > queue.options.MaxRetryInterval {
+		wait = queue.options.MaxRetryInterval
+	}
+	if response != nil {
+		if seconds, err := strconv.Atoi(response.GetHeaders().Get("Retry-After")); err == nil && time.Duration(seconds)*time.Second > wait {
+			wait = time.Duration(seconds) * time.Second
+		}
+	}
+	return wait
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tokenBucket paces calls: it holds up to burst tokens, gains one every interval, and each call takes one.
+type tokenBucket struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	burst    int
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(interval time.Duration, burst int) *tokenBucket {
+	return &tokenBucket{interval: interval, burst: burst, tokens: float64(burst), last: time.Now()}
+}
+
+// wait takes a token, waiting until one is available.
+func (bucket *tokenBucket) wait(ctx context.Context) error {
+	for {
+		bucket.mutex.Lock()
+		now := time.Now()
+		bucket.tokens += float64(now.Sub(bucket.last)) / float64(bucket.interval)
+		if bucket.tokens > float64(bucket.burst) {
+			bucket.tokens = float64(bucket.burst)
+		}
+		bucket.last = now
+		if bucket.tokens >= 1 {
+			bucket.tokens--
+			bucket.mutex.Unlock()
+			return nil
+		}
+		missing := time.Duration((1 - bucket.tokens) * float64(bucket.interval))
+		bucket.mutex.Unlock()
+		if err := sleep(ctx, missing); err != nil {
+			return err
+		}
+	}
+}
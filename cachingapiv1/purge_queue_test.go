@@ -0,0 +1,264 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cachingapiv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/cachingapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakePurge records the purge calls as "kind item,item,...". It answers 429 to the first throttled calls, and 400
+// to the calls that carry a rejected item.
+type fakePurge struct {
+	sync.Mutex
+	calls     []string
+	times     []time.Time
+	throttled int
+	rejected  string
+}
+
+func (api *fakePurge) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	var body map[string][]string
+	Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+	kind := path.Base(req.URL.Path)
+	var items []string
+	for _, values := range body {
+		items = values
+	}
+	api.calls = append(api.calls, kind+" "+strings.Join(items, ","))
+	api.times = append(api.times, time.Now())
+	res.Header().Set("Content-type", "application/json")
+	if api.throttled > 0 {
+		api.throttled--
+		res.Header().Set("Retry-After", "0")
+		res.WriteHeader(429)
+		fmt.Fprint(res, `{"success": false, "errors": [{"code": 10000, "message": "rate limited"}], "messages": []}`)
+		return
+	}
+	for _, item := range items {
+		if api.rejected != "" && item == api.rejected {
+			res.WriteHeader(400)
+			fmt.Fprint(res, `{"success": false, "errors": [{"code": 1012, "message": "invalid url"}], "messages": []}`)
+			return
+		}
+	}
+	fmt.Fprintf(res, `{"success": true, "errors": [], "messages": [], "result": {"id": "purge-%d"}}`, len(api.calls))
+}
+
+var _ = Describe(`PurgeQueue`, func() {
+	var (
+		api     *fakePurge
+		server  *httptest.Server
+		service *cachingapiv1.CachingApiV1
+		queue   *cachingapiv1.PurgeQueue
+	)
+
+	BeforeEach(func() {
+		api = &fakePurge{}
+		server = httptest.NewServer(api)
+		var err error
+		service, err = cachingapiv1.NewCachingApiV1(&cachingapiv1.CachingApiV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Crn:           core.StringPtr("crn"),
+			ZoneID:        core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		queue, err = service.NewPurgeQueue(&cachingapiv1.PurgeQueueOptions{
+			BatchSize:         3,
+			RequestsPerMinute: 60000,
+			RetryInterval:     time.Millisecond,
+		})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Dedupes the items and purges them in batches`, func() {
+		Expect(queue.AddUrls("https://example.com/a", "https://example.com/b", "https://example.com/a", " ", "https://example.com/c", "https://example.com/d")).To(Equal(4))
+		Expect(queue.AddCacheTags("blog", "blog")).To(Equal(1))
+		Expect(queue.AddHosts("www.example.com", "WWW.example.com")).To(Equal(1))
+		Expect(queue.Len()).To(Equal(6))
+
+		report, err := queue.Flush()
+		Expect(err).To(BeNil())
+		Expect(api.calls).To(Equal([]string{
+			"purge_by_urls https://example.com/a,https://example.com/b,https://example.com/c",
+			"purge_by_urls https://example.com/d",
+			"purge_by_cache_tags blog",
+			"purge_by_hosts www.example.com",
+		}))
+		Expect(report.Calls).To(Equal(4))
+		Expect(report.Results).To(HaveLen(6))
+		Expect(report.Results[3]).To(Equal(cachingapiv1.PurgeResult{Kind: cachingapiv1.PurgeResult_Kind_URL, Value: "https://example.com/d", PurgeID: "purge-2", Attempts: 1}))
+		Expect(report.Failed()).To(BeEmpty())
+		Expect(queue.Len()).To(Equal(0))
+	})
+
+	It(`Retries throttled calls`, func() {
+		api.throttled = 2
+		queue.AddUrls("https://example.com/a")
+		report, err := queue.Flush()
+		Expect(err).To(BeNil())
+		Expect(report.Calls).To(Equal(3))
+		Expect(report.Results[0].Attempts).To(Equal(3))
+		Expect(report.Results[0].PurgeID).To(Equal("purge-3"))
+	})
+
+	It(`Gives up after the last retry`, func() {
+		api.throttled = 10
+		queue.AddHosts("www.example.com")
+		report, err := queue.Flush()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("1 of 1 purge items failed, the first: host www.example.com: "))
+		Expect(report.Calls).To(Equal(cachingapiv1.DefaultPurgeMaxRetries + 1))
+	})
+
+	It(`Does not retry when the retries are turned off`, func() {
+		api.throttled = 10
+		once, err := service.NewPurgeQueue(&cachingapiv1.PurgeQueueOptions{MaxRetries: core.Int64Ptr(0), RetryInterval: time.Millisecond})
+		Expect(err).To(BeNil())
+		once.AddHosts("www.example.com")
+		report, err := once.Flush()
+		Expect(err).ToNot(BeNil())
+		Expect(report.Calls).To(Equal(1))
+		Expect(report.Results[0].Attempts).To(Equal(1))
+	})
+
+	It(`Isolates the items that the API rejects`, func() {
+		api.rejected = "bad"
+		queue.AddUrls("https://example.com/a", "bad", "https://example.com/c")
+		report, err := queue.Flush()
+		Expect(err).ToNot(BeNil())
+		Expect(api.calls).To(Equal([]string{
+			"purge_by_urls https://example.com/a,bad,https://example.com/c",
+			"purge_by_urls https://example.com/a",
+			"purge_by_urls bad,https://example.com/c",
+			"purge_by_urls bad",
+			"purge_by_urls https://example.com/c",
+		}))
+		failed := report.Failed()
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].Value).To(Equal("bad"))
+		Expect(report.Results[0].Err).To(BeNil())
+		Expect(report.Results[2].Err).To(BeNil())
+	})
+
+	It(`Paces the calls`, func() {
+		service, _ := cachingapiv1.NewCachingApiV1(&cachingapiv1.CachingApiV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Crn:           core.StringPtr("crn"),
+			ZoneID:        core.StringPtr("zone"),
+		})
+		paced, err := service.NewPurgeQueue(&cachingapiv1.PurgeQueueOptions{BatchSize: 1, RequestsPerMinute: 1200, Burst: 1})
+		Expect(err).To(BeNil())
+		paced.AddCacheTags("a", "b", "c")
+		_, err = paced.Flush()
+		Expect(err).To(BeNil())
+		Expect(api.times).To(HaveLen(3))
+		Expect(api.times[2].Sub(api.times[0])).To(BeNumerically(">=", 90*time.Millisecond))
+	})
+
+	It(`Stops when the context is done`, func() {
+		queue.AddUrls("https://example.com/a")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		report, err := queue.FlushWithContext(ctx)
+		Expect(err).ToNot(BeNil())
+		Expect(report.Failed()).To(HaveLen(1))
+		Expect(api.calls).To(BeEmpty())
+	})
+
+	It(`Reads sitemaps and sitemap indexes`, func() {
+		index := `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/pages.xml</loc></sitemap>
+</sitemapindex>`
+		pages := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc></url>
+  <url><loc> https://example.com/about </loc><lastmod>2025-01-01</lastmod></url>
+</urlset>`
+		fetch := func(loc string) (io.ReadCloser, error) {
+			Expect(loc).To(Equal("https://example.com/pages.xml"))
+			return io.NopCloser(strings.NewReader(pages)), nil
+		}
+		added, err := queue.AddSitemap(strings.NewReader(index), fetch)
+		Expect(err).To(BeNil())
+		Expect(added).To(Equal(2))
+
+		_, err = queue.AddSitemap(strings.NewReader(index), nil)
+		Expect(err).To(MatchError("the sitemap index lists 1 sitemaps that cannot be fetched"))
+		_, err = queue.AddSitemap(strings.NewReader("not xml"), nil)
+		Expect(err).ToNot(BeNil())
+
+		report, err := queue.Flush()
+		Expect(err).To(BeNil())
+		Expect(api.calls).To(Equal([]string{"purge_by_urls https://example.com/,https://example.com/about"}))
+		Expect(report.Results).To(HaveLen(2))
+	})
+
+	It(`Maps changed files to URLs`, func() {
+		added := queue.AddChangedFiles([]string{"public/index.html", "public/css/site.css", "README.md"}, func(file string) []string {
+			if !strings.HasPrefix(file, "public/") {
+				return nil
+			}
+			url := "https://example.com/" + strings.TrimPrefix(file, "public/")
+			if strings.HasSuffix(url, "/index.html") {
+				return []string{strings.TrimSuffix(url, "index.html"), url}
+			}
+			return []string{url}
+		})
+		Expect(added).To(Equal(3))
+		_, err := queue.Flush()
+		Expect(err).To(BeNil())
+		Expect(api.calls).To(Equal([]string{"purge_by_urls https://example.com/,https://example.com/index.html,https://example.com/css/site.css"}))
+	})
+
+	It(`Rejects negative options`, func() {
+		service, _ := cachingapiv1.NewCachingApiV1(&cachingapiv1.CachingApiV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Crn:           core.StringPtr("crn"),
+			ZoneID:        core.StringPtr("zone"),
+		})
+		_, err := service.NewPurgeQueue(&cachingapiv1.PurgeQueueOptions{BatchSize: -1})
+		Expect(err).To(MatchError("purge queue options cannot be negative"))
+		_, err = service.NewPurgeQueue(&cachingapiv1.PurgeQueueOptions{MaxRetries: core.Int64Ptr(-1)})
+		Expect(err).To(MatchError("purge queue options cannot be negative"))
+	})
+})
@@ -0,0 +1,23 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+// LastPage returns true when a page of a list, numbered from 1, is its last one: it holds fewer than perPage items,
+// the list has no total count, or the pages read so far reach the total count.
+func LastPage(page int64, perPage int64, count int, totalCount *int64) bool {
+	return int64(count) < perPage || totalCount == nil || page*perPage >= *totalCount
+}
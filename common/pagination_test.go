@@ -0,0 +1,34 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastPage(t *testing.T) {
+	total := int64(250)
+	assert.False(t, LastPage(1, 100, 100, &total))
+	assert.False(t, LastPage(2, 100, 100, &total))
+	assert.True(t, LastPage(3, 100, 50, &total))
+	assert.True(t, LastPage(1, 100, 100, nil))
+
+	total = 200
+	assert.True(t, LastPage(2, 100, 100, &total))
+}
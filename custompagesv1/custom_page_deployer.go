@@ -0,0 +1,347 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package custompagesv1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Constants associated with the custom page identifiers that the CustomPageObject.ID constants do not list.
+const (
+	CustomPageObject_ID_1000Errors = "1000_errors"
+	CustomPageObject_ID_500Errors  = "500_errors"
+)
+
+// CustomPageRequiredTokens are the tokens the template of each custom page must contain, by page identifier. The
+// required tokens listed by the service for a page take precedence.
+var CustomPageRequiredTokens = map[string][]string{
+	CustomPageObject_ID_1000Errors:       {CustomPageObject_RequiredTokens_CloudflareError1000sBox},
+	CustomPageObject_ID_500Errors:        {CustomPageObject_RequiredTokens_CloudflareError500sBox},
+	CustomPageObject_ID_AlwaysOnline:     {CustomPageObject_RequiredTokens_AlwaysOnlineNoCopyBox},
+	CustomPageObject_ID_BasicChallenge:   {CustomPageObject_RequiredTokens_CaptchaBox},
+	CustomPageObject_ID_CountryChallenge: {CustomPageObject_RequiredTokens_CaptchaBox},
+	CustomPageObject_ID_IpBlock:          {CustomPageObject_RequiredTokens_CloudflareError1000sBox},
+	CustomPageObject_ID_RatelimitBlock:   {CustomPageObject_RequiredTokens_CloudflareError1000sBox},
+	CustomPageObject_ID_UnderAttack:      {CustomPageObject_RequiredTokens_ImUnderAttackBox},
+	CustomPageObject_ID_WafBlock:         {CustomPageObject_RequiredTokens_CloudflareError1000sBox},
+	CustomPageObject_ID_WafChallenge:     {CustomPageObject_RequiredTokens_CaptchaBox},
+}
+
+// CustomPageTemplate : The HTML template of a custom page.
+type CustomPageTemplate struct {
+	// Custom page identifier.
+	PageID string
+
+	// The name of the template file, the page identifier followed by .html.
+	File string
+
+	// The content of the template.
+	Content []byte
+}
+
+// LoadCustomPageTemplates reads the HTML templates of a directory. Each template is named after the page it
+// customizes, as waf_block.html; other files are ignored. The templates are sorted by page identifier.
+func LoadCustomPageTemplates(dir string) (templates []CustomPageTemplate, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".html" {
+			continue
+		}
+		pageID := strings.TrimSuffix(entry.Name(), ".html")
+		if _, ok := CustomPageRequiredTokens[pageID]; !ok {
+			err = fmt.Errorf("%s does not name a custom page", entry.Name())
+			return
+		}
+		content, readErr := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if readErr != nil {
+			err = readErr
+			return
+		}
+		templates = append(templates, CustomPageTemplate{PageID: pageID, File: entry.Name(), Content: content})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].PageID < templates[j].PageID
+	})
+	return
+}
+
+// MissingTokens returns the tokens of a list that the template does not contain.
+func (template *CustomPageTemplate) MissingTokens(tokens []string) (missing []string) {
+	for _, token := range tokens {
+		if !bytes.Contains(template.Content, []byte(token)) {
+			missing = append(missing, token)
+		}
+	}
+	return
+}
+
+// Constants associated with the DeployCustomPagesOptions.Scope property.
+const (
+	DeployCustomPagesOptions_Scope_Instance = "instance"
+	DeployCustomPagesOptions_Scope_Zone     = "zone"
+)
+
+// DeployCustomPagesOptions : The DeployCustomPages options.
+type DeployCustomPagesOptions struct {
+	// The directory of the HTML templates, as read by LoadCustomPageTemplates.
+	Dir *string `validate:"required,ne="`
+
+	// The URL the directory is served from: the template waf_block.html is fetched by the service from
+	// BaseURL/waf_block.html.
+	BaseURL *string `validate:"required,ne="`
+
+	// Customize the pages of the zone or of the whole instance. Defaults to zone.
+	Scope *string
+
+	// When set, each page is fetched from its URL with this client and checked before any page is updated.
+	HTTPClient *http.Client
+}
+
+// NewDeployCustomPagesOptions : Instantiate DeployCustomPagesOptions
+func (*CustomPagesV1) NewDeployCustomPagesOptions(dir string, baseURL string) *DeployCustomPagesOptions {
+	return &DeployCustomPagesOptions{
+		Dir:     core.StringPtr(dir),
+		BaseURL: core.StringPtr(baseURL),
+	}
+}
+
+// SetScope : Allow user to set Scope
+func (options *DeployCustomPagesOptions) SetScope(scope string) *DeployCustomPagesOptions {
+	options.Scope = core.StringPtr(scope)
+	return options
+}
+
+// SetHTTPClient : Allow user to set HTTPClient
+func (options *DeployCustomPagesOptions) SetHTTPClient(client *http.Client) *DeployCustomPagesOptions {
+	options.HTTPClient = client
+	return options
+}
+
+// CustomPageState : The URL and state of a custom page.
+type CustomPageState struct {
+	// Custom page identifier.
+	PageID string
+
+	// A URL that is associated with the Custom Page.
+	URL string
+
+	// The Custom Page state.
+	State string
+}
+
+// CustomPageDeployment : The custom pages updated by a deployment, with their previous URL and state.
+type CustomPageDeployment struct {
+	// One of the DeployCustomPagesOptions_Scope constants.
+	Scope string
+
+	// The pages as they were before the deployment, in the order they were updated.
+	Previous []CustomPageState
+
+	// The pages as the deployment left them.
+	Deployed []CustomPageState
+}
+
+// DeployCustomPages : Deploy a directory of custom page templates
+// Check that every template contains the tokens its page requires, and optionally that it is served at its URL,
+// then point each page to its URL. Nothing is updated when a check fails, and the pages already updated are rolled
+// back when an update fails.
+func (customPages *CustomPagesV1) DeployCustomPages(deployCustomPagesOptions *DeployCustomPagesOptions) (deployment *CustomPageDeployment, err error) {
+	return customPages.DeployCustomPagesWithContext(context.Background(), deployCustomPagesOptions)
+}
+
+// DeployCustomPagesWithContext is an alternate form of the DeployCustomPages method which supports a Context parameter
+func (customPages *CustomPagesV1) DeployCustomPagesWithContext(ctx context.Context, deployCustomPagesOptions *DeployCustomPagesOptions) (deployment *CustomPageDeployment, err error) {
+	err = core.ValidateNotNil(deployCustomPagesOptions, "deployCustomPagesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(deployCustomPagesOptions, "deployCustomPagesOptions")
+	if err != nil {
+		return
+	}
+	scope := DeployCustomPagesOptions_Scope_Zone
+	if deployCustomPagesOptions.Scope != nil {
+		scope = *deployCustomPagesOptions.Scope
+	}
+	if scope != DeployCustomPagesOptions_Scope_Zone && scope != DeployCustomPagesOptions_Scope_Instance {
+		err = fmt.Errorf("invalid custom page scope '%s'", scope)
+		return
+	}
+
+	templates, err := LoadCustomPageTemplates(*deployCustomPagesOptions.Dir)
+	if err != nil {
+		return
+	}
+	if len(templates) == 0 {
+		err = fmt.Errorf("no custom page template in %s", *deployCustomPagesOptions.Dir)
+		return
+	}
+	pages, err := customPages.listCustomPages(ctx, scope)
+	if err != nil {
+		return
+	}
+
+	baseURL := strings.TrimSuffix(*deployCustomPagesOptions.BaseURL, "/")
+	var problems []string
+	for i := range templates {
+		template := &templates[i]
+		page, ok := pages[template.PageID]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("the service has no %s custom page", template.PageID))
+			continue
+		}
+		required := page.RequiredTokens
+		if len(required) == 0 {
+			required = CustomPageRequiredTokens[template.PageID]
+		}
+		if missing := template.MissingTokens(required); len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s is missing %s", template.File, strings.Join(missing, ", ")))
+			continue
+		}
+		if deployCustomPagesOptions.HTTPClient != nil {
+			if problem := checkServedTemplate(ctx, deployCustomPagesOptions.HTTPClient, baseURL+"/"+template.File, required); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	if len(problems) > 0 {
+		err = fmt.Errorf("%d custom page templates cannot be deployed: %s", len(problems), strings.Join(problems, "; "))
+		return
+	}
+
+	deployment = &CustomPageDeployment{Scope: scope}
+	for _, template := range templates {
+		page := pages[template.PageID]
+		target := CustomPageState{PageID: template.PageID, URL: baseURL + "/" + template.File, State: CustomPageObject_State_Customized}
+		err = customPages.updateCustomPage(ctx, scope, target)
+		if err != nil {
+			err = fmt.Errorf("updating the %s custom page: %w", template.PageID, err)
+			if rollbackErr := customPages.RollbackCustomPagesWithContext(ctx, deployment); rollbackErr != nil {
+				err = fmt.Errorf("%w, and the rollback failed: %s", err, rollbackErr.Error())
+			}
+			deployment = nil
+			return
+		}
+		deployment.Previous = append(deployment.Previous, CustomPageState{PageID: template.PageID, URL: core.StringNilMapper(page.URL), State: core.StringNilMapper(page.State)})
+		deployment.Deployed = append(deployment.Deployed, target)
+	}
+	return
+}
+
+// RollbackCustomPages : Restore the custom pages of a deployment
+// Restore the URL and state that each page of the deployment had before it. Every page is restored even when some
+// fail, and the error reports the first failure.
+func (customPages *CustomPagesV1) RollbackCustomPages(deployment *CustomPageDeployment) (err error) {
+	return customPages.RollbackCustomPagesWithContext(context.Background(), deployment)
+}
+
+// RollbackCustomPagesWithContext is an alternate form of the RollbackCustomPages method which supports a Context parameter
+func (customPages *CustomPagesV1) RollbackCustomPagesWithContext(ctx context.Context, deployment *CustomPageDeployment) (err error) {
+	err = core.ValidateNotNil(deployment, "deployment cannot be nil")
+	if err != nil {
+		return
+	}
+	var failed []error
+	for i := len(deployment.Previous) - 1; i >= 0; i-- {
+		previous := deployment.Previous[i]
+		if updateErr := customPages.updateCustomPage(ctx, deployment.Scope, previous); updateErr != nil {
+			failed = append(failed, fmt.Errorf("restoring the %s custom page: %w", previous.PageID, updateErr))
+		}
+	}
+	if len(failed) > 0 {
+		err = fmt.Errorf("%d custom pages could not be restored, the first: %w", len(failed), failed[0])
+	}
+	return
+}
+
+// listCustomPages returns the custom pages of the zone or of the instance by identifier.
+func (customPages *CustomPagesV1) listCustomPages(ctx context.Context, scope string) (pages map[string]CustomPageObject, err error) {
+	var result *ListCustomPagesResp
+	if scope == DeployCustomPagesOptions_Scope_Instance {
+		result, _, err = customPages.ListInstanceCustomPagesWithContext(ctx, customPages.NewListInstanceCustomPagesOptions())
+	} else {
+		result, _, err = customPages.ListZoneCustomPagesWithContext(ctx, customPages.NewListZoneCustomPagesOptions())
+	}
+	if err != nil {
+		return
+	}
+	pages = map[string]CustomPageObject{}
+	for _, page := range result.Result {
+		pages[core.StringNilMapper(page.ID)] = page
+	}
+	return
+}
+
+// updateCustomPage sets the URL and state of a page. A page in the default state is sent without its URL.
+func (customPages *CustomPagesV1) updateCustomPage(ctx context.Context, scope string, page CustomPageState) (err error) {
+	var url *string
+	if page.URL != "" && page.State != CustomPageObject_State_Default {
+		url = core.StringPtr(page.URL)
+	}
+	var state *string
+	if page.State != "" {
+		state = core.StringPtr(page.State)
+	}
+	if scope == DeployCustomPagesOptions_Scope_Instance {
+		options := customPages.NewUpdateInstanceCustomPageOptions(page.PageID)
+		options.URL, options.State = url, state
+		_, _, err = customPages.UpdateInstanceCustomPageWithContext(ctx, options)
+		return
+	}
+	options := customPages.NewUpdateZoneCustomPageOptions(page.PageID)
+	options.URL, options.State = url, state
+	_, _, err = customPages.UpdateZoneCustomPageWithContext(ctx, options)
+	return
+}
+
+// checkServedTemplate fetches a template from its URL, as the service will, and returns what is wrong with it.
+func checkServedTemplate(ctx context.Context, client *http.Client, url string, required []string) string {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Sprintf("%s cannot be fetched: %s", url, err.Error())
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Sprintf("%s cannot be fetched: %s", url, err.Error())
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Sprintf("%s answers %d", url, response.StatusCode)
+	}
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Sprintf("%s cannot be fetched: %s", url, err.Error())
+	}
+	served := CustomPageTemplate{Content: content}
+	if missing := served.MissingTokens(required); len(missing) > 0 {
+		return fmt.Sprintf("%s is served without %s", url, strings.Join(missing, ", "))
+	}
+	return ""
+}
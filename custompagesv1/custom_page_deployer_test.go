@@ -0,0 +1,195 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package custompagesv1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/custompagesv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCustomPages serves the custom pages of a zone and of its instance, and the templates under /pages/.
+type fakeCustomPages struct {
+	sync.Mutex
+	pages    map[string]map[string]interface{}
+	updates  []string
+	failPage string
+	dir      string
+}
+
+func (api *fakeCustomPages) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	if strings.HasPrefix(req.URL.Path, "/pages/") {
+		http.ServeFile(res, req, filepath.Join(api.dir, strings.TrimPrefix(req.URL.Path, "/pages/")))
+		return
+	}
+	res.Header().Set("Content-type", "application/json")
+	path := strings.TrimPrefix(req.URL.Path, "/v1/crn")
+	switch {
+	case req.Method == "GET" && (path == "/zones/zone/custom_pages" || path == "/custom_pages"):
+		var result []interface{}
+		for _, id := range []string{"basic_challenge", "ratelimit_block", "waf_block"} {
+			result = append(result, api.pages[id])
+		}
+		body, _ := json.Marshal(map[string]interface{}{"success": true, "errors": [][]string{}, "messages": [][]string{}, "result": result,
+			"result_info": map[string]interface{}{"page": 1, "per_page": 10, "total_pages": 1, "count": 3, "total_count": 3}})
+		res.Write(body)
+	case req.Method == "PUT":
+		id := path[strings.LastIndex(path, "/")+1:]
+		var update map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&update)).To(Succeed())
+		body, _ := json.Marshal(update)
+		api.updates = append(api.updates, path+" "+string(body))
+		if id == api.failPage {
+			res.WriteHeader(500)
+			fmt.Fprint(res, `{"success": false, "errors": [["internal error"]], "messages": [], "result": null}`)
+			return
+		}
+		for key, value := range update {
+			api.pages[id][key] = value
+		}
+		body, _ = json.Marshal(map[string]interface{}{"success": true, "errors": [][]string{}, "messages": [][]string{}, "result": api.pages[id]})
+		res.Write(body)
+	default:
+		res.WriteHeader(404)
+	}
+}
+
+var _ = Describe(`CustomPageDeployer`, func() {
+	var (
+		api     *fakeCustomPages
+		server  *httptest.Server
+		service *custompagesv1.CustomPagesV1
+		dir     string
+	)
+
+	write := func(name string, content string) {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "custom-pages")
+		Expect(err).To(BeNil())
+		write("basic_challenge.html", "<html><body>::CAPTCHA_BOX::</body></html>")
+		write("waf_block.html", "<html><body>::CLOUDFLARE_ERROR_1000S_BOX::</body></html>")
+		write("README.md", "The custom pages of example.com")
+
+		page := func(id string, token string, url string, state string) map[string]interface{} {
+			return map[string]interface{}{"id": id, "description": id, "required_tokens": []string{token}, "preview_target": "preview:target",
+				"created_on": "2025-01-01T00:00:00Z", "modified_on": "2025-01-01T00:00:00Z", "url": url, "state": state}
+		}
+		api = &fakeCustomPages{dir: dir, pages: map[string]map[string]interface{}{
+			"basic_challenge": page("basic_challenge", "::CAPTCHA_BOX::", "", "default"),
+			"ratelimit_block": page("ratelimit_block", "::CLOUDFLARE_ERROR_1000S_BOX::", "", "default"),
+			"waf_block":       page("waf_block", "::CLOUDFLARE_ERROR_1000S_BOX::", "https://old.example.com/waf.html", "customized"),
+		}}
+		server = httptest.NewServer(api)
+		service, err = custompagesv1.NewCustomPagesV1(&custompagesv1.CustomPagesV1Options{
+			URL:            server.URL,
+			Authenticator:  &core.NoAuthAuthenticator{},
+			Crn:            core.StringPtr("crn"),
+			ZoneIdentifier: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	It(`Deploys the templates and rolls them back`, func() {
+		options := service.NewDeployCustomPagesOptions(dir, server.URL+"/pages/").SetHTTPClient(server.Client())
+		deployment, err := service.DeployCustomPages(options)
+		Expect(err).To(BeNil())
+		Expect(api.updates).To(Equal([]string{
+			`/zones/zone/custom_pages/basic_challenge {"state":"customized","url":"` + server.URL + `/pages/basic_challenge.html"}`,
+			`/zones/zone/custom_pages/waf_block {"state":"customized","url":"` + server.URL + `/pages/waf_block.html"}`,
+		}))
+		Expect(deployment.Previous).To(Equal([]custompagesv1.CustomPageState{
+			{PageID: "basic_challenge", State: "default"},
+			{PageID: "waf_block", URL: "https://old.example.com/waf.html", State: "customized"},
+		}))
+		Expect(deployment.Deployed[1].URL).To(Equal(server.URL + "/pages/waf_block.html"))
+
+		api.updates = nil
+		Expect(service.RollbackCustomPages(deployment)).To(Succeed())
+		Expect(api.updates).To(Equal([]string{
+			`/zones/zone/custom_pages/waf_block {"state":"customized","url":"https://old.example.com/waf.html"}`,
+			`/zones/zone/custom_pages/basic_challenge {"state":"default"}`,
+		}))
+	})
+
+	It(`Checks every template before updating any page`, func() {
+		write("ratelimit_block.html", "<html><body>Slow down</body></html>")
+		_, err := service.DeployCustomPages(service.NewDeployCustomPagesOptions(dir, "https://pages.example.com"))
+		Expect(err).To(MatchError("1 custom page templates cannot be deployed: ratelimit_block.html is missing ::CLOUDFLARE_ERROR_1000S_BOX::"))
+		Expect(api.updates).To(BeEmpty())
+
+		Expect(os.Remove(filepath.Join(dir, "ratelimit_block.html"))).To(Succeed())
+		options := service.NewDeployCustomPagesOptions(dir, server.URL+"/missing").SetHTTPClient(server.Client())
+		_, err = service.DeployCustomPages(options)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("2 custom page templates cannot be deployed: " + server.URL + "/missing/basic_challenge.html answers 404"))
+		Expect(api.updates).To(BeEmpty())
+
+		write("under_attack.html", "::IM_UNDER_ATTACK_BOX::")
+		_, err = service.DeployCustomPages(service.NewDeployCustomPagesOptions(dir, "https://pages.example.com"))
+		Expect(err).To(MatchError("1 custom page templates cannot be deployed: the service has no under_attack custom page"))
+
+		write("teapot.html", "")
+		_, err = service.DeployCustomPages(service.NewDeployCustomPagesOptions(dir, "https://pages.example.com"))
+		Expect(err).To(MatchError("teapot.html does not name a custom page"))
+	})
+
+	It(`Rolls back the pages already updated when an update fails`, func() {
+		api.failPage = "waf_block"
+		options := service.NewDeployCustomPagesOptions(dir, "https://pages.example.com").SetScope(custompagesv1.DeployCustomPagesOptions_Scope_Instance)
+		deployment, err := service.DeployCustomPages(options)
+		Expect(deployment).To(BeNil())
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("updating the waf_block custom page: "))
+		Expect(api.updates).To(Equal([]string{
+			`/custom_pages/basic_challenge {"state":"customized","url":"https://pages.example.com/basic_challenge.html"}`,
+			`/custom_pages/waf_block {"state":"customized","url":"https://pages.example.com/waf_block.html"}`,
+			`/custom_pages/basic_challenge {"state":"default"}`,
+		}))
+	})
+
+	It(`Validates the options`, func() {
+		_, err := service.DeployCustomPages(nil)
+		Expect(err).ToNot(BeNil())
+		_, err = service.DeployCustomPages(service.NewDeployCustomPagesOptions(dir, ""))
+		Expect(err).ToNot(BeNil())
+		_, err = service.DeployCustomPages(service.NewDeployCustomPagesOptions(dir, "https://pages.example.com").SetScope("account"))
+		Expect(err).To(MatchError("invalid custom page scope 'account'"))
+	})
+})
@@ -0,0 +1,454 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package directlinkattach attaches Direct Link gateways to networks.
+//
+// A Direct Link gateway reaches a VPC or the classic network through one of its own virtual connections, and a
+// Transit Gateway through a Transit Gateway connection of network type 'directlink'. The Attacher picks the right
+// API for the requested mode, waits until both the Direct Link and, where there is one, the Transit Gateway side
+// report the attachment as active, and detaches in the reverse order.
+package directlinkattach
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	"github.com/IBM/networking-go-sdk/transitgatewayapisv1"
+)
+
+// DefaultPollInterval is the interval between status checks while waiting for an attachment to settle.
+const DefaultPollInterval = 10 * time.Second
+
+// Constants associated with the AttachOptions.Mode property.
+// How the Direct Link gateway is attached to the network.
+const (
+	AttachOptions_Mode_Classic        = "classic"
+	AttachOptions_Mode_TransitGateway = "transit_gateway"
+	AttachOptions_Mode_Vpc            = "vpc"
+)
+
+// Attacher : Attaches Direct Link gateways to VPCs, the classic network and Transit Gateways.
+type Attacher struct {
+	// The Direct Link client.
+	DirectLink *directlinkv1.DirectLinkV1
+
+	// The Transit Gateway client. Only needed for the transit_gateway mode.
+	TransitGateway *transitgatewayapisv1.TransitGatewayApisV1
+
+	// The interval between status checks. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// AttachOptions : The Attach options.
+type AttachOptions struct {
+	// Direct Link gateway identifier.
+	GatewayID string
+
+	// How the gateway is attached to the network.
+	Mode string
+
+	// The name of the virtual connection or of the Transit Gateway connection.
+	Name string
+
+	// The network to attach to: the CRN of the VPC for the vpc mode, the Transit Gateway identifier for the
+	// transit_gateway mode. Must be empty for the classic mode.
+	NetworkID string
+
+	// The maximum time to wait for the attachment to become active. Zero means wait until the context is done.
+	Timeout time.Duration
+}
+
+// Attachment : The resources created by Attach. The same value is passed to Detach.
+type Attachment struct {
+	// How the gateway is attached to the network.
+	Mode string `json:"mode"`
+
+	// Direct Link gateway identifier.
+	GatewayID string `json:"gateway_id"`
+
+	// The network the gateway is attached to, as given in AttachOptions.NetworkID.
+	NetworkID string `json:"network_id,omitempty"`
+
+	// The virtual connection of the Direct Link gateway. For the transit_gateway mode this is the connection created
+	// on the Direct Link side by the Transit Gateway; it is nil until that connection shows up.
+	VirtualConnection *directlinkv1.GatewayVirtualConnection `json:"virtual_connection,omitempty"`
+
+	// The Transit Gateway connection. Only set for the transit_gateway mode.
+	TransitGatewayConnection *transitgatewayapisv1.TransitGatewayConnectionCust `json:"transit_gateway_connection,omitempty"`
+
+	transitGatewayCrn string
+}
+
+// NewAttacher : Instantiate Attacher
+// The Transit Gateway client may be nil when the transit_gateway mode is not used.
+func NewAttacher(directLink *directlinkv1.DirectLinkV1, transitGateway *transitgatewayapisv1.TransitGatewayApisV1) (attacher *Attacher, err error) {
+	if directLink == nil {
+		err = fmt.Errorf("directLink cannot be nil")
+		return
+	}
+	attacher = &Attacher{
+		DirectLink:     directLink,
+		TransitGateway: transitGateway,
+	}
+	return
+}
+
+// Attach : Attach a Direct Link gateway to a network
+// Create a virtual connection (vpc and classic modes) or a Transit Gateway connection (transit_gateway mode) and
+// wait until it is attached. When waiting fails after the connection was created, the returned Attachment records
+// what was created so that it can be passed to Detach.
+func (attacher *Attacher) Attach(options *AttachOptions) (attachment *Attachment, err error) {
+	return attacher.AttachWithContext(context.Background(), options)
+}
+
+// AttachWithContext is an alternate form of the Attach method which supports a Context parameter
+func (attacher *Attacher) AttachWithContext(ctx context.Context, options *AttachOptions) (attachment *Attachment, err error) {
+	err = attacher.validateAttachOptions(options)
+	if err != nil {
+		return
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	gateway, err := attacher.getGateway(ctx, options.GatewayID)
+	if err != nil {
+		return
+	}
+	status := core.StringNilMapper(gateway.OperationalStatus)
+	if status != directlinkv1.GetGatewayResponse_OperationalStatus_Provisioned {
+		err = fmt.Errorf("gateway %s cannot be attached in operational status '%s'", options.GatewayID, status)
+		return
+	}
+
+	if options.Mode == AttachOptions_Mode_TransitGateway {
+		return attacher.attachTransitGateway(ctx, options, gateway)
+	}
+	return attacher.attachVirtualConnection(ctx, options)
+}
+
+// Detach : Detach a Direct Link gateway from a network
+// Remove what Attach created, in the reverse order, and wait until it is gone. Resources that no longer exist are
+// skipped, so Detach can be retried.
+func (attacher *Attacher) Detach(attachment *Attachment) (err error) {
+	return attacher.DetachWithContext(context.Background(), attachment)
+}
+
+// DetachWithContext is an alternate form of the Detach method which supports a Context parameter
+func (attacher *Attacher) DetachWithContext(ctx context.Context, attachment *Attachment) (err error) {
+	if attachment == nil {
+		return fmt.Errorf("attachment cannot be nil")
+	}
+	if attachment.Mode == AttachOptions_Mode_TransitGateway {
+		return attacher.detachTransitGateway(ctx, attachment)
+	}
+	return attacher.detachVirtualConnection(ctx, attachment)
+}
+
+func (attacher *Attacher) validateAttachOptions(options *AttachOptions) error {
+	if options == nil {
+		return fmt.Errorf("options cannot be nil")
+	}
+	if options.GatewayID == "" {
+		return fmt.Errorf("GatewayID cannot be empty")
+	}
+	if options.Name == "" {
+		return fmt.Errorf("Name cannot be empty")
+	}
+	switch options.Mode {
+	case AttachOptions_Mode_Classic:
+		if options.NetworkID != "" {
+			return fmt.Errorf("NetworkID must be empty for mode '%s'", options.Mode)
+		}
+	case AttachOptions_Mode_Vpc:
+		if options.NetworkID == "" {
+			return fmt.Errorf("NetworkID must be the CRN of the VPC for mode '%s'", options.Mode)
+		}
+	case AttachOptions_Mode_TransitGateway:
+		if options.NetworkID == "" {
+			return fmt.Errorf("NetworkID must be the Transit Gateway identifier for mode '%s'", options.Mode)
+		}
+		if attacher.TransitGateway == nil {
+			return fmt.Errorf("a Transit Gateway client is required for mode '%s'", options.Mode)
+		}
+	default:
+		return fmt.Errorf("unsupported mode '%s'", options.Mode)
+	}
+	return nil
+}
+
+func (attacher *Attacher) attachVirtualConnection(ctx context.Context, options *AttachOptions) (attachment *Attachment, err error) {
+	directLink := attacher.DirectLink
+	createOptions := directLink.NewCreateGatewayVirtualConnectionOptions(options.GatewayID, options.Name, options.Mode)
+	if options.NetworkID != "" {
+		createOptions.SetNetworkID(options.NetworkID)
+	}
+	virtualConnection, _, err := directLink.CreateGatewayVirtualConnectionWithContext(ctx, createOptions)
+	if err != nil {
+		return
+	}
+	attachment = &Attachment{
+		Mode:              options.Mode,
+		GatewayID:         options.GatewayID,
+		NetworkID:         options.NetworkID,
+		VirtualConnection: virtualConnection,
+	}
+
+	err = attacher.poll(ctx, func() (done bool, err error) {
+		virtualConnection, _, err = directLink.GetGatewayVirtualConnectionWithContext(ctx,
+			directLink.NewGetGatewayVirtualConnectionOptions(options.GatewayID, *attachment.VirtualConnection.ID))
+		if err != nil {
+			return
+		}
+		attachment.VirtualConnection = virtualConnection
+		return virtualConnectionAttached(virtualConnection)
+	})
+	if err != nil {
+		err = fmt.Errorf("virtual connection %s of gateway %s: %w", *attachment.VirtualConnection.ID, options.GatewayID, err)
+	}
+	return
+}
+
+func (attacher *Attacher) attachTransitGateway(ctx context.Context, options *AttachOptions, gateway *directlinkv1.GetGatewayResponse) (attachment *Attachment, err error) {
+	transitGatewayApis := attacher.TransitGateway
+	transitGateway, _, err := transitGatewayApis.GetTransitGatewayWithContext(ctx, transitGatewayApis.NewGetTransitGatewayOptions(options.NetworkID))
+	if err != nil {
+		return
+	}
+	status := core.StringNilMapper(transitGateway.Status)
+	if status != transitgatewayapisv1.TransitGateway_Status_Available {
+		err = fmt.Errorf("transit gateway %s cannot be attached in status '%s'", options.NetworkID, status)
+		return
+	}
+	if gateway.Crn == nil {
+		err = fmt.Errorf("gateway %s has no CRN", options.GatewayID)
+		return
+	}
+
+	createOptions := transitGatewayApis.NewCreateTransitGatewayConnectionOptions(options.NetworkID, transitgatewayapisv1.CreateTransitGatewayConnectionOptions_NetworkType_Directlink)
+	createOptions.SetName(options.Name)
+	createOptions.SetNetworkID(*gateway.Crn)
+	connection, _, err := transitGatewayApis.CreateTransitGatewayConnectionWithContext(ctx, createOptions)
+	if err != nil {
+		return
+	}
+	attachment = &Attachment{
+		Mode:                     options.Mode,
+		GatewayID:                options.GatewayID,
+		NetworkID:                options.NetworkID,
+		TransitGatewayConnection: connection,
+		transitGatewayCrn:        core.StringNilMapper(transitGateway.Crn),
+	}
+
+	// The Transit Gateway side is attached first; the Direct Link gateway then gets a virtual connection of type
+	// transit pointing back at the Transit Gateway.
+	err = attacher.poll(ctx, func() (done bool, err error) {
+		connection, _, err = transitGatewayApis.GetTransitGatewayConnectionWithContext(ctx,
+			transitGatewayApis.NewGetTransitGatewayConnectionOptions(options.NetworkID, *attachment.TransitGatewayConnection.ID))
+		if err != nil {
+			return
+		}
+		attachment.TransitGatewayConnection = connection
+		return transitGatewayConnectionAttached(connection)
+	})
+	if err != nil {
+		err = fmt.Errorf("transit gateway connection %s of transit gateway %s: %w", *attachment.TransitGatewayConnection.ID, options.NetworkID, err)
+		return
+	}
+
+	err = attacher.poll(ctx, func() (done bool, err error) {
+		virtualConnection, err := attacher.findTransitVirtualConnection(ctx, attachment)
+		if err != nil || virtualConnection == nil {
+			return
+		}
+		attachment.VirtualConnection = virtualConnection
+		return virtualConnectionAttached(virtualConnection)
+	})
+	if err != nil {
+		err = fmt.Errorf("virtual connection of gateway %s to transit gateway %s: %w", options.GatewayID, options.NetworkID, err)
+	}
+	return
+}
+
+func (attacher *Attacher) detachVirtualConnection(ctx context.Context, attachment *Attachment) (err error) {
+	if attachment.VirtualConnection == nil || attachment.VirtualConnection.ID == nil {
+		return
+	}
+	directLink := attacher.DirectLink
+	id := *attachment.VirtualConnection.ID
+	response, err := directLink.DeleteGatewayVirtualConnectionWithContext(ctx, directLink.NewDeleteGatewayVirtualConnectionOptions(attachment.GatewayID, id))
+	if err != nil && !isNotFound(response) {
+		return
+	}
+	err = attacher.poll(ctx, func() (done bool, err error) {
+		_, response, err := directLink.GetGatewayVirtualConnectionWithContext(ctx, directLink.NewGetGatewayVirtualConnectionOptions(attachment.GatewayID, id))
+		if isNotFound(response) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		err = fmt.Errorf("virtual connection %s of gateway %s: %w", id, attachment.GatewayID, err)
+	}
+	return
+}
+
+func (attacher *Attacher) detachTransitGateway(ctx context.Context, attachment *Attachment) (err error) {
+	if attachment.TransitGatewayConnection == nil || attachment.TransitGatewayConnection.ID == nil {
+		return
+	}
+	if attacher.TransitGateway == nil {
+		return fmt.Errorf("a Transit Gateway client is required for mode '%s'", attachment.Mode)
+	}
+	transitGatewayApis := attacher.TransitGateway
+	id := *attachment.TransitGatewayConnection.ID
+	response, err := transitGatewayApis.DeleteTransitGatewayConnectionWithContext(ctx, transitGatewayApis.NewDeleteTransitGatewayConnectionOptions(attachment.NetworkID, id))
+	if err != nil && !isNotFound(response) {
+		return
+	}
+
+	// Reverse of Attach: the Direct Link side goes away as the Transit Gateway connection is deleted, and the
+	// Transit Gateway connection itself is gone last.
+	err = attacher.poll(ctx, func() (done bool, err error) {
+		virtualConnection, err := attacher.findTransitVirtualConnection(ctx, attachment)
+		return err == nil && virtualConnection == nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("virtual connection of gateway %s to transit gateway %s: %w", attachment.GatewayID, attachment.NetworkID, err)
+	}
+	attachment.VirtualConnection = nil
+
+	err = attacher.poll(ctx, func() (done bool, err error) {
+		_, response, err := transitGatewayApis.GetTransitGatewayConnectionWithContext(ctx, transitGatewayApis.NewGetTransitGatewayConnectionOptions(attachment.NetworkID, id))
+		if isNotFound(response) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		err = fmt.Errorf("transit gateway connection %s of transit gateway %s: %w", id, attachment.NetworkID, err)
+	}
+	return
+}
+
+// findTransitVirtualConnection returns the virtual connection of type transit that the Transit Gateway of the
+// attachment created on the Direct Link gateway, or nil when there is none.
+func (attacher *Attacher) findTransitVirtualConnection(ctx context.Context, attachment *Attachment) (virtualConnection *directlinkv1.GatewayVirtualConnection, err error) {
+	if attachment.transitGatewayCrn == "" && attacher.TransitGateway != nil {
+		var transitGateway *transitgatewayapisv1.TransitGateway
+		transitGateway, _, err = attacher.TransitGateway.GetTransitGatewayWithContext(ctx, attacher.TransitGateway.NewGetTransitGatewayOptions(attachment.NetworkID))
+		if err != nil {
+			return
+		}
+		attachment.transitGatewayCrn = core.StringNilMapper(transitGateway.Crn)
+	}
+
+	directLink := attacher.DirectLink
+	collection, _, err := directLink.ListGatewayVirtualConnectionsWithContext(ctx, directLink.NewListGatewayVirtualConnectionsOptions(attachment.GatewayID))
+	if err != nil {
+		return
+	}
+	for i := range collection.VirtualConnections {
+		candidate := &collection.VirtualConnections[i]
+		if core.StringNilMapper(candidate.Type) != directlinkv1.GatewayVirtualConnection_Type_Transit {
+			continue
+		}
+		if core.StringNilMapper(candidate.NetworkID) == attachment.transitGatewayCrn {
+			virtualConnection = candidate
+			return
+		}
+	}
+	return
+}
+
+func (attacher *Attacher) getGateway(ctx context.Context, gatewayID string) (gateway *directlinkv1.GetGatewayResponse, err error) {
+	directLink := attacher.DirectLink
+	result, _, err := directLink.GetGatewayWithContext(ctx, directLink.NewGetGatewayOptions(gatewayID))
+	if err != nil {
+		return
+	}
+	gateway, ok := result.(*directlinkv1.GetGatewayResponse)
+	if !ok {
+		err = fmt.Errorf("unexpected response type %T for gateway %s", result, gatewayID)
+	}
+	return
+}
+
+// poll calls check until it reports done, returns an error or the context is done.
+func (attacher *Attacher) poll(ctx context.Context, check func() (done bool, err error)) error {
+	pollInterval := attacher.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	for {
+		done, err := check()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func virtualConnectionAttached(virtualConnection *directlinkv1.GatewayVirtualConnection) (done bool, err error) {
+	status := core.StringNilMapper(virtualConnection.Status)
+	switch status {
+	case directlinkv1.GatewayVirtualConnection_Status_Attached:
+		done = true
+	case directlinkv1.GatewayVirtualConnection_Status_Rejected,
+		directlinkv1.GatewayVirtualConnection_Status_Expired,
+		directlinkv1.GatewayVirtualConnection_Status_Deleting,
+		directlinkv1.GatewayVirtualConnection_Status_DetachedByNetwork,
+		directlinkv1.GatewayVirtualConnection_Status_DetachedByNetworkPending:
+		err = fmt.Errorf("unexpected status '%s'", status)
+	}
+	return
+}
+
+func transitGatewayConnectionAttached(connection *transitgatewayapisv1.TransitGatewayConnectionCust) (done bool, err error) {
+	status := core.StringNilMapper(connection.Status)
+	switch status {
+	case transitgatewayapisv1.TransitGatewayConnectionCust_Status_Attached:
+		done = true
+	case transitgatewayapisv1.TransitGatewayConnectionCust_Status_Failed,
+		transitgatewayapisv1.TransitGatewayConnectionCust_Status_Deleting,
+		transitgatewayapisv1.TransitGatewayConnectionCust_Status_Detached,
+		transitgatewayapisv1.TransitGatewayConnectionCust_Status_Detaching,
+		transitgatewayapisv1.TransitGatewayConnectionCust_Status_Suspended,
+		transitgatewayapisv1.TransitGatewayConnectionCust_Status_Suspending:
+		err = fmt.Errorf("unexpected status '%s'", status)
+	}
+	return
+}
+
+func isNotFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
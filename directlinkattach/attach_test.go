@@ -0,0 +1,262 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkattach_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/directlinkattach"
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	"github.com/IBM/networking-go-sdk/transitgatewayapisv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeNetwork serves the Direct Link and Transit Gateway endpoints used by the Attacher. Every read of a pending
+// resource moves it one step closer to attached.
+type fakeNetwork struct {
+	sync.Mutex
+	gatewayStatus        string
+	virtualConnections   []map[string]interface{}
+	connections          []map[string]interface{}
+	createdVirtualConns  int
+	createdConnections   int
+	transitVirtualStatus string
+	requests             []string
+}
+
+func (network *fakeNetwork) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	network.Lock()
+	defer network.Unlock()
+
+	path := req.URL.EscapedPath()
+	network.requests = append(network.requests, req.Method+" "+path)
+	switch {
+	case req.Method == "GET" && path == "/gateways/gw-1":
+		network.reply(res, 200, map[string]interface{}{"id": "gw-1", "name": "gateway", "type": "dedicated", "crn": "crn:v1:dl:gw-1", "operational_status": network.gatewayStatus})
+	case req.Method == "POST" && path == "/gateways/gw-1/virtual_connections":
+		var body map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		network.createdVirtualConns++
+		body["id"] = "vc-1"
+		body["status"] = "pending"
+		network.virtualConnections = append(network.virtualConnections, body)
+		network.reply(res, 201, body)
+	case req.Method == "GET" && path == "/gateways/gw-1/virtual_connections":
+		for _, virtualConnection := range network.virtualConnections {
+			if virtualConnection["type"] == "transit" {
+				virtualConnection["status"] = advance(virtualConnection["status"])
+			}
+		}
+		network.reply(res, 200, map[string]interface{}{"virtual_connections": network.virtualConnections})
+	case req.Method == "GET" && path == "/gateways/gw-1/virtual_connections/vc-1":
+		virtualConnection := network.find(network.virtualConnections, "vc-1")
+		if virtualConnection == nil {
+			network.reply(res, 404, map[string]interface{}{})
+			return
+		}
+		virtualConnection["status"] = advance(virtualConnection["status"])
+		network.reply(res, 200, virtualConnection)
+	case req.Method == "DELETE" && path == "/gateways/gw-1/virtual_connections/vc-1":
+		network.virtualConnections = nil
+		res.WriteHeader(204)
+	case req.Method == "GET" && path == "/transit_gateways/tgw-1":
+		network.reply(res, 200, map[string]interface{}{"id": "tgw-1", "name": "tgw", "crn": "crn:v1:tgw:tgw-1", "status": "available"})
+	case req.Method == "POST" && path == "/transit_gateways/tgw-1/connections":
+		var body map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		Expect(body["network_type"]).To(Equal("directlink"))
+		Expect(body["network_id"]).To(Equal("crn:v1:dl:gw-1"))
+		network.createdConnections++
+		body["id"] = "conn-1"
+		body["status"] = "pending"
+		network.connections = append(network.connections, body)
+		network.reply(res, 201, body)
+	case req.Method == "GET" && path == "/transit_gateways/tgw-1/connections/conn-1":
+		connection := network.find(network.connections, "conn-1")
+		if connection == nil {
+			network.reply(res, 404, map[string]interface{}{})
+			return
+		}
+		connection["status"] = advance(connection["status"])
+		if connection["status"] == "attached" && len(network.virtualConnections) == 0 {
+			network.virtualConnections = append(network.virtualConnections, map[string]interface{}{
+				"id": "vc-transit", "name": "tgw", "type": "transit", "network_id": "crn:v1:tgw:tgw-1", "status": network.transitVirtualStatus,
+			})
+		}
+		network.reply(res, 200, connection)
+	case req.Method == "DELETE" && path == "/transit_gateways/tgw-1/connections/conn-1":
+		network.connections = nil
+		network.virtualConnections = nil
+		res.WriteHeader(204)
+	default:
+		network.reply(res, 404, map[string]interface{}{})
+	}
+}
+
+func (network *fakeNetwork) reply(res http.ResponseWriter, status int, body interface{}) {
+	res.Header().Set("Content-type", "application/json")
+	res.WriteHeader(status)
+	Expect(json.NewEncoder(res).Encode(body)).To(Succeed())
+}
+
+func (network *fakeNetwork) find(resources []map[string]interface{}, id string) map[string]interface{} {
+	for _, resource := range resources {
+		if resource["id"] == id {
+			return resource
+		}
+	}
+	return nil
+}
+
+func advance(status interface{}) interface{} {
+	if status == "pending" {
+		return "attached"
+	}
+	return status
+}
+
+var _ = Describe(`Attacher`, func() {
+	var testServer *httptest.Server
+	var network *fakeNetwork
+	var attacher *directlinkattach.Attacher
+
+	BeforeEach(func() {
+		network = &fakeNetwork{gatewayStatus: "provisioned", transitVirtualStatus: "pending"}
+		testServer = httptest.NewServer(network)
+
+		directLinkService, err := directlinkv1.NewDirectLinkV1(&directlinkv1.DirectLinkV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Version:       core.StringPtr("2024-01-01"),
+		})
+		Expect(err).To(BeNil())
+		transitGatewayService, err := transitgatewayapisv1.NewTransitGatewayApisV1(&transitgatewayapisv1.TransitGatewayApisV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Version:       core.StringPtr("2024-01-01"),
+		})
+		Expect(err).To(BeNil())
+		attacher, err = directlinkattach.NewAttacher(directLinkService, transitGatewayService)
+		Expect(err).To(BeNil())
+		attacher.PollInterval = time.Millisecond
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	Describe(`NewAttacher(directLink, transitGateway)`, func() {
+		It(`Require a Direct Link client`, func() {
+			attacher, err := directlinkattach.NewAttacher(nil, nil)
+			Expect(err).ToNot(BeNil())
+			Expect(attacher).To(BeNil())
+		})
+	})
+	Describe(`Attach(options *AttachOptions)`, func() {
+		It(`Attach a VPC through a virtual connection`, func() {
+			attachment, err := attacher.Attach(&directlinkattach.AttachOptions{
+				GatewayID: "gw-1",
+				Mode:      directlinkattach.AttachOptions_Mode_Vpc,
+				Name:      "vpc-connection",
+				NetworkID: "crn:v1:vpc:vpc-1",
+			})
+			Expect(err).To(BeNil())
+			Expect(*attachment.VirtualConnection.Status).To(Equal("attached"))
+			Expect(*attachment.VirtualConnection.NetworkID).To(Equal("crn:v1:vpc:vpc-1"))
+			Expect(attachment.TransitGatewayConnection).To(BeNil())
+			Expect(network.createdConnections).To(BeZero())
+
+			Expect(attacher.Detach(attachment)).To(Succeed())
+			Expect(network.virtualConnections).To(BeEmpty())
+		})
+		It(`Attach a Transit Gateway and wait for both sides`, func() {
+			attachment, err := attacher.Attach(&directlinkattach.AttachOptions{
+				GatewayID: "gw-1",
+				Mode:      directlinkattach.AttachOptions_Mode_TransitGateway,
+				Name:      "tgw-connection",
+				NetworkID: "tgw-1",
+			})
+			Expect(err).To(BeNil())
+			Expect(network.createdVirtualConns).To(BeZero())
+			Expect(*attachment.TransitGatewayConnection.Status).To(Equal("attached"))
+			Expect(*attachment.VirtualConnection.ID).To(Equal("vc-transit"))
+			Expect(*attachment.VirtualConnection.Status).To(Equal("attached"))
+
+			Expect(attacher.Detach(attachment)).To(Succeed())
+			Expect(network.connections).To(BeEmpty())
+			Expect(network.requests).To(ContainElement("DELETE /transit_gateways/tgw-1/connections/conn-1"))
+			Expect(network.requests).ToNot(ContainElement(HavePrefix("DELETE /gateways/")))
+		})
+		It(`Return the partial attachment when the Direct Link side fails`, func() {
+			network.transitVirtualStatus = "rejected"
+			attachment, err := attacher.Attach(&directlinkattach.AttachOptions{
+				GatewayID: "gw-1",
+				Mode:      directlinkattach.AttachOptions_Mode_TransitGateway,
+				Name:      "tgw-connection",
+				NetworkID: "tgw-1",
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("rejected"))
+			Expect(attachment.TransitGatewayConnection).ToNot(BeNil())
+
+			Expect(attacher.Detach(attachment)).To(Succeed())
+			Expect(network.connections).To(BeEmpty())
+		})
+		It(`Refuse a gateway that is not provisioned`, func() {
+			network.gatewayStatus = "awaiting_loa"
+			attachment, err := attacher.Attach(&directlinkattach.AttachOptions{
+				GatewayID: "gw-1",
+				Mode:      directlinkattach.AttachOptions_Mode_Classic,
+				Name:      "classic-connection",
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(attachment).To(BeNil())
+			Expect(network.createdVirtualConns).To(BeZero())
+		})
+		It(`Validate the options for the mode`, func() {
+			_, err := attacher.Attach(&directlinkattach.AttachOptions{GatewayID: "gw-1", Mode: "vpc", Name: "vpc-connection"})
+			Expect(err).ToNot(BeNil())
+			_, err = attacher.Attach(&directlinkattach.AttachOptions{GatewayID: "gw-1", Mode: "classic", Name: "classic", NetworkID: "crn"})
+			Expect(err).ToNot(BeNil())
+			_, err = attacher.Attach(&directlinkattach.AttachOptions{GatewayID: "gw-1", Mode: "power", Name: "power"})
+			Expect(err).ToNot(BeNil())
+
+			attacher.TransitGateway = nil
+			_, err = attacher.Attach(&directlinkattach.AttachOptions{GatewayID: "gw-1", Mode: "transit_gateway", Name: "tgw", NetworkID: "tgw-1"})
+			Expect(err).ToNot(BeNil())
+			Expect(network.requests).To(BeEmpty())
+		})
+	})
+	Describe(`Detach(attachment *Attachment)`, func() {
+		It(`Succeed when the resources are already gone`, func() {
+			attachment := &directlinkattach.Attachment{
+				Mode:      directlinkattach.AttachOptions_Mode_Vpc,
+				GatewayID: "gw-1",
+				VirtualConnection: &directlinkv1.GatewayVirtualConnection{
+					ID: core.StringPtr("vc-1"),
+				},
+			}
+			Expect(attacher.Detach(attachment)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,602 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkv1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Bounds of the BFD timers accepted by Direct Link gateways.
+const (
+	MinBfdInterval   = 300
+	MaxBfdInterval   = 255000
+	MinBfdMultiplier = 1
+	MaxBfdMultiplier = 255
+)
+
+// Constants returned by BgpAsnKind.
+const (
+	BgpAsnKind_Invalid  = "invalid"
+	BgpAsnKind_Private  = "private"
+	BgpAsnKind_Public   = "public"
+	BgpAsnKind_Reserved = "reserved"
+)
+
+type bgpAsnRange struct {
+	first, last int64
+}
+
+// bgpReservedAsns are the ASNs used by IBM Cloud, which a customer cannot use for a gateway.
+var bgpReservedAsns = []bgpAsnRange{
+	{13884, 13884},
+	{36351, 36351},
+	{64512, 64513},
+	{65100, 65100},
+	{65201, 65234},
+	{65402, 65433},
+	{65500, 65500},
+	{4201065000, 4201065999},
+}
+
+// bgpInvalidAsns are set aside by IANA for documentation, transition or future use.
+var bgpInvalidAsns = []bgpAsnRange{
+	{0, 0},
+	{23456, 23456},
+	{64496, 64511},
+	{65535, 65551},
+	{65552, 131071},
+	{4294967295, 4294967295},
+}
+
+var bgpPrivateAsns = []bgpAsnRange{
+	{64512, 65534},
+	{4200000000, 4294967294},
+}
+
+// bgpPrivatePrefixes are the non-public ranges accepted for bgp_cer_cidr and bgp_ibm_cidr.
+var bgpPrivatePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("10.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+}
+
+// bgpUnusablePrefixes are special purpose ranges that are neither accepted private ranges nor public addresses.
+var bgpUnusablePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// GatewayBgpPlan : The BGP and BFD settings of a gateway.
+// Nil fields are left unchanged.
+type GatewayBgpPlan struct {
+	// Customer BGP ASN.
+	BgpAsn *int64
+
+	// BGP customer edge router CIDR. Must be given together with BgpIbmCidr.
+	BgpCerCidr *string
+
+	// BGP IBM CIDR. Must be given together with BgpCerCidr.
+	BgpIbmCidr *string
+
+	// BFD configuration. An interval of 0 removes the BFD configuration.
+	BfdConfig *GatewayBfdPatchTemplate
+
+	// The CRN of the key used for BGP MD5 authentication. An empty CRN removes the key.
+	AuthenticationKeyCrn *string
+}
+
+// GatewayBgpUpdate : The requests that move a gateway to a GatewayBgpPlan.
+// GatewayActions run first, in order, followed by UpdateGatewayOptions.
+type GatewayBgpUpdate struct {
+	// Gateway actions approving provider requests. Used for Direct Link Connect gateways managed by a provider.
+	GatewayActions []*CreateGatewayActionOptions
+
+	// The gateway patch, nil when no patch is needed.
+	UpdateGatewayOptions *UpdateGatewayOptions
+}
+
+// IsEmpty returns true when the gateway already matches the plan.
+func (update *GatewayBgpUpdate) IsEmpty() bool {
+	return len(update.GatewayActions) == 0 && update.UpdateGatewayOptions == nil
+}
+
+// BgpAsnKind returns whether asn is a private, public, reserved or invalid BGP ASN. Reserved ASNs are used by IBM
+// Cloud and cannot be used for a gateway.
+func BgpAsnKind(asn int64) string {
+	switch {
+	case inBgpAsnRanges(asn, bgpReservedAsns):
+		return BgpAsnKind_Reserved
+	case asn < 0 || asn > 4294967295 || inBgpAsnRanges(asn, bgpInvalidAsns):
+		return BgpAsnKind_Invalid
+	case inBgpAsnRanges(asn, bgpPrivateAsns):
+		return BgpAsnKind_Private
+	}
+	return BgpAsnKind_Public
+}
+
+// ValidateBgpAsn checks that asn can be used as the customer ASN of a gateway.
+func ValidateBgpAsn(asn int64) error {
+	switch kind := BgpAsnKind(asn); kind {
+	case BgpAsnKind_Reserved:
+		return fmt.Errorf("BGP ASN %d is reserved by IBM Cloud", asn)
+	case BgpAsnKind_Invalid:
+		return fmt.Errorf("BGP ASN %d is not a valid ASN", asn)
+	}
+	return nil
+}
+
+// ValidateBgpCidrs checks that the IBM and customer edge router CIDRs are two distinct host addresses of the same /30
+// or /31 network, in an accepted private range or in public address space.
+func ValidateBgpCidrs(ibmCidr string, cerCidr string) error {
+	ibm, err := parseBgpCidr(ibmCidr)
+	if err != nil {
+		return err
+	}
+	cer, err := parseBgpCidr(cerCidr)
+	if err != nil {
+		return err
+	}
+	if ibm.Bits() != cer.Bits() || ibm.Masked() != cer.Masked() {
+		return fmt.Errorf("BGP CIDRs %s and %s are not in the same network", ibmCidr, cerCidr)
+	}
+	if ibm.Addr() == cer.Addr() {
+		return fmt.Errorf("BGP CIDRs %s and %s use the same address", ibmCidr, cerCidr)
+	}
+	return nil
+}
+
+// AllocateBgpCidrs returns the first /30 or /31 network of baseCidr that does not overlap any of the used CIDRs, as
+// an IBM CIDR and a customer edge router CIDR. The IBM side gets the first host address of the network.
+func AllocateBgpCidrs(baseCidr string, prefixLength int, used []string) (ibmCidr string, cerCidr string, err error) {
+	if prefixLength != 30 && prefixLength != 31 {
+		err = fmt.Errorf("BGP networks must be /30 or /31, not /%d", prefixLength)
+		return
+	}
+	base, err := netip.ParsePrefix(baseCidr)
+	if err != nil {
+		return
+	}
+	base = base.Masked()
+	if !base.Addr().Is4() {
+		err = fmt.Errorf("BGP base CIDR %s is not an IPv4 network", baseCidr)
+		return
+	}
+	if base.Bits() > prefixLength {
+		err = fmt.Errorf("BGP base CIDR %s is smaller than a /%d", baseCidr, prefixLength)
+		return
+	}
+	err = checkBgpAddressRange(base)
+	if err != nil {
+		return
+	}
+	usedPrefixes := make([]netip.Prefix, 0, len(used))
+	for _, cidr := range used {
+		var prefix netip.Prefix
+		prefix, err = netip.ParsePrefix(cidr)
+		if err != nil {
+			return
+		}
+		usedPrefixes = append(usedPrefixes, prefix.Masked())
+	}
+
+	candidate := netip.PrefixFrom(base.Addr(), prefixLength)
+	for base.Contains(candidate.Addr()) {
+		free := true
+		for _, prefix := range usedPrefixes {
+			if prefix.Overlaps(candidate) {
+				free = false
+				break
+			}
+		}
+		if free {
+			ibm := candidate.Addr()
+			if prefixLength == 30 {
+				ibm = ibm.Next()
+			}
+			ibmCidr = netip.PrefixFrom(ibm, prefixLength).String()
+			cerCidr = netip.PrefixFrom(ibm.Next(), prefixLength).String()
+			return
+		}
+		next := candidate.Addr()
+		for i := 0; i < 1<<(32-prefixLength); i++ {
+			next = next.Next()
+		}
+		if !next.IsValid() {
+			break
+		}
+		candidate = netip.PrefixFrom(next, prefixLength)
+	}
+	err = fmt.Errorf("no free /%d network left in BGP base CIDR %s", prefixLength, baseCidr)
+	return
+}
+
+// ValidateBfdConfig checks the BFD timers. An interval of 0 removes the BFD configuration; a nil multiplier leaves
+// the default of the service.
+func ValidateBfdConfig(interval int64, multiplier *int64) error {
+	if interval == 0 {
+		return nil
+	}
+	if interval < MinBfdInterval || interval > MaxBfdInterval {
+		return fmt.Errorf("BFD interval %d ms is outside of %d-%d ms", interval, MinBfdInterval, MaxBfdInterval)
+	}
+	if multiplier != nil && (*multiplier < MinBfdMultiplier || *multiplier > MaxBfdMultiplier) {
+		return fmt.Errorf("BFD multiplier %d is outside of %d-%d", *multiplier, MinBfdMultiplier, MaxBfdMultiplier)
+	}
+	return nil
+}
+
+// Validate checks the settings of the plan on their own. A public BGP network requires a public ASN.
+func (plan *GatewayBgpPlan) Validate() error {
+	if plan.BgpAsn != nil {
+		if err := ValidateBgpAsn(*plan.BgpAsn); err != nil {
+			return err
+		}
+	}
+	if (plan.BgpCerCidr == nil) != (plan.BgpIbmCidr == nil) {
+		return fmt.Errorf("BgpCerCidr and BgpIbmCidr must be changed together")
+	}
+	if plan.BgpIbmCidr != nil {
+		if err := ValidateBgpCidrs(*plan.BgpIbmCidr, *plan.BgpCerCidr); err != nil {
+			return err
+		}
+		if plan.BgpAsn != nil && !isPrivateBgpCidr(*plan.BgpIbmCidr) && BgpAsnKind(*plan.BgpAsn) != BgpAsnKind_Public {
+			return fmt.Errorf("public BGP CIDR %s requires a public ASN, not %d", *plan.BgpIbmCidr, *plan.BgpAsn)
+		}
+	}
+	if plan.BfdConfig != nil {
+		if plan.BfdConfig.Interval == nil {
+			return fmt.Errorf("BfdConfig.Interval cannot be nil")
+		}
+		if err := ValidateBfdConfig(*plan.BfdConfig.Interval, plan.BfdConfig.Multiplier); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PlanGatewayBgpUpdate : Plan the update of the BGP and BFD settings of a gateway
+// Compare the current gateway with the desired plan and return the requests that apply the difference.
+//
+// Dedicated gateways and Direct Link Connect gateways managed through this API are changed with a single
+// UpdateGateway patch. The BGP ASN and addresses of a Connect gateway managed by a provider can only change by
+// approving the provider's update_attributes request, so the planner emits an update_attributes_approve gateway
+// action whose updates must match the pending request. While such a gateway waits for create_gateway approval, the
+// BFD and authentication key settings are given with the create_gateway_approve action instead of a patch.
+func PlanGatewayBgpUpdate(current *GetGatewayResponse, desired *GatewayBgpPlan) (update *GatewayBgpUpdate, err error) {
+	if current == nil || current.ID == nil {
+		err = fmt.Errorf("current gateway must have an ID")
+		return
+	}
+	if desired == nil {
+		err = fmt.Errorf("desired plan cannot be nil")
+		return
+	}
+	err = desired.Validate()
+	if err != nil {
+		return
+	}
+
+	// Validate the combination with the settings that the plan leaves unchanged.
+	effective := &GatewayBgpPlan{
+		BgpAsn:     firstInt64(desired.BgpAsn, current.BgpAsn),
+		BgpCerCidr: firstString(desired.BgpCerCidr, current.BgpCerCidr),
+		BgpIbmCidr: firstString(desired.BgpIbmCidr, current.BgpIbmCidr),
+	}
+	if (desired.BgpAsn != nil || desired.BgpIbmCidr != nil) && effective.BgpIbmCidr != nil && effective.BgpCerCidr != nil && effective.BgpAsn != nil {
+		err = effective.Validate()
+		if err != nil {
+			return
+		}
+	}
+
+	gatewayID := *current.ID
+	asnChanged := desired.BgpAsn != nil && !int64Equal(desired.BgpAsn, current.BgpAsn)
+	cidrsChanged := desired.BgpIbmCidr != nil &&
+		(!bgpCidrEqual(desired.BgpIbmCidr, current.BgpIbmCidr) || !bgpCidrEqual(desired.BgpCerCidr, current.BgpCerCidr))
+	bfdChanged := desired.BfdConfig != nil && !bfdConfigEqual(desired.BfdConfig, current.BfdConfig)
+	currentKeyCrn := ""
+	if current.AuthenticationKey != nil {
+		currentKeyCrn = core.StringNilMapper(current.AuthenticationKey.Crn)
+	}
+	keyChanged := desired.AuthenticationKeyCrn != nil && *desired.AuthenticationKeyCrn != currentKeyCrn
+
+	update = &GatewayBgpUpdate{}
+	patch := &GatewayPatchTemplate{}
+	patchNeeded := false
+	providerManaged := current.ProviderApiManaged != nil && *current.ProviderApiManaged
+	changeRequestType, changeRequestUpdates := gatewayChangeRequest(current.ChangeRequest)
+
+	if asnChanged || cidrsChanged {
+		if !providerManaged {
+			if asnChanged {
+				patch.BgpAsn = desired.BgpAsn
+			}
+			if cidrsChanged {
+				patch.BgpCerCidr = desired.BgpCerCidr
+				patch.BgpIbmCidr = desired.BgpIbmCidr
+			}
+			patchNeeded = true
+		} else {
+			if changeRequestType != GatewayChangeRequestGatewayClientGatewayUpdateAttributes_Type_UpdateAttributes {
+				err = fmt.Errorf("BGP settings of gateway %s are managed by the provider and there is no pending update request to approve", gatewayID)
+				update = nil
+				return
+			}
+			action := &CreateGatewayActionOptions{
+				ID:     core.StringPtr(gatewayID),
+				Action: core.StringPtr(CreateGatewayActionOptions_Action_UpdateAttributesApprove),
+			}
+			if asnChanged {
+				if !changeRequestUpdatesAsn(changeRequestUpdates, *desired.BgpAsn) {
+					err = fmt.Errorf("the pending update request of gateway %s does not change the BGP ASN to %d", gatewayID, *desired.BgpAsn)
+					update = nil
+					return
+				}
+				action.Updates = append(action.Updates, &GatewayActionTemplateUpdatesItemGatewayClientBGPASNUpdate{BgpAsn: desired.BgpAsn})
+			}
+			if cidrsChanged {
+				if !changeRequestUpdatesCidrs(changeRequestUpdates, *desired.BgpIbmCidr, *desired.BgpCerCidr) {
+					err = fmt.Errorf("the pending update request of gateway %s does not change the BGP CIDRs to %s and %s", gatewayID, *desired.BgpIbmCidr, *desired.BgpCerCidr)
+					update = nil
+					return
+				}
+				action.Updates = append(action.Updates, &GatewayActionTemplateUpdatesItemGatewayClientBGPIPUpdate{
+					BgpCerCidr: desired.BgpCerCidr,
+					BgpIbmCidr: desired.BgpIbmCidr,
+				})
+			}
+			update.GatewayActions = append(update.GatewayActions, action)
+		}
+	}
+
+	if bfdChanged || keyChanged {
+		if providerManaged && changeRequestType == GatewayChangeRequestGatewayClientGatewayCreate_Type_CreateGateway {
+			action := &CreateGatewayActionOptions{
+				ID:     core.StringPtr(gatewayID),
+				Action: core.StringPtr(CreateGatewayActionOptions_Action_CreateGatewayApprove),
+			}
+			if bfdChanged && *desired.BfdConfig.Interval != 0 {
+				action.BfdConfig = &GatewayBfdConfigActionTemplate{
+					Interval:   desired.BfdConfig.Interval,
+					Multiplier: desired.BfdConfig.Multiplier,
+				}
+			}
+			if keyChanged && *desired.AuthenticationKeyCrn != "" {
+				action.AuthenticationKey = &GatewayActionTemplateAuthenticationKey{Crn: desired.AuthenticationKeyCrn}
+			}
+			update.GatewayActions = append(update.GatewayActions, action)
+		} else {
+			if bfdChanged {
+				patch.BfdConfig = desired.BfdConfig
+			}
+			if keyChanged {
+				patch.AuthenticationKey = &GatewayPatchTemplateAuthenticationKey{Crn: desired.AuthenticationKeyCrn}
+			}
+			patchNeeded = true
+		}
+	}
+
+	if patchNeeded {
+		var gatewayPatch map[string]interface{}
+		gatewayPatch, err = patch.AsPatch()
+		if err != nil {
+			update = nil
+			return
+		}
+		update.UpdateGatewayOptions = &UpdateGatewayOptions{
+			ID:                        core.StringPtr(gatewayID),
+			GatewayPatchTemplatePatch: gatewayPatch,
+		}
+	}
+	return
+}
+
+// ApplyGatewayBgpUpdate : Apply a planned BGP update
+// Run the gateway actions of the update in order, then the gateway patch. Returns the gateway as returned by the
+// last request.
+func (directLink *DirectLinkV1) ApplyGatewayBgpUpdate(update *GatewayBgpUpdate) (result *Gateway, err error) {
+	return directLink.ApplyGatewayBgpUpdateWithContext(context.Background(), update)
+}
+
+// ApplyGatewayBgpUpdateWithContext is an alternate form of the ApplyGatewayBgpUpdate method which supports a Context parameter
+func (directLink *DirectLinkV1) ApplyGatewayBgpUpdateWithContext(ctx context.Context, update *GatewayBgpUpdate) (result *Gateway, err error) {
+	if update == nil {
+		err = fmt.Errorf("update cannot be nil")
+		return
+	}
+	for _, action := range update.GatewayActions {
+		result, _, err = directLink.CreateGatewayActionWithContext(ctx, action)
+		if err != nil {
+			err = fmt.Errorf("gateway action %s: %w", core.StringNilMapper(action.Action), err)
+			return
+		}
+	}
+	if update.UpdateGatewayOptions != nil {
+		result, _, err = directLink.UpdateGatewayWithContext(ctx, update.UpdateGatewayOptions)
+	}
+	return
+}
+
+// gatewayChangeRequest returns the type and the pending updates of a gateway change request.
+func gatewayChangeRequest(changeRequest GatewayChangeRequestIntf) (changeType string, updates []GatewayChangeRequestUpdatesItemIntf) {
+	switch request := changeRequest.(type) {
+	case *GatewayChangeRequest:
+		return core.StringNilMapper(request.Type), request.Updates
+	case *GatewayChangeRequestGatewayClientGatewayCreate:
+		return core.StringNilMapper(request.Type), nil
+	case *GatewayChangeRequestGatewayClientGatewayDelete:
+		return core.StringNilMapper(request.Type), nil
+	case *GatewayChangeRequestGatewayClientGatewayUpdateAttributes:
+		for _, item := range request.Updates {
+			switch item := item.(type) {
+			case *GatewayChangeRequestGatewayClientGatewayUpdateAttributesUpdatesItem:
+				updates = append(updates, &GatewayChangeRequestUpdatesItem{
+					BgpAsn:     item.BgpAsn,
+					BgpCerCidr: item.BgpCerCidr,
+					BgpIbmCidr: item.BgpIbmCidr,
+				})
+			case *GatewayChangeRequestGatewayClientGatewayUpdateAttributesUpdatesItemGatewayClientBGPASNUpdate:
+				updates = append(updates, &GatewayChangeRequestUpdatesItem{BgpAsn: item.BgpAsn})
+			case *GatewayChangeRequestGatewayClientGatewayUpdateAttributesUpdatesItemGatewayClientBGPIPUpdate:
+				updates = append(updates, &GatewayChangeRequestUpdatesItem{BgpCerCidr: item.BgpCerCidr, BgpIbmCidr: item.BgpIbmCidr})
+			}
+		}
+		return core.StringNilMapper(request.Type), updates
+	}
+	return
+}
+
+func changeRequestUpdatesAsn(updates []GatewayChangeRequestUpdatesItemIntf, asn int64) bool {
+	for _, item := range updates {
+		if item, ok := item.(*GatewayChangeRequestUpdatesItem); ok && item.BgpAsn != nil && *item.BgpAsn == asn {
+			return true
+		}
+	}
+	return false
+}
+
+func changeRequestUpdatesCidrs(updates []GatewayChangeRequestUpdatesItemIntf, ibmCidr string, cerCidr string) bool {
+	for _, item := range updates {
+		if item, ok := item.(*GatewayChangeRequestUpdatesItem); ok &&
+			bgpCidrEqual(item.BgpIbmCidr, &ibmCidr) && bgpCidrEqual(item.BgpCerCidr, &cerCidr) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseBgpCidr(cidr string) (prefix netip.Prefix, err error) {
+	prefix, err = netip.ParsePrefix(cidr)
+	if err != nil {
+		return
+	}
+	if !prefix.Addr().Is4() {
+		err = fmt.Errorf("BGP CIDR %s is not an IPv4 address", cidr)
+		return
+	}
+	if prefix.Bits() != 30 && prefix.Bits() != 31 {
+		err = fmt.Errorf("BGP CIDR %s must be a /30 or /31", cidr)
+		return
+	}
+	if prefix.Bits() == 30 {
+		network := prefix.Masked().Addr()
+		if prefix.Addr() == network || prefix.Addr() == network.Next().Next().Next() {
+			err = fmt.Errorf("BGP CIDR %s uses the network or broadcast address", cidr)
+			return
+		}
+	}
+	err = checkBgpAddressRange(prefix.Masked())
+	return
+}
+
+// checkBgpAddressRange accepts the private ranges of bgpPrivatePrefixes and public address space.
+func checkBgpAddressRange(prefix netip.Prefix) error {
+	for _, private := range bgpPrivatePrefixes {
+		if private.Bits() <= prefix.Bits() && private.Contains(prefix.Addr()) {
+			return nil
+		}
+	}
+	for _, unusable := range bgpUnusablePrefixes {
+		if unusable.Overlaps(prefix) {
+			return fmt.Errorf("BGP CIDR %s must be in one of %v or in public address space", prefix, bgpPrivatePrefixes)
+		}
+	}
+	for _, private := range bgpPrivatePrefixes {
+		if private.Overlaps(prefix) {
+			return fmt.Errorf("BGP CIDR %s is larger than the private range %s", prefix, private)
+		}
+	}
+	return nil
+}
+
+func isPrivateBgpCidr(cidr string) bool {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return false
+	}
+	for _, private := range bgpPrivatePrefixes {
+		if private.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+func inBgpAsnRanges(asn int64, ranges []bgpAsnRange) bool {
+	for _, r := range ranges {
+		if asn >= r.first && asn <= r.last {
+			return true
+		}
+	}
+	return false
+}
+
+func bgpCidrEqual(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	prefixA, errA := netip.ParsePrefix(*a)
+	prefixB, errB := netip.ParsePrefix(*b)
+	if errA != nil || errB != nil {
+		return *a == *b
+	}
+	return prefixA == prefixB
+}
+
+func bfdConfigEqual(desired *GatewayBfdPatchTemplate, current *GatewayBfdConfig) bool {
+	if current == nil {
+		return *desired.Interval == 0
+	}
+	if !int64Equal(desired.Interval, current.Interval) {
+		return false
+	}
+	return desired.Multiplier == nil || int64Equal(desired.Multiplier, current.Multiplier)
+}
+
+func int64Equal(a *int64, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func firstInt64(values ...*int64) *int64 {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+func firstString(values ...*string) *string {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkv1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`DirectLinkV1 BGP planner`, func() {
+	Describe(`BgpAsnKind(asn int64)`, func() {
+		It(`Classify ASNs`, func() {
+			Expect(directlinkv1.BgpAsnKind(64999)).To(Equal(directlinkv1.BgpAsnKind_Private))
+			Expect(directlinkv1.BgpAsnKind(4200000001)).To(Equal(directlinkv1.BgpAsnKind_Private))
+			Expect(directlinkv1.BgpAsnKind(3356)).To(Equal(directlinkv1.BgpAsnKind_Public))
+			Expect(directlinkv1.BgpAsnKind(396982)).To(Equal(directlinkv1.BgpAsnKind_Public))
+			Expect(directlinkv1.BgpAsnKind(13884)).To(Equal(directlinkv1.BgpAsnKind_Reserved))
+			Expect(directlinkv1.BgpAsnKind(65210)).To(Equal(directlinkv1.BgpAsnKind_Reserved))
+			Expect(directlinkv1.BgpAsnKind(4201065500)).To(Equal(directlinkv1.BgpAsnKind_Reserved))
+			Expect(directlinkv1.BgpAsnKind(0)).To(Equal(directlinkv1.BgpAsnKind_Invalid))
+			Expect(directlinkv1.BgpAsnKind(23456)).To(Equal(directlinkv1.BgpAsnKind_Invalid))
+			Expect(directlinkv1.BgpAsnKind(65535)).To(Equal(directlinkv1.BgpAsnKind_Invalid))
+			Expect(directlinkv1.ValidateBgpAsn(64999)).To(Succeed())
+			Expect(directlinkv1.ValidateBgpAsn(64512)).ToNot(Succeed())
+		})
+	})
+	Describe(`ValidateBgpCidrs(ibmCidr string, cerCidr string)`, func() {
+		It(`Accept /30 and /31 pairs`, func() {
+			Expect(directlinkv1.ValidateBgpCidrs("169.254.0.1/30", "169.254.0.2/30")).To(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("10.254.30.78/31", "10.254.30.79/31")).To(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("203.0.113.1/30", "203.0.113.2/30")).To(Succeed())
+		})
+		It(`Reject invalid pairs`, func() {
+			Expect(directlinkv1.ValidateBgpCidrs("169.254.0.1/29", "169.254.0.2/29")).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("169.254.0.1/30", "169.254.0.5/30")).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("169.254.0.0/30", "169.254.0.1/30")).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("169.254.0.1/30", "169.254.0.1/30")).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("10.0.0.1/30", "10.0.0.2/30")).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBgpCidrs("fe80::1/127", "fe80::/127")).ToNot(Succeed())
+		})
+	})
+	Describe(`AllocateBgpCidrs(baseCidr string, prefixLength int, used []string)`, func() {
+		It(`Allocate the first free network`, func() {
+			ibm, cer, err := directlinkv1.AllocateBgpCidrs("169.254.0.0/29", 30, []string{"169.254.0.1/30"})
+			Expect(err).To(BeNil())
+			Expect(ibm).To(Equal("169.254.0.5/30"))
+			Expect(cer).To(Equal("169.254.0.6/30"))
+
+			ibm, cer, err = directlinkv1.AllocateBgpCidrs("10.254.0.0/30", 31, []string{"10.254.0.0/31"})
+			Expect(err).To(BeNil())
+			Expect(ibm).To(Equal("10.254.0.2/31"))
+			Expect(cer).To(Equal("10.254.0.3/31"))
+		})
+		It(`Fail when the base CIDR is exhausted or invalid`, func() {
+			_, _, err := directlinkv1.AllocateBgpCidrs("169.254.0.0/30", 30, []string{"169.254.0.0/30"})
+			Expect(err).ToNot(BeNil())
+			_, _, err = directlinkv1.AllocateBgpCidrs("169.254.0.0/24", 29, nil)
+			Expect(err).ToNot(BeNil())
+			_, _, err = directlinkv1.AllocateBgpCidrs("10.0.0.0/24", 30, nil)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+	Describe(`ValidateBfdConfig(interval int64, multiplier *int64)`, func() {
+		It(`Check the timer bounds`, func() {
+			Expect(directlinkv1.ValidateBfdConfig(300, core.Int64Ptr(3))).To(Succeed())
+			Expect(directlinkv1.ValidateBfdConfig(0, nil)).To(Succeed())
+			Expect(directlinkv1.ValidateBfdConfig(299, nil)).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBfdConfig(255001, nil)).ToNot(Succeed())
+			Expect(directlinkv1.ValidateBfdConfig(1000, core.Int64Ptr(256))).ToNot(Succeed())
+		})
+	})
+	Describe(`PlanGatewayBgpUpdate(current *GetGatewayResponse, desired *GatewayBgpPlan)`, func() {
+		var gateway *directlinkv1.GetGatewayResponse
+		BeforeEach(func() {
+			gateway = &directlinkv1.GetGatewayResponse{
+				ID:         core.StringPtr("gw-1"),
+				Type:       core.StringPtr(directlinkv1.GetGatewayResponse_Type_Dedicated),
+				BgpAsn:     core.Int64Ptr(64999),
+				BgpCerCidr: core.StringPtr("169.254.0.2/30"),
+				BgpIbmCidr: core.StringPtr("169.254.0.1/30"),
+				BfdConfig: &directlinkv1.GatewayBfdConfig{
+					Interval:   core.Int64Ptr(500),
+					Multiplier: core.Int64Ptr(3),
+				},
+			}
+		})
+		It(`Return an empty update when nothing changes`, func() {
+			update, err := directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{
+				BgpAsn:     core.Int64Ptr(64999),
+				BgpIbmCidr: core.StringPtr("169.254.0.1/30"),
+				BgpCerCidr: core.StringPtr("169.254.0.2/30"),
+				BfdConfig:  &directlinkv1.GatewayBfdPatchTemplate{Interval: core.Int64Ptr(500)},
+			})
+			Expect(err).To(BeNil())
+			Expect(update.IsEmpty()).To(BeTrue())
+		})
+		It(`Patch a dedicated gateway`, func() {
+			update, err := directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{
+				BgpAsn:               core.Int64Ptr(64998),
+				BfdConfig:            &directlinkv1.GatewayBfdPatchTemplate{Interval: core.Int64Ptr(0)},
+				AuthenticationKeyCrn: core.StringPtr("crn:v1:key"),
+			})
+			Expect(err).To(BeNil())
+			Expect(update.GatewayActions).To(BeEmpty())
+			Expect(*update.UpdateGatewayOptions.ID).To(Equal("gw-1"))
+			Expect(update.UpdateGatewayOptions.GatewayPatchTemplatePatch).To(Equal(map[string]interface{}{
+				"bgp_asn":            float64(64998),
+				"bfd_config":         map[string]interface{}{"interval": float64(0)},
+				"authentication_key": map[string]interface{}{"crn": "crn:v1:key"},
+			}))
+		})
+		It(`Reject an invalid plan`, func() {
+			_, err := directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{BgpCerCidr: core.StringPtr("169.254.0.6/30")})
+			Expect(err).ToNot(BeNil())
+			_, err = directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{
+				BgpIbmCidr: core.StringPtr("203.0.113.1/30"),
+				BgpCerCidr: core.StringPtr("203.0.113.2/30"),
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("public ASN"))
+			_, err = directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{
+				BfdConfig: &directlinkv1.GatewayBfdPatchTemplate{Interval: core.Int64Ptr(100)},
+			})
+			Expect(err).ToNot(BeNil())
+		})
+		It(`Approve the pending update request of a provider managed gateway`, func() {
+			gateway.Type = core.StringPtr(directlinkv1.GetGatewayResponse_Type_Connect)
+			gateway.ProviderApiManaged = core.BoolPtr(true)
+			gateway.ChangeRequest = &directlinkv1.GatewayChangeRequest{
+				Type: core.StringPtr("update_attributes"),
+				Updates: []directlinkv1.GatewayChangeRequestUpdatesItemIntf{
+					&directlinkv1.GatewayChangeRequestUpdatesItem{BgpAsn: core.Int64Ptr(64998)},
+					&directlinkv1.GatewayChangeRequestUpdatesItem{BgpIbmCidr: core.StringPtr("169.254.0.5/30"), BgpCerCidr: core.StringPtr("169.254.0.6/30")},
+				},
+			}
+			update, err := directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{
+				BgpAsn:     core.Int64Ptr(64998),
+				BgpIbmCidr: core.StringPtr("169.254.0.5/30"),
+				BgpCerCidr: core.StringPtr("169.254.0.6/30"),
+				BfdConfig:  &directlinkv1.GatewayBfdPatchTemplate{Interval: core.Int64Ptr(1000), Multiplier: core.Int64Ptr(5)},
+			})
+			Expect(err).To(BeNil())
+			Expect(update.GatewayActions).To(HaveLen(1))
+			action := update.GatewayActions[0]
+			Expect(*action.Action).To(Equal(directlinkv1.CreateGatewayActionOptions_Action_UpdateAttributesApprove))
+			Expect(action.Updates).To(HaveLen(2))
+			Expect(action.Updates[0]).To(Equal(&directlinkv1.GatewayActionTemplateUpdatesItemGatewayClientBGPASNUpdate{BgpAsn: core.Int64Ptr(64998)}))
+			Expect(update.UpdateGatewayOptions.GatewayPatchTemplatePatch).To(Equal(map[string]interface{}{
+				"bfd_config": map[string]interface{}{"interval": float64(1000), "multiplier": float64(5)},
+			}))
+
+			_, err = directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{BgpAsn: core.Int64Ptr(64997)})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("does not change the BGP ASN"))
+
+			gateway.ChangeRequest = nil
+			_, err = directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{BgpAsn: core.Int64Ptr(64998)})
+			Expect(err).ToNot(BeNil())
+		})
+		It(`Give BFD and authentication settings when approving a new connect gateway`, func() {
+			gateway.Type = core.StringPtr(directlinkv1.GetGatewayResponse_Type_Connect)
+			gateway.ProviderApiManaged = core.BoolPtr(true)
+			gateway.BfdConfig = nil
+			gateway.ChangeRequest = &directlinkv1.GatewayChangeRequest{Type: core.StringPtr("create_gateway")}
+			update, err := directlinkv1.PlanGatewayBgpUpdate(gateway, &directlinkv1.GatewayBgpPlan{
+				BfdConfig:            &directlinkv1.GatewayBfdPatchTemplate{Interval: core.Int64Ptr(300)},
+				AuthenticationKeyCrn: core.StringPtr("crn:v1:key"),
+			})
+			Expect(err).To(BeNil())
+			Expect(update.UpdateGatewayOptions).To(BeNil())
+			Expect(update.GatewayActions).To(HaveLen(1))
+			Expect(*update.GatewayActions[0].Action).To(Equal(directlinkv1.CreateGatewayActionOptions_Action_CreateGatewayApprove))
+			Expect(*update.GatewayActions[0].BfdConfig.Interval).To(Equal(int64(300)))
+			Expect(*update.GatewayActions[0].AuthenticationKey.Crn).To(Equal("crn:v1:key"))
+		})
+	})
+	Describe(`ApplyGatewayBgpUpdate(update *GatewayBgpUpdate)`, func() {
+		It(`Run the actions before the patch`, func() {
+			var requests []string
+			testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				var body map[string]interface{}
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				requests = append(requests, req.Method+" "+req.URL.EscapedPath())
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				res.Write([]byte(`{"id":"gw-1","name":"gateway","type":"connect"}`))
+			}))
+			defer testServer.Close()
+			directLinkService, err := directlinkv1.NewDirectLinkV1(&directlinkv1.DirectLinkV1Options{
+				URL:           testServer.URL,
+				Authenticator: &core.NoAuthAuthenticator{},
+				Version:       core.StringPtr("testString"),
+			})
+			Expect(err).To(BeNil())
+
+			update := &directlinkv1.GatewayBgpUpdate{
+				GatewayActions: []*directlinkv1.CreateGatewayActionOptions{
+					directLinkService.NewCreateGatewayActionOptions("gw-1").SetAction(directlinkv1.CreateGatewayActionOptions_Action_UpdateAttributesApprove),
+				},
+				UpdateGatewayOptions: directLinkService.NewUpdateGatewayOptions("gw-1", map[string]interface{}{"bgp_asn": 64998}),
+			}
+			gateway, err := directLinkService.ApplyGatewayBgpUpdate(update)
+			Expect(err).To(BeNil())
+			Expect(*gateway.ID).To(Equal("gw-1"))
+			Expect(requests).To(Equal([]string{"POST /gateways/gw-1/actions", "PATCH /gateways/gw-1"}))
+		})
+	})
+})
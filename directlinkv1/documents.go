@@ -0,0 +1,298 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkv1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// MaxGatewayDocumentSize is the largest Letter of Authorization or completion notice, in bytes, accepted by the
+// document helpers.
+const MaxGatewayDocumentSize = 10 * 1024 * 1024
+
+// DefaultCompletionNoticePollInterval is the interval between gateway status checks while waiting for a completion
+// notice to be reviewed.
+const DefaultCompletionNoticePollInterval = 30 * time.Second
+
+const gatewayDocumentContentType = "application/pdf"
+
+var pdfMagic = []byte("%PDF-")
+
+// CompletionNoticeRejectedError is returned when the completion notice of a gateway was rejected.
+type CompletionNoticeRejectedError struct {
+	// The gateway identifier.
+	GatewayID string
+
+	// The reason given for the rejection.
+	Reason string
+}
+
+func (e *CompletionNoticeRejectedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("completion notice of gateway %s was rejected", e.GatewayID)
+	}
+	return fmt.Sprintf("completion notice of gateway %s was rejected: %s", e.GatewayID, e.Reason)
+}
+
+// CompletionNoticeWaitOptions : The WaitForGatewayCompletionNotice options.
+type CompletionNoticeWaitOptions struct {
+	// The interval between gateway status checks. Defaults to DefaultCompletionNoticePollInterval.
+	PollInterval time.Duration
+
+	// The maximum time to wait. Zero means wait until the context is done.
+	Timeout time.Duration
+
+	// Called every time the operational status of the gateway changes.
+	OnStatusChange func(operationalStatus string, rejectReason string)
+}
+
+// DownloadGatewayLetterOfAuthorization : Download a gateway's Letter of Authorization
+// Copy the Letter of Authorization of a Direct Link Dedicated gateway to the writer. The document must be a PDF no
+// larger than MaxGatewayDocumentSize.
+func (directLink *DirectLinkV1) DownloadGatewayLetterOfAuthorization(gatewayID string, writer io.Writer) (written int64, err error) {
+	return directLink.DownloadGatewayLetterOfAuthorizationWithContext(context.Background(), gatewayID, writer)
+}
+
+// DownloadGatewayLetterOfAuthorizationWithContext is an alternate form of the DownloadGatewayLetterOfAuthorization method which supports a Context parameter
+func (directLink *DirectLinkV1) DownloadGatewayLetterOfAuthorizationWithContext(ctx context.Context, gatewayID string, writer io.Writer) (written int64, err error) {
+	if writer == nil {
+		err = fmt.Errorf("writer cannot be nil")
+		return
+	}
+	result, response, err := directLink.ListGatewayLetterOfAuthorizationWithContext(ctx, directLink.NewListGatewayLetterOfAuthorizationOptions(gatewayID))
+	if err != nil {
+		return
+	}
+	if result == nil {
+		err = fmt.Errorf("letter of authorization of gateway %s is empty", gatewayID)
+		return
+	}
+	defer result.Close()
+
+	if contentType := response.GetHeaders().Get("Content-Type"); contentType != "" {
+		mediaType, _, parseErr := mime.ParseMediaType(contentType)
+		if parseErr != nil || mediaType != gatewayDocumentContentType {
+			err = fmt.Errorf("letter of authorization of gateway %s has unexpected content type '%s'", gatewayID, contentType)
+			return
+		}
+	}
+
+	// Read one byte past the limit so that an oversized document can be told apart from one at the limit.
+	var document bytes.Buffer
+	_, err = io.Copy(&document, io.LimitReader(result, MaxGatewayDocumentSize+1))
+	if err != nil {
+		return
+	}
+	err = validateGatewayDocument(document.Bytes(), int64(document.Len()))
+	if err != nil {
+		err = fmt.Errorf("letter of authorization of gateway %s: %w", gatewayID, err)
+		return
+	}
+	return io.Copy(writer, &document)
+}
+
+// DownloadGatewayLetterOfAuthorizationToFile : Download a gateway's Letter of Authorization to a file
+// The document is written to a temporary file next to the destination and renamed once it is complete, so the
+// destination never holds a partial download.
+func (directLink *DirectLinkV1) DownloadGatewayLetterOfAuthorizationToFile(gatewayID string, path string) (written int64, err error) {
+	return directLink.DownloadGatewayLetterOfAuthorizationToFileWithContext(context.Background(), gatewayID, path)
+}
+
+// DownloadGatewayLetterOfAuthorizationToFileWithContext is an alternate form of the DownloadGatewayLetterOfAuthorizationToFile method which supports a Context parameter
+func (directLink *DirectLinkV1) DownloadGatewayLetterOfAuthorizationToFileWithContext(ctx context.Context, gatewayID string, path string) (written int64, err error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	written, err = directLink.DownloadGatewayLetterOfAuthorizationWithContext(ctx, gatewayID, file)
+	if err != nil {
+		return
+	}
+	err = file.Close()
+	if err != nil {
+		return
+	}
+	err = os.Rename(file.Name(), path)
+	return
+}
+
+// ValidateCompletionNoticeFile checks that the file at path is a PDF document of an acceptable size.
+func ValidateCompletionNoticeFile(path string) (err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("completion notice %s is not a regular file", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	header := make([]byte, len(pdfMagic))
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return
+	}
+	err = validateGatewayDocument(header[:n], info.Size())
+	if err != nil {
+		err = fmt.Errorf("completion notice %s: %w", path, err)
+	}
+	return
+}
+
+// UploadGatewayCompletionNoticeFile : Upload a completion notice from a file
+// Validate the file with ValidateCompletionNoticeFile and upload it through CreateGatewayCompletionNotice.
+func (directLink *DirectLinkV1) UploadGatewayCompletionNoticeFile(gatewayID string, path string) (response *core.DetailedResponse, err error) {
+	return directLink.UploadGatewayCompletionNoticeFileWithContext(context.Background(), gatewayID, path)
+}
+
+// UploadGatewayCompletionNoticeFileWithContext is an alternate form of the UploadGatewayCompletionNoticeFile method which supports a Context parameter
+func (directLink *DirectLinkV1) UploadGatewayCompletionNoticeFileWithContext(ctx context.Context, gatewayID string, path string) (response *core.DetailedResponse, err error) {
+	err = ValidateCompletionNoticeFile(path)
+	if err != nil {
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	createGatewayCompletionNoticeOptions := directLink.NewCreateGatewayCompletionNoticeOptions(gatewayID)
+	createGatewayCompletionNoticeOptions.SetUpload(file)
+	createGatewayCompletionNoticeOptions.SetUploadContentType(gatewayDocumentContentType)
+	return directLink.CreateGatewayCompletionNoticeWithContext(ctx, createGatewayCompletionNoticeOptions)
+}
+
+// WaitForGatewayCompletionNotice : Wait until a gateway's completion notice is accepted
+// Poll the gateway until its operational status shows that the completion notice was approved or that the gateway is
+// provisioned. A rejected notice ends the wait with a *CompletionNoticeRejectedError carrying the
+// CompletionNoticeRejectReason of the gateway.
+func (directLink *DirectLinkV1) WaitForGatewayCompletionNotice(gatewayID string, options *CompletionNoticeWaitOptions) (gateway *GetGatewayResponse, err error) {
+	return directLink.WaitForGatewayCompletionNoticeWithContext(context.Background(), gatewayID, options)
+}
+
+// WaitForGatewayCompletionNoticeWithContext is an alternate form of the WaitForGatewayCompletionNotice method which supports a Context parameter
+func (directLink *DirectLinkV1) WaitForGatewayCompletionNoticeWithContext(ctx context.Context, gatewayID string, options *CompletionNoticeWaitOptions) (gateway *GetGatewayResponse, err error) {
+	if options == nil {
+		options = &CompletionNoticeWaitOptions{}
+	}
+	pollInterval := options.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultCompletionNoticePollInterval
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	lastStatus := ""
+	for {
+		var result GetGatewayResponseIntf
+		result, _, err = directLink.GetGatewayWithContext(ctx, directLink.NewGetGatewayOptions(gatewayID))
+		if err != nil {
+			if ctx.Err() != nil {
+				err = completionNoticeWaitError(gatewayID, lastStatus, ctx.Err())
+			}
+			return
+		}
+		var ok bool
+		gateway, ok = result.(*GetGatewayResponse)
+		if !ok {
+			err = fmt.Errorf("unexpected response type %T for gateway %s", result, gatewayID)
+			return
+		}
+
+		status := core.StringNilMapper(gateway.OperationalStatus)
+		rejectReason := core.StringNilMapper(gateway.CompletionNoticeRejectReason)
+		if status != lastStatus && options.OnStatusChange != nil {
+			options.OnStatusChange(status, rejectReason)
+		}
+		lastStatus = status
+
+		switch status {
+		case GetGatewayResponse_OperationalStatus_CompletionNoticeApproved, GetGatewayResponse_OperationalStatus_Provisioned:
+			return
+		case GetGatewayResponse_OperationalStatus_CompletionNoticeRejected:
+			err = &CompletionNoticeRejectedError{GatewayID: gatewayID, Reason: rejectReason}
+			return
+		case GetGatewayResponse_OperationalStatus_CreateRejected, GetGatewayResponse_OperationalStatus_DeletePending:
+			err = fmt.Errorf("gateway %s cannot accept a completion notice in operational status '%s'", gatewayID, status)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			err = completionNoticeWaitError(gatewayID, status, ctx.Err())
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// SubmitGatewayCompletionNotice : Upload a completion notice and wait for it to be accepted
+// Combine UploadGatewayCompletionNoticeFile and WaitForGatewayCompletionNotice.
+func (directLink *DirectLinkV1) SubmitGatewayCompletionNotice(gatewayID string, path string, options *CompletionNoticeWaitOptions) (gateway *GetGatewayResponse, err error) {
+	return directLink.SubmitGatewayCompletionNoticeWithContext(context.Background(), gatewayID, path, options)
+}
+
+// SubmitGatewayCompletionNoticeWithContext is an alternate form of the SubmitGatewayCompletionNotice method which supports a Context parameter
+func (directLink *DirectLinkV1) SubmitGatewayCompletionNoticeWithContext(ctx context.Context, gatewayID string, path string, options *CompletionNoticeWaitOptions) (gateway *GetGatewayResponse, err error) {
+	_, err = directLink.UploadGatewayCompletionNoticeFileWithContext(ctx, gatewayID, path)
+	if err != nil {
+		return
+	}
+	return directLink.WaitForGatewayCompletionNoticeWithContext(ctx, gatewayID, options)
+}
+
+func completionNoticeWaitError(gatewayID string, lastStatus string, cause error) error {
+	return fmt.Errorf("stopped waiting for completion notice of gateway %s (last status '%s'): %w", gatewayID, lastStatus, cause)
+}
+
+// validateGatewayDocument checks the size and the leading bytes of a PDF document.
+func validateGatewayDocument(header []byte, size int64) error {
+	if size == 0 {
+		return fmt.Errorf("document is empty")
+	}
+	if size > MaxGatewayDocumentSize {
+		return fmt.Errorf("document is larger than %d bytes", MaxGatewayDocumentSize)
+	}
+	if !bytes.HasPrefix(header, pdfMagic) {
+		return fmt.Errorf("document is not a PDF")
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkv1_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`DirectLinkV1 gateway documents`, func() {
+	var testServer *httptest.Server
+	version := "testString"
+	gatewayID := "0a06fb9b-820f-4c44-8a31-77f1f0806d28"
+	gatewayPath := "/gateways/" + gatewayID
+
+	newService := func() *directlinkv1.DirectLinkV1 {
+		directLinkService, serviceErr := directlinkv1.NewDirectLinkV1(&directlinkv1.DirectLinkV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Version:       core.StringPtr(version),
+		})
+		Expect(serviceErr).To(BeNil())
+		return directLinkService
+	}
+
+	var tempDirs []string
+	tempDir := func() string {
+		dir, err := os.MkdirTemp("", "directlinkv1")
+		Expect(err).To(BeNil())
+		tempDirs = append(tempDirs, dir)
+		return dir
+	}
+	AfterEach(func() {
+		for _, dir := range tempDirs {
+			os.RemoveAll(dir)
+		}
+		tempDirs = nil
+	})
+
+	pdf, err := os.ReadFile("completion_notice.pdf")
+	if err != nil {
+		panic(err)
+	}
+
+	Describe(`DownloadGatewayLetterOfAuthorization(gatewayID string, writer io.Writer)`, func() {
+		var contentType string
+		var body []byte
+		BeforeEach(func() {
+			contentType = "application/pdf"
+			body = pdf
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(req.URL.EscapedPath()).To(Equal(gatewayPath + "/letter_of_authorization"))
+				Expect(req.Method).To(Equal("GET"))
+				res.Header().Set("Content-type", contentType)
+				res.WriteHeader(200)
+				res.Write(body)
+			}))
+		})
+		It(`Download the letter of authorization to a writer`, func() {
+			var buffer bytes.Buffer
+			written, err := newService().DownloadGatewayLetterOfAuthorization(gatewayID, &buffer)
+			Expect(err).To(BeNil())
+			Expect(written).To(Equal(int64(len(pdf))))
+			Expect(buffer.Bytes()).To(Equal(pdf))
+		})
+		It(`Download the letter of authorization to a file`, func() {
+			path := filepath.Join(tempDir(), "loa.pdf")
+			written, err := newService().DownloadGatewayLetterOfAuthorizationToFile(gatewayID, path)
+			Expect(err).To(BeNil())
+			Expect(written).To(Equal(int64(len(pdf))))
+			content, err := os.ReadFile(path)
+			Expect(err).To(BeNil())
+			Expect(content).To(Equal(pdf))
+		})
+		It(`Reject an unexpected content type`, func() {
+			contentType = "text/html"
+			var buffer bytes.Buffer
+			_, err := newService().DownloadGatewayLetterOfAuthorization(gatewayID, &buffer)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("unexpected content type"))
+			Expect(buffer.Len()).To(BeZero())
+		})
+		It(`Reject a document that is not a PDF and leave no file behind`, func() {
+			body = []byte("<html>not a pdf</html>")
+			dir := tempDir()
+			_, err := newService().DownloadGatewayLetterOfAuthorizationToFile(gatewayID, filepath.Join(dir, "loa.pdf"))
+			Expect(err).ToNot(BeNil())
+			entries, err := os.ReadDir(dir)
+			Expect(err).To(BeNil())
+			Expect(entries).To(BeEmpty())
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+	Describe(`ValidateCompletionNoticeFile(path string)`, func() {
+		It(`Accept a PDF document`, func() {
+			Expect(directlinkv1.ValidateCompletionNoticeFile("completion_notice.pdf")).To(Succeed())
+		})
+		It(`Reject missing, empty and non-PDF files`, func() {
+			dir := tempDir()
+			Expect(directlinkv1.ValidateCompletionNoticeFile(filepath.Join(dir, "missing.pdf"))).ToNot(Succeed())
+
+			empty := filepath.Join(dir, "empty.pdf")
+			Expect(os.WriteFile(empty, nil, 0600)).To(Succeed())
+			Expect(directlinkv1.ValidateCompletionNoticeFile(empty)).ToNot(Succeed())
+
+			text := filepath.Join(dir, "notice.txt")
+			Expect(os.WriteFile(text, []byte("completion notice"), 0600)).To(Succeed())
+			Expect(directlinkv1.ValidateCompletionNoticeFile(text)).ToNot(Succeed())
+
+			Expect(directlinkv1.ValidateCompletionNoticeFile(dir)).ToNot(Succeed())
+		})
+	})
+	Describe(`SubmitGatewayCompletionNotice(gatewayID string, path string, options *CompletionNoticeWaitOptions)`, func() {
+		var statuses []string
+		var uploaded []byte
+		var getCount int
+		BeforeEach(func() {
+			uploaded = nil
+			getCount = 0
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				switch {
+				case req.Method == "PUT" && req.URL.EscapedPath() == gatewayPath+"/completion_notice":
+					file, header, err := req.FormFile("upload")
+					Expect(err).To(BeNil())
+					Expect(header.Header.Get("Content-Type")).To(Equal("application/pdf"))
+					uploaded, err = io.ReadAll(file)
+					Expect(err).To(BeNil())
+					res.WriteHeader(204)
+				case req.Method == "GET" && req.URL.EscapedPath() == gatewayPath:
+					status := statuses[getCount]
+					if getCount < len(statuses)-1 {
+						getCount++
+					}
+					res.Header().Set("Content-type", "application/json")
+					res.WriteHeader(200)
+					reason := ""
+					if status == "completion_notice_rejected" {
+						reason = `,"completion_notice_reject_reason":"The completion notice file was blank"`
+					}
+					fmt.Fprintf(res, `{"id":"%s","name":"gateway","type":"dedicated","operational_status":"%s"%s}`, gatewayID, status, reason)
+				default:
+					res.WriteHeader(404)
+				}
+			}))
+		})
+		It(`Upload the notice and wait until it is approved`, func() {
+			statuses = []string{"awaiting_completion_notice", "completion_notice_received", "completion_notice_approved"}
+			var seen []string
+			gateway, err := newService().SubmitGatewayCompletionNotice(gatewayID, "completion_notice.pdf", &directlinkv1.CompletionNoticeWaitOptions{
+				PollInterval: time.Millisecond,
+				OnStatusChange: func(status string, rejectReason string) {
+					seen = append(seen, status)
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(*gateway.OperationalStatus).To(Equal("completion_notice_approved"))
+			Expect(uploaded).To(Equal(pdf))
+			Expect(seen).To(Equal(statuses))
+		})
+		It(`Report the rejection reason`, func() {
+			statuses = []string{"completion_notice_received", "completion_notice_rejected"}
+			_, err := newService().SubmitGatewayCompletionNotice(gatewayID, "completion_notice.pdf", &directlinkv1.CompletionNoticeWaitOptions{
+				PollInterval: time.Millisecond,
+			})
+			Expect(err).ToNot(BeNil())
+			var rejected *directlinkv1.CompletionNoticeRejectedError
+			Expect(errors.As(err, &rejected)).To(BeTrue())
+			Expect(rejected.Reason).To(Equal("The completion notice file was blank"))
+		})
+		It(`Stop waiting when the timeout expires`, func() {
+			statuses = []string{"completion_notice_received"}
+			_, err := newService().WaitForGatewayCompletionNotice(gatewayID, &directlinkv1.CompletionNoticeWaitOptions{
+				PollInterval: time.Millisecond,
+				Timeout:      20 * time.Millisecond,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("completion_notice_received"))
+		})
+		It(`Do not upload an invalid file`, func() {
+			statuses = []string{"awaiting_completion_notice"}
+			_, err := newService().SubmitGatewayCompletionNotice(gatewayID, "direct_link_v1.go", nil)
+			Expect(err).ToNot(BeNil())
+			Expect(uploaded).To(BeNil())
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+})
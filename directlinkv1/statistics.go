@@ -0,0 +1,829 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkv1
+
+// The statistics returned by GetGatewayStatistics are the text output of the router CLI. The parsers below accept the
+// common vendor layouts: "key: value" listings, possibly with several pairs per line, and column tables. Keys are
+// matched case-insensitively through a list of aliases, and anything that is not recognised is kept in the Fields map
+// of the result so that no information is lost.
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MacsecMkaSession : A MACsec Key Agreement session of one gateway interface.
+type MacsecMkaSession struct {
+	// The interface the session runs on.
+	Interface string `json:"interface,omitempty"`
+
+	// The local member identifier.
+	MemberIdentifier string `json:"member_identifier,omitempty"`
+
+	// The name of the connectivity association key in use.
+	CakName string `json:"cak_name,omitempty"`
+
+	// The type of the key in use, for example primary or fallback.
+	CakType string `json:"cak_type,omitempty"`
+
+	// The MKA hello transmit interval.
+	TransmitInterval time.Duration `json:"transmit_interval,omitempty"`
+
+	// The latest MKA message number sent.
+	MessageNumber *int64 `json:"message_number,omitempty"`
+
+	// Whether the gateway is the key server.
+	KeyServer *bool `json:"key_server,omitempty"`
+
+	// The key server priority.
+	KeyServerPriority *int64 `json:"key_server_priority,omitempty"`
+
+	// The association number of the latest secure association key.
+	LatestSakAn *int64 `json:"latest_sak_an,omitempty"`
+
+	// The key identifier of the latest secure association key.
+	LatestSakKi string `json:"latest_sak_ki,omitempty"`
+
+	// The peers of the session.
+	Peers []MacsecMkaPeer `json:"peers,omitempty"`
+
+	// Values that are not mapped to a typed field, keyed by normalized name.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// MacsecMkaPeer : A peer of a MACsec Key Agreement session.
+type MacsecMkaPeer struct {
+	// The member identifier of the peer.
+	MemberIdentifier string `json:"member_identifier,omitempty"`
+
+	// The state of the peer, for example live or potential.
+	State string `json:"state,omitempty"`
+
+	// The latest MKA message number received from the peer.
+	MessageNumber *int64 `json:"message_number,omitempty"`
+
+	// The hold time of the peer.
+	HoldTime time.Duration `json:"hold_time,omitempty"`
+
+	// The secure channel identifier of the peer.
+	Sci string `json:"sci,omitempty"`
+
+	// Values that are not mapped to a typed field, keyed by normalized name.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// MacsecMkaStatistics : MACsec Key Agreement packet counters of one gateway interface.
+type MacsecMkaStatistics struct {
+	// The interface the counters belong to.
+	Interface string `json:"interface,omitempty"`
+
+	// The number of MKA packets received.
+	ReceivedPackets *int64 `json:"received_packets,omitempty"`
+
+	// The number of MKA packets transmitted.
+	TransmittedPackets *int64 `json:"transmitted_packets,omitempty"`
+
+	// The number of packets received with an unsupported MKA version.
+	VersionMismatchPackets *int64 `json:"version_mismatch_packets,omitempty"`
+
+	// The number of packets received with a different connectivity association key.
+	CakMismatchPackets *int64 `json:"cak_mismatch_packets,omitempty"`
+
+	// The number of packets received with an invalid integrity check value.
+	IcvMismatchPackets *int64 `json:"icv_mismatch_packets,omitempty"`
+
+	// Every numeric counter found in the output, keyed by normalized name. Includes the counters above.
+	Counters map[string]int64 `json:"counters,omitempty"`
+}
+
+// MacsecPolicy : The MACsec policy applied to one gateway interface.
+type MacsecPolicy struct {
+	// The interface the policy applies to.
+	Interface string `json:"interface,omitempty"`
+
+	// The name of the connectivity association.
+	CaName string `json:"ca_name,omitempty"`
+
+	// The cipher suite in use, for example GCM-AES-256.
+	CipherSuite string `json:"cipher_suite,omitempty"`
+
+	// Whether encryption is enabled.
+	Encryption *bool `json:"encryption,omitempty"`
+
+	// The confidentiality offset.
+	KeyServerOffset *int64 `json:"key_server_offset,omitempty"`
+
+	// Whether the secure channel identifier is included in packets.
+	IncludeSci *bool `json:"include_sci,omitempty"`
+
+	// Whether replay protection is enabled.
+	ReplayProtect *bool `json:"replay_protect,omitempty"`
+
+	// The replay protection window.
+	ReplayWindow *int64 `json:"replay_window,omitempty"`
+
+	// Values that are not mapped to a typed field, keyed by normalized name.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// BfdSession : A bidirectional forwarding detection session.
+type BfdSession struct {
+	// The address of the BFD neighbor.
+	Address string `json:"address,omitempty"`
+
+	// The session state, for example Up, Down, Init or AdminDown.
+	State string `json:"state,omitempty"`
+
+	// The interface the session runs on.
+	Interface string `json:"interface,omitempty"`
+
+	// The detection time.
+	DetectTime time.Duration `json:"detect_time,omitempty"`
+
+	// The negotiated transmit interval.
+	TransmitInterval time.Duration `json:"transmit_interval,omitempty"`
+
+	// The detection multiplier.
+	Multiplier *int64 `json:"multiplier,omitempty"`
+
+	// Values that are not mapped to a typed field, keyed by normalized name.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// IsUp returns true if the session is in the up state.
+func (session *BfdSession) IsUp() bool {
+	return strings.EqualFold(session.State, "up")
+}
+
+// BgpNeighborSummary : The BGP neighbor summary of a gateway.
+type BgpNeighborSummary struct {
+	// The BGP neighbors.
+	Neighbors []BgpNeighbor `json:"neighbors"`
+}
+
+// BgpNeighbor : A BGP neighbor from a neighbor summary.
+type BgpNeighbor struct {
+	// The address of the neighbor.
+	Address string `json:"address"`
+
+	// The autonomous system number of the neighbor.
+	RemoteAsn int64 `json:"remote_asn"`
+
+	// The session state. Established sessions are always reported as "Established".
+	State string `json:"state"`
+
+	// The number of messages received from the neighbor.
+	MessagesReceived *int64 `json:"messages_received,omitempty"`
+
+	// The number of messages sent to the neighbor.
+	MessagesSent *int64 `json:"messages_sent,omitempty"`
+
+	// The number of times the session went down.
+	Flaps *int64 `json:"flaps,omitempty"`
+
+	// The time the session has been in its current state, as printed by the router.
+	Uptime string `json:"uptime,omitempty"`
+
+	// The number of prefixes received from the neighbor.
+	PrefixesReceived *int64 `json:"prefixes_received,omitempty"`
+
+	// The number of received prefixes that are active.
+	PrefixesActive *int64 `json:"prefixes_active,omitempty"`
+}
+
+// IsEstablished returns true if the BGP session is established.
+func (neighbor *BgpNeighbor) IsEstablished() bool {
+	return neighbor.State == bgpStateEstablished
+}
+
+// Decode parses the statistic data according to its type. The result is a []MacsecMkaSession,
+// []MacsecMkaStatistics, []MacsecPolicy or []BfdSession.
+func (statistic *GatewayStatistic) Decode() (result interface{}, err error) {
+	if statistic.Type == nil || statistic.Data == nil {
+		err = fmt.Errorf("statistic type and data are required")
+		return
+	}
+	switch *statistic.Type {
+	case GatewayStatistic_Type_MacsecMkaSession:
+		return ParseMacsecMkaSessions(*statistic.Data)
+	case GatewayStatistic_Type_MacsecMkaStatistics:
+		return ParseMacsecMkaStatistics(*statistic.Data)
+	case GatewayStatistic_Type_MacsecPolicy:
+		return ParseMacsecPolicies(*statistic.Data)
+	case GatewayStatistic_Type_BfdSession:
+		return ParseBfdSessions(*statistic.Data)
+	}
+	err = fmt.Errorf("unsupported statistic type '%s'", *statistic.Type)
+	return
+}
+
+var macsecMkaSessionKeys = map[string]string{
+	"interface":                  "interface",
+	"interface_name":             "interface",
+	"member_identifier":          "member_identifier",
+	"member_id":                  "member_identifier",
+	"mi":                         "member_identifier",
+	"local_member_identifier":    "member_identifier",
+	"cak_name":                   "cak_name",
+	"ckn":                        "cak_name",
+	"cak_type":                   "cak_type",
+	"transmit_interval":          "transmit_interval",
+	"hello_time":                 "transmit_interval",
+	"mka_hello_time":             "transmit_interval",
+	"message_number":             "message_number",
+	"mn":                         "message_number",
+	"key_server":                 "key_server",
+	"key_server_priority":        "key_server_priority",
+	"latest_sak_an":              "latest_sak_an",
+	"latest_sak_ki":              "latest_sak_ki",
+	"latest_sak_key_identifier":  "latest_sak_ki",
+	"latest_sak_association_num": "latest_sak_an",
+}
+
+var macsecMkaPeerKeys = map[string]string{
+	"member_identifier": "member_identifier",
+	"member_id":         "member_identifier",
+	"mi":                "member_identifier",
+	"message_number":    "message_number",
+	"mn":                "message_number",
+	"hold_time":         "hold_time",
+	"sci":               "sci",
+	"state":             "state",
+	"status":            "state",
+}
+
+// ParseMacsecMkaSessions parses the data of a 'macsec_mka_session' statistic.
+func ParseMacsecMkaSessions(data string) (sessions []MacsecMkaSession, err error) {
+	for _, block := range splitInterfaceBlocks(data) {
+		session := MacsecMkaSession{Fields: map[string]string{}}
+		var peer *MacsecMkaPeer
+		inPeers := false
+		for _, line := range block {
+			trimmed := strings.TrimSpace(line)
+			lower := strings.ToLower(trimmed)
+			if strings.Contains(lower, "peer list") || lower == "peers" || lower == "peers:" {
+				inPeers = true
+				continue
+			}
+			if inPeers {
+				if m := listItemRegexp.FindStringSubmatch(trimmed); m != nil {
+					session.Peers = append(session.Peers, MacsecMkaPeer{Fields: map[string]string{}})
+					peer = &session.Peers[len(session.Peers)-1]
+					trimmed = m[1]
+				}
+			}
+			for _, pair := range splitKeyValues(trimmed) {
+				if inPeers && peer != nil {
+					applyMacsecMkaPeerField(peer, pair)
+				} else {
+					applyMacsecMkaSessionField(&session, pair)
+				}
+			}
+		}
+		if session.Interface != "" || session.MemberIdentifier != "" || len(session.Peers) > 0 {
+			sessions = append(sessions, session)
+		}
+	}
+	if len(sessions) == 0 {
+		err = fmt.Errorf("no MKA session found in statistics data")
+	}
+	return
+}
+
+func applyMacsecMkaSessionField(session *MacsecMkaSession, pair keyValue) {
+	switch macsecMkaSessionKeys[pair.key] {
+	case "interface":
+		session.Interface = pair.value
+	case "member_identifier":
+		session.MemberIdentifier, _ = splitParenthesized(pair.value)
+	case "cak_name":
+		session.CakName = pair.value
+	case "cak_type":
+		session.CakType = pair.value
+	case "transmit_interval":
+		session.TransmitInterval = parseStatisticDuration(pair.value, time.Millisecond)
+	case "message_number":
+		session.MessageNumber = parseStatisticInt(pair.value)
+	case "key_server":
+		session.KeyServer = parseStatisticBool(pair.value)
+	case "key_server_priority":
+		session.KeyServerPriority = parseStatisticInt(pair.value)
+	case "latest_sak_an":
+		session.LatestSakAn = parseStatisticInt(pair.value)
+	case "latest_sak_ki":
+		session.LatestSakKi = pair.value
+	default:
+		session.Fields[pair.key] = pair.value
+	}
+}
+
+func applyMacsecMkaPeerField(peer *MacsecMkaPeer, pair keyValue) {
+	switch macsecMkaPeerKeys[pair.key] {
+	case "member_identifier":
+		var state string
+		peer.MemberIdentifier, state = splitParenthesized(pair.value)
+		if state != "" {
+			peer.State = state
+		}
+	case "message_number":
+		peer.MessageNumber = parseStatisticInt(pair.value)
+	case "hold_time":
+		peer.HoldTime = parseStatisticDuration(pair.value, time.Millisecond)
+	case "sci":
+		peer.Sci = pair.value
+	case "state":
+		peer.State = strings.ToLower(pair.value)
+	default:
+		peer.Fields[pair.key] = pair.value
+	}
+}
+
+var macsecMkaStatisticsKeys = map[string]string{
+	"received_packets":         "received_packets",
+	"rx_packets":               "received_packets",
+	"mkpdu_received":           "received_packets",
+	"transmitted_packets":      "transmitted_packets",
+	"tx_packets":               "transmitted_packets",
+	"mkpdu_transmitted":        "transmitted_packets",
+	"version_mismatch_packets": "version_mismatch_packets",
+	"version_mismatch":         "version_mismatch_packets",
+	"cak_mismatch_packets":     "cak_mismatch_packets",
+	"cak_mismatch":             "cak_mismatch_packets",
+	"ckn_mismatch":             "cak_mismatch_packets",
+	"icv_mismatch_packets":     "icv_mismatch_packets",
+	"icv_mismatch":             "icv_mismatch_packets",
+}
+
+// ParseMacsecMkaStatistics parses the data of a 'macsec_mka_statistics' statistic.
+func ParseMacsecMkaStatistics(data string) (statistics []MacsecMkaStatistics, err error) {
+	for _, block := range splitInterfaceBlocks(data) {
+		item := MacsecMkaStatistics{Counters: map[string]int64{}}
+		for _, line := range block {
+			for _, pair := range splitKeyValues(strings.TrimSpace(line)) {
+				if pair.key == "interface" || pair.key == "interface_name" {
+					item.Interface = pair.value
+					continue
+				}
+				value := parseStatisticInt(pair.value)
+				if value == nil {
+					continue
+				}
+				item.Counters[pair.key] = *value
+				switch macsecMkaStatisticsKeys[pair.key] {
+				case "received_packets":
+					item.ReceivedPackets = value
+				case "transmitted_packets":
+					item.TransmittedPackets = value
+				case "version_mismatch_packets":
+					item.VersionMismatchPackets = value
+				case "cak_mismatch_packets":
+					item.CakMismatchPackets = value
+				case "icv_mismatch_packets":
+					item.IcvMismatchPackets = value
+				}
+			}
+		}
+		if len(item.Counters) > 0 {
+			statistics = append(statistics, item)
+		}
+	}
+	if len(statistics) == 0 {
+		err = fmt.Errorf("no MKA counters found in statistics data")
+	}
+	return
+}
+
+var macsecPolicyKeys = map[string]string{
+	"ca_name":                  "ca_name",
+	"connectivity_association": "ca_name",
+	"cipher_suite":             "cipher_suite",
+	"cipher":                   "cipher_suite",
+	"encryption":               "encryption",
+	"confidentiality":          "encryption",
+	"key_server_offset":        "key_server_offset",
+	"offset":                   "key_server_offset",
+	"confidentiality_offset":   "key_server_offset",
+	"include_sci":              "include_sci",
+	"replay_protect":           "replay_protect",
+	"replay_protection":        "replay_protect",
+	"replay_window":            "replay_window",
+	"replay_protect_window":    "replay_window",
+	"replay_window_size":       "replay_window",
+	"replay_protection_window": "replay_window",
+	"security_policy_cipher":   "cipher_suite",
+	"macsec_cipher_suite":      "cipher_suite",
+	"include_sci_in_packets":   "include_sci",
+	"include_sci_tag":          "include_sci",
+}
+
+// ParseMacsecPolicies parses the data of a 'macsec_policy' statistic.
+func ParseMacsecPolicies(data string) (policies []MacsecPolicy, err error) {
+	for _, block := range splitInterfaceBlocks(data) {
+		policy := MacsecPolicy{Fields: map[string]string{}}
+		for _, line := range block {
+			for _, pair := range splitKeyValues(strings.TrimSpace(line)) {
+				if pair.key == "interface" || pair.key == "interface_name" {
+					policy.Interface = pair.value
+					continue
+				}
+				switch macsecPolicyKeys[pair.key] {
+				case "ca_name":
+					policy.CaName = pair.value
+				case "cipher_suite":
+					policy.CipherSuite = strings.ToUpper(pair.value)
+				case "encryption":
+					policy.Encryption = parseStatisticBool(pair.value)
+				case "key_server_offset":
+					policy.KeyServerOffset = parseStatisticInt(pair.value)
+				case "include_sci":
+					policy.IncludeSci = parseStatisticBool(pair.value)
+				case "replay_protect":
+					policy.ReplayProtect = parseStatisticBool(pair.value)
+				case "replay_window":
+					policy.ReplayWindow = parseStatisticInt(pair.value)
+				default:
+					policy.Fields[pair.key] = pair.value
+				}
+			}
+		}
+		if policy.Interface != "" || policy.CipherSuite != "" || policy.CaName != "" {
+			policies = append(policies, policy)
+		}
+	}
+	if len(policies) == 0 {
+		err = fmt.Errorf("no MACsec policy found in statistics data")
+	}
+	return
+}
+
+var bfdSessionKeys = map[string]string{
+	"address":              "address",
+	"neighbor":             "address",
+	"neighbor_address":     "address",
+	"neighaddr":            "address",
+	"peer":                 "address",
+	"peer_address":         "address",
+	"state":                "state",
+	"session_state":        "state",
+	"interface":            "interface",
+	"int":                  "interface",
+	"detect_time":          "detect_time",
+	"detection_time":       "detect_time",
+	"transmit_interval":    "transmit_interval",
+	"tx_interval":          "transmit_interval",
+	"min_tx_interval":      "transmit_interval",
+	"multiplier":           "multiplier",
+	"detect_multiplier":    "multiplier",
+	"detection_multiplier": "multiplier",
+	"mult":                 "multiplier",
+}
+
+var bfdStates = map[string]string{
+	"up":        "Up",
+	"down":      "Down",
+	"init":      "Init",
+	"admindown": "AdminDown",
+	"failing":   "Failing",
+}
+
+// ParseBfdSessions parses the data of a 'bfd_session' statistic. Both the tabular summary layout and the detailed
+// "key: value" layout are accepted.
+func ParseBfdSessions(data string) (sessions []BfdSession, err error) {
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+
+	// Tabular layout: one session per line, starting with the neighbor address.
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !isStatisticAddress(fields[0]) {
+			continue
+		}
+		session := BfdSession{Address: fields[0], Fields: map[string]string{}}
+		stateIndex := -1
+		for i := 1; i < len(fields); i++ {
+			if state, ok := bfdStates[strings.ToLower(fields[i])]; ok {
+				session.State = state
+				stateIndex = i
+			}
+		}
+		if stateIndex < 0 {
+			continue
+		}
+		var numbers []string
+		for _, field := range fields[stateIndex+1:] {
+			if _, numErr := strconv.ParseFloat(field, 64); numErr == nil {
+				numbers = append(numbers, field)
+			} else if session.Interface == "" {
+				session.Interface = field
+			}
+		}
+		if len(numbers) == 3 {
+			session.DetectTime = parseStatisticDuration(numbers[0], time.Second)
+			session.TransmitInterval = parseStatisticDuration(numbers[1], time.Second)
+			session.Multiplier = parseStatisticInt(numbers[2])
+		}
+		sessions = append(sessions, session)
+	}
+	if len(sessions) > 0 {
+		return
+	}
+
+	// Detailed layout: "key: value" pairs where an address key starts a new session.
+	var session *BfdSession
+	for _, line := range lines {
+		for _, pair := range splitKeyValues(strings.TrimSpace(line)) {
+			field := bfdSessionKeys[pair.key]
+			if field == "address" {
+				sessions = append(sessions, BfdSession{Fields: map[string]string{}})
+				session = &sessions[len(sessions)-1]
+			}
+			if session == nil {
+				continue
+			}
+			switch field {
+			case "address":
+				session.Address = firstStatisticWord(pair.value)
+			case "state":
+				if state, ok := bfdStates[strings.ToLower(firstStatisticWord(pair.value))]; ok {
+					session.State = state
+				} else {
+					session.State = pair.value
+				}
+			case "interface":
+				session.Interface = pair.value
+			case "detect_time":
+				session.DetectTime = parseStatisticDuration(pair.value, time.Millisecond)
+			case "transmit_interval":
+				session.TransmitInterval = parseStatisticDuration(pair.value, time.Millisecond)
+			case "multiplier":
+				session.Multiplier = parseStatisticInt(pair.value)
+			default:
+				session.Fields[pair.key] = pair.value
+			}
+		}
+	}
+	if len(sessions) == 0 {
+		err = fmt.Errorf("no BFD session found in statistics data")
+	}
+	return
+}
+
+const bgpStateEstablished = "Established"
+
+var bgpStates = map[string]string{
+	"establ":      bgpStateEstablished,
+	"established": bgpStateEstablished,
+	"estab":       bgpStateEstablished,
+	"active":      "Active",
+	"connect":     "Connect",
+	"idle":        "Idle",
+	"opensent":    "OpenSent",
+	"openconfirm": "OpenConfirm",
+}
+
+var juniperRibCountsRegexp = regexp.MustCompile(`^\s*[\w.-]+\.\d+:\s*(\d+)/(\d+)/(\d+)/(\d+)`)
+
+// ParseBgpNeighborSummary parses a BGP neighbor summary table in either the "Peer AS InPkt OutPkt OutQ Flaps
+// Last Up/Dwn State" layout or the "Neighbor V AS MsgRcvd MsgSent TblVer InQ OutQ Up/Down State/PfxRcd" layout.
+func ParseBgpNeighborSummary(data string) (summary *BgpNeighborSummary, err error) {
+	var columns map[string]int
+	fixedColumns := 0
+	summary = &BgpNeighborSummary{}
+	var last *BgpNeighbor
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		first := strings.ToLower(fields[0])
+		if first == "peer" || first == "neighbor" || first == "neighbour" {
+			columns = map[string]int{}
+			fixedColumns = 0
+			for i, field := range fields {
+				name := normalizeStatisticKey(field)
+				if strings.HasPrefix(name, "up") || strings.HasPrefix(name, "last") || strings.HasPrefix(name, "state") {
+					break
+				}
+				columns[name] = i
+				fixedColumns = i + 1
+			}
+			continue
+		}
+		if m := juniperRibCountsRegexp.FindStringSubmatch(line); m != nil && last != nil {
+			last.PrefixesActive = parseStatisticInt(m[1])
+			last.PrefixesReceived = parseStatisticInt(m[2])
+			continue
+		}
+		if columns == nil || !isStatisticAddress(fields[0]) || len(fields) <= fixedColumns {
+			continue
+		}
+		neighbor := BgpNeighbor{Address: fields[0]}
+		if index, ok := columns["as"]; ok {
+			neighbor.RemoteAsn = parseAsn(fields[index])
+		}
+		for _, name := range []string{"inpkt", "msgrcvd"} {
+			if index, ok := columns[name]; ok {
+				neighbor.MessagesReceived = parseStatisticInt(fields[index])
+			}
+		}
+		for _, name := range []string{"outpkt", "msgsent"} {
+			if index, ok := columns[name]; ok {
+				neighbor.MessagesSent = parseStatisticInt(fields[index])
+			}
+		}
+		if index, ok := columns["flaps"]; ok {
+			neighbor.Flaps = parseStatisticInt(fields[index])
+		}
+
+		stateField := fields[len(fields)-1]
+		neighbor.Uptime = strings.Join(fields[fixedColumns:len(fields)-1], " ")
+		if state, ok := bgpStates[strings.ToLower(strings.TrimSuffix(stateField, "..."))]; ok {
+			neighbor.State = state
+		} else if count := parseStatisticInt(stateField); count != nil && !strings.Contains(stateField, "/") {
+			// A number in the state column is the received prefix count of an established session.
+			neighbor.State = bgpStateEstablished
+			neighbor.PrefixesReceived = count
+		} else if counts := strings.Split(stateField, "/"); len(counts) == 4 {
+			neighbor.State = bgpStateEstablished
+			neighbor.PrefixesActive = parseStatisticInt(counts[0])
+			neighbor.PrefixesReceived = parseStatisticInt(counts[1])
+		} else {
+			neighbor.State = stateField
+		}
+		summary.Neighbors = append(summary.Neighbors, neighbor)
+		last = &summary.Neighbors[len(summary.Neighbors)-1]
+	}
+	if len(summary.Neighbors) == 0 {
+		summary = nil
+		err = fmt.Errorf("no BGP neighbor found in statistics data")
+	}
+	return
+}
+
+type keyValue struct {
+	key   string
+	value string
+}
+
+var (
+	columnSeparatorRegexp  = regexp.MustCompile(`\s{2,}|\t+`)
+	listItemRegexp         = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	nonAlphanumericRegexp  = regexp.MustCompile(`[^a-z0-9]+`)
+	leadingNumberRegexp    = regexp.MustCompile(`^-?\d+(\.\d+)?`)
+	parenthesizedRegexp    = regexp.MustCompile(`^(.*?)\s*\(([^)]*)\)\s*$`)
+	interfaceHeadingRegexp = regexp.MustCompile(`(?i)^\s*interface(\s+name)?\s*[:=]`)
+)
+
+// splitKeyValues splits a line into "key: value" pairs. Several pairs may share a line when they are separated by
+// two or more spaces, and a value may be separated from its key by column padding.
+func splitKeyValues(line string) (pairs []keyValue) {
+	for _, segment := range columnSeparatorRegexp.Split(line, -1) {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		separator := strings.IndexAny(segment, ":=")
+		if separator > 0 {
+			pairs = append(pairs, keyValue{
+				key:   normalizeStatisticKey(segment[:separator]),
+				value: strings.TrimSpace(segment[separator+1:]),
+			})
+			continue
+		}
+		if len(pairs) > 0 {
+			last := &pairs[len(pairs)-1]
+			if last.value == "" {
+				last.value = segment
+			} else {
+				last.value += " " + segment
+			}
+		}
+	}
+	return
+}
+
+// splitInterfaceBlocks splits the output into one block per "Interface name:" heading. Output without such a heading
+// is returned as a single block.
+func splitInterfaceBlocks(data string) (blocks [][]string) {
+	var current []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if interfaceHeadingRegexp.MatchString(line) && len(current) > 0 {
+			blocks = append(blocks, current)
+			current = nil
+		}
+		if strings.TrimSpace(line) != "" {
+			current = append(current, line)
+		}
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+	return
+}
+
+// firstStatisticWord returns the first word of the value without trailing punctuation.
+func firstStatisticWord(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimRight(fields[0], ",;")
+}
+
+func normalizeStatisticKey(key string) string {
+	return strings.Trim(nonAlphanumericRegexp.ReplaceAllString(strings.ToLower(key), "_"), "_")
+}
+
+func splitParenthesized(value string) (text string, inner string) {
+	if m := parenthesizedRegexp.FindStringSubmatch(value); m != nil {
+		return m[1], strings.ToLower(strings.TrimSpace(m[2]))
+	}
+	return value, ""
+}
+
+func parseStatisticInt(value string) *int64 {
+	number := leadingNumberRegexp.FindString(strings.TrimSpace(value))
+	if number == "" || strings.Contains(number, ".") {
+		return nil
+	}
+	parsed, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func parseStatisticBool(value string) *bool {
+	var result bool
+	switch strings.ToLower(firstStatisticWord(value)) {
+	case "yes", "on", "true", "enabled", "enable", "up":
+		result = true
+	case "no", "off", "false", "disabled", "disable", "down":
+		result = false
+	default:
+		return nil
+	}
+	return &result
+}
+
+// parseStatisticDuration parses values such as "10000(ms)", "10 sec", "0.300" or "300ms". A bare number is
+// interpreted in the given unit.
+func parseStatisticDuration(value string, unit time.Duration) time.Duration {
+	value = strings.ToLower(strings.TrimSpace(value))
+	number := leadingNumberRegexp.FindString(value)
+	if number == "" {
+		return 0
+	}
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0
+	}
+	suffix := strings.Trim(strings.TrimSpace(value[len(number):]), "()")
+	switch {
+	case strings.HasPrefix(suffix, "ms"), strings.HasPrefix(suffix, "msec"), strings.HasPrefix(suffix, "milli"):
+		unit = time.Millisecond
+	case strings.HasPrefix(suffix, "us"), strings.HasPrefix(suffix, "usec"), strings.HasPrefix(suffix, "micro"):
+		unit = time.Microsecond
+	case strings.HasPrefix(suffix, "s"):
+		unit = time.Second
+	}
+	return time.Duration(parsed * float64(unit))
+}
+
+// parseAsn parses a plain or asdot notation autonomous system number.
+func parseAsn(value string) int64 {
+	if high, low, found := strings.Cut(value, "."); found {
+		h, errHigh := strconv.ParseInt(high, 10, 64)
+		l, errLow := strconv.ParseInt(low, 10, 64)
+		if errHigh == nil && errLow == nil {
+			return h<<16 + l
+		}
+		return 0
+	}
+	asn, _ := strconv.ParseInt(value, 10, 64)
+	return asn
+}
+
+func isStatisticAddress(value string) bool {
+	if ip, _, err := net.ParseCIDR(value); err == nil && ip != nil {
+		return true
+	}
+	return net.ParseIP(value) != nil
+}
@@ -0,0 +1,209 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directlinkv1_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func readStatisticFixture(name string) string {
+	data, err := os.ReadFile(filepath.Join("testdata", "statistics", name))
+	Expect(err).To(BeNil())
+	return string(data)
+}
+
+var _ = Describe(`DirectLinkV1 statistics decoder`, func() {
+	Describe(`ParseMacsecMkaSessions(data string)`, func() {
+		It(`Parse Junos MKA sessions with peers`, func() {
+			sessions, err := directlinkv1.ParseMacsecMkaSessions(readStatisticFixture("macsec_mka_session_junos.txt"))
+			Expect(err).To(BeNil())
+			Expect(sessions).To(HaveLen(2))
+
+			session := sessions[0]
+			Expect(session.Interface).To(Equal("xe-0/1/0"))
+			Expect(session.MemberIdentifier).To(Equal("B51CB4B4F9A2DF59E2B3A0A2"))
+			Expect(session.CakName).To(Equal("1000"))
+			Expect(session.CakType).To(Equal("primary"))
+			Expect(session.TransmitInterval).To(Equal(10 * time.Second))
+			Expect(*session.MessageNumber).To(Equal(int64(270)))
+			Expect(*session.KeyServer).To(BeTrue())
+			Expect(*session.KeyServerPriority).To(Equal(int64(16)))
+			Expect(*session.LatestSakAn).To(Equal(int64(3)))
+			Expect(session.LatestSakKi).To(Equal("B51CB4B4F9A2DF59E2B3A0A2/8"))
+			Expect(session.Fields).To(HaveKeyWithValue("outbound_sci", "0C:EB:1F:3A:5A:02/1"))
+			Expect(session.Peers).To(HaveLen(1))
+			Expect(session.Peers[0].MemberIdentifier).To(Equal("5F3C8D1A2B4E6F7081920A1B"))
+			Expect(session.Peers[0].State).To(Equal("live"))
+			Expect(*session.Peers[0].MessageNumber).To(Equal(int64(8)))
+			Expect(session.Peers[0].HoldTime).To(Equal(15 * time.Second))
+			Expect(session.Peers[0].Sci).To(Equal("4C:96:14:76:5B:01/1"))
+			Expect(session.Peers[0].Fields).To(HaveKeyWithValue("lowest_acceptable_pn", "87769"))
+
+			Expect(*sessions[1].KeyServer).To(BeFalse())
+			Expect(sessions[1].Peers[0].State).To(Equal("potential"))
+		})
+		It(`Parse IOS style MKA session`, func() {
+			sessions, err := directlinkv1.ParseMacsecMkaSessions(readStatisticFixture("macsec_mka_session_ios.txt"))
+			Expect(err).To(BeNil())
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].Interface).To(Equal("TenGigabitEthernet0/1/0"))
+			Expect(sessions[0].MemberIdentifier).To(Equal("A0B1C2D3E4F5061728394A5B"))
+			Expect(*sessions[0].MessageNumber).To(Equal(int64(1207)))
+			Expect(*sessions[0].KeyServer).To(BeTrue())
+			Expect(sessions[0].CakName).To(Equal("01"))
+			Expect(sessions[0].TransmitInterval).To(Equal(2 * time.Second))
+		})
+		It(`Return an error for unrelated data`, func() {
+			sessions, err := directlinkv1.ParseMacsecMkaSessions("MKA statistics text...")
+			Expect(err).ToNot(BeNil())
+			Expect(sessions).To(BeEmpty())
+		})
+	})
+	Describe(`ParseMacsecMkaStatistics(data string)`, func() {
+		It(`Parse Junos MKA counters`, func() {
+			statistics, err := directlinkv1.ParseMacsecMkaStatistics(readStatisticFixture("macsec_mka_statistics_junos.txt"))
+			Expect(err).To(BeNil())
+			Expect(statistics).To(HaveLen(2))
+			Expect(statistics[0].Interface).To(Equal("xe-0/1/0"))
+			Expect(*statistics[0].ReceivedPackets).To(Equal(int64(2)))
+			Expect(*statistics[0].TransmittedPackets).To(Equal(int64(14)))
+			Expect(*statistics[0].IcvMismatchPackets).To(Equal(int64(1)))
+			Expect(statistics[0].Counters).To(HaveKeyWithValue("old_replayed_message_number_packets", int64(0)))
+			Expect(statistics[0].Counters).To(HaveLen(11))
+			Expect(*statistics[1].CakMismatchPackets).To(Equal(int64(5)))
+		})
+	})
+	Describe(`ParseMacsecPolicies(data string)`, func() {
+		It(`Parse Junos MACsec connection`, func() {
+			policies, err := directlinkv1.ParseMacsecPolicies(readStatisticFixture("macsec_policy_junos.txt"))
+			Expect(err).To(BeNil())
+			Expect(policies).To(HaveLen(1))
+			policy := policies[0]
+			Expect(policy.Interface).To(Equal("xe-0/1/0"))
+			Expect(policy.CaName).To(Equal("ca-dl-1000"))
+			Expect(policy.CipherSuite).To(Equal("GCM-AES-XPN-256"))
+			Expect(*policy.Encryption).To(BeTrue())
+			Expect(*policy.KeyServerOffset).To(Equal(int64(0)))
+			Expect(*policy.IncludeSci).To(BeFalse())
+			Expect(*policy.ReplayProtect).To(BeTrue())
+			Expect(*policy.ReplayWindow).To(Equal(int64(64)))
+			Expect(policy.Fields).To(HaveKeyWithValue("sc_id", "0C:EB:1F:3A:5A:02/1"))
+		})
+	})
+	Describe(`ParseBfdSessions(data string)`, func() {
+		It(`Parse the Junos summary table`, func() {
+			sessions, err := directlinkv1.ParseBfdSessions(readStatisticFixture("bfd_session_junos.txt"))
+			Expect(err).To(BeNil())
+			Expect(sessions).To(HaveLen(2))
+			Expect(sessions[0].Address).To(Equal("169.254.0.2"))
+			Expect(sessions[0].IsUp()).To(BeTrue())
+			Expect(sessions[0].Interface).To(Equal("ae1.1001"))
+			Expect(sessions[0].DetectTime).To(Equal(900 * time.Millisecond))
+			Expect(sessions[0].TransmitInterval).To(Equal(300 * time.Millisecond))
+			Expect(*sessions[0].Multiplier).To(Equal(int64(3)))
+			Expect(sessions[1].State).To(Equal("Down"))
+			Expect(sessions[1].IsUp()).To(BeFalse())
+		})
+		It(`Parse the IOS neighbor table`, func() {
+			sessions, err := directlinkv1.ParseBfdSessions(readStatisticFixture("bfd_session_ios.txt"))
+			Expect(err).To(BeNil())
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].State).To(Equal("Up"))
+			Expect(sessions[0].Interface).To(Equal("Te0/1/0.1001"))
+			Expect(sessions[0].Multiplier).To(BeNil())
+		})
+		It(`Parse the detailed layout`, func() {
+			sessions, err := directlinkv1.ParseBfdSessions(readStatisticFixture("bfd_session_detail.txt"))
+			Expect(err).To(BeNil())
+			Expect(sessions).To(HaveLen(2))
+			Expect(sessions[0].Address).To(Equal("169.254.0.2"))
+			Expect(sessions[0].State).To(Equal("Up"))
+			Expect(sessions[0].TransmitInterval).To(Equal(300 * time.Millisecond))
+			Expect(sessions[0].DetectTime).To(Equal(900 * time.Millisecond))
+			Expect(*sessions[0].Multiplier).To(Equal(int64(3)))
+			Expect(sessions[1].State).To(Equal("AdminDown"))
+		})
+	})
+	Describe(`ParseBgpNeighborSummary(data string)`, func() {
+		It(`Parse the Junos summary`, func() {
+			summary, err := directlinkv1.ParseBgpNeighborSummary(readStatisticFixture("bgp_summary_junos.txt"))
+			Expect(err).To(BeNil())
+			Expect(summary.Neighbors).To(HaveLen(2))
+			neighbor := summary.Neighbors[0]
+			Expect(neighbor.Address).To(Equal("169.254.0.2"))
+			Expect(neighbor.RemoteAsn).To(Equal(int64(64999)))
+			Expect(neighbor.IsEstablished()).To(BeTrue())
+			Expect(*neighbor.MessagesReceived).To(Equal(int64(1234)))
+			Expect(*neighbor.MessagesSent).To(Equal(int64(1240)))
+			Expect(*neighbor.Flaps).To(Equal(int64(2)))
+			Expect(neighbor.Uptime).To(Equal("9:10:11"))
+			Expect(*neighbor.PrefixesActive).To(Equal(int64(5)))
+			Expect(*neighbor.PrefixesReceived).To(Equal(int64(6)))
+
+			Expect(summary.Neighbors[1].RemoteAsn).To(Equal(int64(4200000001)))
+			Expect(summary.Neighbors[1].State).To(Equal("Active"))
+			Expect(summary.Neighbors[1].Uptime).To(Equal("1w2d 3h"))
+		})
+		It(`Parse the IOS summary`, func() {
+			summary, err := directlinkv1.ParseBgpNeighborSummary(readStatisticFixture("bgp_summary_ios.txt"))
+			Expect(err).To(BeNil())
+			Expect(summary.Neighbors).To(HaveLen(2))
+			Expect(summary.Neighbors[0].IsEstablished()).To(BeTrue())
+			Expect(*summary.Neighbors[0].PrefixesReceived).To(Equal(int64(5)))
+			Expect(summary.Neighbors[0].Uptime).To(Equal("1d02h"))
+			Expect(summary.Neighbors[1].RemoteAsn).To(Equal(int64(65546)))
+			Expect(summary.Neighbors[1].State).To(Equal("Idle"))
+		})
+		It(`Return an error when no table is present`, func() {
+			summary, err := directlinkv1.ParseBgpNeighborSummary("BGP not active")
+			Expect(err).ToNot(BeNil())
+			Expect(summary).To(BeNil())
+		})
+	})
+	Describe(`GatewayStatistic.Decode()`, func() {
+		It(`Dispatch on the statistic type`, func() {
+			statistic := &directlinkv1.GatewayStatistic{
+				Type: core.StringPtr(directlinkv1.GatewayStatistic_Type_BfdSession),
+				Data: core.StringPtr(readStatisticFixture("bfd_session_junos.txt")),
+			}
+			result, err := statistic.Decode()
+			Expect(err).To(BeNil())
+			Expect(result).To(BeAssignableToTypeOf([]directlinkv1.BfdSession{}))
+
+			statistic.Type = core.StringPtr(directlinkv1.GatewayStatistic_Type_MacsecMkaStatistics)
+			statistic.Data = core.StringPtr(readStatisticFixture("macsec_mka_statistics_junos.txt"))
+			result, err = statistic.Decode()
+			Expect(err).To(BeNil())
+			Expect(result).To(BeAssignableToTypeOf([]directlinkv1.MacsecMkaStatistics{}))
+		})
+		It(`Reject unknown types`, func() {
+			statistic := &directlinkv1.GatewayStatistic{
+				Type: core.StringPtr("unknown"),
+				Data: core.StringPtr(""),
+			}
+			_, err := statistic.Decode()
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
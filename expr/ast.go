@@ -0,0 +1,251 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package expr builds, parses and prints the rules language expressions used by filters, firewall rules and
+// rulesets.
+//
+// Expressions can be built with Field, And, Or and Not, or parsed from a string with Parse. Both produce the same
+// AST, which Check validates against the field catalog and Format prints in a canonical form.
+package expr
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// Logical operators.
+const (
+	OpAnd = "and"
+	OpOr  = "or"
+	OpXor = "xor"
+	OpNot = "not"
+)
+
+// Comparison operators.
+const (
+	OpEq             = "eq"
+	OpNe             = "ne"
+	OpLt             = "lt"
+	OpLe             = "le"
+	OpGt             = "gt"
+	OpGe             = "ge"
+	OpContains       = "contains"
+	OpMatches        = "matches"
+	OpWildcard       = "wildcard"
+	OpStrictWildcard = "strict wildcard"
+	OpIn             = "in"
+)
+
+// Position : A position in an expression string.
+type Position struct {
+	// Byte offset, starting at 0.
+	Offset int
+
+	// Line number, starting at 1.
+	Line int
+
+	// Column number in characters, starting at 1.
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Expr : A node of the expression AST.
+type Expr interface {
+	// Pos returns the position of the node in the parsed string. Built nodes have the zero Position.
+	Pos() Position
+
+	// String returns the canonical form of the node.
+	String() string
+}
+
+// LogicalExpr : Two or more operands joined by and, or or xor.
+type LogicalExpr struct {
+	Position Position
+
+	// One of OpAnd, OpOr and OpXor.
+	Op string
+
+	Operands []Expr
+}
+
+// NotExpr : The negation of an expression.
+type NotExpr struct {
+	Position Position
+
+	Operand Expr
+}
+
+// ComparisonExpr : A comparison of a field or function call with a value.
+type ComparisonExpr struct {
+	Position Position
+
+	// A *FieldExpr or a *CallExpr.
+	Left Expr
+
+	// One of the comparison operators.
+	Op string
+
+	// A literal; a *SetExpr or a *ListRef for OpIn.
+	Right Expr
+}
+
+// FieldExpr : A field, optionally indexed, such as http.request.headers["host"][0].
+type FieldExpr struct {
+	Position Position
+
+	Name string
+
+	Indexes []Index
+}
+
+// Index : A map key, an array index or [*].
+type Index struct {
+	Position Position
+
+	// A *StringLiteral map key, an *IntLiteral array index, or nil for [*].
+	Key Expr
+}
+
+// CallExpr : A function call, such as lower(http.host).
+type CallExpr struct {
+	Position Position
+
+	Name string
+
+	Args []Expr
+}
+
+// StringLiteral : A string value.
+type StringLiteral struct {
+	Position Position
+
+	Value string
+}
+
+// IntLiteral : An integer value.
+type IntLiteral struct {
+	Position Position
+
+	Value int64
+}
+
+// BoolLiteral : true or false.
+type BoolLiteral struct {
+	Position Position
+
+	Value bool
+}
+
+// IPLiteral : An IP address or a CIDR.
+type IPLiteral struct {
+	Position Position
+
+	// The network. A single address has the full prefix length.
+	Prefix netip.Prefix
+
+	// True when the literal was an address rather than a CIDR.
+	IsAddress bool
+}
+
+// RangeExpr : An inclusive range of integers or IP addresses inside a set, such as 8000..8999.
+type RangeExpr struct {
+	Position Position
+
+	From Expr
+
+	To Expr
+}
+
+// SetExpr : A set of values for the in operator, such as {"GET" "HEAD"}.
+type SetExpr struct {
+	Position Position
+
+	Elements []Expr
+}
+
+// ListRef : A reference to a named list for the in operator, such as $office_ips.
+type ListRef struct {
+	Position Position
+
+	Name string
+}
+
+// Pos returns the position of the node.
+func (e *LogicalExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *NotExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *ComparisonExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *FieldExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *CallExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *StringLiteral) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *IntLiteral) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *BoolLiteral) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *IPLiteral) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *RangeExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *SetExpr) Pos() Position { return e.Position }
+
+// Pos returns the position of the node.
+func (e *ListRef) Pos() Position { return e.Position }
+
+func (e *LogicalExpr) String() string    { return Format(e) }
+func (e *NotExpr) String() string        { return Format(e) }
+func (e *ComparisonExpr) String() string { return Format(e) }
+func (e *FieldExpr) String() string      { return Format(e) }
+func (e *CallExpr) String() string       { return Format(e) }
+func (e *StringLiteral) String() string  { return Format(e) }
+func (e *IntLiteral) String() string     { return Format(e) }
+func (e *BoolLiteral) String() string    { return Format(e) }
+func (e *IPLiteral) String() string      { return Format(e) }
+func (e *RangeExpr) String() string      { return Format(e) }
+func (e *SetExpr) String() string        { return Format(e) }
+func (e *ListRef) String() string        { return Format(e) }
+
+// Error : An error at a position of an expression.
+type Error struct {
+	Position Position
+
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Message)
+}
+
+func errorf(pos Position, format string, args ...interface{}) *Error {
+	return &Error{Position: pos, Message: fmt.Sprintf(format, args...)}
+}
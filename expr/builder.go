@@ -0,0 +1,298 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// Field returns a field of the rules language. Values passed to its comparison methods are converted to literals:
+// strings, integers, booleans, and IP addresses or CIDRs given as net.IP, *net.IPNet, netip.Addr, netip.Prefix or,
+// for IP fields, as strings. The result is not checked until Check is called.
+func Field(name string) *FieldExpr {
+	return &FieldExpr{Name: name}
+}
+
+// Key returns the field indexed by a map key, as in http.request.headers["accept"].
+func (e *FieldExpr) Key(key string) *FieldExpr {
+	return e.withIndex(&StringLiteral{Value: key})
+}
+
+// Index returns the field indexed by an array index, as in http.request.accepted_languages[0].
+func (e *FieldExpr) Index(index int) *FieldExpr {
+	return e.withIndex(&IntLiteral{Value: int64(index)})
+}
+
+// Each returns the field with its array unpacked, as in http.request.headers.names[*]. Comparisons on the result
+// must be wrapped with Any or All.
+func (e *FieldExpr) Each() *FieldExpr {
+	return e.withIndex(nil)
+}
+
+func (e *FieldExpr) withIndex(key Expr) *FieldExpr {
+	indexed := &FieldExpr{Position: e.Position, Name: e.Name}
+	indexed.Indexes = append(append(indexed.Indexes, e.Indexes...), Index{Key: key})
+	return indexed
+}
+
+// Eq returns the comparison "field eq value".
+func (e *FieldExpr) Eq(value interface{}) *ComparisonExpr { return compare(e, OpEq, value) }
+
+// Ne returns the comparison "field ne value".
+func (e *FieldExpr) Ne(value interface{}) *ComparisonExpr { return compare(e, OpNe, value) }
+
+// Lt returns the comparison "field lt value".
+func (e *FieldExpr) Lt(value interface{}) *ComparisonExpr { return compare(e, OpLt, value) }
+
+// Le returns the comparison "field le value".
+func (e *FieldExpr) Le(value interface{}) *ComparisonExpr { return compare(e, OpLe, value) }
+
+// Gt returns the comparison "field gt value".
+func (e *FieldExpr) Gt(value interface{}) *ComparisonExpr { return compare(e, OpGt, value) }
+
+// Ge returns the comparison "field ge value".
+func (e *FieldExpr) Ge(value interface{}) *ComparisonExpr { return compare(e, OpGe, value) }
+
+// Contains returns the comparison "field contains value".
+func (e *FieldExpr) Contains(value string) *ComparisonExpr { return compare(e, OpContains, value) }
+
+// Matches returns the comparison "field matches regex".
+func (e *FieldExpr) Matches(regex string) *ComparisonExpr { return compare(e, OpMatches, regex) }
+
+// Wildcard returns the comparison "field wildcard pattern", which ignores case.
+func (e *FieldExpr) Wildcard(pattern string) *ComparisonExpr { return compare(e, OpWildcard, pattern) }
+
+// StrictWildcard returns the comparison "field strict wildcard pattern", which is case sensitive.
+func (e *FieldExpr) StrictWildcard(pattern string) *ComparisonExpr {
+	return compare(e, OpStrictWildcard, pattern)
+}
+
+// In returns the comparison "field in {values}". A single *ListRef value gives "field in $list" instead. Slices
+// of strings and integers are expanded, and ranges are built with Range.
+func (e *FieldExpr) In(values ...interface{}) *ComparisonExpr { return in(e, values) }
+
+// Call returns a function call. Arguments that are not expressions are converted to literals.
+func Call(name string, args ...interface{}) *CallExpr {
+	call := &CallExpr{Name: name}
+	for _, arg := range args {
+		call.Args = append(call.Args, literal("", arg))
+	}
+	return call
+}
+
+// Eq returns the comparison "call eq value".
+func (e *CallExpr) Eq(value interface{}) *ComparisonExpr { return compare(e, OpEq, value) }
+
+// Ne returns the comparison "call ne value".
+func (e *CallExpr) Ne(value interface{}) *ComparisonExpr { return compare(e, OpNe, value) }
+
+// Lt returns the comparison "call lt value".
+func (e *CallExpr) Lt(value interface{}) *ComparisonExpr { return compare(e, OpLt, value) }
+
+// Le returns the comparison "call le value".
+func (e *CallExpr) Le(value interface{}) *ComparisonExpr { return compare(e, OpLe, value) }
+
+// Gt returns the comparison "call gt value".
+func (e *CallExpr) Gt(value interface{}) *ComparisonExpr { return compare(e, OpGt, value) }
+
+// Ge returns the comparison "call ge value".
+func (e *CallExpr) Ge(value interface{}) *ComparisonExpr { return compare(e, OpGe, value) }
+
+// Contains returns the comparison "call contains value".
+func (e *CallExpr) Contains(value string) *ComparisonExpr { return compare(e, OpContains, value) }
+
+// Matches returns the comparison "call matches regex".
+func (e *CallExpr) Matches(regex string) *ComparisonExpr { return compare(e, OpMatches, regex) }
+
+// Wildcard returns the comparison "call wildcard pattern", which ignores case.
+func (e *CallExpr) Wildcard(pattern string) *ComparisonExpr { return compare(e, OpWildcard, pattern) }
+
+// StrictWildcard returns the comparison "call strict wildcard pattern", which is case sensitive.
+func (e *CallExpr) StrictWildcard(pattern string) *ComparisonExpr {
+	return compare(e, OpStrictWildcard, pattern)
+}
+
+// In returns the comparison "call in {values}".
+func (e *CallExpr) In(values ...interface{}) *ComparisonExpr { return in(e, values) }
+
+// And joins conditions with and. Nil conditions are skipped, nested and operators are flattened, a single
+// condition is returned as is and no condition at all gives true.
+func And(conditions ...Expr) Expr {
+	return join(OpAnd, conditions, true)
+}
+
+// Or joins conditions with or. Nil conditions are skipped, nested or operators are flattened, a single
+// condition is returned as is and no condition at all gives false.
+func Or(conditions ...Expr) Expr {
+	return join(OpOr, conditions, false)
+}
+
+// Xor joins conditions with xor.
+func Xor(conditions ...Expr) Expr {
+	return join(OpXor, conditions, false)
+}
+
+// Not negates a condition.
+func Not(condition Expr) *NotExpr {
+	return &NotExpr{Operand: condition}
+}
+
+// Any is true when the condition holds for at least one element of an array unpacked with Each.
+func Any(condition Expr) *CallExpr {
+	return &CallExpr{Name: "any", Args: []Expr{condition}}
+}
+
+// All is true when the condition holds for every element of an array unpacked with Each.
+func All(condition Expr) *CallExpr {
+	return &CallExpr{Name: "all", Args: []Expr{condition}}
+}
+
+// List returns a reference to a named list, for use with In.
+func List(name string) *ListRef {
+	return &ListRef{Name: name}
+}
+
+// Range returns an inclusive range of integers or IP addresses, for use with In.
+func Range(from, to interface{}) *RangeExpr {
+	return &RangeExpr{From: literal("", from), To: literal("", to)}
+}
+
+func join(op string, conditions []Expr, empty bool) Expr {
+	logical := &LogicalExpr{Op: op}
+	for _, condition := range conditions {
+		if condition == nil {
+			continue
+		}
+		if nested, ok := condition.(*LogicalExpr); ok && nested.Op == op {
+			logical.Operands = append(logical.Operands, nested.Operands...)
+		} else {
+			logical.Operands = append(logical.Operands, condition)
+		}
+	}
+	switch len(logical.Operands) {
+	case 0:
+		return &BoolLiteral{Value: empty}
+	case 1:
+		return logical.Operands[0]
+	}
+	return logical
+}
+
+func compare(left Expr, op string, value interface{}) *ComparisonExpr {
+	return &ComparisonExpr{Left: left, Op: op, Right: literal(kindOf(left), value)}
+}
+
+func in(left Expr, values []interface{}) *ComparisonExpr {
+	if len(values) == 1 {
+		if list, ok := values[0].(*ListRef); ok {
+			return &ComparisonExpr{Left: left, Op: OpIn, Right: list}
+		}
+	}
+
+	kind := kindOf(left)
+	set := &SetExpr{}
+	for _, value := range values {
+		switch value := value.(type) {
+		case []string:
+			for _, v := range value {
+				set.Elements = append(set.Elements, literal(kind, v))
+			}
+		case []int:
+			for _, v := range value {
+				set.Elements = append(set.Elements, literal(kind, v))
+			}
+		case []int64:
+			for _, v := range value {
+				set.Elements = append(set.Elements, literal(kind, v))
+			}
+		case *RangeExpr:
+			set.Elements = append(set.Elements, &RangeExpr{Position: value.Position, From: literal(kind, value.From), To: literal(kind, value.To)})
+		default:
+			set.Elements = append(set.Elements, literal(kind, value))
+		}
+	}
+	return &ComparisonExpr{Left: left, Op: OpIn, Right: set}
+}
+
+// kindOf returns the kind of a field or call, or "" when it is unknown.
+func kindOf(e Expr) string {
+	t, err := typeOf(e)
+	if err != nil {
+		return ""
+	}
+	return t.Kind
+}
+
+// literal converts a Go value to a literal. Strings become IP literals when kind is KindIP and they hold an
+// address or a CIDR.
+func literal(kind string, value interface{}) Expr {
+	switch v := value.(type) {
+	case *StringLiteral:
+		if kind == KindIP {
+			if ip, ok := ipLiteral(v.Value); ok {
+				ip.Position = v.Position
+				return ip
+			}
+		}
+		return v
+	case Expr:
+		return v
+	case string:
+		if kind == KindIP {
+			if ip, ok := ipLiteral(v); ok {
+				return ip
+			}
+		}
+		return &StringLiteral{Value: v}
+	case bool:
+		return &BoolLiteral{Value: v}
+	case int:
+		return &IntLiteral{Value: int64(v)}
+	case int8:
+		return &IntLiteral{Value: int64(v)}
+	case int16:
+		return &IntLiteral{Value: int64(v)}
+	case int32:
+		return &IntLiteral{Value: int64(v)}
+	case int64:
+		return &IntLiteral{Value: v}
+	case uint:
+		return &IntLiteral{Value: int64(v)}
+	case uint8:
+		return &IntLiteral{Value: int64(v)}
+	case uint16:
+		return &IntLiteral{Value: int64(v)}
+	case uint32:
+		return &IntLiteral{Value: int64(v)}
+	case netip.Addr:
+		return &IPLiteral{Prefix: netip.PrefixFrom(v.Unmap(), v.Unmap().BitLen()), IsAddress: true}
+	case netip.Prefix:
+		return &IPLiteral{Prefix: v}
+	case net.IP:
+		if addr, ok := netip.AddrFromSlice(v); ok {
+			return literal(kind, addr)
+		}
+	case *net.IPNet:
+		if addr, ok := netip.AddrFromSlice(v.IP); ok {
+			ones, _ := v.Mask.Size()
+			return &IPLiteral{Prefix: netip.PrefixFrom(addr.Unmap(), ones)}
+		}
+	}
+	return &StringLiteral{Value: fmt.Sprint(value)}
+}
@@ -0,0 +1,313 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Check validates an expression against the field and function catalogs: fields must exist and be indexed
+// according to their type, comparison operators must be allowed for the type of their left side, values must
+// have that type, and the whole expression must be a boolean. The returned error is an *Error.
+func Check(e Expr) error {
+	t, err := typeOf(e)
+	if err != nil {
+		return err
+	}
+	if t != TypeBoolean {
+		return errorf(e.Pos(), "expression must be Boolean, found %s", t)
+	}
+	return nil
+}
+
+// TypeOf returns the type of an expression, checking it on the way.
+func TypeOf(e Expr) (Type, error) {
+	t, err := typeOf(e)
+	if err != nil {
+		return Type{}, err
+	}
+	return t, nil
+}
+
+func typeOf(e Expr) (Type, *Error) {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		if len(e.Operands) < 2 {
+			return Type{}, errorf(e.Position, "%s needs at least two operands", e.Op)
+		}
+		for _, operand := range e.Operands {
+			if err := checkCondition(operand); err != nil {
+				return Type{}, err
+			}
+		}
+		return TypeBoolean, nil
+	case *NotExpr:
+		if err := checkCondition(e.Operand); err != nil {
+			return Type{}, err
+		}
+		return TypeBoolean, nil
+	case *ComparisonExpr:
+		return typeOfComparison(e)
+	case *FieldExpr:
+		return typeOfField(e)
+	case *CallExpr:
+		return typeOfCall(e)
+	case *StringLiteral:
+		return TypeString, nil
+	case *IntLiteral:
+		return TypeInteger, nil
+	case *BoolLiteral:
+		return TypeBoolean, nil
+	case *IPLiteral:
+		return TypeIP, nil
+	case nil:
+		return Type{}, errorf(Position{}, "missing expression")
+	}
+	return Type{}, errorf(e.Pos(), "%s is not allowed here", e)
+}
+
+// checkCondition checks an operand of a logical operator, which must be a boolean.
+func checkCondition(e Expr) *Error {
+	if e == nil {
+		return errorf(Position{}, "missing expression")
+	}
+	t, err := typeOf(e)
+	if err != nil {
+		return err
+	}
+	if t != TypeBoolean {
+		if t.Shape == ShapeEach {
+			return errorf(e.Pos(), "%s must be reduced with any() or all()", e)
+		}
+		return errorf(e.Pos(), "%s must be Boolean, found %s", e, t)
+	}
+	return nil
+}
+
+func typeOfField(e *FieldExpr) (Type, *Error) {
+	field, ok := LookupField(e.Name)
+	if !ok {
+		return Type{}, errorf(e.Position, "unknown field %q", e.Name)
+	}
+	t := field.Type
+	for _, index := range e.Indexes {
+		switch t.Shape {
+		case ShapeMap:
+			if _, ok := index.Key.(*StringLiteral); !ok {
+				return Type{}, errorf(index.Position, "%s must be indexed by a string key", e.Name)
+			}
+			t.Shape = ShapeArray
+		case ShapeArray:
+			switch index.Key.(type) {
+			case *IntLiteral:
+				t.Shape = ShapeScalar
+			case nil:
+				t.Shape = ShapeEach
+			default:
+				return Type{}, errorf(index.Position, "%s must be indexed by an integer or [*]", e.Name)
+			}
+		default:
+			return Type{}, errorf(index.Position, "%s of type %s cannot be indexed", e.Name, t)
+		}
+	}
+	return t, nil
+}
+
+func typeOfCall(e *CallExpr) (Type, *Error) {
+	function, ok := functions[e.Name]
+	if !ok {
+		return Type{}, errorf(e.Position, "unknown function %q", e.Name)
+	}
+	required := len(function.args) - function.optional
+	if len(e.Args) < required || (!function.variadic && len(e.Args) > len(function.args)) {
+		return Type{}, errorf(e.Position, "%s() takes %s, found %d", e.Name, arity(function), len(e.Args))
+	}
+
+	var first Type
+	each := false
+	for i, arg := range e.Args {
+		if arg == nil {
+			return Type{}, errorf(e.Position, "missing argument %d of %s()", i+1, e.Name)
+		}
+		t, err := typeOf(arg)
+		if err != nil {
+			return Type{}, err
+		}
+		if i == 0 {
+			first = t
+		}
+		kinds := function.args[len(function.args)-1]
+		if i < len(function.args) {
+			kinds = function.args[i]
+		}
+		if !containsKind(kinds, t.Kind) {
+			return Type{}, errorf(arg.Pos(), "argument %d of %s() must be %s, found %s", i+1, e.Name, strings.Join(kinds, " or "), t)
+		}
+		switch t.Shape {
+		case ShapeEach:
+			each = true
+		case ShapeScalar:
+		default:
+			return Type{}, errorf(arg.Pos(), "argument %d of %s() must be a single value or use [*], found %s", i+1, e.Name, t)
+		}
+	}
+
+	result := Type{Kind: function.result, Shape: ShapeScalar}
+	if result.Kind == "" {
+		result.Kind = first.Kind
+	}
+	if function.reduces {
+		if !each {
+			return Type{}, errorf(e.Position, "%s() needs a condition on an array unpacked with [*]", e.Name)
+		}
+	} else if each {
+		result.Shape = ShapeEach
+	}
+	return result, nil
+}
+
+func typeOfComparison(e *ComparisonExpr) (Type, *Error) {
+	if e.Left == nil || e.Right == nil {
+		return Type{}, errorf(e.Position, "missing operand of %s", e.Op)
+	}
+	switch e.Left.(type) {
+	case *FieldExpr, *CallExpr:
+	default:
+		return Type{}, errorf(e.Left.Pos(), "left side of %s must be a field or a function, found %s", e.Op, e.Left)
+	}
+	left, err := typeOf(e.Left)
+	if err != nil {
+		return Type{}, err
+	}
+	if left.Shape != ShapeScalar && left.Shape != ShapeEach {
+		return Type{}, errorf(e.Left.Pos(), "%s of type %s must be indexed before comparison", e.Left, left)
+	}
+	if !OperatorAllowed(left.Kind, e.Op) {
+		return Type{}, errorf(e.Position, "operator %s is not allowed for %s, use one of %s", e.Op, left, strings.Join(operatorsByKind[left.Kind], ", "))
+	}
+
+	if e.Op == OpIn {
+		switch right := e.Right.(type) {
+		case *ListRef:
+			if right.Name == "" {
+				return Type{}, errorf(right.Position, "missing list name")
+			}
+		case *SetExpr:
+			if len(right.Elements) == 0 {
+				return Type{}, errorf(right.Position, "set must not be empty")
+			}
+			for _, element := range right.Elements {
+				if err := checkSetElement(left.Kind, element); err != nil {
+					return Type{}, err
+				}
+			}
+		default:
+			return Type{}, errorf(e.Right.Pos(), "right side of in must be a set or a list, found %s", e.Right)
+		}
+	} else if err := checkValue(left.Kind, e.Right); err != nil {
+		return Type{}, err
+	}
+
+	if e.Op == OpMatches {
+		if _, err := regexp.Compile(e.Right.(*StringLiteral).Value); err != nil {
+			return Type{}, errorf(e.Right.Pos(), "invalid regular expression: %s", err)
+		}
+	}
+	return Type{Kind: KindBoolean, Shape: left.Shape}, nil
+}
+
+func checkSetElement(kind string, e Expr) *Error {
+	r, ok := e.(*RangeExpr)
+	if !ok {
+		return checkValue(kind, e)
+	}
+	if kind != KindInteger && kind != KindIP {
+		return errorf(r.Position, "ranges are not allowed for %s", kind)
+	}
+	if err := checkValue(kind, r.From); err != nil {
+		return err
+	}
+	if err := checkValue(kind, r.To); err != nil {
+		return err
+	}
+	switch from := r.From.(type) {
+	case *IntLiteral:
+		if from.Value > r.To.(*IntLiteral).Value {
+			return errorf(r.Position, "range %s is empty", r)
+		}
+	case *IPLiteral:
+		to := r.To.(*IPLiteral)
+		if !from.IsAddress || !to.IsAddress || from.Prefix.Addr().BitLen() != to.Prefix.Addr().BitLen() {
+			return errorf(r.Position, "range %s must join two addresses of the same family", r)
+		}
+		if from.Prefix.Addr().Compare(to.Prefix.Addr()) > 0 {
+			return errorf(r.Position, "range %s is empty", r)
+		}
+	}
+	return nil
+}
+
+// checkValue checks that a value is a literal of the kind.
+func checkValue(kind string, e Expr) *Error {
+	if e == nil {
+		return errorf(Position{}, "missing value")
+	}
+	var found string
+	switch e.(type) {
+	case *StringLiteral:
+		found = KindString
+		if kind == KindBytes {
+			return nil
+		}
+	case *IntLiteral:
+		found = KindInteger
+	case *BoolLiteral:
+		found = KindBoolean
+	case *IPLiteral:
+		found = KindIP
+	default:
+		return errorf(e.Pos(), "expected %s value, found %s", kind, e)
+	}
+	if found != kind {
+		return errorf(e.Pos(), "expected %s value, found %s %s", kind, found, e)
+	}
+	return nil
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func arity(function functionInfo) string {
+	count := len(function.args)
+	switch {
+	case function.variadic:
+		return "at least " + strconv.Itoa(count-function.optional) + " arguments"
+	case function.optional > 0:
+		return strconv.Itoa(count-function.optional) + " to " + strconv.Itoa(count) + " arguments"
+	case count == 1:
+		return "1 argument"
+	}
+	return strconv.Itoa(count) + " arguments"
+}
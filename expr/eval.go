@@ -0,0 +1,869 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Request : A synthetic HTTP request to evaluate expressions against.
+type Request struct {
+	// The HTTP method. Defaults to GET.
+	Method string
+
+	// The full URL, such as https://example.com/login?next=%2F. The scheme sets ssl and the host sets http.host
+	// unless a Host header is given.
+	URL string
+
+	// The HTTP version. Defaults to HTTP/1.1.
+	Version string
+
+	// The request headers. Names are case insensitive.
+	Headers map[string][]string
+
+	// Cookies added to the ones of the Cookie header.
+	Cookies map[string]string
+
+	// The request body.
+	Body string
+
+	// The client IP address.
+	IP netip.Addr
+
+	// The ISO 3166-1 alpha-2 country code of the client.
+	Country string
+
+	// The continent code of the client, such as EU.
+	Continent string
+
+	// The autonomous system number of the client.
+	ASN int64
+
+	// The bot management score, from 1 (bot) to 99 (human).
+	BotScore int64
+
+	// True for a known good bot.
+	VerifiedBot bool
+
+	// The IP reputation score, from 0 to 100.
+	ThreatScore int64
+
+	// Values of any catalog field, overriding the ones derived from the attributes above. Values can be strings,
+	// integers, booleans, netip.Addr, []string or map[string][]string.
+	Fields map[string]interface{}
+}
+
+// Evaluator evaluates expressions against synthetic requests.
+type Evaluator struct {
+	// The entries of the named lists used with "in $name": IP addresses and CIDRs for IP fields, integers for
+	// integer fields and plain strings otherwise.
+	Lists map[string][]string
+
+	regexps sync.Map
+}
+
+// Evaluate checks an expression and evaluates it against a request, without lists.
+func Evaluate(e Expr, request *Request) (bool, error) {
+	return new(Evaluator).Evaluate(e, request)
+}
+
+// Evaluate checks an expression and evaluates it against a request. Comparisons with a missing value, such as a
+// header the request does not have, are false. Errors are *Error values.
+func (evaluator *Evaluator) Evaluate(e Expr, request *Request) (bool, error) {
+	if err := Check(e); err != nil {
+		return false, err
+	}
+	if request == nil {
+		request = &Request{}
+	}
+	state := &evaluation{evaluator: evaluator, request: request, fields: map[string]interface{}{}}
+	value, err := state.eval(e)
+	if err != nil {
+		return false, err
+	}
+	return value == true, nil
+}
+
+// each holds the results for the elements of an array unpacked with [*].
+type each []interface{}
+
+// evaluation holds the state of a single evaluation: values are strings, int64, bool, netip.Addr, netip.Prefix,
+// []string, map[string][]string, each or nil for a missing value.
+type evaluation struct {
+	evaluator *Evaluator
+	request   *Request
+	fields    map[string]interface{}
+	parsedURL *url.URL
+}
+
+func (state *evaluation) eval(e Expr) (interface{}, *Error) {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		result := false
+		for i, operand := range e.Operands {
+			value, err := state.eval(operand)
+			if err != nil {
+				return nil, err
+			}
+			b := value == true
+			switch {
+			case i == 0:
+				result = b
+			case e.Op == OpAnd:
+				result = result && b
+			case e.Op == OpOr:
+				result = result || b
+			default:
+				result = result != b
+			}
+			if (e.Op == OpAnd && !result) || (e.Op == OpOr && result) {
+				return result, nil
+			}
+		}
+		return result, nil
+	case *NotExpr:
+		value, err := state.eval(e.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return value != true, nil
+	case *ComparisonExpr:
+		return state.compare(e)
+	case *FieldExpr:
+		return state.field(e)
+	case *CallExpr:
+		return state.call(e)
+	case *StringLiteral:
+		return e.Value, nil
+	case *IntLiteral:
+		return e.Value, nil
+	case *BoolLiteral:
+		return e.Value, nil
+	case *IPLiteral:
+		return e.Prefix, nil
+	}
+	return nil, errorf(e.Pos(), "%s cannot be evaluated", e)
+}
+
+func (state *evaluation) field(e *FieldExpr) (interface{}, *Error) {
+	value, err := state.fieldValue(e)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range e.Indexes {
+		switch v := value.(type) {
+		case map[string][]string:
+			values, ok := v[index.Key.(*StringLiteral).Value]
+			if !ok {
+				return nil, nil
+			}
+			value = values
+		case []string:
+			if index.Key == nil {
+				elements := make(each, len(v))
+				for i, element := range v {
+					elements[i] = element
+				}
+				return elements, nil
+			}
+			i := index.Key.(*IntLiteral).Value
+			if i < 0 || i >= int64(len(v)) {
+				return nil, nil
+			}
+			value = v[i]
+		default:
+			return nil, nil
+		}
+	}
+	return value, nil
+}
+
+// fieldValue returns the value of a field, from Request.Fields or derived from the request attributes.
+func (state *evaluation) fieldValue(e *FieldExpr) (interface{}, *Error) {
+	if value, ok := state.fields[e.Name]; ok {
+		return value, nil
+	}
+	info, _ := LookupField(e.Name)
+	var value interface{}
+	if override, ok := state.request.Fields[e.Name]; ok {
+		var err error
+		value, err = normalize(info.Type, override)
+		if err != nil {
+			return nil, errorf(e.Position, "invalid value of %s in Request.Fields: %s", e.Name, err)
+		}
+	} else {
+		derived, err := state.derive(strings.TrimPrefix(e.Name, "raw."))
+		if err != nil {
+			return nil, errorf(e.Position, "%s", err)
+		}
+		if derived == nil {
+			derived = zero(info.Type)
+		}
+		value = derived
+	}
+	state.fields[e.Name] = value
+	return value, nil
+}
+
+// derive computes the value of a field from the request attributes, or returns nil when the field has no
+// corresponding attribute.
+func (state *evaluation) derive(name string) (interface{}, error) {
+	request := state.request
+	u, err := state.url()
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "http.request.method":
+		if request.Method == "" {
+			return "GET", nil
+		}
+		return strings.ToUpper(request.Method), nil
+	case "http.host":
+		if host := state.header("host"); host != "" {
+			return host, nil
+		}
+		return u.Hostname(), nil
+	case "ssl":
+		return u.Scheme == "https", nil
+	case "http.request.full_uri":
+		return u.String(), nil
+	case "http.request.uri":
+		return u.RequestURI(), nil
+	case "http.request.uri.path":
+		return u.EscapedPath(), nil
+	case "http.request.uri.path.extension":
+		return strings.ToLower(strings.TrimPrefix(path.Ext(u.Path), ".")), nil
+	case "http.request.uri.query":
+		return u.RawQuery, nil
+	case "http.request.uri.args":
+		return map[string][]string(u.Query()), nil
+	case "http.request.uri.args.names":
+		return names(u.Query()), nil
+	case "http.request.uri.args.values":
+		return values(u.Query()), nil
+	case "http.request.version":
+		if request.Version == "" {
+			return "HTTP/1.1", nil
+		}
+		return request.Version, nil
+	case "http.user_agent":
+		return state.header("user-agent"), nil
+	case "http.referer":
+		return state.header("referer"), nil
+	case "http.x_forwarded_for":
+		return state.header("x-forwarded-for"), nil
+	case "http.cookie":
+		return state.cookieHeader(), nil
+	case "http.request.cookies":
+		return state.cookies(), nil
+	case "http.request.headers":
+		return state.headers(), nil
+	case "http.request.headers.names":
+		return names(state.headers()), nil
+	case "http.request.headers.values":
+		return values(state.headers()), nil
+	case "http.request.accepted_languages":
+		var languages []string
+		for _, language := range strings.Split(state.header("accept-language"), ",") {
+			if language = strings.TrimSpace(strings.Split(language, ";")[0]); language != "" {
+				languages = append(languages, language)
+			}
+		}
+		return languages, nil
+	case "http.request.body.raw":
+		return request.Body, nil
+	case "http.request.body.size":
+		return int64(len(request.Body)), nil
+	case "http.request.body.mime":
+		return strings.TrimSpace(strings.Split(state.header("content-type"), ";")[0]), nil
+	case "http.request.body.form", "http.request.body.form.names", "http.request.body.form.values":
+		form := map[string][]string{}
+		if strings.HasPrefix(state.header("content-type"), "application/x-www-form-urlencoded") {
+			form, _ = url.ParseQuery(request.Body)
+		}
+		switch name {
+		case "http.request.body.form.names":
+			return names(form), nil
+		case "http.request.body.form.values":
+			return values(form), nil
+		}
+		return form, nil
+	case "ip.src":
+		return request.IP, nil
+	case "ip.src.country", "ip.geoip.country":
+		return request.Country, nil
+	case "ip.src.continent", "ip.geoip.continent":
+		return request.Continent, nil
+	case "ip.src.asnum", "ip.geoip.asnum":
+		return request.ASN, nil
+	case "cf.bot_management.score":
+		return request.BotScore, nil
+	case "cf.bot_management.verified_bot", "cf.client.bot":
+		return request.VerifiedBot, nil
+	case "cf.threat_score":
+		return request.ThreatScore, nil
+	}
+	return nil, nil
+}
+
+func (state *evaluation) url() (*url.URL, error) {
+	if state.parsedURL == nil {
+		raw := state.request.URL
+		if raw == "" {
+			raw = "http://localhost/"
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Request.URL: %s", err)
+		}
+		if u.Path == "" {
+			u.Path = "/"
+		}
+		state.parsedURL = u
+	}
+	return state.parsedURL, nil
+}
+
+// headers returns the request headers with lowercase names, as the rules language exposes them.
+func (state *evaluation) headers() map[string][]string {
+	headers := map[string][]string{}
+	for name, values := range state.request.Headers {
+		headers[strings.ToLower(name)] = append(headers[strings.ToLower(name)], values...)
+	}
+	if cookie := state.cookieHeader(); cookie != "" {
+		headers["cookie"] = []string{cookie}
+	}
+	return headers
+}
+
+func (state *evaluation) header(name string) string {
+	for key, values := range state.request.Headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// cookieHeader returns the Cookie header with Request.Cookies appended in name order.
+func (state *evaluation) cookieHeader() string {
+	var parts []string
+	if cookie := state.header("cookie"); cookie != "" {
+		parts = append(parts, cookie)
+	}
+	cookieNames := make([]string, 0, len(state.request.Cookies))
+	for name := range state.request.Cookies {
+		cookieNames = append(cookieNames, name)
+	}
+	sort.Strings(cookieNames)
+	for _, name := range cookieNames {
+		parts = append(parts, name+"="+state.request.Cookies[name])
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (state *evaluation) cookies() map[string][]string {
+	cookies := map[string][]string{}
+	for _, pair := range strings.Split(state.cookieHeader(), ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		if name != "" {
+			cookies[name] = append(cookies[name], value)
+		}
+	}
+	return cookies
+}
+
+func names(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key, values := range m {
+		for range values {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func values(m map[string][]string) []string {
+	var all []string
+	for _, key := range sortedKeys(m) {
+		all = append(all, m[key]...)
+	}
+	return all
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// zero returns the value of a field the request says nothing about.
+func zero(t Type) interface{} {
+	switch t.Shape {
+	case ShapeArray:
+		return []string{}
+	case ShapeMap:
+		return map[string][]string{}
+	}
+	switch t.Kind {
+	case KindInteger:
+		return int64(0)
+	case KindBoolean:
+		return false
+	case KindIP:
+		return netip.Addr{}
+	}
+	return ""
+}
+
+// normalize converts a value of Request.Fields to the representation of the type.
+func normalize(t Type, value interface{}) (interface{}, error) {
+	switch t.Shape {
+	case ShapeArray:
+		if v, ok := value.([]string); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("expected []string, found %T", value)
+	case ShapeMap:
+		if v, ok := value.(map[string][]string); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("expected map[string][]string, found %T", value)
+	}
+	switch t.Kind {
+	case KindInteger:
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		}
+	case KindBoolean:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case KindIP:
+		switch v := value.(type) {
+		case netip.Addr:
+			return v, nil
+		case string:
+			return netip.ParseAddr(v)
+		}
+	default:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		}
+	}
+	return nil, fmt.Errorf("expected %s, found %T", t, value)
+}
+
+func (state *evaluation) compare(e *ComparisonExpr) (interface{}, *Error) {
+	left, err := state.eval(e.Left)
+	if err != nil {
+		return nil, err
+	}
+	if elements, ok := left.(each); ok {
+		results := make(each, len(elements))
+		for i, element := range elements {
+			if results[i], err = state.compareValue(e, element); err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	}
+	return state.compareValue(e, left)
+}
+
+func (state *evaluation) compareValue(e *ComparisonExpr, left interface{}) (bool, *Error) {
+	if left == nil {
+		return false, nil
+	}
+	if e.Op == OpIn {
+		if list, ok := e.Right.(*ListRef); ok {
+			return state.inList(list, left)
+		}
+		for _, element := range e.Right.(*SetExpr).Elements {
+			if r, ok := element.(*RangeExpr); ok {
+				if inRange(left, r) {
+					return true, nil
+				}
+				continue
+			}
+			if equal(left, mustLiteral(element)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	right := mustLiteral(e.Right)
+	switch e.Op {
+	case OpEq:
+		return equal(left, right), nil
+	case OpNe:
+		return !equal(left, right), nil
+	case OpLt, OpLe, OpGt, OpGe:
+		c, ok := order(left, right)
+		if !ok {
+			return false, nil
+		}
+		switch e.Op {
+		case OpLt:
+			return c < 0, nil
+		case OpLe:
+			return c <= 0, nil
+		case OpGt:
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case OpContains:
+		s, _ := left.(string)
+		return strings.Contains(s, right.(string)), nil
+	case OpMatches:
+		s, _ := left.(string)
+		return state.regexp(right.(string)).MatchString(s), nil
+	case OpWildcard, OpStrictWildcard:
+		s, _ := left.(string)
+		return wildcardMatch(right.(string), s, e.Op == OpWildcard), nil
+	}
+	return false, errorf(e.Position, "operator %s cannot be evaluated", e.Op)
+}
+
+func (state *evaluation) inList(list *ListRef, left interface{}) (bool, *Error) {
+	entries, ok := state.evaluator.Lists[list.Name]
+	if !ok {
+		return false, errorf(list.Position, "unknown list $%s", list.Name)
+	}
+	for _, entry := range entries {
+		var right interface{} = entry
+		switch left.(type) {
+		case netip.Addr:
+			ip, ok := ipLiteral(strings.TrimSpace(entry))
+			if !ok {
+				return false, errorf(list.Position, "list $%s holds %q, which is not an IP address or a CIDR", list.Name, entry)
+			}
+			right = ip.Prefix
+		case int64:
+			i, err := strconv.ParseInt(strings.TrimSpace(entry), 10, 64)
+			if err != nil {
+				return false, errorf(list.Position, "list $%s holds %q, which is not an integer", list.Name, entry)
+			}
+			right = i
+		}
+		if equal(left, right) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (state *evaluation) regexp(pattern string) *regexp.Regexp {
+	if re, ok := state.evaluator.regexps.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	// Check has already compiled the pattern successfully.
+	re := regexp.MustCompile(pattern)
+	state.evaluator.regexps.Store(pattern, re)
+	return re
+}
+
+func mustLiteral(e Expr) interface{} {
+	switch e := e.(type) {
+	case *StringLiteral:
+		return e.Value
+	case *IntLiteral:
+		return e.Value
+	case *BoolLiteral:
+		return e.Value
+	case *IPLiteral:
+		return e.Prefix
+	}
+	return nil
+}
+
+// equal compares a value with a literal. An IP address equals a CIDR that contains it.
+func equal(left, right interface{}) bool {
+	if addr, ok := left.(netip.Addr); ok {
+		prefix, ok := right.(netip.Prefix)
+		return ok && addr.IsValid() && prefix.Contains(addr.Unmap())
+	}
+	return left == right
+}
+
+func order(left, right interface{}) (int, bool) {
+	switch l := left.(type) {
+	case int64:
+		r, ok := right.(int64)
+		switch {
+		case !ok:
+			return 0, false
+		case l < r:
+			return -1, true
+		case l > r:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		r, ok := right.(string)
+		return strings.Compare(l, r), ok
+	}
+	return 0, false
+}
+
+func inRange(value interface{}, r *RangeExpr) bool {
+	switch v := value.(type) {
+	case int64:
+		return r.From.(*IntLiteral).Value <= v && v <= r.To.(*IntLiteral).Value
+	case netip.Addr:
+		v = v.Unmap()
+		return v.IsValid() && r.From.(*IPLiteral).Prefix.Addr().Compare(v) <= 0 && v.Compare(r.To.(*IPLiteral).Prefix.Addr()) <= 0
+	}
+	return false
+}
+
+// wildcardMatch matches s against a pattern where * matches any sequence of characters and \* a literal star.
+func wildcardMatch(pattern, s string, ignoreCase bool) bool {
+	var expression strings.Builder
+	expression.WriteString("^")
+	if ignoreCase {
+		expression.WriteString("(?i)")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case pattern[i] == '*':
+			expression.WriteString("(?s:.*)")
+		default:
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String()).MatchString(s)
+}
+
+func (state *evaluation) call(e *CallExpr) (interface{}, *Error) {
+	args := make([]interface{}, len(e.Args))
+	length := -1
+	for i, arg := range e.Args {
+		value, err := state.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		if elements, ok := value.(each); ok {
+			length = len(elements)
+		}
+		args[i] = value
+	}
+
+	switch e.Name {
+	case "any", "all":
+		// A missing array unpacks to no element: any gives false and all gives true.
+		elements, _ := args[0].(each)
+		for _, element := range elements {
+			if (element == true) == (e.Name == "any") {
+				return e.Name == "any", nil
+			}
+		}
+		return e.Name == "all", nil
+	}
+	if length < 0 {
+		return state.apply(e, args)
+	}
+
+	// The function applies to each element of the unpacked array.
+	results := make(each, length)
+	for i := range results {
+		elementArgs := make([]interface{}, len(args))
+		for j, arg := range args {
+			if elements, ok := arg.(each); ok {
+				elementArgs[j] = elements[i]
+			} else {
+				elementArgs[j] = arg
+			}
+		}
+		result, err := state.apply(e, elementArgs)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// apply evaluates a function on scalar arguments. A missing argument gives a missing result.
+func (state *evaluation) apply(e *CallExpr, args []interface{}) (interface{}, *Error) {
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+	str := func(i int) string {
+		s, _ := args[i].(string)
+		return s
+	}
+	switch e.Name {
+	case "lower":
+		return strings.ToLower(str(0)), nil
+	case "upper":
+		return strings.ToUpper(str(0)), nil
+	case "len":
+		return int64(len(str(0))), nil
+	case "starts_with":
+		return strings.HasPrefix(str(0), str(1)), nil
+	case "ends_with":
+		return strings.HasSuffix(str(0), str(1)), nil
+	case "concat":
+		var b strings.Builder
+		for i := range args {
+			b.WriteString(str(i))
+		}
+		return b.String(), nil
+	case "url_decode":
+		decoded, err := url.QueryUnescape(str(0))
+		if err != nil {
+			return str(0), nil
+		}
+		return decoded, nil
+	case "to_string":
+		switch v := args[0].(type) {
+		case netip.Prefix:
+			return v.Addr().String(), nil
+		case netip.Addr:
+			return v.String(), nil
+		}
+		return fmt.Sprint(args[0]), nil
+	case "regex_replace":
+		re, err := regexp.Compile(str(1))
+		if err != nil {
+			return nil, errorf(e.Args[1].Pos(), "invalid regular expression: %s", err)
+		}
+		return re.ReplaceAllString(str(0), strings.ReplaceAll(str(2), "${", "$${")), nil
+	case "remove_bytes":
+		return strings.Map(func(r rune) rune {
+			if strings.ContainsRune(str(1), r) {
+				return -1
+			}
+			return r
+		}, str(0)), nil
+	case "substring":
+		return substring(str(0), args[1:]), nil
+	case "decode_base64":
+		decoded, err := base64.StdEncoding.DecodeString(str(0))
+		if err != nil {
+			return nil, nil
+		}
+		return string(decoded), nil
+	case "encode_base64":
+		return base64.StdEncoding.EncodeToString([]byte(str(0))), nil
+	case "lookup_json_string", "lookup_json_integer":
+		value, ok := lookupJSON(str(0), args[1:])
+		if !ok {
+			return nil, nil
+		}
+		if e.Name == "lookup_json_string" {
+			s, ok := value.(string)
+			if !ok {
+				return nil, nil
+			}
+			return s, nil
+		}
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, nil
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, nil
+		}
+		return i, nil
+	}
+	return nil, errorf(e.Position, "%s() is not supported by the local evaluator", e.Name)
+}
+
+// substring returns the bytes from start to end, where negative positions count from the end.
+func substring(s string, bounds []interface{}) string {
+	position := func(i int64) int {
+		if i < 0 {
+			i += int64(len(s))
+		}
+		if i < 0 {
+			return 0
+		}
+		if i > int64(len(s)) {
+			return len(s)
+		}
+		return int(i)
+	}
+	start, end := position(bounds[0].(int64)), len(s)
+	if len(bounds) > 1 {
+		end = position(bounds[1].(int64))
+	}
+	if start >= end {
+		return ""
+	}
+	return s[start:end]
+}
+
+func lookupJSON(document string, keys []interface{}) (interface{}, bool) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	var value interface{}
+	if decoder.Decode(&value) != nil {
+		return nil, false
+	}
+	for _, key := range keys {
+		switch k := key.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[k]; !ok {
+				return nil, false
+			}
+		case int64:
+			array, ok := value.([]interface{})
+			if !ok || k < 0 || k >= int64(len(array)) {
+				return nil, false
+			}
+			value = array[k]
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,133 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr_test
+
+import (
+	"net/netip"
+
+	"github.com/IBM/networking-go-sdk/expr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Evaluator`, func() {
+	request := &expr.Request{
+		Method: "post",
+		URL:    "https://www.example.com/api/v1/Login.PHP?next=%2Fhome&lang=en&lang=fr",
+		Headers: map[string][]string{
+			"User-Agent":      {"curl/8.0"},
+			"X-Debug":         {"1"},
+			"Accept-Language": {"fr-CH, fr;q=0.9, en;q=0.8"},
+			"Cookie":          {"session=abc"},
+			"Content-Type":    {"application/json"},
+		},
+		Cookies:     map[string]string{"theme": "dark"},
+		Body:        `{"user": {"name": "alice", "roles": ["admin"]}, "attempts": 3}`,
+		IP:          netip.MustParseAddr("198.51.100.7"),
+		Country:     "FR",
+		Continent:   "EU",
+		ASN:         64500,
+		BotScore:    12,
+		ThreatScore: 40,
+	}
+	evaluator := &expr.Evaluator{Lists: map[string][]string{
+		"office":   {"203.0.113.0/24", "198.51.100.7"},
+		"partners": {"64500", "64501"},
+	}}
+
+	It(`Evaluate expressions against a request`, func() {
+		for expression, expected := range map[string]bool{
+			`true`:                                                                 true,
+			`http.request.method eq "POST"`:                                        true,
+			`http.host eq "www.example.com" and ssl`:                               true,
+			`http.request.uri.path eq "/api/v1/Login.PHP"`:                         true,
+			`http.request.uri.path.extension eq "php"`:                             true,
+			`http.request.uri.query contains "next="`:                              true,
+			`http.request.uri eq "/api/v1/Login.PHP?next=%2Fhome&lang=en&lang=fr"`: true,
+			`http.request.uri.args["lang"][1] eq "fr"`:                             true,
+			`http.request.uri.args["missing"][0] ne "x"`:                           false,
+			`http.request.uri.path wildcard "/API/*.php"`:                          true,
+			`http.request.uri.path strict wildcard "/API/*"`:                       false,
+			`http.request.uri.path matches "^/api/v[0-9]+/"`:                       true,
+			`http.user_agent matches "(?i)^CURL/"`:                                 true,
+			`http.request.headers["x-debug"][0] == "1"`:                            true,
+			`any(http.request.headers.names[*] eq "x-debug")`:                      true,
+			`all(http.request.uri.args.values[*] in {"/home" "en" "fr"})`:          true,
+			`http.request.accepted_languages[0] eq "fr-CH"`:                        true,
+			`http.request.cookies["theme"][0] eq "dark"`:                           true,
+			`http.cookie eq "session=abc; theme=dark"`:                             true,
+			`ip.src in {198.51.100.0/24}`:                                          true,
+			`ip.src in {198.51.100.1..198.51.100.6}`:                               false,
+			`ip.src eq 198.51.100.7`:                                               true,
+			`ip.src in $office`:                                                    true,
+			`ip.geoip.asnum in $partners`:                                          true,
+			`ip.src.country in {"FR" "DE"} and ip.src.continent eq "EU"`:           true,
+			`cf.bot_management.score lt 30 and not cf.bot_management.verified_bot`: true,
+			`cf.threat_score ge 50 or cf.threat_score in {30..45}`:                 true,
+			`ssl xor http.request.method eq "POST"`:                                false,
+			`lower(http.request.uri.path) eq "/api/v1/login.php"`:                  true,
+			`len(http.request.body.raw) gt 10`:                                     true,
+			`lookup_json_string(http.request.body.raw, "user", "name") eq "alice"`: true,
+			`lookup_json_integer(http.request.body.raw, "attempts") ge 3`:          true,
+			`substring(http.host, -11) eq "example.com"`:                           true,
+			`cf.waf.score eq 0`:                                                    true,
+		} {
+			e, err := expr.Parse(expression)
+			Expect(err).To(BeNil(), expression)
+			matched, err := evaluator.Evaluate(e, request)
+			Expect(err).To(BeNil(), expression)
+			Expect(matched).To(Equal(expected), expression)
+		}
+	})
+	It(`Unpack a missing array to no element`, func() {
+		for expression, expected := range map[string]bool{
+			`any(http.request.headers["x-missing"][*] eq "a")`:       false,
+			`all(http.request.headers["x-missing"][*] eq "a")`:       true,
+			`any(http.request.accepted_languages[*] eq "de")`:        false,
+			`all(http.request.accepted_languages[*] ne "de")`:        true,
+			`not any(http.request.uri.args["lang"][*] eq "de")`:      true,
+			`any(lower(http.request.headers["x-missing"][*]) eq "")`: false,
+		} {
+			for _, r := range []*expr.Request{request, {URL: "https://www.example.com/"}} {
+				matched, err := evaluator.Evaluate(expr.MustParse(expression), r)
+				Expect(err).To(BeNil(), expression)
+				Expect(matched).To(Equal(expected), expression)
+			}
+		}
+	})
+	It(`Prefer the values given in Request.Fields`, func() {
+		overridden := &expr.Request{Fields: map[string]interface{}{
+			"cf.waf.score": 5,
+			"ip.src":       "2001:db8::1",
+		}}
+		matched, err := expr.Evaluate(expr.MustParse(`cf.waf.score lt 10 and ip.src in {2001:db8::/32}`), overridden)
+		Expect(err).To(BeNil())
+		Expect(matched).To(BeTrue())
+
+		overridden.Fields["cf.waf.score"] = "5"
+		_, err = expr.Evaluate(expr.MustParse(`cf.waf.score lt 10`), overridden)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Report what cannot be evaluated`, func() {
+		_, err := expr.Evaluate(expr.MustParse(`ip.src in $unknown`), request)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`1:11: unknown list $unknown`))
+
+		_, err = expr.Evaluate(expr.Field("http.hots").Eq("a"), request)
+		Expect(err).ToNot(BeNil())
+	})
+})
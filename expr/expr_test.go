@@ -0,0 +1,175 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr_test
+
+import (
+	"net/netip"
+
+	"github.com/IBM/networking-go-sdk/expr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Expr`, func() {
+	Describe(`Parse(input string)`, func() {
+		It(`Parse and format expressions canonically`, func() {
+			for input, canonical := range map[string]string{
+				`http.host == "example.com"`:                                                `http.host eq "example.com"`,
+				`(http.host eq "a.com" || http.host eq "b.com") && !ssl`:                    `(http.host eq "a.com" or http.host eq "b.com") and not ssl`,
+				`ip.src in {192.0.2.0/24 2001:db8::/32 198.51.100.1..198.51.100.9}`:         `ip.src in {192.0.2.0/24 2001:db8::/32 198.51.100.1..198.51.100.9}`,
+				`ip.src in $office_ips`:                                                     `ip.src in $office_ips`,
+				`cf.edge.server_port in {80, 443 8000..8999}`:                               `cf.edge.server_port in {80 443 8000..8999}`,
+				`http.request.uri.path ~ "^/api/"`:                                          `http.request.uri.path matches "^/api/"`,
+				`http.request.uri.path strict wildcard "/Admin/*"`:                          `http.request.uri.path strict wildcard "/Admin/*"`,
+				`http.request.headers["x-api-key"][0] eq r#"say "hi""#`:                     `http.request.headers["x-api-key"][0] eq "say \"hi\""`,
+				`any(lower(http.request.headers.names[*]) == "x-debug")`:                    `any(lower(http.request.headers.names[*]) eq "x-debug")`,
+				`not (ssl and cf.threat_score gt 10)`:                                       `not (ssl and cf.threat_score gt 10)`,
+				`((ssl and ssl) and ssl) or (cf.client.bot xor ip.geoip.country eq "FR")`:   `ssl and ssl and ssl or cf.client.bot xor ip.geoip.country eq "FR"`,
+				`starts_with(http.request.uri.path, "/static") and len(http.cookie) > 4096`: `starts_with(http.request.uri.path, "/static") and len(http.cookie) gt 4096`,
+				`cf.threat_score in {-5 0..10} or cf.threat_score gt -1`:                    `cf.threat_score in {-5 0..10} or cf.threat_score gt -1`,
+				`true`: `true`,
+			} {
+				e, err := expr.Parse(input)
+				Expect(err).To(BeNil(), input)
+				Expect(expr.Format(e)).To(Equal(canonical))
+
+				reparsed, err := expr.Parse(canonical)
+				Expect(err).To(BeNil())
+				Expect(reparsed.String()).To(Equal(canonical))
+			}
+		})
+		It(`Build the AST with positions`, func() {
+			e, err := expr.Parse("ssl and\n  ip.src in {10.0.0.0/8}")
+			Expect(err).To(BeNil())
+			logical := e.(*expr.LogicalExpr)
+			Expect(logical.Op).To(Equal(expr.OpAnd))
+			Expect(logical.Operands).To(HaveLen(2))
+
+			comparison := logical.Operands[1].(*expr.ComparisonExpr)
+			Expect(comparison.Pos().String()).To(Equal("2:3"))
+			Expect(comparison.Left.(*expr.FieldExpr).Name).To(Equal("ip.src"))
+			set := comparison.Right.(*expr.SetExpr)
+			Expect(set.Elements[0].(*expr.IPLiteral).Prefix).To(Equal(netip.MustParsePrefix("10.0.0.0/8")))
+		})
+		It(`Report syntax errors with their position`, func() {
+			for input, message := range map[string]string{
+				``:                                     `1:1: empty expression`,
+				`http.host eq`:                         `1:13: expected a value, found end of expression`,
+				`(ssl`:                                 `1:5: expected ')', found end of expression`,
+				`http.host eq "a.com" ssl`:             `1:22: unexpected 'ssl'`,
+				"ssl and\nhttp.host eq \"unterminated": `2:14: unterminated string`,
+				`http.host eq "a\d"`:                   `1:16: invalid escape sequence in string, only \" and \\ are allowed`,
+				`ip.src in {10.0.0.300}`:               `1:12: invalid IP address "10.0.0.300"`,
+				`ip.src in 10.0.0.1`:                   `1:11: expected '{' or a list reference after in, found '10.0.0.1'`,
+				`http.host # "a"`:                      `1:11: unexpected character '#'`,
+			} {
+				_, err := expr.Parse(input)
+				Expect(err).ToNot(BeNil(), input)
+				Expect(err).To(BeAssignableToTypeOf(&expr.Error{}))
+				Expect(err.Error()).To(Equal(message))
+			}
+		})
+		It(`Report type errors with their position`, func() {
+			for input, message := range map[string]string{
+				`http.hots eq "a.com"`:                         `1:1: unknown field "http.hots"`,
+				`ssl and ip.src contains "10."`:                `1:9: operator contains is not allowed for IP address, use one of eq, ne, in`,
+				`cf.threat_score eq "10"`:                      `1:20: expected Integer value, found String "10"`,
+				`http.host`:                                    `1:1: expression must be Boolean, found String`,
+				`http.request.headers eq "a"`:                  `1:1: http.request.headers of type Map<Array<String>> must be indexed before comparison`,
+				`http.request.headers[0][0] eq "a"`:            `1:21: http.request.headers must be indexed by a string key`,
+				`http.request.headers.names[*] eq "a"`:         `1:1: expression must be Boolean, found Array<Boolean>[*]`,
+				`http.request.uri.path matches "("`:            "1:31: invalid regular expression: error parsing regexp: missing closing ): `(`",
+				`http.host in {"a" 1..3}`:                      `1:19: ranges are not allowed for String`,
+				`cf.edge.server_port in {9000..80}`:            `1:25: range 9000..80 is empty`,
+				`lower() eq "a"`:                               `1:1: lower() takes 1 argument, found 0`,
+				`any(http.host eq "a")`:                        `1:1: any() needs a condition on an array unpacked with [*]`,
+				`ssl or upper(ip.src) eq "A"`:                  `1:14: argument 1 of upper() must be String or Bytes, found IP address`,
+				`ssl and http.request.uri.path.extension == 1`: `1:44: expected String value, found Integer 1`,
+			} {
+				_, err := expr.Parse(input)
+				Expect(err).ToNot(BeNil(), input)
+				Expect(err.Error()).To(Equal(message), input)
+			}
+
+			e, err := expr.ParseUnchecked(`http.hots eq "a.com"`)
+			Expect(err).To(BeNil())
+			Expect(expr.Check(e)).ToNot(Succeed())
+		})
+	})
+	Describe(`Builder`, func() {
+		It(`Build the same AST as the parser`, func() {
+			built := expr.And(
+				expr.Field("http.request.uri.path").Matches("^/api/"),
+				expr.Or(
+					expr.Field("ip.src").In("192.0.2.0/24", expr.Range("198.51.100.1", "198.51.100.9")),
+					expr.Not(expr.Field("ssl")),
+				),
+				expr.Field("http.request.method").In([]string{"GET", "HEAD"}),
+				expr.Any(expr.Call("lower", expr.Field("http.request.headers.names").Each()).Eq("x-debug")),
+			)
+			Expect(expr.Check(built)).To(Succeed())
+
+			canonical := `http.request.uri.path matches "^/api/" and (ip.src in {192.0.2.0/24 198.51.100.1..198.51.100.9} or not ssl)` +
+				` and http.request.method in {"GET" "HEAD"} and any(lower(http.request.headers.names[*]) eq "x-debug")`
+			Expect(built.String()).To(Equal(canonical))
+			Expect(expr.MustParse(canonical).String()).To(Equal(canonical))
+		})
+		It(`Index fields and convert values`, func() {
+			Expect(expr.Field("http.request.headers").Key(`x-"id"`).Index(0).Eq("a").String()).To(Equal(`http.request.headers["x-\"id\""][0] eq "a"`))
+			Expect(expr.Field("cf.threat_score").Ge(uint8(10)).String()).To(Equal(`cf.threat_score ge 10`))
+			Expect(expr.Field("ip.src").Eq(netip.MustParseAddr("2001:db8::1")).String()).To(Equal(`ip.src eq 2001:db8::1`))
+			Expect(expr.Field("ip.src").In(expr.List("office")).String()).To(Equal(`ip.src in $office`))
+			Expect(expr.Field("cf.edge.server_port").In([]int{80, 443}, expr.Range(8000, 8999)).String()).To(Equal(`cf.edge.server_port in {80 443 8000..8999}`))
+			Expect(expr.Field("http.host").StrictWildcard("*.Example.com").String()).To(Equal(`http.host strict wildcard "*.Example.com"`))
+		})
+		It(`Join conditions`, func() {
+			ssl := expr.Field("ssl")
+			Expect(expr.And().String()).To(Equal("true"))
+			Expect(expr.Or().String()).To(Equal("false"))
+			Expect(expr.And(nil, ssl)).To(BeIdenticalTo(ssl))
+			Expect(expr.And(expr.And(ssl, ssl), ssl).(*expr.LogicalExpr).Operands).To(HaveLen(3))
+			Expect(expr.Not(expr.Or(ssl, ssl)).String()).To(Equal("not (ssl or ssl)"))
+			Expect(expr.Or(expr.And(ssl, ssl), ssl).String()).To(Equal("ssl and ssl or ssl"))
+			Expect(expr.And(expr.Xor(ssl, ssl), ssl).String()).To(Equal("(ssl xor ssl) and ssl"))
+		})
+		It(`Leave type errors to Check`, func() {
+			Expect(expr.Check(expr.Field("ip.src").In("not-an-ip"))).ToNot(Succeed())
+			Expect(expr.Check(expr.Field("http.host").Gt(true))).ToNot(Succeed())
+		})
+	})
+	Describe(`Field catalog`, func() {
+		It(`Describe fields and operators`, func() {
+			field, ok := expr.LookupField("http.request.uri.args")
+			Expect(ok).To(BeTrue())
+			Expect(field.Type.String()).To(Equal("Map<Array<String>>"))
+			Expect(expr.Operators(expr.KindIP)).To(Equal([]string{expr.OpEq, expr.OpNe, expr.OpIn}))
+			Expect(expr.OperatorAllowed(expr.KindBoolean, expr.OpGt)).To(BeFalse())
+
+			field, _ = expr.LookupField("http.response.code")
+			Expect(field.AvailableIn("http_request_firewall_custom")).To(BeFalse())
+			Expect(field.AvailableIn("http_custom_errors")).To(BeTrue())
+			Expect(expr.FieldNames()).To(ContainElement("cf.waf.score"))
+		})
+		It(`Accept registered fields`, func() {
+			_, err := expr.Parse(`cf.custom.tier eq "gold"`)
+			Expect(err).ToNot(BeNil())
+			expr.RegisterField(expr.FieldInfo{Name: "cf.custom.tier", Type: expr.TypeString})
+			_, err = expr.Parse(`cf.custom.tier eq "gold"`)
+			Expect(err).To(BeNil())
+		})
+	})
+})
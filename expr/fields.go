@@ -0,0 +1,291 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"sort"
+	"sync"
+)
+
+// Kinds of scalar values.
+const (
+	KindString  = "String"
+	KindInteger = "Integer"
+	KindBoolean = "Boolean"
+	KindIP      = "IP address"
+	KindBytes   = "Bytes"
+)
+
+// Shapes of values.
+const (
+	// A single value.
+	ShapeScalar = "scalar"
+
+	// An array of values.
+	ShapeArray = "array"
+
+	// A map from string keys to arrays of values.
+	ShapeMap = "map"
+
+	// The elements of an array unpacked with [*], one result per element.
+	ShapeEach = "each"
+)
+
+// Type : The type of a field, of a function result or of a comparison.
+type Type struct {
+	// One of the Kind constants.
+	Kind string
+
+	// One of the Shape constants.
+	Shape string
+}
+
+// Common types.
+var (
+	TypeString      = Type{KindString, ShapeScalar}
+	TypeInteger     = Type{KindInteger, ShapeScalar}
+	TypeBoolean     = Type{KindBoolean, ShapeScalar}
+	TypeIP          = Type{KindIP, ShapeScalar}
+	TypeBytes       = Type{KindBytes, ShapeScalar}
+	TypeStringArray = Type{KindString, ShapeArray}
+	TypeStringMap   = Type{KindString, ShapeMap}
+	TypeIntegerMap  = Type{KindInteger, ShapeMap}
+)
+
+func (t Type) String() string {
+	switch t.Shape {
+	case ShapeArray:
+		return "Array<" + t.Kind + ">"
+	case ShapeMap:
+		return "Map<Array<" + t.Kind + ">>"
+	case ShapeEach:
+		return "Array<" + t.Kind + ">[*]"
+	}
+	return t.Kind
+}
+
+// operatorsByKind lists the comparison operators allowed for each kind of value.
+var operatorsByKind = map[string][]string{
+	KindString:  {OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpContains, OpMatches, OpWildcard, OpStrictWildcard, OpIn},
+	KindBytes:   {OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpContains, OpMatches, OpWildcard, OpStrictWildcard, OpIn},
+	KindInteger: {OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpIn},
+	KindIP:      {OpEq, OpNe, OpIn},
+	KindBoolean: {OpEq, OpNe},
+}
+
+// Operators returns the comparison operators allowed for a kind of value.
+func Operators(kind string) []string {
+	return append([]string(nil), operatorsByKind[kind]...)
+}
+
+// OperatorAllowed returns true when op can compare values of the kind.
+func OperatorAllowed(kind string, op string) bool {
+	for _, allowed := range operatorsByKind[kind] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldInfo : A field of the rules language.
+type FieldInfo struct {
+	Name string
+
+	Type Type
+
+	// The phases in which the field is available. Empty means every phase.
+	Phases []string
+}
+
+// Phases of the response fields.
+var responsePhases = []string{"http_response_headers_transform", "http_custom_errors", "http_response_firewall_managed", "http_response_compression", "http_log_custom_fields"}
+
+var (
+	fieldsMu sync.RWMutex
+	fields   = map[string]*FieldInfo{}
+)
+
+func init() {
+	for _, field := range []FieldInfo{
+		{Name: "cf.bot_management.ja3_hash", Type: TypeString},
+		{Name: "cf.bot_management.score", Type: TypeInteger},
+		{Name: "cf.bot_management.static_resource", Type: TypeBoolean},
+		{Name: "cf.bot_management.verified_bot", Type: TypeBoolean},
+		{Name: "cf.client.bot", Type: TypeBoolean},
+		{Name: "cf.colo.id", Type: TypeInteger},
+		{Name: "cf.edge.server_ip", Type: TypeIP},
+		{Name: "cf.edge.server_port", Type: TypeInteger},
+		{Name: "cf.hostname.metadata", Type: TypeString},
+		{Name: "cf.random_seed", Type: TypeBytes},
+		{Name: "cf.ray_id", Type: TypeString},
+		{Name: "cf.threat_score", Type: TypeInteger},
+		{Name: "cf.tls_client_auth.cert_presented", Type: TypeBoolean},
+		{Name: "cf.tls_client_auth.cert_revoked", Type: TypeBoolean},
+		{Name: "cf.tls_client_auth.cert_verified", Type: TypeBoolean},
+		{Name: "cf.waf.score", Type: TypeInteger},
+		{Name: "cf.waf.score.rce", Type: TypeInteger},
+		{Name: "cf.waf.score.sqli", Type: TypeInteger},
+		{Name: "cf.waf.score.xss", Type: TypeInteger},
+		{Name: "http.cookie", Type: TypeString},
+		{Name: "http.host", Type: TypeString},
+		{Name: "http.referer", Type: TypeString},
+		{Name: "http.request.accepted_languages", Type: TypeStringArray},
+		{Name: "http.request.body.form", Type: TypeStringMap},
+		{Name: "http.request.body.form.names", Type: TypeStringArray},
+		{Name: "http.request.body.form.values", Type: TypeStringArray},
+		{Name: "http.request.body.mime", Type: TypeString},
+		{Name: "http.request.body.raw", Type: TypeString},
+		{Name: "http.request.body.size", Type: TypeInteger},
+		{Name: "http.request.body.truncated", Type: TypeBoolean},
+		{Name: "http.request.cookies", Type: TypeStringMap},
+		{Name: "http.request.full_uri", Type: TypeString},
+		{Name: "http.request.headers", Type: TypeStringMap},
+		{Name: "http.request.headers.names", Type: TypeStringArray},
+		{Name: "http.request.headers.truncated", Type: TypeBoolean},
+		{Name: "http.request.headers.values", Type: TypeStringArray},
+		{Name: "http.request.method", Type: TypeString},
+		{Name: "http.request.timestamp.sec", Type: TypeInteger},
+		{Name: "http.request.uri", Type: TypeString},
+		{Name: "http.request.uri.args", Type: TypeStringMap},
+		{Name: "http.request.uri.args.names", Type: TypeStringArray},
+		{Name: "http.request.uri.args.values", Type: TypeStringArray},
+		{Name: "http.request.uri.path", Type: TypeString},
+		{Name: "http.request.uri.path.extension", Type: TypeString},
+		{Name: "http.request.uri.query", Type: TypeString},
+		{Name: "http.request.version", Type: TypeString},
+		{Name: "http.response.code", Type: TypeInteger, Phases: responsePhases},
+		{Name: "http.response.content_type.media_type", Type: TypeString, Phases: responsePhases},
+		{Name: "http.response.headers", Type: TypeStringMap, Phases: responsePhases},
+		{Name: "http.response.headers.names", Type: TypeStringArray, Phases: responsePhases},
+		{Name: "http.response.headers.values", Type: TypeStringArray, Phases: responsePhases},
+		{Name: "http.user_agent", Type: TypeString},
+		{Name: "http.x_forwarded_for", Type: TypeString},
+		{Name: "ip.geoip.asnum", Type: TypeInteger},
+		{Name: "ip.geoip.continent", Type: TypeString},
+		{Name: "ip.geoip.country", Type: TypeString},
+		{Name: "ip.geoip.is_in_european_union", Type: TypeBoolean},
+		{Name: "ip.geoip.subdivision_1_iso_code", Type: TypeString},
+		{Name: "ip.geoip.subdivision_2_iso_code", Type: TypeString},
+		{Name: "ip.src", Type: TypeIP},
+		{Name: "ip.src.asnum", Type: TypeInteger},
+		{Name: "ip.src.city", Type: TypeString},
+		{Name: "ip.src.continent", Type: TypeString},
+		{Name: "ip.src.country", Type: TypeString},
+		{Name: "ip.src.is_in_european_union", Type: TypeBoolean},
+		{Name: "ip.src.lat", Type: TypeString},
+		{Name: "ip.src.lon", Type: TypeString},
+		{Name: "ip.src.metro_code", Type: TypeString},
+		{Name: "ip.src.postal_code", Type: TypeString},
+		{Name: "ip.src.region", Type: TypeString},
+		{Name: "ip.src.region_code", Type: TypeString},
+		{Name: "ip.src.subdivision_1_iso_code", Type: TypeString},
+		{Name: "ip.src.subdivision_2_iso_code", Type: TypeString},
+		{Name: "raw.http.request.full_uri", Type: TypeString},
+		{Name: "raw.http.request.uri", Type: TypeString},
+		{Name: "raw.http.request.uri.path", Type: TypeString},
+		{Name: "raw.http.request.uri.query", Type: TypeString},
+		{Name: "ssl", Type: TypeBoolean},
+	} {
+		RegisterField(field)
+	}
+}
+
+// RegisterField adds a field to the catalog, or replaces the field with the same name.
+func RegisterField(field FieldInfo) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	registered := field
+	fields[field.Name] = &registered
+}
+
+// LookupField returns the catalog entry of a field.
+func LookupField(name string) (field *FieldInfo, ok bool) {
+	fieldsMu.RLock()
+	defer fieldsMu.RUnlock()
+	field, ok = fields[name]
+	return
+}
+
+// FieldNames returns the names of the fields in the catalog, sorted.
+func FieldNames() []string {
+	fieldsMu.RLock()
+	defer fieldsMu.RUnlock()
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AvailableIn returns true when the field can be used in the phase.
+func (field *FieldInfo) AvailableIn(phase string) bool {
+	if len(field.Phases) == 0 {
+		return true
+	}
+	for _, p := range field.Phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// functionInfo describes a function of the rules language.
+type functionInfo struct {
+	// The kinds accepted for each argument. The last entry repeats when variadic is set.
+	args [][]string
+
+	// The number of trailing optional arguments.
+	optional int
+
+	variadic bool
+
+	// The kind of the result. Empty means the kind of the first argument.
+	result string
+
+	// The function reduces an unpacked array ([*]) to a single value.
+	reduces bool
+}
+
+var anyKind = []string{KindString, KindInteger, KindBoolean, KindIP, KindBytes}
+
+var functions = map[string]functionInfo{
+	"all":                    {args: [][]string{{KindBoolean}}, result: KindBoolean, reduces: true},
+	"any":                    {args: [][]string{{KindBoolean}}, result: KindBoolean, reduces: true},
+	"concat":                 {args: [][]string{{KindString, KindBytes}}, variadic: true},
+	"decode_base64":          {args: [][]string{{KindString}}, result: KindString},
+	"ends_with":              {args: [][]string{{KindString, KindBytes}, {KindString}}, result: KindBoolean},
+	"len":                    {args: [][]string{{KindString, KindBytes}}, result: KindInteger},
+	"lookup_json_integer":    {args: [][]string{{KindString}, {KindString, KindInteger}}, variadic: true, result: KindInteger},
+	"lookup_json_string":     {args: [][]string{{KindString}, {KindString, KindInteger}}, variadic: true, result: KindString},
+	"lower":                  {args: [][]string{{KindString, KindBytes}}},
+	"regex_replace":          {args: [][]string{{KindString}, {KindString}, {KindString}}, result: KindString},
+	"remove_bytes":           {args: [][]string{{KindString, KindBytes}, {KindString, KindBytes}}},
+	"starts_with":            {args: [][]string{{KindString, KindBytes}, {KindString}}, result: KindBoolean},
+	"substring":              {args: [][]string{{KindString, KindBytes}, {KindInteger}, {KindInteger}}, optional: 1},
+	"to_string":              {args: [][]string{anyKind}, result: KindString},
+	"upper":                  {args: [][]string{{KindString, KindBytes}}},
+	"url_decode":             {args: [][]string{{KindString}, {KindString}}, optional: 1, result: KindString},
+	"uuidv4":                 {args: [][]string{{KindBytes}}, result: KindString},
+	"wildcard_replace":       {args: [][]string{{KindString, KindBytes}, {KindString}, {KindString}, {KindString}}, optional: 1, result: KindString},
+	"encode_base64":          {args: [][]string{{KindString, KindBytes}}, result: KindString},
+	"cidr":                   {args: [][]string{{KindIP}, {KindInteger}, {KindInteger}}, result: KindIP},
+	"cidr6":                  {args: [][]string{{KindIP}, {KindInteger}}, result: KindIP},
+	"is_timed_hmac_valid_v0": {args: [][]string{{KindString}, {KindString}, {KindInteger}, {KindInteger}, {KindInteger}}, optional: 1, result: KindBoolean},
+}
@@ -0,0 +1,228 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of tokens.
+const (
+	tokenEOF = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenIP
+	tokenPunct
+)
+
+// token : A token of an expression string. For strings, text holds the unescaped value.
+type token struct {
+	kind int
+	text string
+	pos  Position
+}
+
+// punctuation lists the operators and punctuation, longest first.
+var punctuation = []string{"==", "!=", "<=", ">=", "&&", "||", "^^", "..", "<", ">", "~", "!", "(", ")", "{", "}", "[", "]", ",", "*", "$"}
+
+// lexer splits an expression string into tokens.
+type lexer struct {
+	input string
+	pos   Position
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: input, pos: Position{Line: 1, Column: 1}}
+}
+
+func (l *lexer) peekByte(offset int) byte {
+	if l.pos.Offset+offset < len(l.input) {
+		return l.input[l.pos.Offset+offset]
+	}
+	return 0
+}
+
+// advance moves the position n bytes forward.
+func (l *lexer) advance(n int) {
+	end := l.pos.Offset + n
+	for l.pos.Offset < end {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos.Offset:])
+		l.pos.Offset += size
+		if r == '\n' {
+			l.pos.Line++
+			l.pos.Column = 1
+		} else {
+			l.pos.Column++
+		}
+	}
+}
+
+// tokenize returns all the tokens of the input, ending with a tokenEOF.
+func (l *lexer) tokenize() ([]token, error) {
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos.Offset < len(l.input) && strings.ContainsRune(" \t\r\n", rune(l.input[l.pos.Offset])) {
+		l.advance(1)
+	}
+	start := l.pos
+	if start.Offset >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.peekByte(0)
+	switch {
+	case c == '"':
+		return l.quoted()
+	case c == 'r' && (l.peekByte(1) == '"' || l.peekByte(1) == '#'):
+		return l.raw()
+	case c == ':' && l.peekByte(1) == ':':
+		return l.ip(), nil
+	case c == '-' && isDigit(l.peekByte(1)):
+		l.advance(1)
+		text := "-" + l.scan(isDigit)
+		l.advance(len(text) - 1)
+		return token{kind: tokenNumber, text: text, pos: start}, nil
+	case isDigit(c):
+		text := l.scan(isNumberByte)
+		if strings.ContainsAny(text, ".:/") {
+			return l.ip(), nil
+		}
+		l.advance(len(text))
+		return token{kind: tokenNumber, text: text, pos: start}, nil
+	case isIdentStart(c):
+		// IPv6 addresses can start with a letter, as in fe80::1. Identifiers never contain a colon.
+		if strings.Contains(l.scan(isNumberByte), ":") {
+			return l.ip(), nil
+		}
+		text := l.scan(isIdentByte)
+		l.advance(len(text))
+		return token{kind: tokenIdent, text: text, pos: start}, nil
+	}
+
+	for _, punct := range punctuation {
+		if strings.HasPrefix(l.input[start.Offset:], punct) {
+			l.advance(len(punct))
+			return token{kind: tokenPunct, text: punct, pos: start}, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[start.Offset:])
+	return token{}, errorf(start, "unexpected character %q", r)
+}
+
+// scan returns the longest run of bytes accepted by fn at the current position, without advancing. A run never
+// includes "..", which separates the bounds of a range.
+func (l *lexer) scan(fn func(byte) bool) string {
+	end := l.pos.Offset
+	for end < len(l.input) && fn(l.input[end]) {
+		if l.input[end] == '.' && end+1 < len(l.input) && l.input[end+1] == '.' {
+			break
+		}
+		end++
+	}
+	return l.input[l.pos.Offset:end]
+}
+
+func (l *lexer) ip() token {
+	start := l.pos
+	text := l.scan(isNumberByte)
+	l.advance(len(text))
+	return token{kind: tokenIP, text: text, pos: start}
+}
+
+// quoted reads a double-quoted string, in which only \" and \\ are escaped.
+func (l *lexer) quoted() (token, error) {
+	start := l.pos
+	l.advance(1)
+	var value strings.Builder
+	for {
+		if l.pos.Offset >= len(l.input) {
+			return token{}, errorf(start, "unterminated string")
+		}
+		c := l.peekByte(0)
+		switch c {
+		case '"':
+			l.advance(1)
+			return token{kind: tokenString, text: value.String(), pos: start}, nil
+		case '\\':
+			escaped := l.peekByte(1)
+			if escaped != '"' && escaped != '\\' {
+				escapePos := l.pos
+				return token{}, errorf(escapePos, "invalid escape sequence in string, only \\\" and \\\\ are allowed")
+			}
+			value.WriteByte(escaped)
+			l.advance(2)
+		default:
+			_, size := utf8.DecodeRuneInString(l.input[l.pos.Offset:])
+			value.WriteString(l.input[l.pos.Offset : l.pos.Offset+size])
+			l.advance(size)
+		}
+	}
+}
+
+// raw reads a raw string such as r"a\b" or r#"say "hi""#, which has no escapes.
+func (l *lexer) raw() (token, error) {
+	start := l.pos
+	l.advance(1)
+	hashes := 0
+	for l.peekByte(0) == '#' {
+		hashes++
+		l.advance(1)
+	}
+	if l.peekByte(0) != '"' {
+		return token{}, errorf(start, "invalid raw string, expected '\"'")
+	}
+	l.advance(1)
+	terminator := "\"" + strings.Repeat("#", hashes)
+	end := strings.Index(l.input[l.pos.Offset:], terminator)
+	if end < 0 {
+		return token{}, errorf(start, "unterminated raw string")
+	}
+	value := l.input[l.pos.Offset : l.pos.Offset+end]
+	l.advance(end + len(terminator))
+	return token{kind: tokenString, text: value, pos: start}, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.'
+}
+
+// isNumberByte accepts the bytes of integers, IPv4 and IPv6 addresses and CIDRs.
+func isNumberByte(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') || c == '.' || c == ':' || c == '/'
+}
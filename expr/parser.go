@@ -0,0 +1,405 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// symbolOperators maps the symbol forms of the operators to their word forms.
+var symbolOperators = map[string]string{
+	"&&": OpAnd,
+	"||": OpOr,
+	"^^": OpXor,
+	"!":  OpNot,
+	"==": OpEq,
+	"!=": OpNe,
+	"<":  OpLt,
+	"<=": OpLe,
+	">":  OpGt,
+	">=": OpGe,
+	"~":  OpMatches,
+}
+
+// Parse parses an expression string into an AST and checks it against the field catalog. Errors are *Error values
+// holding the position of the problem.
+func Parse(input string) (Expr, error) {
+	e, err := ParseUnchecked(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := Check(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ParseUnchecked parses an expression string into an AST without checking fields, functions and operators.
+func ParseUnchecked(input string) (Expr, error) {
+	tokens, err := newLexer(input).tokenize()
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(p.peek().pos, "empty expression")
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "unexpected %s", describe(tok))
+	}
+	return e, nil
+}
+
+// MustParse is like Parse but panics when the expression is invalid. It simplifies the initialization of
+// expressions held in global variables.
+func MustParse(input string) Expr {
+	e, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+// operator returns the word form of the operator at the current token, or "" when there is none.
+func (p *parser) operator() string {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenPunct:
+		return symbolOperators[tok.text]
+	case tokenIdent:
+		if tok.text == "strict" {
+			if next := p.tokens[p.next+1]; next.kind == tokenIdent && next.text == OpWildcard {
+				return OpStrictWildcard
+			}
+			return ""
+		}
+		switch tok.text {
+		case OpAnd, OpOr, OpXor, OpNot, OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpContains, OpMatches, OpWildcard, OpIn:
+			return tok.text
+		}
+	}
+	return ""
+}
+
+// takeOperator consumes the operator at the current token.
+func (p *parser) takeOperator(op string) {
+	p.take()
+	if op == OpStrictWildcard {
+		p.take()
+	}
+}
+
+func (p *parser) expect(punct string) (token, error) {
+	tok := p.peek()
+	if tok.kind != tokenPunct || tok.text != punct {
+		return tok, errorf(tok.pos, "expected '%s', found %s", punct, describe(tok))
+	}
+	return p.take(), nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	return p.parseLogical(OpOr, p.parseXor)
+}
+
+func (p *parser) parseXor() (Expr, error) {
+	return p.parseLogical(OpXor, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	return p.parseLogical(OpAnd, p.parseNot)
+}
+
+// parseLogical parses operands joined by op into a single LogicalExpr.
+func (p *parser) parseLogical(op string, operand func() (Expr, error)) (Expr, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.operator() != op {
+		return first, nil
+	}
+	logical := &LogicalExpr{Position: first.Pos(), Op: op, Operands: []Expr{first}}
+	for p.operator() == op {
+		p.takeOperator(op)
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		logical.Operands = append(logical.Operands, next)
+	}
+	return logical, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.operator() == OpNot {
+		pos := p.take().pos
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Position: pos, Operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.peek()
+	if tok.kind == tokenPunct && tok.text == "(" {
+		p.take()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses a comparison, or a field, function call or boolean literal standing alone.
+func (p *parser) parseComparison() (Expr, error) {
+	tok := p.peek()
+	if tok.kind == tokenIdent && (tok.text == "true" || tok.text == "false") {
+		p.take()
+		return &BoolLiteral{Position: tok.pos, Value: tok.text == "true"}, nil
+	}
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.operator()
+	switch op {
+	case "", OpAnd, OpOr, OpXor, OpNot:
+		return left, nil
+	}
+	p.takeOperator(op)
+	comparison := &ComparisonExpr{Position: left.Pos(), Left: left, Op: op}
+	if op == OpIn {
+		comparison.Right, err = p.parseSetOrList()
+	} else {
+		comparison.Right, err = p.parseValue()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// parseOperand parses a field with its indexes, or a function call.
+func (p *parser) parseOperand() (Expr, error) {
+	tok := p.peek()
+	if tok.kind != tokenIdent {
+		return nil, errorf(tok.pos, "expected a field or a function, found %s", describe(tok))
+	}
+	p.take()
+	if next := p.peek(); next.kind == tokenPunct && next.text == "(" {
+		return p.parseCall(tok)
+	}
+
+	field := &FieldExpr{Position: tok.pos, Name: tok.text}
+	for {
+		open := p.peek()
+		if open.kind != tokenPunct || open.text != "[" {
+			return field, nil
+		}
+		p.take()
+		index := Index{Position: open.pos}
+		key := p.take()
+		switch {
+		case key.kind == tokenString:
+			index.Key = &StringLiteral{Position: key.pos, Value: key.text}
+		case key.kind == tokenNumber:
+			value, err := parseInt(key)
+			if err != nil {
+				return nil, err
+			}
+			index.Key = value
+		case key.kind == tokenPunct && key.text == "*":
+		default:
+			return nil, errorf(key.pos, "expected a string, an integer or '*' as index, found %s", describe(key))
+		}
+		if _, err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		field.Indexes = append(field.Indexes, index)
+	}
+}
+
+// parseCall parses the arguments of a function call. Arguments are full expressions, so that any() and all()
+// can hold comparisons.
+func (p *parser) parseCall(name token) (Expr, error) {
+	p.take()
+	call := &CallExpr{Position: name.pos, Name: name.text}
+	if next := p.peek(); next.kind == tokenPunct && next.text == ")" {
+		p.take()
+		return call, nil
+	}
+	for {
+		var arg Expr
+		var err error
+		switch tok := p.peek(); tok.kind {
+		case tokenString, tokenNumber, tokenIP:
+			arg, err = p.parseValue()
+		default:
+			arg, err = p.parseOr()
+		}
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+
+		tok := p.take()
+		if tok.kind == tokenPunct && tok.text == ")" {
+			return call, nil
+		}
+		if tok.kind != tokenPunct || tok.text != "," {
+			return nil, errorf(tok.pos, "expected ',' or ')', found %s", describe(tok))
+		}
+	}
+}
+
+// parseValue parses a literal.
+func (p *parser) parseValue() (Expr, error) {
+	tok := p.take()
+	switch tok.kind {
+	case tokenString:
+		return &StringLiteral{Position: tok.pos, Value: tok.text}, nil
+	case tokenNumber:
+		return parseInt(tok)
+	case tokenIP:
+		return parseIP(tok)
+	case tokenIdent:
+		if tok.text == "true" || tok.text == "false" {
+			return &BoolLiteral{Position: tok.pos, Value: tok.text == "true"}, nil
+		}
+	}
+	return nil, errorf(tok.pos, "expected a value, found %s", describe(tok))
+}
+
+// parseSetOrList parses the right side of the in operator.
+func (p *parser) parseSetOrList() (Expr, error) {
+	tok := p.take()
+	if tok.kind == tokenPunct && tok.text == "$" {
+		name := p.take()
+		if name.kind != tokenIdent {
+			return nil, errorf(name.pos, "expected a list name, found %s", describe(name))
+		}
+		return &ListRef{Position: tok.pos, Name: name.text}, nil
+	}
+	if tok.kind != tokenPunct || tok.text != "{" {
+		return nil, errorf(tok.pos, "expected '{' or a list reference after in, found %s", describe(tok))
+	}
+
+	set := &SetExpr{Position: tok.pos}
+	for {
+		next := p.peek()
+		if next.kind == tokenPunct && next.text == "}" {
+			p.take()
+			return set, nil
+		}
+		if next.kind == tokenPunct && next.text == "," {
+			p.take()
+			continue
+		}
+		element, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if dots := p.peek(); dots.kind == tokenPunct && dots.text == ".." {
+			p.take()
+			to, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			element = &RangeExpr{Position: element.Pos(), From: element, To: to}
+		}
+		set.Elements = append(set.Elements, element)
+	}
+}
+
+func parseInt(tok token) (*IntLiteral, error) {
+	value, err := strconv.ParseInt(tok.text, 10, 64)
+	if err != nil {
+		return nil, errorf(tok.pos, "invalid integer %q", tok.text)
+	}
+	return &IntLiteral{Position: tok.pos, Value: value}, nil
+}
+
+func parseIP(tok token) (*IPLiteral, error) {
+	ip, ok := ipLiteral(tok.text)
+	if !ok {
+		if strings.Contains(tok.text, "/") {
+			return nil, errorf(tok.pos, "invalid CIDR %q", tok.text)
+		}
+		return nil, errorf(tok.pos, "invalid IP address %q", tok.text)
+	}
+	ip.Position = tok.pos
+	return ip, nil
+}
+
+// ipLiteral parses an IP address or a CIDR.
+func ipLiteral(text string) (*IPLiteral, bool) {
+	if strings.Contains(text, "/") {
+		prefix, err := netip.ParsePrefix(text)
+		if err != nil {
+			return nil, false
+		}
+		return &IPLiteral{Prefix: prefix}, true
+	}
+	addr, err := netip.ParseAddr(text)
+	if err != nil {
+		return nil, false
+	}
+	return &IPLiteral{Prefix: netip.PrefixFrom(addr, addr.BitLen()), IsAddress: true}, true
+}
+
+// describe names a token in error messages.
+func describe(tok token) string {
+	switch tok.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(tok.text)
+	}
+	return "'" + tok.text + "'"
+}
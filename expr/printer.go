@@ -0,0 +1,154 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Precedence of the operators, from loosest to tightest.
+const (
+	precedenceOr = iota + 1
+	precedenceXor
+	precedenceAnd
+	precedenceNot
+	precedenceAtom
+)
+
+var logicalPrecedence = map[string]int{
+	OpOr:  precedenceOr,
+	OpXor: precedenceXor,
+	OpAnd: precedenceAnd,
+}
+
+// Format prints an expression in canonical form: word operators, double-quoted strings, single spaces, nested
+// chains of the same logical operator flattened, and parentheses only where precedence requires them. Parsing the
+// result gives back an equivalent AST.
+func Format(e Expr) string {
+	var b strings.Builder
+	format(&b, e)
+	return b.String()
+}
+
+func precedence(e Expr) int {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		return logicalPrecedence[e.Op]
+	case *NotExpr:
+		return precedenceNot
+	}
+	return precedenceAtom
+}
+
+// formatOperand prints an operand, in parentheses when it binds looser than its parent.
+func formatOperand(b *strings.Builder, e Expr, parent int) {
+	if precedence(e) < parent {
+		b.WriteString("(")
+		format(b, e)
+		b.WriteString(")")
+		return
+	}
+	format(b, e)
+}
+
+func format(b *strings.Builder, e Expr) {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		for i, operand := range flatten(e) {
+			if i > 0 {
+				b.WriteString(" " + e.Op + " ")
+			}
+			formatOperand(b, operand, logicalPrecedence[e.Op])
+		}
+	case *NotExpr:
+		b.WriteString("not ")
+		formatOperand(b, e.Operand, precedenceNot)
+	case *ComparisonExpr:
+		format(b, e.Left)
+		b.WriteString(" " + e.Op + " ")
+		format(b, e.Right)
+	case *FieldExpr:
+		b.WriteString(e.Name)
+		for _, index := range e.Indexes {
+			b.WriteString("[")
+			if index.Key == nil {
+				b.WriteString("*")
+			} else {
+				format(b, index.Key)
+			}
+			b.WriteString("]")
+		}
+	case *CallExpr:
+		b.WriteString(e.Name + "(")
+		for i, arg := range e.Args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			format(b, arg)
+		}
+		b.WriteString(")")
+	case *StringLiteral:
+		b.WriteString(quote(e.Value))
+	case *IntLiteral:
+		b.WriteString(strconv.FormatInt(e.Value, 10))
+	case *BoolLiteral:
+		b.WriteString(strconv.FormatBool(e.Value))
+	case *IPLiteral:
+		if e.IsAddress {
+			b.WriteString(e.Prefix.Addr().String())
+		} else {
+			b.WriteString(e.Prefix.String())
+		}
+	case *RangeExpr:
+		format(b, e.From)
+		b.WriteString("..")
+		format(b, e.To)
+	case *SetExpr:
+		b.WriteString("{")
+		for i, element := range e.Elements {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			format(b, element)
+		}
+		b.WriteString("}")
+	case *ListRef:
+		b.WriteString("$" + e.Name)
+	case nil:
+		b.WriteString("<nil>")
+	}
+}
+
+// flatten returns the operands of a logical expression, replacing operands with the same operator by their own
+// operands.
+func flatten(e *LogicalExpr) []Expr {
+	var operands []Expr
+	for _, operand := range e.Operands {
+		if nested, ok := operand.(*LogicalExpr); ok && nested.Op == e.Op {
+			operands = append(operands, flatten(nested)...)
+		} else {
+			operands = append(operands, operand)
+		}
+	}
+	return operands
+}
+
+// quote returns a double-quoted string, escaping only '"' and '\' as the rules language expects.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
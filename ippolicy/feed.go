@@ -0,0 +1,286 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ippolicy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// MaxFeedSplit is the most access rules a feed entry is split into when no ip_range can target it as a whole.
+const MaxFeedSplit = 256
+
+// ParseFeed reads a blocklist feed: one address or CIDR per line. Blank lines and everything after a # are
+// ignored.
+func ParseFeed(reader io.Reader) (prefixes []netip.Prefix, err error) {
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		entry, _, _ := strings.Cut(scanner.Text(), "#")
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		target := Rule_Target_Ip
+		if strings.Contains(entry, "/") {
+			target = Rule_Target_IpRange
+		}
+		_, prefix, normalizeErr := Normalize(target, entry)
+		if normalizeErr != nil {
+			err = fmt.Errorf("line %d: %w", line, normalizeErr)
+			return
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	err = scanner.Err()
+	return
+}
+
+// FeedOptions : The FeedOptions options.
+type FeedOptions struct {
+	// The name of the feed, which marks the notes of the rules added for it. Required.
+	Name string
+
+	// The mode of the rules added for the feed, block by default.
+	Mode string
+
+	// How long a rule lives after its address was last seen in the feed. Required.
+	TTL time.Duration
+
+	// The time of the sync, now by default.
+	Now time.Time
+
+	// Only report the changes.
+	DryRun bool
+}
+
+// FeedReport : The values of the access rules changed by a feed sync.
+type FeedReport struct {
+	Created []string
+
+	// Rules of the feed whose expiry was pushed back.
+	Refreshed []string
+
+	// Rules of the feed deleted after they expired.
+	Expired []string
+
+	// Feed entries left alone because another access rule targets them.
+	Skipped []string
+
+	// Feed entries left alone because they would take more than MaxFeedSplit access rules.
+	Uncreatable []string
+}
+
+// SyncFeed : Sync the access rules of a blocklist feed
+// Add an access rule for each entry of the feed to a zone, or to the account when the zone is empty. The notes of
+// the rules record the name of the feed and when they expire; the expiry is pushed back while the entry stays in the
+// feed, once half the TTL is spent, and the rule is deleted once it expires. Entries are aggregated as far as the
+// ip_range target allows, and the entries that no ip_range can target are split into smaller ranges or single
+// addresses. Rules not added by the feed are never changed.
+func (manager *Manager) SyncFeed(zone string, feed []netip.Prefix, feedOptions *FeedOptions) (report *FeedReport, err error) {
+	return manager.SyncFeedWithContext(context.Background(), zone, feed, feedOptions)
+}
+
+// SyncFeedWithContext is an alternate form of the SyncFeed method which supports a Context parameter
+func (manager *Manager) SyncFeedWithContext(ctx context.Context, zone string, feed []netip.Prefix, feedOptions *FeedOptions) (report *FeedReport, err error) {
+	err = core.ValidateNotNil(feedOptions, "feedOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	if feedOptions.Name == "" || feedOptions.TTL <= 0 {
+		err = fmt.Errorf("feeds need a name and a TTL")
+		return
+	}
+	mode := feedOptions.Mode
+	if mode == "" {
+		mode = Rule_Mode_Block
+	}
+	now := feedOptions.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	rules, err := manager.accessRules(zone)
+	if err != nil {
+		return
+	}
+	existing, err := rules.list(ctx)
+	if err != nil {
+		return
+	}
+
+	marker := feedOptions.Name + " expires "
+	owned := map[string]Rule{}
+	expiries := map[string]time.Time{}
+	others := map[string]bool{}
+	for _, rule := range existing {
+		expiry, parseErr := time.Parse(time.RFC3339, strings.TrimPrefix(rule.Notes, marker))
+		if strings.HasPrefix(rule.Notes, marker) && parseErr == nil {
+			owned[rule.Value] = rule
+			expiries[rule.Value] = expiry
+		} else {
+			others[rule.Value] = true
+		}
+	}
+
+	report = &FeedReport{}
+	notes := marker + now.Add(feedOptions.TTL).UTC().Format(time.RFC3339)
+	listed := map[string]bool{}
+	prefixes, uncreatable := feedPrefixes(feed)
+	for _, prefix := range uncreatable {
+		report.Uncreatable = append(report.Uncreatable, prefix.String())
+	}
+	for _, prefix := range prefixes {
+		target, value := TargetOf(prefix)
+		listed[value] = true
+		rule, ok := owned[value]
+		switch {
+		case ok && expiries[value].Sub(now) >= feedOptions.TTL/2 && rule.Mode == mode:
+		case ok:
+			report.Refreshed = append(report.Refreshed, value)
+			if !feedOptions.DryRun {
+				err = rules.update(ctx, rule.ID, mode, notes)
+			}
+		case others[value]:
+			report.Skipped = append(report.Skipped, value)
+		default:
+			report.Created = append(report.Created, value)
+			if !feedOptions.DryRun {
+				err = rules.create(ctx, mode, target, value, notes)
+			}
+		}
+		if err != nil {
+			err = fmt.Errorf("syncing %s of feed %s: %w", value, feedOptions.Name, err)
+			return
+		}
+	}
+	for _, rule := range existing {
+		if _, ok := owned[rule.Value]; !ok || listed[rule.Value] || expiries[rule.Value].After(now) {
+			continue
+		}
+		report.Expired = append(report.Expired, rule.Value)
+		if !feedOptions.DryRun {
+			err = rules.delete(ctx, rule.ID)
+			if err != nil {
+				err = fmt.Errorf("expiring %s of feed %s: %w", rule.Value, feedOptions.Name, err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// accessRules returns the access rules of a zone of the manager, or of the account when the zone is empty.
+func (manager *Manager) accessRules(zone string) (rules accessRules, err error) {
+	if zone == "" {
+		if manager.Account == nil {
+			err = fmt.Errorf("the manager has no account client")
+			return
+		}
+		return accountAccessRules{client: manager.Account}, nil
+	}
+	for _, candidate := range manager.Zones {
+		if candidate.Name == zone {
+			return zoneAccessRules{zone: zone, client: candidate.AccessRules}, nil
+		}
+	}
+	err = fmt.Errorf("zone %s is not managed", zone)
+	return
+}
+
+// feedPrefixes aggregates the entries of a feed, keeping the entries of an aggregate that no access rule can
+// target. The entries that no access rule can target either are split, or returned as uncreatable when they would
+// take more than MaxFeedSplit rules.
+func feedPrefixes(feed []netip.Prefix) (prefixes []netip.Prefix, uncreatable []netip.Prefix) {
+	for _, aggregate := range Aggregate(feed) {
+		if Creatable(aggregate) {
+			prefixes = append(prefixes, aggregate)
+			continue
+		}
+		var covered []netip.Prefix
+		for _, entry := range feed {
+			if entry = entry.Masked(); aggregate.Contains(entry.Addr()) {
+				covered = append(covered, entry)
+			}
+		}
+		sort.Slice(covered, func(i, j int) bool {
+			return covered[i].Addr().Less(covered[j].Addr())
+		})
+		for _, entry := range withoutContained(covered) {
+			if Creatable(entry) {
+				prefixes = append(prefixes, entry)
+			} else if parts := splitCreatable(entry); parts != nil {
+				prefixes = append(prefixes, parts...)
+			} else {
+				uncreatable = append(uncreatable, entry)
+			}
+		}
+	}
+	return
+}
+
+// splitCreatable splits a prefix into the prefixes of the next length that an access rule can target, or returns
+// nil when that takes more than MaxFeedSplit prefixes.
+func splitCreatable(prefix netip.Prefix) (parts []netip.Prefix) {
+	bitLen := prefix.Addr().BitLen()
+	for _, bits := range append(RangePrefixLengths[bitLen], bitLen) {
+		if bits < prefix.Bits() {
+			continue
+		}
+		if split := bits - prefix.Bits(); split > 16 || 1<<split > MaxFeedSplit {
+			return nil
+		}
+		for addr := prefix.Addr(); prefix.Contains(addr); addr = lastAddr(netip.PrefixFrom(addr, bits)).Next() {
+			parts = append(parts, netip.PrefixFrom(addr, bits))
+		}
+		return
+	}
+	return nil
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Masked().Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// withoutContained drops duplicates and the prefixes contained in others.
+func withoutContained(prefixes []netip.Prefix) (kept []netip.Prefix) {
+	for i, prefix := range prefixes {
+		contained := false
+		for j, other := range prefixes {
+			if i != j && other.Bits() <= prefix.Bits() && other.Contains(prefix.Addr()) && (other.Bits() < prefix.Bits() || j < i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			kept = append(kept, prefix)
+		}
+	}
+	return
+}
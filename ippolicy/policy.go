@@ -0,0 +1,470 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ippolicy brings together the IP policy of an account and its zones: the account and zone access rules and
+// the zone lockdowns.
+//
+// A Manager loads them into a Policy, which tells what happens to an address on a zone, finds contradicting and
+// redundant rules and the rules that could be aggregated into ranges. The Manager also keeps the access rules of a
+// blocklist feed in line with the feed, expiring the rules it added once they leave the feed.
+package ippolicy
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/IBM/networking-go-sdk/firewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonefirewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+)
+
+// Constants associated with the Rule.Scope property.
+const (
+	Rule_Scope_Account = "account"
+	Rule_Scope_Zone    = "zone"
+)
+
+// Constants associated with the Rule.Mode property.
+const (
+	Rule_Mode_Block       = "block"
+	Rule_Mode_Challenge   = "challenge"
+	Rule_Mode_JsChallenge = "js_challenge"
+	Rule_Mode_Whitelist   = "whitelist"
+)
+
+// Constants associated with the Conflict.Kind property.
+const (
+	// Rules of different modes apply to the same addresses.
+	Conflict_Kind_Contradiction = "contradiction"
+
+	// An unpaused lockdown allows addresses that an access rule does not whitelist.
+	Conflict_Kind_Lockdown = "lockdown"
+
+	// A rule is covered by another rule of the same mode.
+	Conflict_Kind_Redundant = "redundant"
+)
+
+// Rule : An account or zone access rule.
+type Rule struct {
+	// One of the Rule_Scope constants.
+	Scope string
+
+	// The zone of zone access rules.
+	Zone string
+
+	ID string
+
+	// One of the Rule_Mode constants.
+	Mode string
+
+	// One of the Rule_Target constants.
+	Target string
+
+	// The value of the target, normalized.
+	Value string
+
+	Notes string
+
+	// The addresses of the ip and ip_range targets.
+	Prefix netip.Prefix
+}
+
+func (rule *Rule) String() string {
+	scope := rule.Scope
+	if rule.Zone != "" {
+		scope += " " + rule.Zone
+	}
+	return fmt.Sprintf("%s %s %s %s", scope, rule.Mode, rule.Target, rule.Value)
+}
+
+// specificity orders rules from the least to the most specific target: countries, ASNs, then ranges by length
+// and addresses.
+func (rule *Rule) specificity() int {
+	switch rule.Target {
+	case Rule_Target_Country:
+		return 0
+	case Rule_Target_Asn:
+		return 1
+	}
+	return 2 + rule.Prefix.Bits()
+}
+
+// overlaps returns true when both rules can apply to the same request.
+func (rule *Rule) overlaps(other *Rule) bool {
+	if rule.Prefix.IsValid() && other.Prefix.IsValid() {
+		return rule.Prefix.Overlaps(other.Prefix)
+	}
+	return rule.Target == other.Target && rule.Value == other.Value
+}
+
+// covers returns true when the rule applies to every request the other rule applies to.
+func (rule *Rule) covers(other *Rule) bool {
+	if rule.Prefix.IsValid() && other.Prefix.IsValid() {
+		return rule.Prefix.Bits() <= other.Prefix.Bits() && rule.Prefix.Contains(other.Prefix.Addr())
+	}
+	return rule.Target == other.Target && rule.Value == other.Value
+}
+
+// Lockdown : A zone lockdown.
+type Lockdown struct {
+	Zone string
+
+	ID string
+
+	Description string
+
+	Paused bool
+
+	// The URL patterns only the allowed addresses can access.
+	Urls []string
+
+	// The allowed addresses.
+	Allowed []netip.Prefix
+}
+
+// allows returns true when the lockdown lets the address through.
+func (lockdown *Lockdown) allows(addr netip.Addr) bool {
+	for _, prefix := range lockdown.Allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Zone : The clients of a zone. The lockdowns are not loaded when their client is nil.
+type Zone struct {
+	Name string
+
+	AccessRules *zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1
+
+	Lockdowns *zonelockdownv1.ZoneLockdownV1
+}
+
+// Manager : Manages the IP policy of an account and its zones.
+type Manager struct {
+	// The client of the account access rules, nil to leave them out.
+	Account *firewallaccessrulesv1.FirewallAccessRulesV1
+
+	Zones []Zone
+}
+
+// NewManager : Instantiate Manager
+func NewManager(account *firewallaccessrulesv1.FirewallAccessRulesV1, zones ...Zone) (manager *Manager, err error) {
+	for _, zone := range zones {
+		if zone.Name == "" || zone.AccessRules == nil {
+			err = fmt.Errorf("zones need a name and an access rules client")
+			return
+		}
+	}
+	manager = &Manager{Account: account, Zones: zones}
+	return
+}
+
+// Policy : The access rules and lockdowns of an account and its zones.
+type Policy struct {
+	// The account access rules, which apply to all the zones.
+	Account []Rule
+
+	// The zone access rules by zone name.
+	Zones map[string][]Rule
+
+	// The lockdowns by zone name.
+	Lockdowns map[string][]Lockdown
+}
+
+// Load : Load the IP policy
+// Load the account access rules and the access rules and lockdowns of each zone.
+func (manager *Manager) Load() (policy *Policy, err error) {
+	return manager.LoadWithContext(context.Background())
+}
+
+// LoadWithContext is an alternate form of the Load method which supports a Context parameter
+func (manager *Manager) LoadWithContext(ctx context.Context) (policy *Policy, err error) {
+	policy = &Policy{Zones: map[string][]Rule{}, Lockdowns: map[string][]Lockdown{}}
+	if manager.Account != nil {
+		policy.Account, err = accountAccessRules{client: manager.Account}.list(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, zone := range manager.Zones {
+		policy.Zones[zone.Name], err = zoneAccessRules{zone: zone.Name, client: zone.AccessRules}.list(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if zone.Lockdowns != nil {
+			policy.Lockdowns[zone.Name], err = listLockdowns(ctx, zone.Name, zone.Lockdowns)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+// zoneNames returns the names of the zones of the policy, sorted.
+func (policy *Policy) zoneNames() (names []string) {
+	for name := range policy.Zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// Request : The client of a request. The ASN and country are optional.
+type Request struct {
+	IP string
+
+	ASN string
+
+	Country string
+}
+
+// LockdownDecision : Whether a lockdown lets a request through.
+type LockdownDecision struct {
+	Lockdown Lockdown
+
+	Allowed bool
+}
+
+// Decision : What happens to a request on a zone.
+type Decision struct {
+	// The mode of the rule that applies, empty when no access rule applies.
+	Mode string
+
+	// The rule that applies.
+	Rule *Rule
+
+	// All the rules that match the request, the one that applies first.
+	Matches []Rule
+
+	// The ASN and country rules that could apply, when the request does not tell its ASN or country and no address
+	// rule applies.
+	Undetermined []Rule
+
+	// The unpaused lockdowns of the zone.
+	Lockdowns []LockdownDecision
+}
+
+func (decision *Decision) String() string {
+	var explanation strings.Builder
+	if decision.Rule == nil {
+		explanation.WriteString("no access rule applies")
+	} else {
+		fmt.Fprintf(&explanation, "%s by %s", decision.Mode, decision.Rule)
+	}
+	for _, rule := range decision.Matches[min(1, len(decision.Matches)):] {
+		fmt.Fprintf(&explanation, "; overrides %s", &rule)
+	}
+	for _, rule := range decision.Undetermined {
+		fmt.Fprintf(&explanation, "; %s may apply", &rule)
+	}
+	for _, lockdown := range decision.Lockdowns {
+		if !lockdown.Allowed {
+			fmt.Fprintf(&explanation, "; locked out of %s by lockdown %q", strings.Join(lockdown.Lockdown.Urls, ", "), lockdown.Lockdown.Description)
+		}
+	}
+	return explanation.String()
+}
+
+// Evaluate tells what happens to a request on a zone. The rule with the most specific target applies: addresses
+// first, then ranges from the longest, ASNs and countries. Between rules as specific, the zone rule applies. The
+// lockdowns are evaluated apart, as they only apply to their URLs.
+func (policy *Policy) Evaluate(zone string, request *Request) (decision *Decision, err error) {
+	zoneRules, ok := policy.Zones[zone]
+	if !ok {
+		err = fmt.Errorf("zone %s is not in the policy", zone)
+		return
+	}
+	addr, err := netip.ParseAddr(request.IP)
+	if err != nil {
+		err = fmt.Errorf("invalid ip %q: %w", request.IP, err)
+		return
+	}
+	addr = addr.Unmap()
+	asn, _, _ := Normalize(Rule_Target_Asn, request.ASN)
+	country, _, _ := Normalize(Rule_Target_Country, request.Country)
+
+	decision = &Decision{}
+	for _, rule := range append(append([]Rule{}, zoneRules...), policy.Account...) {
+		switch {
+		case rule.Prefix.IsValid():
+			if rule.Prefix.Contains(addr) {
+				decision.Matches = append(decision.Matches, rule)
+			}
+		case rule.Target == Rule_Target_Asn && request.ASN == "", rule.Target == Rule_Target_Country && request.Country == "":
+			decision.Undetermined = append(decision.Undetermined, rule)
+		case rule.Target == Rule_Target_Asn && rule.Value == asn, rule.Target == Rule_Target_Country && rule.Value == country:
+			decision.Matches = append(decision.Matches, rule)
+		}
+	}
+	// The zone rules come first, so a stable sort keeps them ahead of the account rules as specific.
+	sort.SliceStable(decision.Matches, func(i, j int) bool {
+		return decision.Matches[i].specificity() > decision.Matches[j].specificity()
+	})
+	if len(decision.Matches) > 0 {
+		decision.Rule = &decision.Matches[0]
+		decision.Mode = decision.Rule.Mode
+		if decision.Rule.Prefix.IsValid() {
+			decision.Undetermined = nil
+		}
+	}
+	for _, lockdown := range policy.Lockdowns[zone] {
+		if !lockdown.Paused {
+			decision.Lockdowns = append(decision.Lockdowns, LockdownDecision{Lockdown: lockdown, Allowed: lockdown.allows(addr)})
+		}
+	}
+	return
+}
+
+// Conflict : Rules that contradict or repeat each other.
+type Conflict struct {
+	// One of the Conflict_Kind constants.
+	Kind string
+
+	// The zone, empty for conflicts between account rules.
+	Zone string
+
+	// The rules, the broader one first for redundant rules.
+	Rules []Rule
+
+	// The lockdown, for lockdown conflicts.
+	Lockdown *Lockdown
+}
+
+func (conflict *Conflict) String() string {
+	switch conflict.Kind {
+	case Conflict_Kind_Redundant:
+		return fmt.Sprintf("%s is covered by %s", &conflict.Rules[1], &conflict.Rules[0])
+	case Conflict_Kind_Lockdown:
+		return fmt.Sprintf("lockdown %q of zone %s allows addresses that %s does not let through", conflict.Lockdown.Description, conflict.Zone, &conflict.Rules[0])
+	}
+	return fmt.Sprintf("%s contradicts %s", &conflict.Rules[0], &conflict.Rules[1])
+}
+
+// Conflicts returns the rules that contradict each other, the rules covered by another rule of the same mode and
+// the unpaused lockdowns allowing addresses that an access rule blocks or challenges. The account rules are compared
+// once, and each zone's rules with each other and with the account rules.
+func (policy *Policy) Conflicts() (conflicts []Conflict) {
+	conflicts = compareRules("", nil, policy.Account)
+	for _, zone := range policy.zoneNames() {
+		conflicts = append(conflicts, compareRules(zone, policy.Account, policy.Zones[zone])...)
+		for i := range policy.Lockdowns[zone] {
+			lockdown := policy.Lockdowns[zone][i]
+			if lockdown.Paused {
+				continue
+			}
+			for _, rule := range append(append([]Rule{}, policy.Account...), policy.Zones[zone]...) {
+				if rule.Mode == Rule_Mode_Whitelist || !rule.Prefix.IsValid() {
+					continue
+				}
+				for _, allowed := range lockdown.Allowed {
+					if allowed.Overlaps(rule.Prefix) {
+						conflicts = append(conflicts, Conflict{Kind: Conflict_Kind_Lockdown, Zone: zone, Rules: []Rule{rule}, Lockdown: &lockdown})
+						break
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// compareRules compares the rules with each other and with the given account rules.
+func compareRules(zone string, account []Rule, rules []Rule) (conflicts []Conflict) {
+	for i := range rules {
+		others := append(append([]Rule{}, account...), rules[i+1:]...)
+		for j := range others {
+			a, b := &others[j], &rules[i]
+			if !a.overlaps(b) {
+				continue
+			}
+			switch {
+			case a.Mode != b.Mode:
+				conflicts = append(conflicts, Conflict{Kind: Conflict_Kind_Contradiction, Zone: zone, Rules: []Rule{*a, *b}})
+			case a.covers(b):
+				conflicts = append(conflicts, Conflict{Kind: Conflict_Kind_Redundant, Zone: zone, Rules: []Rule{*a, *b}})
+			case b.covers(a):
+				conflicts = append(conflicts, Conflict{Kind: Conflict_Kind_Redundant, Zone: zone, Rules: []Rule{*b, *a}})
+			}
+		}
+	}
+	return
+}
+
+// Aggregation : Access rules of the same scope and mode whose addresses make up a single prefix.
+type Aggregation struct {
+	Scope string
+
+	Zone string
+
+	Mode string
+
+	Prefix netip.Prefix
+
+	// The rules the prefix replaces.
+	Rules []Rule
+
+	// Whether an access rule can target the prefix; see Creatable.
+	Creatable bool
+}
+
+// Aggregations returns the groups of two or more ip and ip_range rules of the same scope and mode that adjacent
+// prefixes let replace with one prefix.
+func (policy *Policy) Aggregations() (aggregations []Aggregation) {
+	aggregations = aggregateRules(Rule_Scope_Account, "", policy.Account)
+	for _, zone := range policy.zoneNames() {
+		aggregations = append(aggregations, aggregateRules(Rule_Scope_Zone, zone, policy.Zones[zone])...)
+	}
+	return
+}
+
+func aggregateRules(scope string, zone string, rules []Rule) (aggregations []Aggregation) {
+	byMode := map[string][]Rule{}
+	var modes []string
+	for _, rule := range rules {
+		if !rule.Prefix.IsValid() {
+			continue
+		}
+		if byMode[rule.Mode] == nil {
+			modes = append(modes, rule.Mode)
+		}
+		byMode[rule.Mode] = append(byMode[rule.Mode], rule)
+	}
+	sort.Strings(modes)
+	for _, mode := range modes {
+		var prefixes []netip.Prefix
+		for _, rule := range byMode[mode] {
+			prefixes = append(prefixes, rule.Prefix)
+		}
+		for _, prefix := range Aggregate(prefixes) {
+			aggregation := Aggregation{Scope: scope, Zone: zone, Mode: mode, Prefix: prefix, Creatable: Creatable(prefix)}
+			for _, rule := range byMode[mode] {
+				if prefix.Contains(rule.Prefix.Addr()) {
+					aggregation.Rules = append(aggregation.Rules, rule)
+				}
+			}
+			if len(aggregation.Rules) > 1 {
+				aggregations = append(aggregations, aggregation)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,284 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ippolicy_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/firewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/ippolicy"
+	"github.com/IBM/networking-go-sdk/zonefirewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var objectPath = regexp.MustCompile(`^/v1/crn(?:/zones/([^/]+))?/firewall/(access_rules/rules|lockdowns)(?:/([^/]+))?$`)
+
+// fakeAPI keeps the access rules of an account and the access rules and lockdowns of its zones.
+type fakeAPI struct {
+	sync.Mutex
+	objects map[string][]map[string]interface{}
+	writes  []string
+	lastID  int
+}
+
+func accessRule(id string, mode string, target string, value string, notes string) map[string]interface{} {
+	return map[string]interface{}{"id": id, "mode": mode, "notes": notes, "configuration": map[string]interface{}{"target": target, "value": value}}
+}
+
+func (api *fakeAPI) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	matches := objectPath.FindStringSubmatch(req.URL.EscapedPath())
+	Expect(matches).ToNot(BeNil())
+	key := matches[1] + "/" + matches[2]
+	var body map[string]interface{}
+	if req.Method == "POST" || req.Method == "PATCH" {
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+	}
+	if req.Method != "GET" {
+		api.writes = append(api.writes, fmt.Sprintf("%s %s %v", req.Method, strings.TrimPrefix(key, "/"), body))
+	}
+	var result interface{}
+	switch req.Method {
+	case "GET":
+		objects := api.objects[key]
+		if matches[1] != "" && matches[2] == "access_rules/rules" {
+			// The zone lists also show the rules of the account.
+			for _, rule := range objects {
+				rule["scope"] = map[string]interface{}{"type": "zone"}
+			}
+			for _, rule := range api.objects["/access_rules/rules"] {
+				objects = append(objects, map[string]interface{}{"id": rule["id"], "mode": rule["mode"], "configuration": rule["configuration"], "scope": map[string]interface{}{"type": "account"}})
+			}
+		}
+		res.Header().Set("Content-type", "application/json")
+		Expect(json.NewEncoder(res).Encode(map[string]interface{}{"success": true, "errors": []interface{}{}, "messages": []interface{}{}, "result": objects,
+			"result_info": map[string]interface{}{"page": 1, "per_page": 100, "count": len(objects), "total_count": len(objects)}})).To(Succeed())
+		return
+	case "POST":
+		api.lastID++
+		body["id"] = fmt.Sprintf("new-%d", api.lastID)
+		api.objects[key] = append(api.objects[key], body)
+		result = body
+	case "PATCH":
+		for _, object := range api.objects[key] {
+			if object["id"] == matches[3] {
+				object["mode"], object["notes"] = body["mode"], body["notes"]
+			}
+		}
+		result = body
+	case "DELETE":
+		for i, object := range api.objects[key] {
+			if object["id"] == matches[3] {
+				api.objects[key] = append(api.objects[key][:i:i], api.objects[key][i+1:]...)
+				break
+			}
+		}
+		result = map[string]interface{}{"id": matches[3]}
+	}
+	res.Header().Set("Content-type", "application/json")
+	Expect(json.NewEncoder(res).Encode(map[string]interface{}{"success": true, "errors": []interface{}{}, "messages": []interface{}{}, "result": result})).To(Succeed())
+}
+
+func (api *fakeAPI) values(key string) (values []string) {
+	api.Lock()
+	defer api.Unlock()
+	for _, object := range api.objects[key] {
+		values = append(values, fmt.Sprintf("%s %s %s", object["mode"], object["configuration"].(map[string]interface{})["value"], object["notes"]))
+	}
+	return
+}
+
+var _ = Describe(`Manager`, func() {
+	var api *fakeAPI
+	var server *httptest.Server
+	var manager *ippolicy.Manager
+
+	BeforeEach(func() {
+		api = &fakeAPI{objects: map[string][]map[string]interface{}{
+			"/access_rules/rules": {
+				accessRule("a-1", "whitelist", "ip", "198.51.100.7", "office"),
+				accessRule("a-2", "challenge", "country", "XX", ""),
+				accessRule("a-3", "block", "ip_range", "192.0.2.0/25", ""),
+				accessRule("a-4", "block", "ip_range", "192.0.2.128/25", ""),
+			},
+			"zone-a/access_rules/rules": {
+				accessRule("z-1", "block", "ip_range", "198.51.100.0/24", ""),
+				accessRule("z-2", "block", "asn", "as64496", ""),
+				accessRule("z-3", "block", "ip", "198.51.100.9", ""),
+			},
+			"zone-a/lockdowns": {
+				{"id": "l-1", "paused": false, "description": "admin", "urls": []string{"example.com/admin*"}, "configurations": []interface{}{
+					map[string]interface{}{"target": "ip_range", "value": "198.51.100.0/28"},
+				}},
+				{"id": "l-2", "paused": true, "description": "old", "urls": []string{"example.com/old*"}, "configurations": []interface{}{}},
+			},
+			"zone-b/access_rules/rules": {},
+		}}
+		server = httptest.NewServer(api)
+
+		account, err := firewallaccessrulesv1.NewFirewallAccessRulesV1(&firewallaccessrulesv1.FirewallAccessRulesV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"),
+		})
+		Expect(err).To(BeNil())
+		var zones []ippolicy.Zone
+		for _, name := range []string{"zone-a", "zone-b"} {
+			accessRules, err := zonefirewallaccessrulesv1.NewZoneFirewallAccessRulesV1(&zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1Options{
+				URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr(name),
+			})
+			Expect(err).To(BeNil())
+			lockdowns, err := zonelockdownv1.NewZoneLockdownV1(&zonelockdownv1.ZoneLockdownV1Options{
+				URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr(name),
+			})
+			Expect(err).To(BeNil())
+			zones = append(zones, ippolicy.Zone{Name: name, AccessRules: accessRules, Lockdowns: lockdowns})
+		}
+		manager, err = ippolicy.NewManager(account, zones...)
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Tell what happens to an address on a zone`, func() {
+		policy, err := manager.Load()
+		Expect(err).To(BeNil())
+		Expect(policy.Account).To(HaveLen(4))
+		Expect(policy.Zones["zone-a"]).To(HaveLen(3))
+		Expect(policy.Zones["zone-a"][1].Value).To(Equal("AS64496"))
+
+		decision, err := policy.Evaluate("zone-a", &ippolicy.Request{IP: "198.51.100.7"})
+		Expect(err).To(BeNil())
+		Expect(decision.Mode).To(Equal(ippolicy.Rule_Mode_Whitelist))
+		Expect(decision.Rule.ID).To(Equal("a-1"))
+		Expect(decision.String()).To(Equal("whitelist by account whitelist ip 198.51.100.7; overrides zone zone-a block ip_range 198.51.100.0/24"))
+
+		decision, err = policy.Evaluate("zone-a", &ippolicy.Request{IP: "198.51.100.200"})
+		Expect(err).To(BeNil())
+		Expect(decision.Rule.ID).To(Equal("z-1"))
+		Expect(decision.String()).To(Equal("block by zone zone-a block ip_range 198.51.100.0/24; locked out of example.com/admin* by lockdown \"admin\""))
+
+		decision, err = policy.Evaluate("zone-a", &ippolicy.Request{IP: "203.0.113.1", Country: "xx"})
+		Expect(err).To(BeNil())
+		Expect(decision.Mode).To(Equal(ippolicy.Rule_Mode_Challenge))
+		Expect(decision.Undetermined).To(HaveLen(1))
+		Expect(decision.Undetermined[0].ID).To(Equal("z-2"))
+
+		decision, err = policy.Evaluate("zone-b", &ippolicy.Request{IP: "203.0.113.1", ASN: "64496", Country: "YY"})
+		Expect(err).To(BeNil())
+		Expect(decision.Rule).To(BeNil())
+		Expect(decision.String()).To(Equal("no access rule applies"))
+
+		_, err = policy.Evaluate("zone-c", &ippolicy.Request{IP: "203.0.113.1"})
+		Expect(err).ToNot(BeNil())
+		_, err = policy.Evaluate("zone-a", &ippolicy.Request{IP: "203.0.113"})
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Detect conflicts and aggregations`, func() {
+		policy, err := manager.Load()
+		Expect(err).To(BeNil())
+		var conflicts []string
+		for _, conflict := range policy.Conflicts() {
+			conflicts = append(conflicts, conflict.Kind+": "+conflict.String())
+		}
+		Expect(conflicts).To(Equal([]string{
+			"contradiction: account whitelist ip 198.51.100.7 contradicts zone zone-a block ip_range 198.51.100.0/24",
+			"redundant: zone zone-a block ip 198.51.100.9 is covered by zone zone-a block ip_range 198.51.100.0/24",
+			`lockdown: lockdown "admin" of zone zone-a allows addresses that zone zone-a block ip_range 198.51.100.0/24 does not let through`,
+			`lockdown: lockdown "admin" of zone zone-a allows addresses that zone zone-a block ip 198.51.100.9 does not let through`,
+		}))
+
+		aggregations := policy.Aggregations()
+		Expect(aggregations).To(HaveLen(2))
+		Expect(aggregations[0].Scope).To(Equal(ippolicy.Rule_Scope_Account))
+		Expect(aggregations[0].Prefix.String()).To(Equal("192.0.2.0/24"))
+		Expect(aggregations[0].Creatable).To(BeTrue())
+		Expect(aggregations[0].Rules).To(HaveLen(2))
+		Expect(aggregations[1].Zone).To(Equal("zone-a"))
+		Expect(aggregations[1].Prefix.String()).To(Equal("198.51.100.0/24"))
+	})
+	It(`Sync a blocklist feed and expire the rules it added`, func() {
+		feed, err := ippolicy.ParseFeed(strings.NewReader("# feed\n203.0.113.1\n203.0.113.0/25 # scanners\n\n203.0.113.128/25\n198.51.100.9\n10.0.0.1\n10.0.0.0\n"))
+		Expect(err).To(BeNil())
+		Expect(feed).To(HaveLen(6))
+		now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		options := &ippolicy.FeedOptions{Name: "feed", TTL: 24 * time.Hour, Now: now}
+
+		report, err := manager.SyncFeed("zone-a", feed, options)
+		Expect(err).To(BeNil())
+		Expect(report).To(Equal(&ippolicy.FeedReport{Created: []string{"10.0.0.0", "10.0.0.1", "203.0.113.0/24"}, Skipped: []string{"198.51.100.9"}}))
+		Expect(api.values("zone-a/access_rules/rules")[3:]).To(Equal([]string{
+			"block 10.0.0.0 feed expires 2024-05-02T00:00:00Z",
+			"block 10.0.0.1 feed expires 2024-05-02T00:00:00Z",
+			"block 203.0.113.0/24 feed expires 2024-05-02T00:00:00Z",
+		}))
+
+		options.Now = now.Add(6 * time.Hour)
+		report, err = manager.SyncFeed("zone-a", feed[:4], options)
+		Expect(err).To(BeNil())
+		Expect(report).To(Equal(&ippolicy.FeedReport{Skipped: []string{"198.51.100.9"}}))
+
+		options.Now = now.Add(13 * time.Hour)
+		report, err = manager.SyncFeed("zone-a", feed[:4], options)
+		Expect(err).To(BeNil())
+		Expect(report).To(Equal(&ippolicy.FeedReport{Refreshed: []string{"203.0.113.0/24"}, Skipped: []string{"198.51.100.9"}}))
+
+		options.Now, options.DryRun = now.Add(25*time.Hour), true
+		writes := len(api.writes)
+		report, err = manager.SyncFeed("zone-a", feed[:4], options)
+		Expect(err).To(BeNil())
+		Expect(report.Expired).To(Equal([]string{"10.0.0.0", "10.0.0.1"}))
+		Expect(api.writes).To(HaveLen(writes))
+
+		options.DryRun = false
+		_, err = manager.SyncFeed("zone-a", feed[:4], options)
+		Expect(err).To(BeNil())
+		Expect(api.values("zone-a/access_rules/rules")[3:]).To(Equal([]string{"block 203.0.113.0/24 feed expires 2024-05-02T13:00:00Z"}))
+
+		_, err = manager.SyncFeed("zone-c", feed, options)
+		Expect(err).ToNot(BeNil())
+		_, err = ippolicy.ParseFeed(strings.NewReader("203.0.113.1\nnot an address\n"))
+		Expect(err).To(MatchError(ContainSubstring("line 2: ")))
+	})
+	It(`Split the feed entries that no ip_range can target`, func() {
+		feed, err := ippolicy.ParseFeed(strings.NewReader("192.0.16.0/20\n203.0.113.128/25\n10.0.0.0/7\n"))
+		Expect(err).To(BeNil())
+		report, err := manager.SyncFeed("zone-a", feed, &ippolicy.FeedOptions{Name: "feed", TTL: time.Hour})
+		Expect(err).To(BeNil())
+		Expect(report.Uncreatable).To(Equal([]string{"10.0.0.0/7"}))
+		Expect(report.Created).To(HaveLen(16 + 128))
+		Expect(report.Created[:2]).To(Equal([]string{"192.0.16.0/24", "192.0.17.0/24"}))
+		Expect(report.Created[15:18]).To(Equal([]string{"192.0.31.0/24", "203.0.113.128", "203.0.113.129"}))
+		Expect(report.Created[16+127]).To(Equal("203.0.113.255"))
+		Expect(api.values("zone-a/access_rules/rules")[3:]).To(HaveLen(16 + 128))
+
+		_, err = manager.SyncFeed("zone-a", feed, nil)
+		Expect(err).To(MatchError("feedOptions cannot be nil"))
+	})
+})
@@ -0,0 +1,179 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ippolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/common"
+	"github.com/IBM/networking-go-sdk/firewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonefirewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+)
+
+// pageSize is the page size used to list access rules and lockdowns.
+const pageSize = 100
+
+// accessRules reads and writes the access rules of an account or of a zone.
+type accessRules interface {
+	list(ctx context.Context) ([]Rule, error)
+	create(ctx context.Context, mode string, target string, value string, notes string) error
+	update(ctx context.Context, id string, mode string, notes string) error
+	delete(ctx context.Context, id string) error
+}
+
+// newRule builds the Rule of an access rule, keeping the value as is when it cannot be normalized.
+func newRule(scope string, zone string, id *string, mode *string, notes *string, target *string, value *string) Rule {
+	rule := Rule{
+		Scope:  scope,
+		Zone:   zone,
+		ID:     core.StringNilMapper(id),
+		Mode:   core.StringNilMapper(mode),
+		Notes:  core.StringNilMapper(notes),
+		Target: core.StringNilMapper(target),
+		Value:  core.StringNilMapper(value),
+	}
+	if normalized, prefix, err := Normalize(rule.Target, rule.Value); err == nil {
+		rule.Value, rule.Prefix = normalized, prefix
+	}
+	return rule
+}
+
+type accountAccessRules struct {
+	client *firewallaccessrulesv1.FirewallAccessRulesV1
+}
+
+func (rules accountAccessRules) list(ctx context.Context) (list []Rule, err error) {
+	for page := int64(1); ; page++ {
+		options := rules.client.NewListAllAccountAccessRulesOptions().SetPage(page).SetPerPage(pageSize)
+		result, _, listErr := rules.client.ListAllAccountAccessRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing account access rules: %w", listErr)
+			return
+		}
+		for _, rule := range result.Result {
+			var target, value *string
+			if rule.Configuration != nil {
+				target, value = rule.Configuration.Target, rule.Configuration.Value
+			}
+			list = append(list, newRule(Rule_Scope_Account, "", rule.ID, rule.Mode, rule.Notes, target, value))
+		}
+		if result.ResultInfo == nil || common.LastPage(page, pageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			return
+		}
+	}
+}
+
+func (rules accountAccessRules) create(ctx context.Context, mode string, target string, value string, notes string) (err error) {
+	options := rules.client.NewCreateAccountAccessRuleOptions().SetMode(mode).SetNotes(notes).SetConfiguration(&firewallaccessrulesv1.AccountAccessRuleInputConfiguration{
+		Target: core.StringPtr(target),
+		Value:  core.StringPtr(value),
+	})
+	_, _, err = rules.client.CreateAccountAccessRuleWithContext(ctx, options)
+	return
+}
+
+func (rules accountAccessRules) update(ctx context.Context, id string, mode string, notes string) (err error) {
+	_, _, err = rules.client.UpdateAccountAccessRuleWithContext(ctx, rules.client.NewUpdateAccountAccessRuleOptions(id).SetMode(mode).SetNotes(notes))
+	return
+}
+
+func (rules accountAccessRules) delete(ctx context.Context, id string) (err error) {
+	_, _, err = rules.client.DeleteAccountAccessRuleWithContext(ctx, rules.client.NewDeleteAccountAccessRuleOptions(id))
+	return
+}
+
+type zoneAccessRules struct {
+	zone   string
+	client *zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1
+}
+
+// list returns the access rules of the zone, without the rules of the account that the API lists with them.
+func (rules zoneAccessRules) list(ctx context.Context) (list []Rule, err error) {
+	for page := int64(1); ; page++ {
+		options := rules.client.NewListAllZoneAccessRulesOptions().SetPage(page).SetPerPage(pageSize)
+		result, _, listErr := rules.client.ListAllZoneAccessRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing access rules of zone %s: %w", rules.zone, listErr)
+			return
+		}
+		for _, rule := range result.Result {
+			if rule.Scope != nil && core.StringNilMapper(rule.Scope.Type) == zonefirewallaccessrulesv1.ZoneAccessRuleObjectScope_Type_Account {
+				continue
+			}
+			var target, value *string
+			if rule.Configuration != nil {
+				target, value = rule.Configuration.Target, rule.Configuration.Value
+			}
+			list = append(list, newRule(Rule_Scope_Zone, rules.zone, rule.ID, rule.Mode, rule.Notes, target, value))
+		}
+		if result.ResultInfo == nil || common.LastPage(page, pageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			return
+		}
+	}
+}
+
+func (rules zoneAccessRules) create(ctx context.Context, mode string, target string, value string, notes string) (err error) {
+	options := rules.client.NewCreateZoneAccessRuleOptions().SetMode(mode).SetNotes(notes).SetConfiguration(&zonefirewallaccessrulesv1.ZoneAccessRuleInputConfiguration{
+		Target: core.StringPtr(target),
+		Value:  core.StringPtr(value),
+	})
+	_, _, err = rules.client.CreateZoneAccessRuleWithContext(ctx, options)
+	return
+}
+
+func (rules zoneAccessRules) update(ctx context.Context, id string, mode string, notes string) (err error) {
+	_, _, err = rules.client.UpdateZoneAccessRuleWithContext(ctx, rules.client.NewUpdateZoneAccessRuleOptions(id).SetMode(mode).SetNotes(notes))
+	return
+}
+
+func (rules zoneAccessRules) delete(ctx context.Context, id string) (err error) {
+	_, _, err = rules.client.DeleteZoneAccessRuleWithContext(ctx, rules.client.NewDeleteZoneAccessRuleOptions(id))
+	return
+}
+
+func listLockdowns(ctx context.Context, zone string, client *zonelockdownv1.ZoneLockdownV1) (lockdowns []Lockdown, err error) {
+	for page := int64(1); ; page++ {
+		options := client.NewListAllZoneLockownRulesOptions().SetPage(page).SetPerPage(pageSize)
+		result, _, listErr := client.ListAllZoneLockownRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing lockdowns of zone %s: %w", zone, listErr)
+			return
+		}
+		for _, object := range result.Result {
+			lockdown := Lockdown{
+				Zone:        zone,
+				ID:          core.StringNilMapper(object.ID),
+				Description: core.StringNilMapper(object.Description),
+				Paused:      object.Paused != nil && *object.Paused,
+				Urls:        object.Urls,
+			}
+			for _, configuration := range object.Configurations {
+				_, prefix, normalizeErr := Normalize(core.StringNilMapper(configuration.Target), core.StringNilMapper(configuration.Value))
+				if normalizeErr == nil {
+					lockdown.Allowed = append(lockdown.Allowed, prefix)
+				}
+			}
+			lockdowns = append(lockdowns, lockdown)
+		}
+		if result.ResultInfo == nil || common.LastPage(page, pageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			return
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ippolicy
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// Constants associated with the Rule.Target property.
+const (
+	Rule_Target_Asn     = "asn"
+	Rule_Target_Country = "country"
+	Rule_Target_Ip      = "ip"
+	Rule_Target_IpRange = "ip_range"
+)
+
+// RangePrefixLengths are the prefix lengths accepted for the ip_range target, by address length in bits.
+var RangePrefixLengths = map[int][]int{
+	32:  {16, 24},
+	128: {32, 48, 64},
+}
+
+// Normalize returns the canonical form of the value of an access rule target, and the addresses it covers for the
+// ip and ip_range targets. IPv4-mapped IPv6 addresses become IPv4 addresses, ranges are masked, ASNs are written
+// AS<number> and countries are upper case.
+func Normalize(target string, value string) (normalized string, prefix netip.Prefix, err error) {
+	value = strings.TrimSpace(value)
+	switch target {
+	case Rule_Target_Ip:
+		var addr netip.Addr
+		addr, err = netip.ParseAddr(value)
+		if err != nil {
+			err = fmt.Errorf("invalid ip %q: %w", value, err)
+			return
+		}
+		addr = addr.Unmap().WithZone("")
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+		normalized = addr.String()
+	case Rule_Target_IpRange:
+		prefix, err = parsePrefix(value)
+		if err != nil {
+			err = fmt.Errorf("invalid ip_range %q: %w", value, err)
+			return
+		}
+		normalized = prefix.String()
+	case Rule_Target_Asn:
+		number := strings.TrimPrefix(strings.ToUpper(value), "AS")
+		if number == "" || strings.Trim(number, "0123456789") != "" {
+			err = fmt.Errorf("invalid asn %q", value)
+			return
+		}
+		normalized = "AS" + number
+	case Rule_Target_Country:
+		if len(value) != 2 {
+			err = fmt.Errorf("invalid country %q", value)
+			return
+		}
+		normalized = strings.ToUpper(value)
+	default:
+		err = fmt.Errorf("unknown target %q", target)
+	}
+	return
+}
+
+// parsePrefix parses and masks a CIDR, unmapping IPv4-mapped IPv6 ranges.
+func parsePrefix(value string) (prefix netip.Prefix, err error) {
+	prefix, err = netip.ParsePrefix(value)
+	if err != nil {
+		return
+	}
+	if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+	}
+	prefix = prefix.Masked()
+	return
+}
+
+// TargetOf returns the access rule target and value of a prefix: ip for a single address and ip_range otherwise.
+func TargetOf(prefix netip.Prefix) (target string, value string) {
+	if prefix.IsSingleIP() {
+		return Rule_Target_Ip, prefix.Addr().String()
+	}
+	return Rule_Target_IpRange, prefix.String()
+}
+
+// Creatable returns true when an access rule can target the prefix, as an ip or as an ip_range of one of the
+// RangePrefixLengths.
+func Creatable(prefix netip.Prefix) bool {
+	if prefix.IsSingleIP() {
+		return true
+	}
+	for _, bits := range RangePrefixLengths[prefix.Addr().BitLen()] {
+		if prefix.Bits() == bits {
+			return true
+		}
+	}
+	return false
+}
+
+// Aggregate returns the smallest set of prefixes covering the same addresses as the given ones: duplicates and
+// prefixes contained in others are dropped and adjacent prefixes are merged. The result is sorted, IPv4 first.
+func Aggregate(prefixes []netip.Prefix) (aggregated []netip.Prefix) {
+	sorted := make([]netip.Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if prefix.IsValid() {
+			sorted = append(sorted, prefix.Masked())
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if order := sorted[i].Addr().Compare(sorted[j].Addr()); order != 0 {
+			return order < 0
+		}
+		return sorted[i].Bits() < sorted[j].Bits()
+	})
+	for _, prefix := range sorted {
+		if len(aggregated) > 0 && aggregated[len(aggregated)-1].Overlaps(prefix) {
+			continue
+		}
+		aggregated = append(aggregated, prefix)
+		// Merge the last two prefixes while they are the two halves of the same prefix.
+		for len(aggregated) >= 2 {
+			low, high := aggregated[len(aggregated)-2], aggregated[len(aggregated)-1]
+			if low.Bits() != high.Bits() || low.Bits() == 0 || low.Addr().BitLen() != high.Addr().BitLen() {
+				break
+			}
+			parent := netip.PrefixFrom(low.Addr(), low.Bits()-1).Masked()
+			if parent.Addr() != low.Addr() || !parent.Contains(high.Addr()) {
+				break
+			}
+			aggregated = append(aggregated[:len(aggregated)-2], parent)
+		}
+	}
+	return
+}
@@ -0,0 +1,76 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ippolicy_test
+
+import (
+	"net/netip"
+
+	"github.com/IBM/networking-go-sdk/ippolicy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func prefixes(values ...string) (list []netip.Prefix) {
+	for _, value := range values {
+		list = append(list, netip.MustParsePrefix(value))
+	}
+	return
+}
+
+var _ = Describe(`Targets`, func() {
+	It(`Normalize target values`, func() {
+		for _, test := range []struct{ target, value, normalized, prefix string }{
+			{"ip", " 198.51.100.7 ", "198.51.100.7", "198.51.100.7/32"},
+			{"ip", "::ffff:198.51.100.7", "198.51.100.7", "198.51.100.7/32"},
+			{"ip", "2001:DB8::1", "2001:db8::1", "2001:db8::1/128"},
+			{"ip_range", "198.51.100.77/24", "198.51.100.0/24", "198.51.100.0/24"},
+			{"ip_range", "::ffff:198.51.0.0/112", "198.51.0.0/16", "198.51.0.0/16"},
+			{"asn", "as64496", "AS64496", ""},
+			{"asn", "64496", "AS64496", ""},
+			{"country", "xx", "XX", ""},
+		} {
+			normalized, prefix, err := ippolicy.Normalize(test.target, test.value)
+			Expect(err).To(BeNil())
+			Expect(normalized).To(Equal(test.normalized))
+			if test.prefix != "" {
+				Expect(prefix).To(Equal(netip.MustParsePrefix(test.prefix)))
+			} else {
+				Expect(prefix.IsValid()).To(BeFalse())
+			}
+		}
+		for _, test := range [][2]string{{"ip", "198.51.100"}, {"ip_range", "198.51.100.0"}, {"asn", "ASX"}, {"country", "XXX"}, {"cidr", "1.2.3.4"}} {
+			_, _, err := ippolicy.Normalize(test[0], test[1])
+			Expect(err).ToNot(BeNil())
+		}
+	})
+	It(`Aggregate adjacent and contained prefixes`, func() {
+		Expect(ippolicy.Aggregate(prefixes(
+			"198.51.100.1/32", "198.51.100.0/32", "198.51.100.2/31", "198.51.100.3/32",
+			"203.0.113.0/25", "2001:db8::/33", "203.0.113.128/25", "2001:db8:8000::/33", "192.0.2.9/32",
+		))).To(Equal(prefixes("192.0.2.9/32", "198.51.100.0/30", "203.0.113.0/24", "2001:db8::/32")))
+		Expect(ippolicy.Aggregate(prefixes("198.51.100.1/32", "198.51.100.2/32"))).To(Equal(prefixes("198.51.100.1/32", "198.51.100.2/32")))
+		Expect(ippolicy.Aggregate(nil)).To(BeEmpty())
+	})
+	It(`Tell the prefixes access rules can target`, func() {
+		Expect(ippolicy.Creatable(netip.MustParsePrefix("198.51.100.0/24"))).To(BeTrue())
+		Expect(ippolicy.Creatable(netip.MustParsePrefix("198.51.100.7/32"))).To(BeTrue())
+		Expect(ippolicy.Creatable(netip.MustParsePrefix("198.51.100.0/23"))).To(BeFalse())
+		Expect(ippolicy.Creatable(netip.MustParsePrefix("2001:db8::/48"))).To(BeTrue())
+		target, value := ippolicy.TargetOf(netip.MustParsePrefix("2001:db8::1/128"))
+		Expect([]string{target, value}).To(Equal([]string{"ip", "2001:db8::1"}))
+	})
+})
@@ -0,0 +1,348 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pageruleapiv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Constants associated with the TargetsItem.Target property.
+const (
+	TargetsItem_Target_URL = "url"
+)
+
+// Constants associated with the TargetsItemConstraint.Operator property.
+const (
+	TargetsItemConstraint_Operator_Matches = "matches"
+)
+
+// Constants associated with the CreatePageRuleOptions.Status property.
+const (
+	CreatePageRuleOptions_Status_Active   = "active"
+	CreatePageRuleOptions_Status_Disabled = "disabled"
+)
+
+// standaloneActions are the actions that a page rule cannot combine with any other action.
+var standaloneActions = map[string]bool{
+	PageRulesBodyActionsItem_ID_ForwardingURL:  true,
+	PageRulesBodyActionsItem_ID_AlwaysUseHttps: true,
+}
+
+// conflictingActions are the pairs of actions that a page rule cannot combine.
+var conflictingActions = map[string][]string{
+	PageRulesBodyActionsItem_ID_DisableSecurity: {
+		PageRulesBodyActionsItem_ID_EmailObfuscation,
+		PageRulesBodyActionsItem_ID_ServerSideExclude,
+		PageRulesBodyActionsItem_ID_Waf,
+	},
+}
+
+// onOffActions are the actions whose value is on or off.
+var onOffActions = map[string]bool{
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_AlwaysOnline:            true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_AutomaticHttpsRewrites:  true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_BrowserCheck:            true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_CacheDeceptionArmor:     true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_EmailObfuscation:        true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_ExplicitCacheControl:    true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_IpGeolocation:           true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_OpportunisticEncryption: true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_ServerSideExclude:       true,
+	PageRulesBodyActionsItemActionsSecurityOptions_ID_Waf:                     true,
+}
+
+// actionID returns the ID of an action of any of the action models.
+func actionID(action PageRulesBodyActionsItemIntf) string {
+	buffer, err := json.Marshal(action)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		ID *string `json:"id"`
+	}
+	json.Unmarshal(buffer, &fields)
+	return core.StringNilMapper(fields.ID)
+}
+
+// ValidatePageRuleActions returns an error when a page rule cannot have the actions: when it has none, when it has
+// an action twice, or when it combines actions the API forbids together, such as forwarding_url with any other
+// action.
+func ValidatePageRuleActions(actions []PageRulesBodyActionsItemIntf) error {
+	if len(actions) == 0 {
+		return fmt.Errorf("a page rule needs at least one action")
+	}
+	ids := map[string]bool{}
+	for _, action := range actions {
+		id := actionID(action)
+		if id == "" {
+			return fmt.Errorf("page rule actions need an ID")
+		}
+		if ids[id] {
+			return fmt.Errorf("the %s action is set twice", id)
+		}
+		ids[id] = true
+	}
+	for _, action := range actions {
+		id := actionID(action)
+		if standaloneActions[id] && len(actions) > 1 {
+			return fmt.Errorf("the %s action cannot be combined with other actions", id)
+		}
+		for _, other := range conflictingActions[id] {
+			if ids[other] {
+				return fmt.Errorf("the %s action cannot be combined with the %s action", id, other)
+			}
+		}
+	}
+	return nil
+}
+
+// PageRuleBuilder : Builds a page rule from a URL pattern and typed actions. The first invalid value is kept and
+// returned by Build.
+type PageRuleBuilder struct {
+	pattern  string
+	actions  []PageRulesBodyActionsItemIntf
+	priority *int64
+	status   *string
+	err      error
+}
+
+// NewPageRuleBuilder : Instantiate PageRuleBuilder
+// The URL pattern is matched against the URL of the requests, where * stands for any text, as in
+// *example.com/images/*.
+func (*PageRuleApiV1) NewPageRuleBuilder(pattern string) *PageRuleBuilder {
+	return &PageRuleBuilder{pattern: pattern}
+}
+
+func (builder *PageRuleBuilder) fail(format string, args ...interface{}) *PageRuleBuilder {
+	if builder.err == nil {
+		builder.err = fmt.Errorf(format, args...)
+	}
+	return builder
+}
+
+func (builder *PageRuleBuilder) add(action PageRulesBodyActionsItemIntf) *PageRuleBuilder {
+	builder.actions = append(builder.actions, action)
+	return builder
+}
+
+func oneOf(value string, values ...string) bool {
+	for _, candidate := range values {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheLevel adds the cache_level action, with one of the PageRulesBodyActionsItemActionsCacheLevel_Value constants.
+func (builder *PageRuleBuilder) CacheLevel(value string) *PageRuleBuilder {
+	if !oneOf(value, PageRulesBodyActionsItemActionsCacheLevel_Value_Aggressive, PageRulesBodyActionsItemActionsCacheLevel_Value_Basic,
+		PageRulesBodyActionsItemActionsCacheLevel_Value_Bypass, PageRulesBodyActionsItemActionsCacheLevel_Value_CacheEverything,
+		PageRulesBodyActionsItemActionsCacheLevel_Value_Simplified) {
+		return builder.fail("invalid cache level %q", value)
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsCacheLevel{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsCacheLevel_ID_CacheLevel),
+		Value: core.StringPtr(value),
+	})
+}
+
+// EdgeCacheTTL adds the edge_cache_ttl action, in seconds.
+func (builder *PageRuleBuilder) EdgeCacheTTL(seconds int64) *PageRuleBuilder {
+	if seconds <= 0 {
+		return builder.fail("invalid edge cache TTL %d", seconds)
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsEdgeCacheTTL{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsEdgeCacheTTL_ID_EdgeCacheTTL),
+		Value: core.Int64Ptr(seconds),
+	})
+}
+
+// BrowserCacheTTL adds the browser_cache_ttl action, in seconds. Zero respects the headers of the origin.
+func (builder *PageRuleBuilder) BrowserCacheTTL(seconds int64) *PageRuleBuilder {
+	if seconds < 0 {
+		return builder.fail("invalid browser cache TTL %d", seconds)
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsTTL{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsTTL_ID_BrowserCacheTTL),
+		Value: core.Int64Ptr(seconds),
+	})
+}
+
+// ForwardingURL adds the forwarding_url action, which redirects to a URL with status 301 or 302. It cannot be
+// combined with other actions.
+func (builder *PageRuleBuilder) ForwardingURL(url string, statusCode int64) *PageRuleBuilder {
+	if url == "" {
+		return builder.fail("the forwarding URL cannot be empty")
+	}
+	if statusCode != 301 && statusCode != 302 {
+		return builder.fail("invalid forwarding status code %d, use 301 or 302", statusCode)
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsForwardingURL{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsForwardingURL_ID_ForwardingURL),
+		Value: &ActionsForwardingUrlValue{URL: core.StringPtr(url), StatusCode: core.Int64Ptr(statusCode)},
+	})
+}
+
+// SecurityLevel adds the security_level action, with one of the PageRulesBodyActionsItemActionsSecurityLevel_Value
+// constants.
+func (builder *PageRuleBuilder) SecurityLevel(value string) *PageRuleBuilder {
+	if !oneOf(value, PageRulesBodyActionsItemActionsSecurityLevel_Value_EssentiallyOff, PageRulesBodyActionsItemActionsSecurityLevel_Value_High,
+		PageRulesBodyActionsItemActionsSecurityLevel_Value_Low, PageRulesBodyActionsItemActionsSecurityLevel_Value_Medium,
+		PageRulesBodyActionsItemActionsSecurityLevel_Value_Off, PageRulesBodyActionsItemActionsSecurityLevel_Value_UnderAttack) {
+		return builder.fail("invalid security level %q", value)
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsSecurityLevel{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsSecurityLevel_ID_SecurityLevel),
+		Value: core.StringPtr(value),
+	})
+}
+
+// Ssl adds the ssl action, with one of the PageRulesBodyActionsItemActionsSsl_Value constants.
+func (builder *PageRuleBuilder) Ssl(value string) *PageRuleBuilder {
+	if !oneOf(value, PageRulesBodyActionsItemActionsSsl_Value_Flexible, PageRulesBodyActionsItemActionsSsl_Value_Full,
+		PageRulesBodyActionsItemActionsSsl_Value_Off, PageRulesBodyActionsItemActionsSsl_Value_OriginPull,
+		PageRulesBodyActionsItemActionsSsl_Value_Strict) {
+		return builder.fail("invalid SSL mode %q", value)
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsSsl{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsSsl_ID_Ssl),
+		Value: core.StringPtr(value),
+	})
+}
+
+// BypassCacheOnCookie adds the bypass_cache_on_cookie action, with a cookie name pattern such as wp-.*|wordpress.*.
+func (builder *PageRuleBuilder) BypassCacheOnCookie(cookie string) *PageRuleBuilder {
+	if cookie == "" {
+		return builder.fail("the bypass cache on cookie pattern cannot be empty")
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsBypassCacheOnCookie{
+		ID:    core.StringPtr(PageRulesBodyActionsItemActionsBypassCacheOnCookie_ID_BypassCacheOnCookie),
+		Value: core.StringPtr(cookie),
+	})
+}
+
+// AlwaysUseHttps adds the always_use_https action. It cannot be combined with other actions.
+func (builder *PageRuleBuilder) AlwaysUseHttps() *PageRuleBuilder {
+	return builder.add(&PageRulesBodyActionsItemActionsSecurity{ID: core.StringPtr(PageRulesBodyActionsItemActionsSecurity_ID_AlwaysUseHttps)})
+}
+
+// DisableSecurity adds the disable_security action. It cannot be combined with the email_obfuscation,
+// server_side_exclude and waf actions.
+func (builder *PageRuleBuilder) DisableSecurity() *PageRuleBuilder {
+	return builder.add(&PageRulesBodyActionsItemActionsSecurity{ID: core.StringPtr(PageRulesBodyActionsItemActionsSecurity_ID_DisableSecurity)})
+}
+
+// Setting adds one of the actions that turn a feature on or off, named by the
+// PageRulesBodyActionsItemActionsSecurityOptions_ID constants.
+func (builder *PageRuleBuilder) Setting(id string, on bool) *PageRuleBuilder {
+	if !onOffActions[id] {
+		return builder.fail("%q is not an on or off action", id)
+	}
+	value := PageRulesBodyActionsItemActionsSecurityOptions_Value_Off
+	if on {
+		value = PageRulesBodyActionsItemActionsSecurityOptions_Value_On
+	}
+	return builder.add(&PageRulesBodyActionsItemActionsSecurityOptions{ID: core.StringPtr(id), Value: core.StringPtr(value)})
+}
+
+// Priority sets the priority of the rule. Of the rules that match a request, the one with the highest priority
+// applies.
+func (builder *PageRuleBuilder) Priority(priority int64) *PageRuleBuilder {
+	builder.priority = core.Int64Ptr(priority)
+	return builder
+}
+
+// Status sets the status of the rule, one of the CreatePageRuleOptions_Status constants.
+func (builder *PageRuleBuilder) Status(status string) *PageRuleBuilder {
+	if !oneOf(status, CreatePageRuleOptions_Status_Active, CreatePageRuleOptions_Status_Disabled) {
+		return builder.fail("invalid status %q", status)
+	}
+	builder.status = core.StringPtr(status)
+	return builder
+}
+
+// validate returns the target and actions of the rule, or the first error.
+func (builder *PageRuleBuilder) validate() (targets []TargetsItem, err error) {
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	if strings.TrimSpace(builder.pattern) == "" {
+		return nil, fmt.Errorf("the URL pattern of a page rule cannot be empty")
+	}
+	if err = ValidatePageRuleActions(builder.actions); err != nil {
+		return nil, err
+	}
+	targets = []TargetsItem{{
+		Target: core.StringPtr(TargetsItem_Target_URL),
+		Constraint: &TargetsItemConstraint{
+			Operator: core.StringPtr(TargetsItemConstraint_Operator_Matches),
+			Value:    core.StringPtr(builder.pattern),
+		},
+	}}
+	return
+}
+
+// Build returns the options to create the rule, or the first invalid value or combination of actions.
+func (builder *PageRuleBuilder) Build() (options *CreatePageRuleOptions, err error) {
+	targets, err := builder.validate()
+	if err != nil {
+		return
+	}
+	options = &CreatePageRuleOptions{Targets: targets, Actions: builder.actions, Priority: builder.priority, Status: builder.status}
+	return
+}
+
+// BuildUpdate returns the options to replace a rule with the built rule.
+func (builder *PageRuleBuilder) BuildUpdate(ruleID string) (options *UpdatePageRuleOptions, err error) {
+	targets, err := builder.validate()
+	if err != nil {
+		return
+	}
+	options = &UpdatePageRuleOptions{RuleID: core.StringPtr(ruleID), Targets: targets, Actions: builder.actions, Priority: builder.priority, Status: builder.status}
+	return
+}
+
+// PageRuleContent : The targets, actions, priority and status of a page rule, in the JSON form shared by
+// PageRuleResult and the options to create or update a rule, as in a saved copy of a rule.
+type PageRuleContent struct {
+	Targets  []TargetsItem               `json:"targets"`
+	Actions  []*PageRulesBodyActionsItem `json:"actions"`
+	Priority *int64                      `json:"priority,omitempty"`
+	Status   *string                     `json:"status,omitempty"`
+}
+
+func (content *PageRuleContent) actions() (actions []PageRulesBodyActionsItemIntf) {
+	for _, action := range content.Actions {
+		actions = append(actions, action)
+	}
+	return
+}
+
+// CreateOptions returns the options to create a rule with the content.
+func (content *PageRuleContent) CreateOptions() *CreatePageRuleOptions {
+	return &CreatePageRuleOptions{Targets: content.Targets, Actions: content.actions(), Priority: content.Priority, Status: content.Status}
+}
+
+// UpdateOptions returns the options to replace a rule with the content.
+func (content *PageRuleContent) UpdateOptions(ruleID string) *UpdatePageRuleOptions {
+	return &UpdatePageRuleOptions{RuleID: core.StringPtr(ruleID), Targets: content.Targets, Actions: content.actions(), Priority: content.Priority, Status: content.Status}
+}
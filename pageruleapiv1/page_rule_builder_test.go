@@ -0,0 +1,223 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pageruleapiv1_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`PageRuleBuilder`, func() {
+	var service *pageruleapiv1.PageRuleApiV1
+
+	BeforeEach(func() {
+		var err error
+		service, err = pageruleapiv1.NewPageRuleApiV1(&pageruleapiv1.PageRuleApiV1Options{
+			URL:           "http://pageruleapiv1/api",
+			Authenticator: &core.NoAuthAuthenticator{},
+			Crn:           core.StringPtr("crn"),
+			ZoneID:        core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+	})
+
+	It(`Builds the options of a rule`, func() {
+		options, err := service.NewPageRuleBuilder("*example.com/images/*").
+			CacheLevel(pageruleapiv1.PageRulesBodyActionsItemActionsCacheLevel_Value_CacheEverything).
+			EdgeCacheTTL(7200).
+			Setting(pageruleapiv1.PageRulesBodyActionsItemActionsSecurityOptions_ID_BrowserCheck, false).
+			Priority(2).
+			Status(pageruleapiv1.CreatePageRuleOptions_Status_Active).
+			Build()
+		Expect(err).To(BeNil())
+		Expect(*options.Targets[0].Target).To(Equal("url"))
+		Expect(*options.Targets[0].Constraint.Operator).To(Equal("matches"))
+		Expect(*options.Targets[0].Constraint.Value).To(Equal("*example.com/images/*"))
+		Expect(options.Actions).To(HaveLen(3))
+		Expect(options.Actions[2]).To(Equal(&pageruleapiv1.PageRulesBodyActionsItemActionsSecurityOptions{
+			ID:    core.StringPtr("browser_check"),
+			Value: core.StringPtr("off"),
+		}))
+		Expect(*options.Priority).To(Equal(int64(2)))
+		Expect(*options.Status).To(Equal("active"))
+
+		update, err := service.NewPageRuleBuilder("example.com/*").AlwaysUseHttps().BuildUpdate("rule")
+		Expect(err).To(BeNil())
+		Expect(*update.RuleID).To(Equal("rule"))
+		Expect(update.Actions).To(HaveLen(1))
+	})
+
+	It(`Builds the options of a saved rule`, func() {
+		var content pageruleapiv1.PageRuleContent
+		Expect(json.Unmarshal([]byte(`{
+			"targets": [{"target": "url", "constraint": {"operator": "matches", "value": "example.com/*"}}],
+			"actions": [{"id": "ssl", "value": "full"}, {"id": "always_online"}],
+			"priority": 3,
+			"status": "disabled"
+		}`), &content)).To(Succeed())
+		options := content.CreateOptions()
+		Expect(*options.Targets[0].Constraint.Value).To(Equal("example.com/*"))
+		Expect(options.Actions).To(Equal([]pageruleapiv1.PageRulesBodyActionsItemIntf{
+			&pageruleapiv1.PageRulesBodyActionsItem{ID: core.StringPtr("ssl"), Value: "full"},
+			&pageruleapiv1.PageRulesBodyActionsItem{ID: core.StringPtr("always_online")},
+		}))
+		Expect(*options.Priority).To(Equal(int64(3)))
+		update := content.UpdateOptions("rule")
+		Expect(*update.RuleID).To(Equal("rule"))
+		Expect(*update.Status).To(Equal("disabled"))
+	})
+
+	It(`Rejects the combinations the API forbids`, func() {
+		_, err := service.NewPageRuleBuilder("example.com/old/*").ForwardingURL("https://example.com/new/$1", 301).CacheLevel("bypass").Build()
+		Expect(err).To(MatchError("the forwarding_url action cannot be combined with other actions"))
+		_, err = service.NewPageRuleBuilder("example.com/*").AlwaysUseHttps().Ssl("strict").Build()
+		Expect(err).To(MatchError("the always_use_https action cannot be combined with other actions"))
+		_, err = service.NewPageRuleBuilder("example.com/*").Setting("waf", true).DisableSecurity().Build()
+		Expect(err).To(MatchError("the disable_security action cannot be combined with the waf action"))
+		_, err = service.NewPageRuleBuilder("example.com/*").EdgeCacheTTL(60).EdgeCacheTTL(120).Build()
+		Expect(err).To(MatchError("the edge_cache_ttl action is set twice"))
+		_, err = service.NewPageRuleBuilder("example.com/*").Build()
+		Expect(err).To(MatchError("a page rule needs at least one action"))
+	})
+
+	It(`Rejects invalid values`, func() {
+		_, err := service.NewPageRuleBuilder("example.com/*").ForwardingURL("https://example.com/", 307).Build()
+		Expect(err).To(MatchError("invalid forwarding status code 307, use 301 or 302"))
+		_, err = service.NewPageRuleBuilder("example.com/*").CacheLevel("everything").SecurityLevel("extreme").Build()
+		Expect(err).To(MatchError(`invalid cache level "everything"`))
+		_, err = service.NewPageRuleBuilder("example.com/*").Setting("ssl", true).Build()
+		Expect(err).To(MatchError(`"ssl" is not an on or off action`))
+		_, err = service.NewPageRuleBuilder(" ").BrowserCacheTTL(0).Build()
+		Expect(err).To(MatchError("the URL pattern of a page rule cannot be empty"))
+	})
+})
+
+var _ = Describe(`PageRuleSet`, func() {
+	rule := func(id string, pattern string, priority int64, status string, actions ...pageruleapiv1.PageRulesBodyActionsItemIntf) pageruleapiv1.PageRuleResult {
+		if len(actions) == 0 {
+			actions = []pageruleapiv1.PageRulesBodyActionsItemIntf{&pageruleapiv1.PageRulesBodyActionsItem{
+				ID:    core.StringPtr("cache_level"),
+				Value: "bypass",
+			}}
+		}
+		return pageruleapiv1.PageRuleResult{
+			ID: core.StringPtr(id),
+			Targets: []pageruleapiv1.TargetsItem{{
+				Target:     core.StringPtr("url"),
+				Constraint: &pageruleapiv1.TargetsItemConstraint{Operator: core.StringPtr("matches"), Value: core.StringPtr(pattern)},
+			}},
+			Actions:  actions,
+			Priority: core.Int64Ptr(priority),
+			Status:   core.StringPtr(status),
+		}
+	}
+
+	It(`Matches a URL with the first rule by priority`, func() {
+		set := pageruleapiv1.NewPageRuleSet([]pageruleapiv1.PageRuleResult{
+			rule("all", "*example.com/*", 1, "active"),
+			rule("images", "https://www.example.com/images/*.png", 3, "active"),
+			rule("disabled", "*", 4, "disabled"),
+			rule("root", "Example.com", 2, "active"),
+		})
+		Expect(set.Rules()[0].ID).To(Equal(core.StringPtr("disabled")))
+
+		Expect(*set.Match("https://www.example.com/images/logo.png").ID).To(Equal("images"))
+		Expect(*set.Match("http://www.example.com/images/logo.png").ID).To(Equal("all"))
+		Expect(*set.Match("https://EXAMPLE.com").ID).To(Equal("root"))
+		Expect(*set.Match("https://example.com/?page=2").ID).To(Equal("all"))
+		Expect(*set.Match("example.com/about").ID).To(Equal("all"))
+		Expect(set.Match("https://example.org/")).To(BeNil())
+	})
+
+	It(`Lists the rules that apply before a rule on the same URLs`, func() {
+		set := pageruleapiv1.NewPageRuleSet([]pageruleapiv1.PageRuleResult{
+			rule("all", "*example.com/*", 1, "active"),
+			rule("images", "example.com/images/*", 4, "active"),
+			rule("png", "*example.com/*.png", 5, "active"),
+			rule("docs", "http://example.com/docs/*", 3, "active"),
+			rule("secure", "https://*/*", 2, "active"),
+			rule("off", "*", 6, "disabled"),
+		})
+		ids := func(rules []pageruleapiv1.PageRuleResult) (ids []string) {
+			for _, rule := range rules {
+				ids = append(ids, *rule.ID)
+			}
+			return
+		}
+		Expect(ids(set.Rules())).To(Equal([]string{"off", "png", "images", "docs", "secure", "all"}))
+		Expect(set.Preceding(0)).To(BeEmpty())
+		Expect(ids(set.Preceding(2))).To(Equal([]string{"png"}))
+		Expect(ids(set.Preceding(3))).To(Equal([]string{"png"}))
+		Expect(ids(set.Preceding(4))).To(Equal([]string{"png", "images"}))
+		Expect(ids(set.Preceding(5))).To(Equal([]string{"png", "images", "docs", "secure"}))
+
+		set = pageruleapiv1.NewPageRuleSet([]pageruleapiv1.PageRuleResult{
+			rule("any", "*://example.com/*", 3, "active"),
+			rule("http", "http*://example.com/docs/*", 2, "active"),
+			rule("insecure", "http://example.com/docs/*", 1, "active"),
+		})
+		Expect(ids(set.Preceding(2))).To(Equal([]string{"any", "http"}))
+	})
+
+	It(`Matches the schemes of a URL with the scheme patterns`, func() {
+		set := pageruleapiv1.NewPageRuleSet([]pageruleapiv1.PageRuleResult{
+			rule("any", "*://example.com/*", 3, "active"),
+			rule("http", "http*://www.example.com/*", 2, "active"),
+			rule("secure", "https://example.com/x", 1, "active"),
+		})
+		Expect(*set.Match("https://example.com/y").ID).To(Equal("any"))
+		Expect(*set.Match("http://example.com/x").ID).To(Equal("any"))
+		Expect(*set.Match("https://www.example.com/").ID).To(Equal("http"))
+		Expect(*set.Match("http://www.example.com/").ID).To(Equal("http"))
+		Expect(set.Match("ftp://www.example.com/")).To(BeNil())
+		Expect(set.Analyze()).To(Equal([]pageruleapiv1.PageRuleFinding{{
+			Kind:    pageruleapiv1.PageRuleFinding_Kind_Shadowed,
+			RuleID:  "secure",
+			By:      "any",
+			Message: "the *://example.com/* pattern of rule any matches every URL of the https://example.com/x pattern",
+		}}))
+	})
+
+	It(`Finds shadowed rules and invalid actions`, func() {
+		set := pageruleapiv1.NewPageRuleSet([]pageruleapiv1.PageRuleResult{
+			rule("all", "*example.com/*", 5, "active"),
+			rule("blog", "www.example.com/blog/*", 4, "active"),
+			rule("secure", "https://example.com/*", 6, "active"),
+			rule("other", "*example.org/*", 3, "active",
+				&pageruleapiv1.PageRulesBodyActionsItemActionsSecurity{ID: core.StringPtr("always_use_https")},
+				&pageruleapiv1.PageRulesBodyActionsItemActionsSsl{ID: core.StringPtr("ssl"), Value: core.StringPtr("full")}),
+			rule("off", "*", 7, "disabled"),
+		})
+		Expect(set.Analyze()).To(Equal([]pageruleapiv1.PageRuleFinding{
+			{
+				Kind:    pageruleapiv1.PageRuleFinding_Kind_Shadowed,
+				RuleID:  "blog",
+				By:      "all",
+				Message: "the *example.com/* pattern of rule all matches every URL of the www.example.com/blog/* pattern",
+			},
+			{
+				Kind:    pageruleapiv1.PageRuleFinding_Kind_InvalidActions,
+				RuleID:  "other",
+				Message: "the always_use_https action cannot be combined with other actions",
+			},
+		}))
+	})
+})
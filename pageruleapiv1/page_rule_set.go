@@ -0,0 +1,287 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pageruleapiv1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Constants associated with the PageRuleFinding.Kind property.
+const (
+	PageRuleFinding_Kind_InvalidActions = "invalid_actions"
+	PageRuleFinding_Kind_Shadowed       = "shadowed"
+)
+
+// PageRuleFinding : A problem with a page rule of a zone.
+type PageRuleFinding struct {
+	// The kind of problem, one of the PageRuleFinding_Kind constants.
+	Kind string
+
+	// The rule with the problem.
+	RuleID string
+
+	// The rule that shadows it, for shadowed rules.
+	By string
+
+	// What is wrong with the rule.
+	Message string
+}
+
+// PageRulePattern : A URL or the URL pattern of a page rule, split into its parts. The parts of a pattern may hold
+// the * wildcard.
+type PageRulePattern struct {
+	// The schemes, lowercased: http and https when the pattern has no scheme.
+	Schemes []string
+
+	// The host, lowercased.
+	Host string
+
+	// The path followed by the query string, / when the pattern has no path.
+	Path string
+}
+
+// ParsePageRulePattern splits a URL such as https://www.example.com/images/logo.png or a URL pattern such as
+// *example.com/images/*. A pattern without a scheme matches both http and https, and a pattern or URL without a
+// path has the path /.
+func ParsePageRulePattern(pattern string) PageRulePattern {
+	parsed := PageRulePattern{Schemes: []string{"http", "https"}}
+	pattern = strings.TrimSpace(pattern)
+	if index := strings.Index(pattern, "://"); index >= 0 {
+		parsed.Schemes = []string{strings.ToLower(pattern[:index])}
+		pattern = pattern[index+3:]
+	}
+	host, path := pattern, "/"
+	if index := strings.IndexAny(pattern, "/?"); index >= 0 {
+		host, path = pattern[:index], pattern[index:]
+		if strings.HasPrefix(path, "?") {
+			path = "/" + path
+		}
+	}
+	parsed.Host, parsed.Path = strings.ToLower(host), path
+	return parsed
+}
+
+// MatchesScheme reports whether the pattern matches a scheme such as https.
+func (pattern PageRulePattern) MatchesScheme(scheme string) bool {
+	for _, candidate := range pattern.Schemes {
+		if globMatch(candidate, strings.ToLower(scheme)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rest returns the host followed by the path, which the glob helpers compare.
+func (pattern PageRulePattern) rest() string {
+	return pattern.Host + pattern.Path
+}
+
+// globMatch reports whether a pattern, where * stands for any text, matches a text.
+func globMatch(pattern, text string) bool {
+	p, t := 0, 0
+	star, next := -1, 0
+	for t < len(text) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, t
+			p++
+		case p < len(pattern) && pattern[p] == text[t]:
+			p++
+			t++
+		case star >= 0:
+			p = star + 1
+			next++
+			t = next
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// globCovers reports whether a pattern matches every text that another pattern matches: each * of the other
+// pattern can only be matched by a * of the pattern.
+func globCovers(pattern, other string) bool {
+	covers := make([][]bool, len(pattern)+1)
+	for p := range covers {
+		covers[p] = make([]bool, len(other)+1)
+	}
+	covers[len(pattern)][len(other)] = true
+	for p := len(pattern) - 1; p >= 0; p-- {
+		for o := len(other); o >= 0; o-- {
+			if pattern[p] == '*' {
+				covers[p][o] = covers[p+1][o] || (o < len(other) && covers[p][o+1])
+			} else {
+				covers[p][o] = o < len(other) && other[o] != '*' && pattern[p] == other[o] && covers[p+1][o+1]
+			}
+		}
+	}
+	return covers[0][0]
+}
+
+// globOverlaps reports whether two patterns match at least one common text: a * of either pattern can stand for
+// any part of the other pattern.
+func globOverlaps(pattern, other string) bool {
+	overlaps := make([][]bool, len(pattern)+1)
+	for p := range overlaps {
+		overlaps[p] = make([]bool, len(other)+1)
+	}
+	for p := len(pattern); p >= 0; p-- {
+		for o := len(other); o >= 0; o-- {
+			switch {
+			case p == len(pattern) && o == len(other):
+				overlaps[p][o] = true
+			case p < len(pattern) && pattern[p] == '*':
+				overlaps[p][o] = overlaps[p+1][o] || (o < len(other) && overlaps[p][o+1])
+			case o < len(other) && other[o] == '*':
+				overlaps[p][o] = overlaps[p][o+1] || (p < len(pattern) && overlaps[p+1][o])
+			default:
+				overlaps[p][o] = p < len(pattern) && o < len(other) && pattern[p] == other[o] && overlaps[p+1][o+1]
+			}
+		}
+	}
+	return overlaps[0][0]
+}
+
+// hasSchemes reports whether a pattern matches all the schemes of another pattern or URL. Schemes are patterns too,
+// as in *://example.com/* or http*://example.com/*.
+func (pattern PageRulePattern) hasSchemes(other PageRulePattern) bool {
+	for _, scheme := range other.Schemes {
+		covered := false
+		for _, candidate := range pattern.Schemes {
+			covered = covered || globCovers(candidate, scheme)
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// sharesScheme reports whether a pattern matches one of the schemes of another pattern.
+func (pattern PageRulePattern) sharesScheme(other PageRulePattern) bool {
+	for _, scheme := range other.Schemes {
+		for _, candidate := range pattern.Schemes {
+			if globOverlaps(candidate, scheme) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PageRuleSet : The page rules of a zone, in the order they apply: by descending priority. Only the first rule that
+// matches a request applies.
+type PageRuleSet struct {
+	rules    []PageRuleResult
+	patterns []PageRulePattern
+}
+
+// NewPageRuleSet : Instantiate PageRuleSet
+// The rules are usually the result of ListPageRules. A rule with a higher priority applies before a rule with a
+// lower priority, and rules with the same priority keep their order.
+func NewPageRuleSet(rules []PageRuleResult) *PageRuleSet {
+	set := &PageRuleSet{rules: append([]PageRuleResult(nil), rules...)}
+	sort.SliceStable(set.rules, func(i, j int) bool {
+		return rulePriority(set.rules[i]) > rulePriority(set.rules[j])
+	})
+	for _, rule := range set.rules {
+		set.patterns = append(set.patterns, ParsePageRulePattern(PageRuleURL(rule)))
+	}
+	return set
+}
+
+// PageRuleURL returns the URL pattern of a rule, or an empty string when the rule has no URL target.
+func PageRuleURL(rule PageRuleResult) string {
+	for _, target := range rule.Targets {
+		if target.Constraint != nil && core.StringNilMapper(target.Target) == TargetsItem_Target_URL {
+			return core.StringNilMapper(target.Constraint.Value)
+		}
+	}
+	return ""
+}
+
+func rulePriority(rule PageRuleResult) int64 {
+	if rule.Priority == nil {
+		return 0
+	}
+	return *rule.Priority
+}
+
+func ruleActive(rule PageRuleResult) bool {
+	return core.StringNilMapper(rule.Status) == CreatePageRuleOptions_Status_Active
+}
+
+// Rules returns the rules in the order they apply.
+func (set *PageRuleSet) Rules() []PageRuleResult {
+	return set.rules
+}
+
+// Match returns the active rule that applies to a URL such as https://www.example.com/images/logo.png, or nil when
+// no rule matches. A URL without a scheme matches only the patterns without one.
+func (set *PageRuleSet) Match(url string) *PageRuleResult {
+	parsed := ParsePageRulePattern(url)
+	for i, rule := range set.rules {
+		if ruleActive(rule) && set.patterns[i].hasSchemes(parsed) && globMatch(set.patterns[i].rest(), parsed.rest()) {
+			return &set.rules[i]
+		}
+	}
+	return nil
+}
+
+// Preceding returns the active rules that apply before the rule at an index of Rules and match some of its URLs: the
+// rules that take the requests it matches first.
+func (set *PageRuleSet) Preceding(index int) (rules []PageRuleResult) {
+	for j := 0; j < index; j++ {
+		if ruleActive(set.rules[j]) && set.patterns[j].sharesScheme(set.patterns[index]) && globOverlaps(set.patterns[j].rest(), set.patterns[index].rest()) {
+			rules = append(rules, set.rules[j])
+		}
+	}
+	return
+}
+
+// Analyze returns the problems of the rules: the rules whose actions the API rejects, and the rules that never apply
+// because an active rule with a higher priority matches every URL they match.
+func (set *PageRuleSet) Analyze() (findings []PageRuleFinding) {
+	for i, rule := range set.rules {
+		id := core.StringNilMapper(rule.ID)
+		if err := ValidatePageRuleActions(rule.Actions); err != nil {
+			findings = append(findings, PageRuleFinding{Kind: PageRuleFinding_Kind_InvalidActions, RuleID: id, Message: err.Error()})
+		}
+		for j := 0; j < i; j++ {
+			if !ruleActive(set.rules[j]) || !set.patterns[j].hasSchemes(set.patterns[i]) || !globCovers(set.patterns[j].rest(), set.patterns[i].rest()) {
+				continue
+			}
+			by := core.StringNilMapper(set.rules[j].ID)
+			findings = append(findings, PageRuleFinding{
+				Kind:    PageRuleFinding_Kind_Shadowed,
+				RuleID:  id,
+				By:      by,
+				Message: fmt.Sprintf("the %s pattern of rule %s matches every URL of the %s pattern", PageRuleURL(set.rules[j]), by, PageRuleURL(rule)),
+			})
+			break
+		}
+	}
+	return
+}
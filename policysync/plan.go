@@ -0,0 +1,463 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policysync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/common"
+	"github.com/IBM/networking-go-sdk/firewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/zonefirewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+)
+
+// Constants associated with the Change.Resource property.
+const (
+	Change_Resource_AccessRule        = "access_rule"
+	Change_Resource_AccountAccessRule = "account_access_rule"
+	Change_Resource_Lockdown          = "lockdown"
+	Change_Resource_Rule              = "rule"
+	Change_Resource_Ruleset           = "ruleset"
+)
+
+// Constants associated with the Change.Action property.
+const (
+	Change_Action_Create = "create"
+	Change_Action_Delete = "delete"
+	Change_Action_Update = "update"
+)
+
+// pageSize is the page size used to list access rules and lockdowns.
+const pageSize = 100
+
+// Change : A change of a zone or account needed to match the policy.
+type Change struct {
+	// One of the Change_Resource constants.
+	Resource string
+
+	// One of the Change_Action constants.
+	Action string
+
+	// The phase of the entry point ruleset, for rules and rulesets.
+	Phase string
+
+	// The key of the resource: the ref of a rule, the phase of a ruleset, the target and value of an access rule or
+	// the description of a lockdown.
+	Key string
+
+	// The ID of the resource, for updates and deletions.
+	ID string
+
+	// For rules that are created or moved, the ref of the rule they are placed after, or, for the first rule of the
+	// policy, before.
+	After  string
+	Before string
+
+	// For updates, the properties that change.
+	Fields []string
+
+	rule       *rulesetsv1.RuleCreate
+	rules      []rulesetsv1.RuleCreate
+	rulesetID  string
+	accessRule *AccessRule
+	lockdown   *Lockdown
+}
+
+func (change Change) String() string {
+	var description strings.Builder
+	fmt.Fprintf(&description, "%s %s ", change.Action, strings.ReplaceAll(change.Resource, "_", " "))
+	if change.Resource == Change_Resource_Rule {
+		description.WriteString(change.Phase + "/")
+	}
+	description.WriteString(change.Key)
+	if change.After != "" {
+		description.WriteString(" after " + change.After)
+	}
+	if change.Before != "" {
+		description.WriteString(" before " + change.Before)
+	}
+	if len(change.Fields) > 0 {
+		fmt.Fprintf(&description, " (%s)", strings.Join(change.Fields, ", "))
+	}
+	return description.String()
+}
+
+// Plan : The changes of a zone or account, in the order they are applied.
+type Plan struct {
+	// The name of the zone or account.
+	Target string
+
+	Changes []Change
+
+	// The IDs of the deployed rules by phase and ref, to position the rules of the changes.
+	ruleIDs map[string]string
+}
+
+// Empty returns true when the target already matches the policy.
+func (plan *Plan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// PlanZone : Compute the changes of a zone
+// Compare the entry point rulesets, access rules and lockdowns of a zone with the policy. The resources of the clients
+// that are nil in the zone are not compared.
+func (syncer *Syncer) PlanZone(zone *Zone) (plan *Plan, err error) {
+	return syncer.PlanZoneWithContext(context.Background(), zone)
+}
+
+// PlanZoneWithContext is an alternate form of the PlanZone method which supports a Context parameter
+func (syncer *Syncer) PlanZoneWithContext(ctx context.Context, zone *Zone) (plan *Plan, err error) {
+	plan = &Plan{Target: zone.Name, ruleIDs: map[string]string{}}
+	if zone.Rulesets != nil {
+		for _, phase := range syncer.phases() {
+			var changes []Change
+			changes, err = syncer.planRuleset(ctx, zone.Rulesets, phase, plan.ruleIDs)
+			if err != nil {
+				return
+			}
+			plan.Changes = append(plan.Changes, changes...)
+		}
+	}
+	if zone.AccessRules != nil {
+		var existing []accessRuleState
+		existing, err = listZoneAccessRules(ctx, zone.AccessRules)
+		if err != nil {
+			return
+		}
+		plan.Changes = append(plan.Changes, syncer.planAccessRules(Change_Resource_AccessRule, syncer.Policy.AccessRules, existing)...)
+	}
+	if zone.Lockdowns != nil {
+		var changes []Change
+		changes, err = syncer.planLockdowns(ctx, zone.Lockdowns)
+		if err != nil {
+			return
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	return
+}
+
+// PlanAccount : Compute the changes of an account
+// Compare the access rules of an account with the account access rules of the policy.
+func (syncer *Syncer) PlanAccount(account *Account) (plan *Plan, err error) {
+	return syncer.PlanAccountWithContext(context.Background(), account)
+}
+
+// PlanAccountWithContext is an alternate form of the PlanAccount method which supports a Context parameter
+func (syncer *Syncer) PlanAccountWithContext(ctx context.Context, account *Account) (plan *Plan, err error) {
+	plan = &Plan{Target: account.Name}
+	existing, err := listAccountAccessRules(ctx, account.AccessRules)
+	if err != nil {
+		return
+	}
+	plan.Changes = syncer.planAccessRules(Change_Resource_AccountAccessRule, syncer.Policy.AccountAccessRules, existing)
+	return
+}
+
+// phases returns the phases of the policy, sorted so that plans are stable.
+func (syncer *Syncer) phases() (phases []string) {
+	for phase := range syncer.Policy.Rulesets {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	return
+}
+
+// planRuleset compares the entry point ruleset of a phase with the policy. Rules that are not in the policy keep
+// their place. The rules of the policy that are new or out of order are placed after the previous rule of the
+// policy; a minimal set of rules is moved, as found by rulesetsv1.DiffRulesetVersions.
+func (syncer *Syncer) planRuleset(ctx context.Context, rulesets *rulesetsv1.RulesetsV1, phase string, ruleIDs map[string]string) (changes []Change, err error) {
+	desired := syncer.Policy.Rulesets[phase]
+	result, response, err := rulesets.GetZoneEntrypointRulesetWithContext(ctx, rulesets.NewGetZoneEntrypointRulesetOptions(phase))
+	if err != nil && response != nil && response.StatusCode == http.StatusNotFound {
+		err = nil
+		if len(desired) > 0 {
+			changes = append(changes, Change{Resource: Change_Resource_Ruleset, Action: Change_Action_Create, Phase: phase, Key: phase, rules: desired})
+		}
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("getting the %s entry point ruleset: %w", phase, err)
+		return
+	}
+	ruleset := result.Result
+
+	// The diff of the deployed rules and the policy tells the rules that are not in the policy, and the rules of
+	// the policy that moved.
+	policy := &rulesetsv1.RulesetDetails{}
+	inPolicy := map[string]bool{}
+	for i := range desired {
+		policy.Rules = append(policy.Rules, policyRule(&desired[i]))
+		inPolicy[*desired[i].Ref] = true
+	}
+	diff := rulesetsv1.DiffRulesetVersions(ruleset, policy)
+	if syncer.Policy.Prune {
+		for _, removed := range diff.Removed {
+			changes = append(changes, Change{Resource: Change_Resource_Rule, Action: Change_Action_Delete, Phase: phase, Key: removed.Key, ID: *removed.From.ID, rulesetID: *ruleset.ID})
+		}
+	}
+	moved := map[string]bool{}
+	for _, reordered := range diff.Reordered {
+		moved[reordered.Key] = true
+	}
+	current := map[string]*rulesetsv1.RuleDetails{}
+	var first string
+	for i := range ruleset.Rules {
+		rule := &ruleset.Rules[i]
+		key := rulesetsv1.RuleKey(rule)
+		if !inPolicy[key] {
+			continue
+		}
+		current[key] = rule
+		ruleIDs[phase+"/"+key] = *rule.ID
+		if first == "" {
+			first = key
+		}
+	}
+
+	for i := range desired {
+		rule := &desired[i]
+		ref := *rule.Ref
+		change := Change{Resource: Change_Resource_Rule, Phase: phase, Key: ref, rule: rule, rulesetID: *ruleset.ID}
+		if i > 0 {
+			change.After = *desired[i-1].Ref
+		} else if first != "" && first != ref {
+			change.Before = first
+		}
+		existingRule := current[ref]
+		switch {
+		case existingRule == nil:
+			change.Action = Change_Action_Create
+		case moved[ref]:
+			change.Action, change.ID = Change_Action_Update, *existingRule.ID
+			change.Fields = append(diffRule(existingRule, rule), "position")
+		default:
+			change.Action, change.ID = Change_Action_Update, *existingRule.ID
+			change.Fields = diffRule(existingRule, rule)
+			change.After, change.Before = "", ""
+			if len(change.Fields) == 0 {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+// policyRule returns a rule of the policy as a deployed rule, to compare with the deployed rules.
+func policyRule(rule *rulesetsv1.RuleCreate) rulesetsv1.RuleDetails {
+	return rulesetsv1.RuleDetails{
+		Action:           rule.Action,
+		ActionParameters: rule.ActionParameters,
+		Ratelimit:        rule.Ratelimit,
+		Description:      rule.Description,
+		Enabled:          rule.Enabled,
+		Expression:       rule.Expression,
+		Logging:          rule.Logging,
+		Ref:              rule.Ref,
+	}
+}
+
+// diffRule returns the properties of a deployed rule that differ from the policy. A rule is enabled unless told
+// otherwise.
+func diffRule(existing *rulesetsv1.RuleDetails, desired *rulesetsv1.RuleCreate) (fields []string) {
+	current := ruleProperties(rulesetsv1.RuleCreate{
+		Action:           existing.Action,
+		ActionParameters: existing.ActionParameters,
+		Ratelimit:        existing.Ratelimit,
+		Description:      existing.Description,
+		Enabled:          existing.Enabled,
+		Expression:       existing.Expression,
+		Logging:          existing.Logging,
+	})
+	wanted := ruleProperties(*desired)
+	for _, name := range []string{"action", "action_parameters", "ratelimit", "description", "enabled", "expression", "logging"} {
+		if !reflect.DeepEqual(current[name], wanted[name]) {
+			fields = append(fields, name)
+		}
+	}
+	return
+}
+
+func ruleProperties(rule rulesetsv1.RuleCreate) map[string]interface{} {
+	rule.ID, rule.Ref, rule.Position = nil, nil, nil
+	if rule.Enabled == nil {
+		rule.Enabled = core.BoolPtr(true)
+	}
+	buffer, _ := json.Marshal(rule)
+	var properties map[string]interface{}
+	_ = json.Unmarshal(buffer, &properties)
+	return properties
+}
+
+// accessRuleState : A deployed zone or account access rule.
+type accessRuleState struct {
+	id   string
+	rule AccessRule
+}
+
+func listZoneAccessRules(ctx context.Context, client *zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1) (rules []accessRuleState, err error) {
+	for page := int64(1); ; page++ {
+		options := client.NewListAllZoneAccessRulesOptions().SetPage(page).SetPerPage(pageSize)
+		result, _, listErr := client.ListAllZoneAccessRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing access rules: %w", listErr)
+			return
+		}
+		for _, rule := range result.Result {
+			// Rules inherited from the account are managed through the account.
+			if rule.Scope != nil && core.StringNilMapper(rule.Scope.Type) == zonefirewallaccessrulesv1.ZoneAccessRuleObjectScope_Type_Account {
+				continue
+			}
+			state := accessRuleState{id: core.StringNilMapper(rule.ID), rule: AccessRule{Mode: core.StringNilMapper(rule.Mode), Notes: core.StringNilMapper(rule.Notes)}}
+			if rule.Configuration != nil {
+				state.rule.Target, state.rule.Value = core.StringNilMapper(rule.Configuration.Target), core.StringNilMapper(rule.Configuration.Value)
+			}
+			rules = append(rules, state)
+		}
+		if result.ResultInfo == nil || common.LastPage(page, pageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			return
+		}
+	}
+}
+
+func listAccountAccessRules(ctx context.Context, client *firewallaccessrulesv1.FirewallAccessRulesV1) (rules []accessRuleState, err error) {
+	for page := int64(1); ; page++ {
+		options := client.NewListAllAccountAccessRulesOptions().SetPage(page).SetPerPage(pageSize)
+		result, _, listErr := client.ListAllAccountAccessRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing account access rules: %w", listErr)
+			return
+		}
+		for _, rule := range result.Result {
+			state := accessRuleState{id: core.StringNilMapper(rule.ID), rule: AccessRule{Mode: core.StringNilMapper(rule.Mode), Notes: core.StringNilMapper(rule.Notes)}}
+			if rule.Configuration != nil {
+				state.rule.Target, state.rule.Value = core.StringNilMapper(rule.Configuration.Target), core.StringNilMapper(rule.Configuration.Value)
+			}
+			rules = append(rules, state)
+		}
+		if result.ResultInfo == nil || common.LastPage(page, pageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			return
+		}
+	}
+}
+
+// planAccessRules compares deployed access rules with the policy. Only the mode and notes of an access rule can
+// be updated.
+func (syncer *Syncer) planAccessRules(resource string, desired []AccessRule, existing []accessRuleState) (changes []Change) {
+	current := map[string]accessRuleState{}
+	for _, state := range existing {
+		current[state.rule.Key()] = state
+	}
+	wanted := map[string]bool{}
+	for i := range desired {
+		rule := &desired[i]
+		wanted[rule.Key()] = true
+		state, ok := current[rule.Key()]
+		if !ok {
+			changes = append(changes, Change{Resource: resource, Action: Change_Action_Create, Key: rule.Key(), accessRule: rule})
+			continue
+		}
+		var fields []string
+		if state.rule.Mode != rule.Mode {
+			fields = append(fields, "mode")
+		}
+		if state.rule.Notes != rule.Notes {
+			fields = append(fields, "notes")
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Resource: resource, Action: Change_Action_Update, Key: rule.Key(), ID: state.id, Fields: fields, accessRule: rule})
+		}
+	}
+	if syncer.Policy.Prune {
+		for _, state := range existing {
+			if !wanted[state.rule.Key()] {
+				changes = append(changes, Change{Resource: resource, Action: Change_Action_Delete, Key: state.rule.Key(), ID: state.id})
+			}
+		}
+	}
+	return
+}
+
+// planLockdowns compares the lockdowns of a zone with the policy.
+func (syncer *Syncer) planLockdowns(ctx context.Context, client *zonelockdownv1.ZoneLockdownV1) (changes []Change, err error) {
+	current := map[string]zonelockdownv1.LockdownObject{}
+	var order []string
+	for page := int64(1); ; page++ {
+		options := client.NewListAllZoneLockownRulesOptions().SetPage(page).SetPerPage(pageSize)
+		result, _, listErr := client.ListAllZoneLockownRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing zone lockdowns: %w", listErr)
+			return
+		}
+		for _, lockdown := range result.Result {
+			description := core.StringNilMapper(lockdown.Description)
+			current[description] = lockdown
+			order = append(order, description)
+		}
+		if result.ResultInfo == nil || common.LastPage(page, pageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+
+	wanted := map[string]bool{}
+	for i := range syncer.Policy.Lockdowns {
+		lockdown := &syncer.Policy.Lockdowns[i]
+		wanted[lockdown.Description] = true
+		existing, ok := current[lockdown.Description]
+		if !ok {
+			changes = append(changes, Change{Resource: Change_Resource_Lockdown, Action: Change_Action_Create, Key: lockdown.Description, lockdown: lockdown})
+			continue
+		}
+		var fields []string
+		if (existing.Paused != nil && *existing.Paused) != lockdown.Paused {
+			fields = append(fields, "paused")
+		}
+		if !reflect.DeepEqual(append([]string{}, existing.Urls...), append([]string{}, lockdown.Urls...)) {
+			fields = append(fields, "urls")
+		}
+		var configurations []LockdownConfiguration
+		for _, configuration := range existing.Configurations {
+			configurations = append(configurations, LockdownConfiguration{Target: core.StringNilMapper(configuration.Target), Value: core.StringNilMapper(configuration.Value)})
+		}
+		if !reflect.DeepEqual(append([]LockdownConfiguration{}, configurations...), append([]LockdownConfiguration{}, lockdown.Configurations...)) {
+			fields = append(fields, "configurations")
+		}
+		if lockdown.Priority != 0 && (existing.Priority == nil || *existing.Priority != lockdown.Priority) {
+			fields = append(fields, "priority")
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Resource: Change_Resource_Lockdown, Action: Change_Action_Update, Key: lockdown.Description, ID: *existing.ID, Fields: fields, lockdown: lockdown})
+		}
+	}
+	if syncer.Policy.Prune {
+		for _, description := range order {
+			if !wanted[description] {
+				changes = append(changes, Change{Resource: Change_Resource_Lockdown, Action: Change_Action_Delete, Key: description, ID: *current[description].ID})
+			}
+		}
+	}
+	return
+}
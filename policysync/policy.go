@@ -0,0 +1,147 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package policysync keeps the firewall policy of many zones in line with one declarative policy document.
+//
+// A Policy lists the rules of the zone entry point rulesets by phase, identified by their ref, the zone and account
+// access rules, identified by their configuration, and the zone lockdowns, identified by their description. A
+// Syncer computes a Plan per zone or account from what is deployed, keeping the policy order of the ruleset rules
+// through their position, and applies the plans concurrently.
+package policysync
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+)
+
+// Policy : The desired firewall policy of a set of zones.
+type Policy struct {
+	// The rules of the zone entry point rulesets, by phase, in order. Every rule must have a ref, unique within its
+	// phase.
+	Rulesets map[string][]rulesetsv1.RuleCreate `json:"rulesets,omitempty"`
+
+	// The access rules of each zone.
+	AccessRules []AccessRule `json:"access_rules,omitempty"`
+
+	// The access rules of each account, which apply to all its zones.
+	AccountAccessRules []AccessRule `json:"account_access_rules,omitempty"`
+
+	// The zone lockdowns of each zone. Descriptions must be unique.
+	Lockdowns []Lockdown `json:"lockdowns,omitempty"`
+
+	// Delete the rules, access rules and lockdowns that are not in the policy. By default they are left alone.
+	Prune bool `json:"prune,omitempty"`
+}
+
+// AccessRule : An access rule, identified by its target and value.
+type AccessRule struct {
+	// One of block, challenge, js_challenge and whitelist.
+	Mode string `json:"mode"`
+
+	// One of ip, ip_range, asn and country.
+	Target string `json:"target"`
+
+	Value string `json:"value"`
+
+	Notes string `json:"notes,omitempty"`
+}
+
+// Key returns the key identifying the access rule across zones.
+func (rule *AccessRule) Key() string {
+	return rule.Target + ":" + rule.Value
+}
+
+// Lockdown : A zone lockdown, identified by its description.
+type Lockdown struct {
+	Description string `json:"description"`
+
+	Paused bool `json:"paused,omitempty"`
+
+	// The URL patterns the lockdown applies to.
+	Urls []string `json:"urls"`
+
+	// The addresses allowed to access the URLs.
+	Configurations []LockdownConfiguration `json:"configurations"`
+
+	Priority int64 `json:"priority,omitempty"`
+}
+
+// LockdownConfiguration : An address or range allowed by a zone lockdown.
+type LockdownConfiguration struct {
+	// One of ip and ip_range.
+	Target string `json:"target"`
+
+	Value string `json:"value"`
+}
+
+// ParsePolicy parses and validates a JSON policy document.
+func ParsePolicy(data []byte) (policy *Policy, err error) {
+	policy = &Policy{}
+	err = json.Unmarshal(data, policy)
+	if err != nil {
+		err = fmt.Errorf("parsing policy: %w", err)
+		return nil, err
+	}
+	err = policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Validate checks that the rules, access rules and lockdowns of the policy can be told apart.
+func (policy *Policy) Validate() error {
+	for phase, rules := range policy.Rulesets {
+		refs := map[string]bool{}
+		for i, rule := range rules {
+			if rule.Ref == nil || *rule.Ref == "" {
+				return fmt.Errorf("rule %d of phase %s has no ref", i, phase)
+			}
+			if refs[*rule.Ref] {
+				return fmt.Errorf("ref %s is used twice in phase %s", *rule.Ref, phase)
+			}
+			refs[*rule.Ref] = true
+			if rule.Position != nil {
+				return fmt.Errorf("rule %s of phase %s has a position; the order of the policy is used instead", *rule.Ref, phase)
+			}
+		}
+	}
+	for name, rules := range map[string][]AccessRule{"access rule": policy.AccessRules, "account access rule": policy.AccountAccessRules} {
+		keys := map[string]bool{}
+		for _, rule := range rules {
+			if rule.Target == "" || rule.Value == "" || rule.Mode == "" {
+				return fmt.Errorf("%s %s needs a mode, a target and a value", name, rule.Key())
+			}
+			if keys[rule.Key()] {
+				return fmt.Errorf("%s %s is listed twice", name, rule.Key())
+			}
+			keys[rule.Key()] = true
+		}
+	}
+	descriptions := map[string]bool{}
+	for _, lockdown := range policy.Lockdowns {
+		if lockdown.Description == "" {
+			return fmt.Errorf("lockdowns need a description")
+		}
+		if descriptions[lockdown.Description] {
+			return fmt.Errorf("lockdown %q is listed twice", lockdown.Description)
+		}
+		descriptions[lockdown.Description] = true
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policysync_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/firewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/policysync"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/zonefirewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const phase = "http_request_firewall_custom"
+
+var resourcePath = regexp.MustCompile(`^/v1/crn(?:/zones/zone)?(/.*?)(?:/([^/]+))?$`)
+
+// fakeZone keeps the entry point ruleset, access rules and lockdowns of a zone, or the access rules of an account.
+type fakeZone struct {
+	sync.Mutex
+	server      *httptest.Server
+	rulesetID   string
+	rules       []map[string]interface{}
+	accessRules []map[string]interface{}
+	lockdowns   []map[string]interface{}
+	failWrites  bool
+	writes      []string
+	lastID      int
+}
+
+func newFakeZone() *fakeZone {
+	zone := &fakeZone{}
+	zone.server = httptest.NewServer(zone)
+	return zone
+}
+
+func (zone *fakeZone) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	zone.Lock()
+	defer zone.Unlock()
+
+	var body map[string]interface{}
+	if req.Method != "GET" {
+		zone.writes = append(zone.writes, req.Method+" "+req.URL.EscapedPath())
+		if zone.failWrites {
+			zone.reply(res, 500, nil)
+			return
+		}
+		if req.Method != "DELETE" {
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		}
+	}
+	matches := resourcePath.FindStringSubmatch(req.URL.EscapedPath())
+	Expect(matches).ToNot(BeNil())
+	collection, id := matches[1], matches[2]
+	switch {
+	case collection == "/rulesets/phases/"+phase && id == "entrypoint" && req.Method == "GET":
+		if zone.rulesetID == "" {
+			zone.reply(res, 404, nil)
+			return
+		}
+		zone.replyRuleset(res)
+	case collection == "/rulesets/phases/"+phase && id == "entrypoint" && req.Method == "PUT":
+		zone.rulesetID = "rs-1"
+		zone.rules = nil
+		for _, rule := range body["rules"].([]interface{}) {
+			zone.rules = append(zone.rules, zone.withID(rule.(map[string]interface{})))
+		}
+		zone.replyRuleset(res)
+	case collection == "/rulesets/"+zone.rulesetID && id == "rules" && req.Method == "POST":
+		zone.insert(zone.withID(body), body["position"])
+		zone.replyRuleset(res)
+	case collection == "/rulesets/"+zone.rulesetID+"/rules" && req.Method == "PATCH":
+		rule := zone.remove(zone.rules, id)
+		for name, value := range body {
+			rule[name] = value
+		}
+		zone.insert(rule, body["position"])
+		zone.replyRuleset(res)
+	case collection == "/rulesets/"+zone.rulesetID+"/rules" && req.Method == "DELETE":
+		zone.remove(zone.rules, id)
+		zone.replyRuleset(res)
+	case id == "rules" && req.Method == "GET":
+		zone.replyList(res, zone.accessRules)
+	case id == "rules" && req.Method == "POST":
+		zone.accessRules = append(zone.accessRules, zone.withID(body))
+		zone.reply(res, 200, body)
+	case collection == "/firewall/access_rules/rules" && req.Method == "PATCH":
+		for _, rule := range zone.accessRules {
+			if rule["id"] == id {
+				rule["mode"], rule["notes"] = body["mode"], body["notes"]
+			}
+		}
+		zone.reply(res, 200, body)
+	case id == "lockdowns" && req.Method == "GET":
+		zone.replyList(res, zone.lockdowns)
+	case id == "lockdowns" && req.Method == "POST":
+		zone.lockdowns = append(zone.lockdowns, zone.withID(body))
+		zone.reply(res, 200, body)
+	case collection == "/firewall/lockdowns" && req.Method == "PUT":
+		for i, lockdown := range zone.lockdowns {
+			if lockdown["id"] == id {
+				zone.lockdowns[i] = body
+			}
+		}
+		zone.reply(res, 200, body)
+	default:
+		Fail("unexpected request " + req.Method + " " + req.URL.EscapedPath())
+	}
+}
+
+func (zone *fakeZone) withID(object map[string]interface{}) map[string]interface{} {
+	zone.lastID++
+	object["id"] = fmt.Sprintf("id-%d", zone.lastID)
+	return object
+}
+
+func (zone *fakeZone) remove(rules []map[string]interface{}, id string) map[string]interface{} {
+	for i, rule := range rules {
+		if rule["id"] == id {
+			zone.rules = append(rules[:i:i], rules[i+1:]...)
+			return rule
+		}
+	}
+	Fail("no rule " + id)
+	return nil
+}
+
+func (zone *fakeZone) insert(rule map[string]interface{}, position interface{}) {
+	delete(rule, "position")
+	at := len(zone.rules)
+	if position, ok := position.(map[string]interface{}); ok {
+		for i, existing := range zone.rules {
+			if existing["id"] == position["before"] {
+				at = i
+			}
+			if existing["id"] == position["after"] {
+				at = i + 1
+			}
+		}
+	}
+	zone.rules = append(zone.rules[:at:at], append([]map[string]interface{}{rule}, zone.rules[at:]...)...)
+}
+
+func (zone *fakeZone) refs() (refs []string) {
+	zone.Lock()
+	defer zone.Unlock()
+	for _, rule := range zone.rules {
+		ref, _ := rule["ref"].(string)
+		refs = append(refs, ref)
+	}
+	return
+}
+
+func (zone *fakeZone) reply(res http.ResponseWriter, status int, result interface{}) {
+	res.Header().Set("Content-type", "application/json")
+	res.WriteHeader(status)
+	Expect(json.NewEncoder(res).Encode(map[string]interface{}{"success": status == 200, "errors": []interface{}{}, "messages": []interface{}{}, "result": result})).To(Succeed())
+}
+
+func (zone *fakeZone) replyRuleset(res http.ResponseWriter) {
+	zone.reply(res, 200, map[string]interface{}{"id": zone.rulesetID, "name": "default", "kind": "zone", "phase": phase, "rules": zone.rules})
+}
+
+func (zone *fakeZone) replyList(res http.ResponseWriter, objects []map[string]interface{}) {
+	res.Header().Set("Content-type", "application/json")
+	Expect(json.NewEncoder(res).Encode(map[string]interface{}{"success": true, "errors": []interface{}{}, "messages": []interface{}{}, "result": objects,
+		"result_info": map[string]interface{}{"page": 1, "per_page": 100, "count": len(objects), "total_count": len(objects)}})).To(Succeed())
+}
+
+func (zone *fakeZone) clients(name string) policysync.Zone {
+	rulesets, err := rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{
+		URL: zone.server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr("zone"),
+	})
+	Expect(err).To(BeNil())
+	accessRules, err := zonefirewallaccessrulesv1.NewZoneFirewallAccessRulesV1(&zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1Options{
+		URL: zone.server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr("zone"),
+	})
+	Expect(err).To(BeNil())
+	lockdowns, err := zonelockdownv1.NewZoneLockdownV1(&zonelockdownv1.ZoneLockdownV1Options{
+		URL: zone.server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneIdentifier: core.StringPtr("zone"),
+	})
+	Expect(err).To(BeNil())
+	return policysync.Zone{Name: name, Rulesets: rulesets, AccessRules: accessRules, Lockdowns: lockdowns}
+}
+
+func policyRule(ref string, action string, expression string) rulesetsv1.RuleCreate {
+	return rulesetsv1.RuleCreate{Ref: core.StringPtr(ref), Action: core.StringPtr(action), Expression: core.StringPtr(expression)}
+}
+
+func deployedRule(id string, ref string, action string, expression string) map[string]interface{} {
+	rule := map[string]interface{}{"id": id, "action": action, "expression": expression, "enabled": true}
+	if ref != "" {
+		rule["ref"] = ref
+	}
+	return rule
+}
+
+func changeStrings(plan *policysync.Plan) (changes []string) {
+	for _, change := range plan.Changes {
+		changes = append(changes, change.String())
+	}
+	return
+}
+
+var _ = Describe(`Syncer`, func() {
+	var policy *policysync.Policy
+	var zone *fakeZone
+
+	BeforeEach(func() {
+		policy = &policysync.Policy{
+			Rulesets: map[string][]rulesetsv1.RuleCreate{phase: {
+				policyRule("log-admin", "log", `http.request.uri.path eq "/admin"`),
+				policyRule("allow-office", "skip", `ip.src in $office`),
+				policyRule("block-bad", "block", `cf.threat_score gt 50`),
+				policyRule("challenge", "managed_challenge", `cf.threat_score gt 10`),
+			}},
+			AccessRules: []policysync.AccessRule{
+				{Mode: "challenge", Target: "ip", Value: "198.51.100.1", Notes: "scanner"},
+				{Mode: "block", Target: "country", Value: "XX"},
+			},
+			Lockdowns: []policysync.Lockdown{
+				{Description: "admin", Urls: []string{"example.com/admin*"}, Configurations: []policysync.LockdownConfiguration{{Target: "ip_range", Value: "203.0.113.0/24"}}},
+				{Description: "api", Urls: []string{"example.com/api*"}, Configurations: []policysync.LockdownConfiguration{{Target: "ip", Value: "203.0.113.7"}}},
+			},
+		}
+		zone = newFakeZone()
+		zone.rulesetID = "rs-1"
+		zone.rules = []map[string]interface{}{
+			deployedRule("r-1", "allow-office", "skip", `ip.src in $office`),
+			deployedRule("r-2", "", "block", `ssl`),
+			deployedRule("r-3", "block-bad", "block", `cf.threat_score gt 40`),
+			deployedRule("r-4", "log-admin", "log", `http.request.uri.path eq "/admin"`),
+		}
+		zone.accessRules = []map[string]interface{}{
+			{"id": "a-1", "mode": "block", "notes": "scanner", "configuration": map[string]interface{}{"target": "ip", "value": "198.51.100.1"}, "scope": map[string]interface{}{"type": "zone"}},
+			{"id": "a-2", "mode": "block", "configuration": map[string]interface{}{"target": "asn", "value": "64496"}, "scope": map[string]interface{}{"type": "account"}},
+		}
+		zone.lockdowns = []map[string]interface{}{
+			{"id": "l-1", "paused": false, "description": "admin", "urls": []string{"example.com/wp-admin*"}, "configurations": []interface{}{
+				map[string]interface{}{"target": "ip_range", "value": "203.0.113.0/24"},
+			}},
+		}
+	})
+	AfterEach(func() {
+		zone.server.Close()
+	})
+
+	It(`Plan the changes of a zone, moving as few rules as possible`, func() {
+		syncer, err := policysync.NewSyncer(policy)
+		Expect(err).To(BeNil())
+		clients := zone.clients("zone-a")
+		plan, err := syncer.PlanZone(&clients)
+		Expect(err).To(BeNil())
+		Expect(plan.Target).To(Equal("zone-a"))
+		Expect(changeStrings(plan)).To(Equal([]string{
+			"update rule http_request_firewall_custom/log-admin before allow-office (position)",
+			"update rule http_request_firewall_custom/block-bad (expression)",
+			"create rule http_request_firewall_custom/challenge after block-bad",
+			"update access rule ip:198.51.100.1 (mode)",
+			"create access rule country:XX",
+			"update lockdown admin (urls)",
+			"create lockdown api",
+		}))
+	})
+	It(`Apply the plan and keep rules outside the policy in place`, func() {
+		syncer, err := policysync.NewSyncer(policy)
+		Expect(err).To(BeNil())
+		report := syncer.Sync(&policysync.SyncOptions{Zones: []policysync.Zone{zone.clients("zone-a")}})
+		Expect(report.Failed()).To(BeEmpty())
+		Expect(report.Results[0].Applied).To(Equal(7))
+		Expect(zone.refs()).To(Equal([]string{"log-admin", "allow-office", "", "block-bad", "challenge"}))
+
+		clients := zone.clients("zone-a")
+		plan, err := syncer.PlanZone(&clients)
+		Expect(err).To(BeNil())
+		Expect(plan.Empty()).To(BeTrue())
+
+		policy.Prune = true
+		plan, err = syncer.PlanZone(&clients)
+		Expect(err).To(BeNil())
+		Expect(changeStrings(plan)).To(Equal([]string{"delete rule http_request_firewall_custom/r-2"}))
+	})
+	It(`Sync many zones and report the failures of each`, func() {
+		missing := newFakeZone()
+		defer missing.server.Close()
+		failing := newFakeZone()
+		defer failing.server.Close()
+		failing.rulesetID = "rs-1"
+		failing.failWrites = true
+		account := newFakeZone()
+		defer account.server.Close()
+		accountAccessRules, err := firewallaccessrulesv1.NewFirewallAccessRulesV1(&firewallaccessrulesv1.FirewallAccessRulesV1Options{
+			URL: account.server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"),
+		})
+		Expect(err).To(BeNil())
+		policy.AccountAccessRules = []policysync.AccessRule{{Mode: "whitelist", Target: "ip_range", Value: "203.0.113.0/24"}}
+
+		syncer, err := policysync.NewSyncer(policy)
+		Expect(err).To(BeNil())
+		syncer.Concurrency = 2
+		report := syncer.Sync(&policysync.SyncOptions{
+			Accounts: []policysync.Account{{Name: "account", AccessRules: accountAccessRules}},
+			Zones:    []policysync.Zone{zone.clients("zone-a"), missing.clients("zone-b"), failing.clients("zone-c")},
+		})
+		Expect(report.Results).To(HaveLen(4))
+		Expect(report.Succeeded()).To(HaveLen(3))
+		Expect(report.Failed()).To(HaveLen(1))
+		Expect(report.Failed()[0].Target).To(Equal("zone-c"))
+		Expect(report.Failed()[0].Applied).To(Equal(0))
+		Expect(report.Failed()[0].Err.Error()).To(HavePrefix("create rule http_request_firewall_custom/log-admin: "))
+		Expect(failing.writes).To(HaveLen(1))
+		Expect(report.String()).To(HavePrefix("3 of 4 targets synced\n  account: 1 changes\n"))
+
+		Expect(account.writes).To(Equal([]string{"POST /v1/crn/firewall/access_rules/rules"}))
+		Expect(missing.writes[0]).To(Equal("PUT /v1/crn/zones/zone/rulesets/phases/http_request_firewall_custom/entrypoint"))
+		Expect(missing.refs()).To(Equal([]string{"log-admin", "allow-office", "block-bad", "challenge"}))
+		Expect(zone.refs()).To(Equal([]string{"log-admin", "allow-office", "", "block-bad", "challenge"}))
+	})
+	It(`Only plan in a dry run`, func() {
+		syncer, err := policysync.NewSyncer(policy)
+		Expect(err).To(BeNil())
+		report := syncer.Sync(&policysync.SyncOptions{Zones: []policysync.Zone{zone.clients("zone-a")}, DryRun: true})
+		Expect(report.Failed()).To(BeEmpty())
+		Expect(report.Results[0].Plan.Changes).To(HaveLen(7))
+		Expect(report.Results[0].Applied).To(Equal(0))
+		Expect(zone.writes).To(BeEmpty())
+	})
+	It(`Reject policies with rules that cannot be told apart`, func() {
+		_, err := policysync.ParsePolicy([]byte(`{"rulesets": {"http_request_firewall_custom": [{"ref": "a", "action": "block", "expression": "true"}, {"action": "log", "expression": "true"}]}}`))
+		Expect(err).To(MatchError("rule 1 of phase http_request_firewall_custom has no ref"))
+		_, err = policysync.ParsePolicy([]byte(`{"lockdowns": [{"description": "admin", "urls": []}, {"description": "admin", "urls": []}]}`))
+		Expect(err).To(MatchError(`lockdown "admin" is listed twice`))
+		policy, err := policysync.ParsePolicy([]byte(`{"access_rules": [{"mode": "block", "target": "country", "value": "XX"}], "prune": true}`))
+		Expect(err).To(BeNil())
+		Expect(policy.Prune).To(BeTrue())
+	})
+})
@@ -0,0 +1,367 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policysync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/firewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/zonefirewallaccessrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+)
+
+// DefaultConcurrency is the number of zones synced at the same time when the Syncer does not say otherwise.
+const DefaultConcurrency = 4
+
+// Zone : The clients of a zone. The resources of the clients that are nil are left alone.
+type Zone struct {
+	// The name of the zone in the report.
+	Name string
+
+	Rulesets *rulesetsv1.RulesetsV1
+
+	AccessRules *zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1
+
+	Lockdowns *zonelockdownv1.ZoneLockdownV1
+}
+
+// Account : The client of an account.
+type Account struct {
+	// The name of the account in the report.
+	Name string
+
+	AccessRules *firewallaccessrulesv1.FirewallAccessRulesV1
+}
+
+// Syncer : Syncs zones and accounts with a policy.
+type Syncer struct {
+	Policy *Policy
+
+	// The number of zones and accounts synced at the same time.
+	Concurrency int
+}
+
+// NewSyncer : Instantiate Syncer
+func NewSyncer(policy *Policy) (syncer *Syncer, err error) {
+	if policy == nil {
+		err = fmt.Errorf("a policy is required")
+		return
+	}
+	err = policy.Validate()
+	if err != nil {
+		return
+	}
+	syncer = &Syncer{Policy: policy, Concurrency: DefaultConcurrency}
+	return
+}
+
+// SyncOptions : The SyncOptions options.
+type SyncOptions struct {
+	Zones []Zone
+
+	Accounts []Account
+
+	// Only compute the plans.
+	DryRun bool
+}
+
+// Result : The outcome of the sync of a zone or account.
+type Result struct {
+	// The name of the zone or account.
+	Target string
+
+	// The changes computed for the target, nil when they could not be computed.
+	Plan *Plan
+
+	// The number of changes of the plan that were applied.
+	Applied int
+
+	// The error that stopped the sync of the target.
+	Err error
+}
+
+// Report : The outcome of a sync, with the accounts first and then the zones, in the order of the options.
+type Report struct {
+	Results []Result
+}
+
+// Succeeded returns the results of the targets that were synced.
+func (report *Report) Succeeded() (results []Result) {
+	for _, result := range report.Results {
+		if result.Err == nil {
+			results = append(results, result)
+		}
+	}
+	return
+}
+
+// Failed returns the results of the targets that could not be synced.
+func (report *Report) Failed() (results []Result) {
+	for _, result := range report.Results {
+		if result.Err != nil {
+			results = append(results, result)
+		}
+	}
+	return
+}
+
+func (report *Report) String() string {
+	var summary strings.Builder
+	failed := report.Failed()
+	fmt.Fprintf(&summary, "%d of %d targets synced\n", len(report.Results)-len(failed), len(report.Results))
+	for _, result := range report.Results {
+		changes := 0
+		if result.Plan != nil {
+			changes = len(result.Plan.Changes)
+		}
+		if result.Err != nil {
+			fmt.Fprintf(&summary, "  %s: failed after %d of %d changes: %s\n", result.Target, result.Applied, changes, result.Err)
+		} else {
+			fmt.Fprintf(&summary, "  %s: %d changes\n", result.Target, changes)
+		}
+	}
+	return summary.String()
+}
+
+// Sync : Sync zones and accounts with the policy
+// Compute the plan of each account and zone and apply it, several targets at a time. The accounts are synced before
+// the zones. The sync of a target stops at its first error without affecting the other targets.
+func (syncer *Syncer) Sync(syncOptions *SyncOptions) (report *Report) {
+	return syncer.SyncWithContext(context.Background(), syncOptions)
+}
+
+// SyncWithContext is an alternate form of the Sync method which supports a Context parameter
+func (syncer *Syncer) SyncWithContext(ctx context.Context, syncOptions *SyncOptions) (report *Report) {
+	report = &Report{}
+	accounts := make([]Result, len(syncOptions.Accounts))
+	syncer.forEach(len(accounts), func(i int) {
+		account := &syncOptions.Accounts[i]
+		accounts[i] = syncer.syncTarget(ctx, account.Name, syncOptions.DryRun, func() (*Plan, error) {
+			return syncer.PlanAccountWithContext(ctx, account)
+		}, func(change *Change, ids map[string]string) error {
+			return applyAccountChange(ctx, account, change)
+		})
+	})
+	zones := make([]Result, len(syncOptions.Zones))
+	syncer.forEach(len(zones), func(i int) {
+		zone := &syncOptions.Zones[i]
+		zones[i] = syncer.syncTarget(ctx, zone.Name, syncOptions.DryRun, func() (*Plan, error) {
+			return syncer.PlanZoneWithContext(ctx, zone)
+		}, func(change *Change, ids map[string]string) error {
+			return applyZoneChange(ctx, zone, change, ids)
+		})
+	})
+	report.Results = append(accounts, zones...)
+	return
+}
+
+// forEach calls f for 0 to count-1, at most Concurrency calls at a time.
+func (syncer *Syncer) forEach(count int, f func(i int)) {
+	concurrency := syncer.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	semaphore := make(chan struct{}, concurrency)
+	var group sync.WaitGroup
+	for i := 0; i < count; i++ {
+		group.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				group.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	group.Wait()
+}
+
+func (syncer *Syncer) syncTarget(ctx context.Context, name string, dryRun bool, plan func() (*Plan, error), apply func(*Change, map[string]string) error) (result Result) {
+	result.Target = name
+	result.Plan, result.Err = plan()
+	if result.Err != nil || dryRun {
+		return
+	}
+	// The IDs of the rules by phase and ref, completed as rules are created, to position the rules that follow.
+	ids := map[string]string{}
+	for key, id := range result.Plan.ruleIDs {
+		ids[key] = id
+	}
+	for i := range result.Plan.Changes {
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			return
+		}
+		change := &result.Plan.Changes[i]
+		err := apply(change, ids)
+		if err != nil {
+			result.Err = fmt.Errorf("%s: %w", change, err)
+			return
+		}
+		result.Applied++
+	}
+	return
+}
+
+func applyAccountChange(ctx context.Context, account *Account, change *Change) (err error) {
+	client := account.AccessRules
+	rule := change.accessRule
+	switch change.Action {
+	case Change_Action_Create:
+		options := client.NewCreateAccountAccessRuleOptions().SetMode(rule.Mode).SetConfiguration(&firewallaccessrulesv1.AccountAccessRuleInputConfiguration{
+			Target: core.StringPtr(rule.Target),
+			Value:  core.StringPtr(rule.Value),
+		})
+		if rule.Notes != "" {
+			options.SetNotes(rule.Notes)
+		}
+		_, _, err = client.CreateAccountAccessRuleWithContext(ctx, options)
+	case Change_Action_Update:
+		_, _, err = client.UpdateAccountAccessRuleWithContext(ctx, client.NewUpdateAccountAccessRuleOptions(change.ID).SetMode(rule.Mode).SetNotes(rule.Notes))
+	case Change_Action_Delete:
+		_, _, err = client.DeleteAccountAccessRuleWithContext(ctx, client.NewDeleteAccountAccessRuleOptions(change.ID))
+	}
+	return
+}
+
+func applyZoneChange(ctx context.Context, zone *Zone, change *Change, ids map[string]string) (err error) {
+	switch change.Resource {
+	case Change_Resource_Ruleset:
+		rulesets := zone.Rulesets
+		options := rulesets.NewUpdateZoneEntrypointRulesetOptions(change.Phase).SetName("default").SetKind("zone").SetPhase(change.Phase).SetRules(change.rules)
+		_, _, err = rulesets.UpdateZoneEntrypointRulesetWithContext(ctx, options)
+	case Change_Resource_Rule:
+		err = applyRuleChange(ctx, zone.Rulesets, change, ids)
+	case Change_Resource_AccessRule:
+		err = applyAccessRuleChange(ctx, zone.AccessRules, change)
+	case Change_Resource_Lockdown:
+		err = applyLockdownChange(ctx, zone.Lockdowns, change)
+	}
+	return
+}
+
+func applyRuleChange(ctx context.Context, rulesets *rulesetsv1.RulesetsV1, change *Change, ids map[string]string) (err error) {
+	if change.Action == Change_Action_Delete {
+		_, _, err = rulesets.DeleteZoneRulesetRuleWithContext(ctx, rulesets.NewDeleteZoneRulesetRuleOptions(change.rulesetID, change.ID))
+		return
+	}
+	position, err := rulePosition(change, ids)
+	if err != nil {
+		return
+	}
+	rule := change.rule
+	if change.Action == Change_Action_Create {
+		options := rulesets.NewCreateZoneRulesetRuleOptions(change.rulesetID)
+		options.Action, options.ActionParameters, options.Ratelimit = rule.Action, rule.ActionParameters, rule.Ratelimit
+		options.Description, options.Enabled, options.Expression = rule.Description, rule.Enabled, rule.Expression
+		options.Logging, options.Ref, options.Position = rule.Logging, rule.Ref, position
+		var result *rulesetsv1.RulesetResp
+		result, _, err = rulesets.CreateZoneRulesetRuleWithContext(ctx, options)
+		if err != nil {
+			return
+		}
+		for _, created := range result.Result.Rules {
+			if created.Ref != nil && *created.Ref == change.Key && created.ID != nil {
+				ids[change.Phase+"/"+change.Key] = *created.ID
+			}
+		}
+		return
+	}
+	options := rulesets.NewUpdateZoneRulesetRuleOptions(change.rulesetID, change.ID)
+	options.Action, options.ActionParameters, options.Ratelimit = rule.Action, rule.ActionParameters, rule.Ratelimit
+	options.Description, options.Enabled, options.Expression = rule.Description, rule.Enabled, rule.Expression
+	options.Logging, options.Ref, options.Position = rule.Logging, rule.Ref, position
+	_, _, err = rulesets.UpdateZoneRulesetRuleWithContext(ctx, options)
+	return
+}
+
+// rulePosition resolves the refs of the anchor of a rule to rule IDs.
+func rulePosition(change *Change, ids map[string]string) (position *rulesetsv1.Position, err error) {
+	anchor := change.After
+	if anchor == "" {
+		anchor = change.Before
+	}
+	if anchor == "" {
+		return
+	}
+	id, ok := ids[change.Phase+"/"+anchor]
+	if !ok {
+		err = fmt.Errorf("the ID of rule %s is not known", anchor)
+		return
+	}
+	if change.After != "" {
+		position = &rulesetsv1.Position{After: core.StringPtr(id)}
+	} else {
+		position = &rulesetsv1.Position{Before: core.StringPtr(id)}
+	}
+	return
+}
+
+func applyAccessRuleChange(ctx context.Context, client *zonefirewallaccessrulesv1.ZoneFirewallAccessRulesV1, change *Change) (err error) {
+	rule := change.accessRule
+	switch change.Action {
+	case Change_Action_Create:
+		options := client.NewCreateZoneAccessRuleOptions().SetMode(rule.Mode).SetConfiguration(&zonefirewallaccessrulesv1.ZoneAccessRuleInputConfiguration{
+			Target: core.StringPtr(rule.Target),
+			Value:  core.StringPtr(rule.Value),
+		})
+		if rule.Notes != "" {
+			options.SetNotes(rule.Notes)
+		}
+		_, _, err = client.CreateZoneAccessRuleWithContext(ctx, options)
+	case Change_Action_Update:
+		_, _, err = client.UpdateZoneAccessRuleWithContext(ctx, client.NewUpdateZoneAccessRuleOptions(change.ID).SetMode(rule.Mode).SetNotes(rule.Notes))
+	case Change_Action_Delete:
+		_, _, err = client.DeleteZoneAccessRuleWithContext(ctx, client.NewDeleteZoneAccessRuleOptions(change.ID))
+	}
+	return
+}
+
+func applyLockdownChange(ctx context.Context, client *zonelockdownv1.ZoneLockdownV1, change *Change) (err error) {
+	if change.Action == Change_Action_Delete {
+		_, _, err = client.DeleteZoneLockdownRuleWithContext(ctx, client.NewDeleteZoneLockdownRuleOptions(change.ID))
+		return
+	}
+	lockdown := change.lockdown
+	var configurations []zonelockdownv1.LockdownInputConfigurationsItem
+	for _, configuration := range lockdown.Configurations {
+		configurations = append(configurations, zonelockdownv1.LockdownInputConfigurationsItem{
+			Target: core.StringPtr(configuration.Target),
+			Value:  core.StringPtr(configuration.Value),
+		})
+	}
+	var priority *int64
+	if lockdown.Priority != 0 {
+		priority = core.Int64Ptr(lockdown.Priority)
+	}
+	if change.Action == Change_Action_Create {
+		options := client.NewCreateZoneLockdownRuleOptions().SetDescription(lockdown.Description).SetPaused(lockdown.Paused).SetUrls(lockdown.Urls).SetConfigurations(configurations)
+		options.Priority = priority
+		_, _, err = client.CreateZoneLockdownRuleWithContext(ctx, options)
+		return
+	}
+	options := client.NewUpdateLockdownRuleOptions(change.ID).SetID(change.ID).SetDescription(lockdown.Description).SetPaused(lockdown.Paused).SetUrls(lockdown.Urls).SetConfigurations(configurations)
+	options.Priority = priority
+	_, _, err = client.UpdateLockdownRuleWithContext(ctx, options)
+	return
+}
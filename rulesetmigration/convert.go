@@ -0,0 +1,431 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetmigration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/expr"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+)
+
+// Sources of the migrated rules.
+const (
+	Source_Filter        = "filter"
+	Source_FirewallRule  = "firewall_rule"
+	Source_Lockdown      = "lockdown"
+	Source_PageRule      = "page_rule"
+	Source_RateLimit     = "rate_limit"
+	Source_UserAgentRule = "user_agent_rule"
+)
+
+// Phases of the zone entry point rulesets the legacy rules move to.
+const (
+	Phase_HttpConfigSettings         = "http_config_settings"
+	Phase_HttpRatelimit              = "http_ratelimit"
+	Phase_HttpRequestCacheSettings   = "http_request_cache_settings"
+	Phase_HttpRequestDynamicRedirect = "http_request_dynamic_redirect"
+	Phase_HttpRequestFirewallCustom  = "http_request_firewall_custom"
+)
+
+// Severities of the findings.
+const (
+	// The rule is migrated, but does not behave exactly like the legacy one.
+	Finding_Severity_Approximation = "approximation"
+
+	// The object is not migrated.
+	Finding_Severity_Untranslatable = "untranslatable"
+
+	// Nothing changes in behavior; the finding is for information.
+	Finding_Severity_Info = "info"
+)
+
+// Periods and mitigation timeouts accepted by rate limiting rules, in seconds.
+var (
+	RatelimitPeriods            = []int64{10, 60, 120, 300, 600, 3600}
+	RatelimitMitigationTimeouts = []int64{0, 60, 120, 300, 600, 3600, 86400}
+)
+
+// Finding : A construct of a legacy object that cannot be migrated exactly.
+type Finding struct {
+	// One of the Source constants.
+	Source string
+
+	// The ID of the legacy object.
+	ID string
+
+	// One of the Finding_Severity constants.
+	Severity string
+
+	Message string
+}
+
+func (finding Finding) String() string {
+	return fmt.Sprintf("%s %s %s: %s", finding.Severity, finding.Source, finding.ID, finding.Message)
+}
+
+// Rule : A ruleset rule converted from a legacy object.
+type Rule struct {
+	// One of the Source constants.
+	Source string
+
+	// The ID of the legacy object.
+	SourceID string
+
+	// The phase of the zone entry point ruleset that receives the rule.
+	Phase string
+
+	// The rule. Its Ref identifies the legacy object, so that a migration can be run again without duplicating
+	// rules.
+	RuleCreate *rulesetsv1.RuleCreate
+}
+
+// Ref returns the ruleset rule reference used for the legacy object.
+func Ref(source string, id string) string {
+	return source + ":" + id
+}
+
+// converter collects the findings of a conversion.
+type converter struct {
+	source   string
+	id       string
+	findings []Finding
+}
+
+func (c *converter) finding(severity string, format string, args ...interface{}) {
+	c.findings = append(c.findings, Finding{Source: c.source, ID: c.id, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// ConvertFirewallRule converts a firewall rule, with its filter, to a rule of the http_request_firewall_custom
+// phase. The allow action becomes a skip of the remaining custom rules. A paused rule or filter gives a disabled
+// rule. The returned rule is nil when the rule cannot be migrated.
+func ConvertFirewallRule(rule *firewallrulesv1.FirewallRuleObject) (*Rule, []Finding) {
+	c := &converter{source: Source_FirewallRule, id: core.StringNilMapper(rule.ID)}
+	if rule.Filter == nil || rule.Filter.Expression == nil {
+		c.finding(Finding_Severity_Untranslatable, "the rule has no filter expression")
+		return nil, c.findings
+	}
+	expression := *rule.Filter.Expression
+	if _, err := expr.Parse(expression); err != nil {
+		c.finding(Finding_Severity_Approximation, "the expression is migrated as is but could not be checked: %s", err)
+	}
+
+	ruleCreate := &rulesetsv1.RuleCreate{
+		Expression:  core.StringPtr(expression),
+		Description: description(rule.Description, "Firewall rule "+c.id),
+		Enabled:     core.BoolPtr(!boolValue(rule.Paused) && (rule.Filter.Paused == nil || !*rule.Filter.Paused)),
+		Ref:         core.StringPtr(Ref(c.source, c.id)),
+	}
+	switch action := core.StringNilMapper(rule.Action); action {
+	case firewallrulesv1.FirewallRuleObject_Action_Block, firewallrulesv1.FirewallRuleObject_Action_Challenge,
+		firewallrulesv1.FirewallRuleObject_Action_JsChallenge, firewallrulesv1.FirewallRuleObject_Action_Log, "managed_challenge":
+		ruleCreate.Action = core.StringPtr(action)
+	case firewallrulesv1.FirewallRuleObject_Action_Allow:
+		ruleCreate.Action = core.StringPtr("skip")
+		ruleCreate.ActionParameters = &rulesetsv1.ActionParameters{Ruleset: core.StringPtr("current")}
+	case "bypass":
+		ruleCreate.Action = core.StringPtr("skip")
+		ruleCreate.ActionParameters = &rulesetsv1.ActionParameters{Ruleset: core.StringPtr("current")}
+		c.finding(Finding_Severity_Approximation, "bypass only skips the remaining custom rules; skip the phases of the bypassed products explicitly")
+	default:
+		c.finding(Finding_Severity_Untranslatable, "action %q has no ruleset equivalent", action)
+		return nil, c.findings
+	}
+	if ruleCreate.Action != nil && *ruleCreate.Action == "skip" {
+		ruleCreate.Logging = &rulesetsv1.Logging{Enabled: core.BoolPtr(true)}
+	}
+	return &Rule{Source: c.source, SourceID: c.id, Phase: Phase_HttpRequestFirewallCustom, RuleCreate: ruleCreate}, c.findings
+}
+
+// ConvertLockdown converts a zone lockdown rule to a block rule of the http_request_firewall_custom phase, matching
+// its URLs from any address outside its configurations.
+func ConvertLockdown(lockdown *zonelockdownv1.LockdownObject) (*Rule, []Finding) {
+	c := &converter{source: Source_Lockdown, id: core.StringNilMapper(lockdown.ID)}
+	if len(lockdown.Urls) == 0 {
+		c.finding(Finding_Severity_Untranslatable, "the lockdown has no URL")
+		return nil, c.findings
+	}
+	var urls []expr.Expr
+	for _, pattern := range lockdown.Urls {
+		urls = append(urls, c.urlCondition(pattern))
+	}
+	urlMatch := expr.Or(urls...)
+	if literal, ok := urlMatch.(*expr.BoolLiteral); ok && literal.Value {
+		// A URL matches all the traffic of the zone.
+		urlMatch = nil
+	}
+
+	var allowed []interface{}
+	for _, configuration := range lockdown.Configurations {
+		value := core.StringNilMapper(configuration.Value)
+		switch core.StringNilMapper(configuration.Target) {
+		case zonelockdownv1.LockdownObjectConfigurationsItem_Target_Ip, zonelockdownv1.LockdownObjectConfigurationsItem_Target_IpRange:
+			allowed = append(allowed, value)
+		default:
+			c.finding(Finding_Severity_Approximation, "configuration target %q is not migrated", core.StringNilMapper(configuration.Target))
+		}
+	}
+	conditions := []expr.Expr{urlMatch}
+	if len(allowed) > 0 {
+		conditions = append(conditions, expr.Not(expr.Field("ip.src").In(allowed...)))
+	}
+	e := expr.And(conditions...)
+	if err := expr.Check(e); err != nil {
+		c.finding(Finding_Severity_Untranslatable, "the converted expression is invalid: %s", err)
+		return nil, c.findings
+	}
+
+	ruleCreate := &rulesetsv1.RuleCreate{
+		Action:      core.StringPtr("block"),
+		Expression:  core.StringPtr(expr.Format(e)),
+		Description: description(lockdown.Description, "Zone lockdown "+c.id),
+		Enabled:     core.BoolPtr(!boolValue(lockdown.Paused)),
+		Ref:         core.StringPtr(Ref(c.source, c.id)),
+	}
+	return &Rule{Source: c.source, SourceID: c.id, Phase: Phase_HttpRequestFirewallCustom, RuleCreate: ruleCreate}, c.findings
+}
+
+// ConvertUserAgentRule converts a user agent blocking rule to a rule of the http_request_firewall_custom phase
+// matching the exact user agent.
+func ConvertUserAgentRule(rule *useragentblockingrulesv1.UseragentRuleObject) (*Rule, []Finding) {
+	c := &converter{source: Source_UserAgentRule, id: core.StringNilMapper(rule.ID)}
+	if rule.Configuration == nil || core.StringNilMapper(rule.Configuration.Target) != useragentblockingrulesv1.UseragentRuleObjectConfiguration_Target_Ua {
+		c.finding(Finding_Severity_Untranslatable, "the rule has no user agent configuration")
+		return nil, c.findings
+	}
+	mode := core.StringNilMapper(rule.Mode)
+	switch mode {
+	case useragentblockingrulesv1.UseragentRuleObject_Mode_Block, useragentblockingrulesv1.UseragentRuleObject_Mode_Challenge,
+		useragentblockingrulesv1.UseragentRuleObject_Mode_JsChallenge:
+	default:
+		c.finding(Finding_Severity_Untranslatable, "mode %q has no ruleset equivalent", mode)
+		return nil, c.findings
+	}
+
+	ruleCreate := &rulesetsv1.RuleCreate{
+		Action:      core.StringPtr(mode),
+		Expression:  core.StringPtr(expr.Field("http.user_agent").Eq(core.StringNilMapper(rule.Configuration.Value)).String()),
+		Description: description(rule.Description, "User agent rule "+c.id),
+		Enabled:     core.BoolPtr(!boolValue(rule.Paused)),
+		Ref:         core.StringPtr(Ref(c.source, c.id)),
+	}
+	return &Rule{Source: c.source, SourceID: c.id, Phase: Phase_HttpRequestFirewallCustom, RuleCreate: ruleCreate}, c.findings
+}
+
+// ConvertRateLimit converts a rate limit to a rule of the http_ratelimit phase, counting by client IP address and
+// data center. Periods and timeouts that rate limiting rules do not accept are rounded to the nearest accepted
+// value, and the threshold is scaled to keep the same rate.
+func ConvertRateLimit(rateLimit *zoneratelimitsv1.RatelimitObject) (*Rule, []Finding) {
+	c := &converter{source: Source_RateLimit, id: core.StringNilMapper(rateLimit.ID)}
+	var conditions []expr.Expr
+	var counting []expr.Expr
+	if rateLimit.Match != nil && rateLimit.Match.Request != nil {
+		request := rateLimit.Match.Request
+		conditions = append(conditions, c.urlCondition(core.StringNilMapper(request.URL)))
+		if methods := without(request.Methods, zoneratelimitsv1.RatelimitObjectMatchRequest_Methods_All); len(methods) > 0 && len(methods) == len(request.Methods) {
+			conditions = append(conditions, expr.Field("http.request.method").In(methods))
+		}
+		schemes := without(request.Schemes, zoneratelimitsv1.RatelimitObjectMatchRequest_Schemes_All)
+		if len(schemes) == 1 && len(request.Schemes) == 1 {
+			if strings.EqualFold(schemes[0], zoneratelimitsv1.RatelimitObjectMatchRequest_Schemes_Https) {
+				conditions = append(conditions, expr.Field("ssl"))
+			} else {
+				conditions = append(conditions, expr.Not(expr.Field("ssl")))
+			}
+		}
+	}
+	for _, bypass := range rateLimit.Bypass {
+		if core.StringNilMapper(bypass.Name) != zoneratelimitsv1.RatelimitObjectBypassItem_Name_URL {
+			c.finding(Finding_Severity_Approximation, "bypass %q is not migrated", core.StringNilMapper(bypass.Name))
+			continue
+		}
+		conditions = append(conditions, expr.Not(c.urlCondition(core.StringNilMapper(bypass.Value))))
+	}
+
+	ratelimit := &rulesetsv1.Ratelimit{Characteristics: []string{"cf.colo.id", "ip.src"}}
+	if rateLimit.Match != nil && rateLimit.Match.Response != nil {
+		response := rateLimit.Match.Response
+		if len(response.Status) > 0 {
+			var statuses []interface{}
+			for _, status := range response.Status {
+				statuses = append(statuses, status)
+			}
+			counting = append(counting, expr.Field("http.response.code").In(statuses...))
+		}
+		for _, header := range response.HeadersVar {
+			value := expr.Field("http.response.headers").Key(strings.ToLower(core.StringNilMapper(header.Name))).Index(0)
+			if core.StringNilMapper(header.Op) == zoneratelimitsv1.RatelimitObjectMatchResponseHeadersItem_Op_Ne {
+				counting = append(counting, value.Ne(core.StringNilMapper(header.Value)))
+			} else {
+				counting = append(counting, value.Eq(core.StringNilMapper(header.Value)))
+			}
+		}
+		if response.OriginTraffic != nil {
+			ratelimit.RequestsToOrigin = core.BoolPtr(*response.OriginTraffic)
+			if *response.OriginTraffic {
+				c.finding(Finding_Severity_Approximation, "origin_traffic is migrated as requests_to_origin, which also ignores cached responses")
+			}
+		}
+	}
+	if len(counting) > 0 {
+		// The counting expression also needs the request conditions, or every request would be counted.
+		countingExpression := expr.And(append(append([]expr.Expr(nil), conditions...), counting...)...)
+		ratelimit.CountingExpression = core.StringPtr(expr.Format(countingExpression))
+	}
+	if rateLimit.Correlate != nil && core.StringNilMapper(rateLimit.Correlate.By) == zoneratelimitsv1.RatelimitObjectCorrelate_By_Nat {
+		c.finding(Finding_Severity_Approximation, "NAT correlation is not available; requests are counted by IP address")
+	}
+
+	period, threshold := int64Value(rateLimit.Period), int64Value(rateLimit.Threshold)
+	ratelimit.Period = core.Int64Ptr(nearest(RatelimitPeriods, period))
+	ratelimit.RequestsPerPeriod = core.Int64Ptr(threshold)
+	if *ratelimit.Period != period && period > 0 {
+		scaled := (threshold**ratelimit.Period + period - 1) / period
+		ratelimit.RequestsPerPeriod = core.Int64Ptr(scaled)
+		c.finding(Finding_Severity_Approximation, "period %ds is not accepted; %d requests per %ds become %d requests per %ds", period, threshold, period, scaled, *ratelimit.Period)
+	}
+
+	ruleCreate := &rulesetsv1.RuleCreate{
+		Description: description(rateLimit.Description, "Rate limit "+c.id),
+		Enabled:     core.BoolPtr(!boolValue(rateLimit.Disabled)),
+		Ref:         core.StringPtr(Ref(c.source, c.id)),
+		Ratelimit:   ratelimit,
+	}
+	mode := ""
+	if rateLimit.Action != nil {
+		mode = core.StringNilMapper(rateLimit.Action.Mode)
+	}
+	switch mode {
+	case zoneratelimitsv1.RatelimitObjectAction_Mode_Ban, zoneratelimitsv1.RatelimitObjectAction_Mode_Simulate:
+		ruleCreate.Action = core.StringPtr("block")
+		if mode == zoneratelimitsv1.RatelimitObjectAction_Mode_Simulate {
+			ruleCreate.Action = core.StringPtr("log")
+		}
+		timeout := int64Value(rateLimit.Action.Timeout)
+		ratelimit.MitigationTimeout = core.Int64Ptr(nearest(RatelimitMitigationTimeouts, timeout))
+		if *ratelimit.MitigationTimeout != timeout {
+			c.finding(Finding_Severity_Approximation, "timeout %ds is not accepted and becomes %ds", timeout, *ratelimit.MitigationTimeout)
+		}
+		if response := rateLimit.Action.Response; response != nil && mode == zoneratelimitsv1.RatelimitObjectAction_Mode_Ban {
+			ruleCreate.ActionParameters = &rulesetsv1.ActionParameters{Response: &rulesetsv1.ActionParametersResponse{
+				Content:     response.Body,
+				ContentType: response.ContentType,
+				StatusCode:  core.Int64Ptr(429),
+			}}
+		}
+	case zoneratelimitsv1.RatelimitObjectAction_Mode_Challenge, zoneratelimitsv1.RatelimitObjectAction_Mode_JsChallenge:
+		ruleCreate.Action = core.StringPtr(mode)
+		ratelimit.MitigationTimeout = core.Int64Ptr(0)
+	default:
+		c.finding(Finding_Severity_Untranslatable, "action mode %q has no ruleset equivalent", mode)
+		return nil, c.findings
+	}
+
+	e := expr.And(conditions...)
+	if err := expr.Check(e); err != nil {
+		c.finding(Finding_Severity_Untranslatable, "the converted expression is invalid: %s", err)
+		return nil, c.findings
+	}
+	ruleCreate.Expression = core.StringPtr(expr.Format(e))
+	return &Rule{Source: c.source, SourceID: c.id, Phase: Phase_HttpRatelimit, RuleCreate: ruleCreate}, c.findings
+}
+
+// urlCondition converts a legacy URL pattern such as *.example.com/api/* to a condition on the host and the path.
+// Query strings are ignored, as legacy rules ignore them. The condition is true for the pattern *.
+func (c *converter) urlCondition(pattern string) expr.Expr {
+	pattern = strings.TrimSpace(pattern)
+	if scheme := strings.Index(pattern, "://"); scheme >= 0 {
+		c.finding(Finding_Severity_Approximation, "the scheme of URL %q is ignored", pattern)
+		pattern = pattern[scheme+3:]
+	}
+	if query := strings.IndexByte(pattern, '?'); query >= 0 {
+		pattern = pattern[:query]
+	}
+	host, path := pattern, ""
+	if slash := strings.IndexByte(pattern, '/'); slash >= 0 {
+		host, path = pattern[:slash], pattern[slash:]
+	}
+
+	var conditions []expr.Expr
+	switch {
+	case host == "" || host == "*":
+	case strings.Contains(host, "*"):
+		conditions = append(conditions, expr.Field("http.host").Wildcard(host))
+	default:
+		conditions = append(conditions, expr.Field("http.host").Eq(strings.ToLower(host)))
+	}
+	switch {
+	case path == "" || path == "*" || path == "/*":
+	case strings.Contains(path, "*"):
+		conditions = append(conditions, expr.Field("http.request.uri.path").StrictWildcard(path))
+	default:
+		conditions = append(conditions, expr.Field("http.request.uri.path").Eq(path))
+	}
+	return expr.And(conditions...)
+}
+
+// nearest returns the accepted value closest to value, preferring the larger one on ties.
+func nearest(accepted []int64, value int64) int64 {
+	best := accepted[0]
+	for _, candidate := range accepted {
+		if abs(candidate-value) <= abs(best-value) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+func abs(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func without(values []string, excluded string) []string {
+	var kept []string
+	for _, value := range values {
+		if value != excluded {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+func description(description *string, fallback string) *string {
+	if description == nil || *description == "" {
+		return core.StringPtr(fallback)
+	}
+	return core.StringPtr(*description)
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+func int64Value(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
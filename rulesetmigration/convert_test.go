@@ -0,0 +1,197 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetmigration_test
+
+import (
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetmigration"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func firewallRule(id string, action string, expression string) firewallrulesv1.FirewallRuleObject {
+	return firewallrulesv1.FirewallRuleObject{
+		ID:     core.StringPtr(id),
+		Paused: core.BoolPtr(false),
+		Action: core.StringPtr(action),
+		Filter: &firewallrulesv1.FirewallRuleObjectFilter{ID: core.StringPtr("filter-" + id), Paused: core.BoolPtr(false), Expression: core.StringPtr(expression)},
+	}
+}
+
+func severities(findings []rulesetmigration.Finding) (result []string) {
+	for _, finding := range findings {
+		result = append(result, finding.Severity)
+	}
+	return
+}
+
+var _ = Describe(`Converters`, func() {
+	Describe(`ConvertFirewallRule(rule)`, func() {
+		It(`Keep the expression and map the action`, func() {
+			legacy := firewallRule("fw-1", "js_challenge", `ip.src.country eq "XX"`)
+			legacy.Description = core.StringPtr("Challenge XX")
+			rule, findings := rulesetmigration.ConvertFirewallRule(&legacy)
+			Expect(findings).To(BeEmpty())
+			Expect(rule.Phase).To(Equal(rulesetmigration.Phase_HttpRequestFirewallCustom))
+			Expect(*rule.RuleCreate.Action).To(Equal("js_challenge"))
+			Expect(*rule.RuleCreate.Expression).To(Equal(`ip.src.country eq "XX"`))
+			Expect(*rule.RuleCreate.Description).To(Equal("Challenge XX"))
+			Expect(*rule.RuleCreate.Enabled).To(BeTrue())
+			Expect(*rule.RuleCreate.Ref).To(Equal("firewall_rule:fw-1"))
+		})
+		It(`Turn allow and bypass into a skip of the custom rules`, func() {
+			legacy := firewallRule("fw-2", "allow", `ip.src in {203.0.113.0/24}`)
+			legacy.Filter.Paused = core.BoolPtr(true)
+			rule, findings := rulesetmigration.ConvertFirewallRule(&legacy)
+			Expect(findings).To(BeEmpty())
+			Expect(*rule.RuleCreate.Action).To(Equal("skip"))
+			Expect(*rule.RuleCreate.ActionParameters.Ruleset).To(Equal("current"))
+			Expect(*rule.RuleCreate.Logging.Enabled).To(BeTrue())
+			Expect(*rule.RuleCreate.Enabled).To(BeFalse())
+			Expect(*rule.RuleCreate.Description).To(Equal("Firewall rule fw-2"))
+
+			legacy = firewallRule("fw-3", "bypass", `ssl`)
+			rule, findings = rulesetmigration.ConvertFirewallRule(&legacy)
+			Expect(*rule.RuleCreate.Action).To(Equal("skip"))
+			Expect(severities(findings)).To(Equal([]string{rulesetmigration.Finding_Severity_Approximation}))
+		})
+		It(`Report what cannot be migrated`, func() {
+			legacy := firewallRule("fw-4", "block", `http.request.uri.path eq`)
+			rule, findings := rulesetmigration.ConvertFirewallRule(&legacy)
+			Expect(rule).ToNot(BeNil())
+			Expect(severities(findings)).To(Equal([]string{rulesetmigration.Finding_Severity_Approximation}))
+
+			legacy = firewallRule("fw-5", "drop", `ssl`)
+			rule, findings = rulesetmigration.ConvertFirewallRule(&legacy)
+			Expect(rule).To(BeNil())
+			Expect(findings[0].String()).To(Equal(`untranslatable firewall_rule fw-5: action "drop" has no ruleset equivalent`))
+		})
+	})
+	Describe(`ConvertLockdown(lockdown)`, func() {
+		It(`Block the URLs outside the allowed addresses`, func() {
+			lockdown := &zonelockdownv1.LockdownObject{
+				ID:     core.StringPtr("ld-1"),
+				Paused: core.BoolPtr(false),
+				Urls:   []string{"www.example.com/admin/*", "*.example.com/login"},
+				Configurations: []zonelockdownv1.LockdownObjectConfigurationsItem{
+					{Target: core.StringPtr("ip"), Value: core.StringPtr("198.51.100.4")},
+					{Target: core.StringPtr("ip_range"), Value: core.StringPtr("203.0.113.0/24")},
+				},
+			}
+			rule, findings := rulesetmigration.ConvertLockdown(lockdown)
+			Expect(findings).To(BeEmpty())
+			Expect(*rule.RuleCreate.Action).To(Equal("block"))
+			Expect(*rule.RuleCreate.Expression).To(Equal(`(http.host eq "www.example.com" and http.request.uri.path strict wildcard "/admin/*" or ` +
+				`http.host wildcard "*.example.com" and http.request.uri.path eq "/login") and not ip.src in {198.51.100.4 203.0.113.0/24}`))
+
+			lockdown.Urls = []string{"*"}
+			rule, _ = rulesetmigration.ConvertLockdown(lockdown)
+			Expect(*rule.RuleCreate.Expression).To(Equal(`not ip.src in {198.51.100.4 203.0.113.0/24}`))
+		})
+	})
+	Describe(`ConvertUserAgentRule(rule)`, func() {
+		It(`Match the exact user agent`, func() {
+			legacy := &useragentblockingrulesv1.UseragentRuleObject{
+				ID:            core.StringPtr("ua-1"),
+				Paused:        core.BoolPtr(true),
+				Mode:          core.StringPtr("challenge"),
+				Configuration: &useragentblockingrulesv1.UseragentRuleObjectConfiguration{Target: core.StringPtr("ua"), Value: core.StringPtr(`Bad "Bot"`)},
+			}
+			rule, findings := rulesetmigration.ConvertUserAgentRule(legacy)
+			Expect(findings).To(BeEmpty())
+			Expect(*rule.RuleCreate.Action).To(Equal("challenge"))
+			Expect(*rule.RuleCreate.Expression).To(Equal(`http.user_agent eq "Bad \"Bot\""`))
+			Expect(*rule.RuleCreate.Enabled).To(BeFalse())
+		})
+	})
+	Describe(`ConvertRateLimit(rateLimit)`, func() {
+		var rateLimit *zoneratelimitsv1.RatelimitObject
+
+		BeforeEach(func() {
+			rateLimit = &zoneratelimitsv1.RatelimitObject{
+				ID:        core.StringPtr("rl-1"),
+				Disabled:  core.BoolPtr(false),
+				Threshold: core.Int64Ptr(50),
+				Period:    core.Int64Ptr(60),
+				Bypass:    []zoneratelimitsv1.RatelimitObjectBypassItem{{Name: core.StringPtr("url"), Value: core.StringPtr("api.example.com/health")}},
+				Action: &zoneratelimitsv1.RatelimitObjectAction{
+					Mode:     core.StringPtr("ban"),
+					Timeout:  core.Int64Ptr(600),
+					Response: &zoneratelimitsv1.RatelimitObjectActionResponse{ContentType: core.StringPtr("text/plain"), Body: core.StringPtr("Slow down")},
+				},
+				Match: &zoneratelimitsv1.RatelimitObjectMatch{
+					Request: &zoneratelimitsv1.RatelimitObjectMatchRequest{
+						Methods: []string{"POST", "PUT"},
+						Schemes: []string{"HTTPS"},
+						URL:     core.StringPtr("api.example.com/*"),
+					},
+				},
+			}
+		})
+
+		It(`Convert the request match, threshold and action`, func() {
+			rule, findings := rulesetmigration.ConvertRateLimit(rateLimit)
+			Expect(findings).To(BeEmpty())
+			Expect(rule.Phase).To(Equal(rulesetmigration.Phase_HttpRatelimit))
+			Expect(*rule.RuleCreate.Expression).To(Equal(`http.host eq "api.example.com" and http.request.method in {"POST" "PUT"} and ssl and ` +
+				`not (http.host eq "api.example.com" and http.request.uri.path eq "/health")`))
+			Expect(*rule.RuleCreate.Action).To(Equal("block"))
+			Expect(*rule.RuleCreate.Ratelimit.Period).To(Equal(int64(60)))
+			Expect(*rule.RuleCreate.Ratelimit.RequestsPerPeriod).To(Equal(int64(50)))
+			Expect(*rule.RuleCreate.Ratelimit.MitigationTimeout).To(Equal(int64(600)))
+			Expect(rule.RuleCreate.Ratelimit.Characteristics).To(Equal([]string{"cf.colo.id", "ip.src"}))
+			Expect(rule.RuleCreate.Ratelimit.CountingExpression).To(BeNil())
+			Expect(*rule.RuleCreate.ActionParameters.Response.StatusCode).To(Equal(int64(429)))
+			Expect(*rule.RuleCreate.ActionParameters.Response.Content).To(Equal("Slow down"))
+		})
+		It(`Count the matching responses only`, func() {
+			rateLimit.Match.Request.Methods = []string{"_ALL_"}
+			rateLimit.Match.Request.Schemes = []string{"_ALL_"}
+			rateLimit.Bypass = nil
+			rateLimit.Match.Response = &zoneratelimitsv1.RatelimitObjectMatchResponse{
+				Status:     []int64{401, 403},
+				HeadersVar: []zoneratelimitsv1.RatelimitObjectMatchResponseHeadersItem{{Name: core.StringPtr("Cf-Cache-Status"), Op: core.StringPtr("ne"), Value: core.StringPtr("HIT")}},
+			}
+			rule, findings := rulesetmigration.ConvertRateLimit(rateLimit)
+			Expect(findings).To(BeEmpty())
+			Expect(*rule.RuleCreate.Expression).To(Equal(`http.host eq "api.example.com"`))
+			Expect(*rule.RuleCreate.Ratelimit.CountingExpression).To(Equal(`http.host eq "api.example.com" and http.response.code in {401 403} and ` +
+				`http.response.headers["cf-cache-status"][0] ne "HIT"`))
+		})
+		It(`Round the period and timeout, and report it`, func() {
+			rateLimit.Period = core.Int64Ptr(30)
+			rateLimit.Action.Mode = core.StringPtr("simulate")
+			rateLimit.Action.Timeout = core.Int64Ptr(1000)
+			rateLimit.Correlate = &zoneratelimitsv1.RatelimitObjectCorrelate{By: core.StringPtr("nat")}
+			rule, findings := rulesetmigration.ConvertRateLimit(rateLimit)
+			Expect(severities(findings)).To(Equal([]string{
+				rulesetmigration.Finding_Severity_Approximation,
+				rulesetmigration.Finding_Severity_Approximation,
+				rulesetmigration.Finding_Severity_Approximation,
+			}))
+			Expect(*rule.RuleCreate.Action).To(Equal("log"))
+			Expect(rule.RuleCreate.ActionParameters).To(BeNil())
+			Expect(*rule.RuleCreate.Ratelimit.Period).To(Equal(int64(10)))
+			Expect(*rule.RuleCreate.Ratelimit.RequestsPerPeriod).To(Equal(int64(17)))
+			Expect(*rule.RuleCreate.Ratelimit.MitigationTimeout).To(Equal(int64(600)))
+		})
+	})
+})
@@ -0,0 +1,542 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rulesetmigration moves the legacy products of a zone to rulesets.
+//
+// Firewall rules (with their filters), zone lockdowns and user agent blocking rules become rules of the
+// http_request_firewall_custom entry point ruleset, and rate limits become rules of the http_ratelimit entry point
+// ruleset. Page rules become rules of the http_request_dynamic_redirect, http_request_cache_settings and
+// http_config_settings entry point rulesets. A Migrator reads the legacy objects through their list APIs, converts
+// them and reports the constructs that cannot be translated exactly. In apply mode it creates the rules, and only
+// once every rule is in place it disables the legacy objects it migrated.
+package rulesetmigration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+)
+
+// DefaultPageSize is the page size used to list the paginated legacy objects.
+const DefaultPageSize = 100
+
+// firewallActionOrder lists the firewall rule actions in the order the rules run.
+var firewallActionOrder = []string{
+	firewallrulesv1.FirewallRuleObject_Action_Log,
+	"bypass",
+	firewallrulesv1.FirewallRuleObject_Action_Allow,
+	firewallrulesv1.FirewallRuleObject_Action_Challenge,
+	firewallrulesv1.FirewallRuleObject_Action_JsChallenge,
+	"managed_challenge",
+	firewallrulesv1.FirewallRuleObject_Action_Block,
+}
+
+// Migrator : Migrates the legacy security products of a zone to its entry point rulesets.
+type Migrator struct {
+	// The firewall rules client. Firewall rules are not migrated when nil.
+	FirewallRules *firewallrulesv1.FirewallRulesV1
+
+	// The filters client, used to report the filters no firewall rule uses. Optional.
+	Filters *filtersv1.FiltersV1
+
+	// The rate limits client. Rate limits are not migrated when nil.
+	RateLimits *zoneratelimitsv1.ZoneRateLimitsV1
+
+	// The zone lockdown client. Zone lockdowns are not migrated when nil.
+	Lockdowns *zonelockdownv1.ZoneLockdownV1
+
+	// The user agent blocking rules client. User agent rules are not migrated when nil.
+	UserAgentRules *useragentblockingrulesv1.UserAgentBlockingRulesV1
+
+	// The page rules client. Page rules are not migrated when nil.
+	PageRules *pageruleapiv1.PageRuleApiV1
+
+	// The rulesets client of the zone.
+	Rulesets *rulesetsv1.RulesetsV1
+
+	// The IAM token, CRN and zone identifier passed to the firewall rules and filters APIs.
+	XAuthUserToken string
+	Crn            string
+	ZoneID         string
+
+	// The page size used to list rate limits, lockdowns and user agent rules. Defaults to DefaultPageSize.
+	PageSize int64
+}
+
+// MigrateOptions : The Migrate options.
+type MigrateOptions struct {
+	// Only convert the legacy objects and report the result, without changing anything.
+	DryRun bool
+
+	// Create the rules but leave the legacy objects enabled, for a staged cutover: once the rules are checked,
+	// running Migrate again without DeployOnly finds them in place and disables the legacy objects.
+	DeployOnly bool
+}
+
+// Report : The result of a migration.
+type Report struct {
+	// The converted rules, in the order they are added to their entry point ruleset.
+	Rules []Rule
+
+	// The constructs that cannot be migrated exactly.
+	Findings []Finding
+
+	// The refs of the rules created by the migration.
+	Created []string
+
+	// The refs of the rules that were already in the entry point rulesets, from an earlier migration.
+	Existing []string
+
+	// The refs of the legacy objects disabled by the migration, as returned by Ref.
+	Disabled []string
+}
+
+// Untranslatable returns the findings of the legacy objects that are not migrated.
+func (report *Report) Untranslatable() (findings []Finding) {
+	for _, finding := range report.Findings {
+		if finding.Severity == Finding_Severity_Untranslatable {
+			findings = append(findings, finding)
+		}
+	}
+	return
+}
+
+// legacy : The legacy objects read from the zone.
+type legacy struct {
+	firewallRules  []firewallrulesv1.FirewallRuleObject
+	filters        []filtersv1.FilterObject
+	rateLimits     []zoneratelimitsv1.RatelimitObject
+	lockdowns      []zonelockdownv1.LockdownObject
+	userAgentRules []useragentblockingrulesv1.UseragentRuleObject
+	pageRules      []pageruleapiv1.PageRuleResult
+}
+
+// NewMigrator : Instantiate Migrator
+// The clients of the legacy products not to migrate may be nil.
+func NewMigrator(rulesets *rulesetsv1.RulesetsV1) (migrator *Migrator, err error) {
+	if rulesets == nil {
+		err = fmt.Errorf("rulesets cannot be nil")
+		return
+	}
+	migrator = &Migrator{Rulesets: rulesets}
+	return
+}
+
+// Migrate : Migrate the legacy products to rulesets
+// Read and convert the legacy objects. Unless DryRun is set, add the converted rules missing from the entry point
+// rulesets, then, unless DeployOnly is set, disable the legacy objects that were migrated. Objects reported as
+// untranslatable are left untouched, as are page rules with an untranslatable action, and nothing is disabled when
+// a rule could not be created. Running Migrate again after a failure resumes the migration.
+func (migrator *Migrator) Migrate(options *MigrateOptions) (report *Report, err error) {
+	return migrator.MigrateWithContext(context.Background(), options)
+}
+
+// MigrateWithContext is an alternate form of the Migrate method which supports a Context parameter
+func (migrator *Migrator) MigrateWithContext(ctx context.Context, options *MigrateOptions) (report *Report, err error) {
+	if migrator.Rulesets == nil {
+		err = fmt.Errorf("the rulesets client cannot be nil")
+		return
+	}
+	if options == nil {
+		options = &MigrateOptions{}
+	}
+	if (migrator.FirewallRules != nil || migrator.Filters != nil) && (migrator.XAuthUserToken == "" || migrator.Crn == "" || migrator.ZoneID == "") {
+		err = fmt.Errorf("XAuthUserToken, Crn and ZoneID are required to migrate firewall rules")
+		return
+	}
+
+	objects, err := migrator.read(ctx)
+	if err != nil {
+		return
+	}
+	report = Convert(objects.firewallRules, objects.filters, objects.rateLimits, objects.lockdowns, objects.userAgentRules)
+	if len(objects.pageRules) > 0 {
+		pageRules := ConvertPageRules(objects.pageRules)
+		report.Rules = append(report.Rules, pageRules.Rules...)
+		report.Findings = append(report.Findings, pageRules.Findings...)
+	}
+	if options.DryRun {
+		return
+	}
+
+	for _, phase := range []string{Phase_HttpRequestFirewallCustom, Phase_HttpRatelimit, Phase_HttpRequestDynamicRedirect,
+		Phase_HttpRequestCacheSettings, Phase_HttpConfigSettings} {
+		err = migrator.apply(ctx, report, phase)
+		if err != nil {
+			return
+		}
+	}
+	if options.DeployOnly {
+		return
+	}
+	err = migrator.disable(ctx, report, objects)
+	return
+}
+
+// Convert converts legacy objects to ruleset rules. Firewall rules come first, in the order of their actions,
+// followed by zone lockdowns by priority and user agent rules. Filters are only used to report the ones that no
+// firewall rule uses.
+func Convert(firewallRules []firewallrulesv1.FirewallRuleObject, filters []filtersv1.FilterObject, rateLimits []zoneratelimitsv1.RatelimitObject,
+	lockdowns []zonelockdownv1.LockdownObject, userAgentRules []useragentblockingrulesv1.UseragentRuleObject) *Report {
+	report := &Report{}
+	add := func(rule *Rule, findings []Finding) {
+		if rule != nil {
+			report.Rules = append(report.Rules, *rule)
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	firewallRules = append([]firewallrulesv1.FirewallRuleObject(nil), firewallRules...)
+	sort.SliceStable(firewallRules, func(i, j int) bool {
+		return firewallActionRank(firewallRules[i].Action) < firewallActionRank(firewallRules[j].Action)
+	})
+	usedFilters := map[string]bool{}
+	for i := range firewallRules {
+		if firewallRules[i].Filter != nil {
+			usedFilters[core.StringNilMapper(firewallRules[i].Filter.ID)] = true
+		}
+		add(ConvertFirewallRule(&firewallRules[i]))
+	}
+	for _, filter := range filters {
+		if !usedFilters[core.StringNilMapper(filter.ID)] {
+			report.Findings = append(report.Findings, Finding{
+				Source:   Source_Filter,
+				ID:       core.StringNilMapper(filter.ID),
+				Severity: Finding_Severity_Info,
+				Message:  "the filter is not used by any firewall rule and is not migrated",
+			})
+		}
+	}
+
+	lockdowns = append([]zonelockdownv1.LockdownObject(nil), lockdowns...)
+	sort.SliceStable(lockdowns, func(i, j int) bool {
+		return int64Value(lockdowns[i].Priority) < int64Value(lockdowns[j].Priority)
+	})
+	for i := range lockdowns {
+		add(ConvertLockdown(&lockdowns[i]))
+	}
+	for i := range userAgentRules {
+		add(ConvertUserAgentRule(&userAgentRules[i]))
+	}
+	for i := range rateLimits {
+		add(ConvertRateLimit(&rateLimits[i]))
+	}
+	return report
+}
+
+func firewallActionRank(action *string) int {
+	for rank, ordered := range firewallActionOrder {
+		if ordered == core.StringNilMapper(action) {
+			return rank
+		}
+	}
+	return len(firewallActionOrder)
+}
+
+func (migrator *Migrator) pageSize() int64 {
+	if migrator.PageSize > 0 {
+		return migrator.PageSize
+	}
+	return DefaultPageSize
+}
+
+// read lists the legacy objects of the products that have a client.
+func (migrator *Migrator) read(ctx context.Context) (objects *legacy, err error) {
+	objects = &legacy{}
+	if migrator.FirewallRules != nil {
+		options := migrator.FirewallRules.NewListAllFirewallRulesOptions(migrator.XAuthUserToken, migrator.Crn, migrator.ZoneID)
+		result, _, listErr := migrator.FirewallRules.ListAllFirewallRulesWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing firewall rules: %w", listErr)
+			return
+		}
+		objects.firewallRules = result.Result
+	}
+	if migrator.Filters != nil {
+		options := migrator.Filters.NewListAllFiltersOptions(migrator.XAuthUserToken, migrator.Crn, migrator.ZoneID)
+		result, _, listErr := migrator.Filters.ListAllFiltersWithContext(ctx, options)
+		if listErr != nil {
+			err = fmt.Errorf("listing filters: %w", listErr)
+			return
+		}
+		objects.filters = result.Result
+	}
+	if migrator.RateLimits != nil {
+		for page := int64(1); ; page++ {
+			options := migrator.RateLimits.NewListAllZoneRateLimitsOptions().SetPage(page).SetPerPage(migrator.pageSize())
+			result, _, listErr := migrator.RateLimits.ListAllZoneRateLimitsWithContext(ctx, options)
+			if listErr != nil {
+				err = fmt.Errorf("listing rate limits: %w", listErr)
+				return
+			}
+			objects.rateLimits = append(objects.rateLimits, result.Result...)
+			if len(result.Result) == 0 || result.ResultInfo == nil || int64(len(objects.rateLimits)) >= int64Value(result.ResultInfo.TotalCount) {
+				break
+			}
+		}
+	}
+	if migrator.Lockdowns != nil {
+		for page := int64(1); ; page++ {
+			options := migrator.Lockdowns.NewListAllZoneLockownRulesOptions().SetPage(page).SetPerPage(migrator.pageSize())
+			result, _, listErr := migrator.Lockdowns.ListAllZoneLockownRulesWithContext(ctx, options)
+			if listErr != nil {
+				err = fmt.Errorf("listing zone lockdowns: %w", listErr)
+				return
+			}
+			objects.lockdowns = append(objects.lockdowns, result.Result...)
+			if len(result.Result) == 0 || result.ResultInfo == nil || int64(len(objects.lockdowns)) >= int64Value(result.ResultInfo.TotalCount) {
+				break
+			}
+		}
+	}
+	if migrator.UserAgentRules != nil {
+		for page := int64(1); ; page++ {
+			options := migrator.UserAgentRules.NewListAllZoneUserAgentRulesOptions().SetPage(page).SetPerPage(migrator.pageSize())
+			result, _, listErr := migrator.UserAgentRules.ListAllZoneUserAgentRulesWithContext(ctx, options)
+			if listErr != nil {
+				err = fmt.Errorf("listing user agent rules: %w", listErr)
+				return
+			}
+			objects.userAgentRules = append(objects.userAgentRules, result.Result...)
+			if len(result.Result) == 0 || result.ResultInfo == nil || int64(len(objects.userAgentRules)) >= int64Value(result.ResultInfo.TotalCount) {
+				break
+			}
+		}
+	}
+	if migrator.PageRules != nil {
+		result, _, listErr := migrator.PageRules.ListPageRulesWithContext(ctx, migrator.PageRules.NewListPageRulesOptions())
+		if listErr != nil {
+			err = fmt.Errorf("listing page rules: %w", listErr)
+			return
+		}
+		objects.pageRules = result.Result
+	}
+	return
+}
+
+// apply adds the rules of a phase that are missing from its entry point ruleset. The entry point ruleset is
+// created with the rules when the zone has none.
+func (migrator *Migrator) apply(ctx context.Context, report *Report, phase string) (err error) {
+	var rules []Rule
+	for _, rule := range report.Rules {
+		if rule.Phase == phase {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	result, response, err := migrator.Rulesets.GetZoneEntrypointRulesetWithContext(ctx, migrator.Rulesets.NewGetZoneEntrypointRulesetOptions(phase))
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		err = fmt.Errorf("getting the %s entry point ruleset: %w", phase, err)
+		return
+	}
+	if err != nil {
+		options := migrator.Rulesets.NewUpdateZoneEntrypointRulesetOptions(phase)
+		options.SetName("default").SetKind(rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Zone).SetPhase(phase)
+		for _, rule := range rules {
+			options.Rules = append(options.Rules, *rule.RuleCreate)
+		}
+		_, _, err = migrator.Rulesets.UpdateZoneEntrypointRulesetWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("creating the %s entry point ruleset: %w", phase, err)
+			return
+		}
+		for _, rule := range rules {
+			report.Created = append(report.Created, *rule.RuleCreate.Ref)
+		}
+		return
+	}
+
+	existing := map[string]bool{}
+	if result.Result != nil {
+		for _, rule := range result.Result.Rules {
+			existing[core.StringNilMapper(rule.Ref)] = true
+		}
+	}
+	for _, rule := range rules {
+		ref := *rule.RuleCreate.Ref
+		if existing[ref] {
+			report.Existing = append(report.Existing, ref)
+			continue
+		}
+		options := migrator.Rulesets.NewCreateZoneRulesetRuleOptions(*result.Result.ID)
+		options.Action = rule.RuleCreate.Action
+		options.ActionParameters = rule.RuleCreate.ActionParameters
+		options.Ratelimit = rule.RuleCreate.Ratelimit
+		options.Description = rule.RuleCreate.Description
+		options.Enabled = rule.RuleCreate.Enabled
+		options.Expression = rule.RuleCreate.Expression
+		options.Logging = rule.RuleCreate.Logging
+		options.Ref = rule.RuleCreate.Ref
+		_, _, err = migrator.Rulesets.CreateZoneRulesetRuleWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("creating rule %s: %w", ref, err)
+			return
+		}
+		report.Created = append(report.Created, ref)
+	}
+	return
+}
+
+// disable pauses or disables the legacy objects that were migrated. The update APIs replace the whole object, so
+// every other property is sent back unchanged.
+func (migrator *Migrator) disable(ctx context.Context, report *Report, objects *legacy) (err error) {
+	migrated := map[string]bool{}
+	for _, rule := range report.Rules {
+		migrated[Ref(rule.Source, rule.SourceID)] = true
+	}
+
+	for _, rule := range objects.firewallRules {
+		ref := Ref(Source_FirewallRule, core.StringNilMapper(rule.ID))
+		if !migrated[ref] || boolValue(rule.Paused) {
+			continue
+		}
+		options := migrator.FirewallRules.NewUpdateFirewallRuleOptions(migrator.XAuthUserToken, migrator.Crn, migrator.ZoneID, *rule.ID)
+		options.SetPaused(true)
+		options.Action = rule.Action
+		options.Description = rule.Description
+		options.Filter = &firewallrulesv1.FirewallRuleUpdateInputFilter{ID: rule.Filter.ID}
+		_, _, err = migrator.FirewallRules.UpdateFirewallRuleWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("pausing firewall rule %s: %w", *rule.ID, err)
+			return
+		}
+		report.Disabled = append(report.Disabled, ref)
+	}
+	for _, lockdown := range objects.lockdowns {
+		ref := Ref(Source_Lockdown, core.StringNilMapper(lockdown.ID))
+		if !migrated[ref] || boolValue(lockdown.Paused) {
+			continue
+		}
+		options := migrator.Lockdowns.NewUpdateLockdownRuleOptions(*lockdown.ID)
+		options.SetPaused(true)
+		options.Description = lockdown.Description
+		options.Urls = lockdown.Urls
+		options.Priority = lockdown.Priority
+		for _, configuration := range lockdown.Configurations {
+			options.Configurations = append(options.Configurations, zonelockdownv1.LockdownInputConfigurationsItem{
+				Target: configuration.Target,
+				Value:  configuration.Value,
+			})
+		}
+		_, _, err = migrator.Lockdowns.UpdateLockdownRuleWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("pausing zone lockdown %s: %w", *lockdown.ID, err)
+			return
+		}
+		report.Disabled = append(report.Disabled, ref)
+	}
+	for _, rule := range objects.userAgentRules {
+		ref := Ref(Source_UserAgentRule, core.StringNilMapper(rule.ID))
+		if !migrated[ref] || boolValue(rule.Paused) {
+			continue
+		}
+		options := migrator.UserAgentRules.NewUpdateUserAgentRuleOptions(*rule.ID)
+		options.SetPaused(true)
+		options.Description = rule.Description
+		options.Mode = rule.Mode
+		options.Configuration = &useragentblockingrulesv1.UseragentRuleInputConfiguration{
+			Target: rule.Configuration.Target,
+			Value:  rule.Configuration.Value,
+		}
+		_, _, err = migrator.UserAgentRules.UpdateUserAgentRuleWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("pausing user agent rule %s: %w", *rule.ID, err)
+			return
+		}
+		report.Disabled = append(report.Disabled, ref)
+	}
+	for _, rateLimit := range objects.rateLimits {
+		ref := Ref(Source_RateLimit, core.StringNilMapper(rateLimit.ID))
+		if !migrated[ref] || boolValue(rateLimit.Disabled) {
+			continue
+		}
+		options := migrator.RateLimits.NewUpdateRateLimitOptions(*rateLimit.ID)
+		err = convertRateLimitInput(&rateLimit, options)
+		if err != nil {
+			err = fmt.Errorf("disabling rate limit %s: %w", *rateLimit.ID, err)
+			return
+		}
+		options.SetDisabled(true)
+		_, _, err = migrator.RateLimits.UpdateRateLimitWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("disabling rate limit %s: %w", *rateLimit.ID, err)
+			return
+		}
+		report.Disabled = append(report.Disabled, ref)
+	}
+
+	// A page rule is converted even when some of its actions are not, and must then stay active.
+	partial := map[string]bool{}
+	for _, finding := range report.Untranslatable() {
+		partial[Ref(finding.Source, finding.ID)] = true
+	}
+	for _, rule := range objects.pageRules {
+		ref := Ref(Source_PageRule, core.StringNilMapper(rule.ID))
+		if !migrated[ref] || partial[ref] || core.StringNilMapper(rule.Status) != pageruleapiv1.CreatePageRuleOptions_Status_Active {
+			continue
+		}
+		options := migrator.PageRules.NewChangePageRuleOptions(*rule.ID)
+		options.SetStatus(pageruleapiv1.CreatePageRuleOptions_Status_Disabled)
+		_, _, err = migrator.PageRules.ChangePageRuleWithContext(ctx, options)
+		if err != nil {
+			err = fmt.Errorf("pausing page rule %s: %w", *rule.ID, err)
+			return
+		}
+		report.Disabled = append(report.Disabled, ref)
+	}
+	return
+}
+
+// convertRateLimitInput copies a rate limit to the update options. The input models have the same JSON shape as
+// the rate limit object.
+func convertRateLimitInput(rateLimit *zoneratelimitsv1.RatelimitObject, options *zoneratelimitsv1.UpdateRateLimitOptions) error {
+	buffer, err := json.Marshal(rateLimit)
+	if err != nil {
+		return err
+	}
+	var input struct {
+		Bypass    []zoneratelimitsv1.RatelimitInputBypassItem `json:"bypass"`
+		Action    *zoneratelimitsv1.RatelimitInputAction      `json:"action"`
+		Correlate *zoneratelimitsv1.RatelimitInputCorrelate   `json:"correlate"`
+		Match     *zoneratelimitsv1.RatelimitInputMatch       `json:"match"`
+	}
+	err = json.Unmarshal(buffer, &input)
+	if err != nil {
+		return err
+	}
+	options.Description = rateLimit.Description
+	options.Threshold = rateLimit.Threshold
+	options.Period = rateLimit.Period
+	options.Bypass = input.Bypass
+	options.Action = input.Action
+	options.Correlate = input.Correlate
+	options.Match = input.Match
+	return nil
+}
@@ -0,0 +1,276 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetmigration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetmigration"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const zonePath = "/v1/crn-1/zones/zone-1"
+
+// fakeZone serves the legacy products and the entry point rulesets of a zone.
+type fakeZone struct {
+	sync.Mutex
+	entrypoints map[string]map[string]interface{}
+	failCreate  bool
+	updates     map[string]map[string]interface{}
+	requests    []string
+}
+
+func newFakeZone() *fakeZone {
+	return &fakeZone{
+		entrypoints: map[string]map[string]interface{}{
+			"http_ratelimit": {"id": "rs-ratelimit", "phase": "http_ratelimit", "rules": []interface{}{
+				map[string]interface{}{"id": "r-1", "ref": "rate_limit:rl-1", "action": "block", "expression": "true"},
+			}},
+		},
+		updates: map[string]map[string]interface{}{},
+	}
+}
+
+func (zone *fakeZone) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	zone.Lock()
+	defer zone.Unlock()
+
+	path := strings.TrimPrefix(req.URL.EscapedPath(), zonePath)
+	zone.requests = append(zone.requests, req.Method+" "+path)
+	var body map[string]interface{}
+	if req.Method == "PUT" || req.Method == "POST" || req.Method == "PATCH" {
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+	}
+	switch {
+	case req.Method == "GET" && path == "/firewall/rules":
+		zone.reply(res, 200, map[string]interface{}{"result": []interface{}{
+			map[string]interface{}{"id": "fw-block", "paused": false, "action": "block", "filter": map[string]interface{}{"id": "f-1", "paused": false, "expression": `http.request.uri.path eq "/wp-login.php"`}},
+			map[string]interface{}{"id": "fw-allow", "paused": false, "action": "allow", "filter": map[string]interface{}{"id": "f-2", "paused": false, "expression": `ip.src in {203.0.113.0/24}`}},
+			map[string]interface{}{"id": "fw-drop", "paused": false, "action": "drop", "filter": map[string]interface{}{"id": "f-3", "paused": false, "expression": `ssl`}},
+		}})
+	case req.Method == "GET" && path == "/filters":
+		zone.reply(res, 200, map[string]interface{}{"result": []interface{}{
+			map[string]interface{}{"id": "f-1", "paused": false, "expression": `http.request.uri.path eq "/wp-login.php"`},
+			map[string]interface{}{"id": "f-orphan", "paused": false, "expression": `ssl`},
+		}})
+	case req.Method == "GET" && path == "/firewall/lockdowns":
+		Expect(req.URL.Query().Get("per_page")).To(Equal("1"))
+		lockdowns := []interface{}{
+			map[string]interface{}{"id": "ld-1", "paused": false, "priority": 2, "urls": []string{"example.com/admin/*"},
+				"configurations": []interface{}{map[string]interface{}{"target": "ip", "value": "198.51.100.4"}}},
+			map[string]interface{}{"id": "ld-2", "paused": true, "priority": 1, "urls": []string{"example.com/private"},
+				"configurations": []interface{}{map[string]interface{}{"target": "ip_range", "value": "192.0.2.0/24"}}},
+		}
+		page := 0
+		if req.URL.Query().Get("page") == "2" {
+			page = 1
+		}
+		zone.reply(res, 200, map[string]interface{}{"result": lockdowns[page : page+1], "result_info": map[string]interface{}{"page": page + 1, "per_page": 1, "count": 1, "total_count": 2}})
+	case req.Method == "GET" && path == "/firewall/ua_rules":
+		zone.reply(res, 200, map[string]interface{}{"result": []interface{}{
+			map[string]interface{}{"id": "ua-1", "paused": false, "mode": "block", "configuration": map[string]interface{}{"target": "ua", "value": "BadBot/1.0"}},
+		}, "result_info": map[string]interface{}{"page": 1, "per_page": 1, "count": 1, "total_count": 1}})
+	case req.Method == "GET" && path == "/rate_limits":
+		zone.reply(res, 200, map[string]interface{}{"result": []interface{}{
+			map[string]interface{}{"id": "rl-1", "disabled": false, "threshold": 10, "period": 60,
+				"action": map[string]interface{}{"mode": "ban", "timeout": 60},
+				"match":  map[string]interface{}{"request": map[string]interface{}{"url": "*/api/*"}}},
+			map[string]interface{}{"id": "rl-2", "disabled": false, "threshold": 100, "period": 60,
+				"action": map[string]interface{}{"mode": "challenge"},
+				"match":  map[string]interface{}{"request": map[string]interface{}{"url": "*/login", "methods": []string{"POST"}}}},
+		}, "result_info": map[string]interface{}{"page": 1, "per_page": 1, "count": 2, "total_count": 2}})
+	case req.Method == "GET" && path == "/pagerules":
+		zone.reply(res, 200, map[string]interface{}{"result": []interface{}{
+			map[string]interface{}{"id": "pr-forward", "status": "active", "priority": 3,
+				"targets": []interface{}{map[string]interface{}{"target": "url", "constraint": map[string]interface{}{"operator": "matches", "value": "example.com/old/*"}}},
+				"actions": []interface{}{map[string]interface{}{"id": "forwarding_url", "value": map[string]interface{}{"url": "https://example.com/new/$1", "status_code": 301}}}},
+			map[string]interface{}{"id": "pr-static", "status": "active", "priority": 2,
+				"targets": []interface{}{map[string]interface{}{"target": "url", "constraint": map[string]interface{}{"operator": "matches", "value": "*example.com/static/*"}}},
+				"actions": []interface{}{map[string]interface{}{"id": "cache_level", "value": "cache_everything"}, map[string]interface{}{"id": "edge_cache_ttl", "value": 7200}}},
+			map[string]interface{}{"id": "pr-all", "status": "active", "priority": 1,
+				"targets": []interface{}{map[string]interface{}{"target": "url", "constraint": map[string]interface{}{"operator": "matches", "value": "*example.com/*"}}},
+				"actions": []interface{}{map[string]interface{}{"id": "ssl", "value": "strict"}, map[string]interface{}{"id": "always_online", "value": "on"}}},
+		}})
+	case req.Method == "PATCH":
+		zone.updates[path] = body
+		zone.reply(res, 200, map[string]interface{}{"result": body})
+	case req.Method == "GET" && strings.HasPrefix(path, "/rulesets/phases/"):
+		phase := strings.TrimSuffix(strings.TrimPrefix(path, "/rulesets/phases/"), "/entrypoint")
+		if entrypoint, ok := zone.entrypoints[phase]; ok {
+			zone.reply(res, 200, map[string]interface{}{"result": entrypoint})
+			return
+		}
+		zone.reply(res, 404, map[string]interface{}{"errors": []interface{}{map[string]interface{}{"code": 10003, "message": "not found"}}})
+	case req.Method == "PUT" && strings.HasPrefix(path, "/rulesets/phases/"):
+		phase := strings.TrimSuffix(strings.TrimPrefix(path, "/rulesets/phases/"), "/entrypoint")
+		body["id"] = "rs-" + phase
+		zone.entrypoints[phase] = body
+		zone.reply(res, 200, map[string]interface{}{"result": body})
+	case req.Method == "POST" && path == "/rulesets/rs-ratelimit/rules":
+		if zone.failCreate {
+			zone.reply(res, 500, map[string]interface{}{"errors": []interface{}{map[string]interface{}{"code": 10000, "message": "internal error"}}})
+			return
+		}
+		entrypoint := zone.entrypoints["http_ratelimit"]
+		entrypoint["rules"] = append(entrypoint["rules"].([]interface{}), body)
+		zone.reply(res, 200, map[string]interface{}{"result": entrypoint})
+	case req.Method == "PUT":
+		zone.updates[path] = body
+		zone.reply(res, 200, map[string]interface{}{"result": body})
+	default:
+		zone.reply(res, 404, map[string]interface{}{})
+	}
+}
+
+func (zone *fakeZone) reply(res http.ResponseWriter, status int, body map[string]interface{}) {
+	body["success"] = status < 300
+	if body["errors"] == nil {
+		body["errors"] = []interface{}{}
+	}
+	body["messages"] = []interface{}{}
+	res.Header().Set("Content-type", "application/json")
+	res.WriteHeader(status)
+	Expect(json.NewEncoder(res).Encode(body)).To(Succeed())
+}
+
+func (zone *fakeZone) writes() (writes []string) {
+	for _, request := range zone.requests {
+		if !strings.HasPrefix(request, "GET ") {
+			writes = append(writes, request)
+		}
+	}
+	return
+}
+
+func refs(rules []rulesetmigration.Rule) (result []string) {
+	for _, rule := range rules {
+		result = append(result, *rule.RuleCreate.Ref)
+	}
+	return
+}
+
+var _ = Describe(`Migrator`, func() {
+	var zone *fakeZone
+	var server *httptest.Server
+	var migrator *rulesetmigration.Migrator
+
+	BeforeEach(func() {
+		zone = newFakeZone()
+		server = httptest.NewServer(zone)
+		authenticator := &core.NoAuthAuthenticator{}
+		crn, zoneID := core.StringPtr("crn-1"), core.StringPtr("zone-1")
+
+		rulesets, err := rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{URL: server.URL, Authenticator: authenticator, Crn: crn, ZoneIdentifier: zoneID})
+		Expect(err).To(BeNil())
+		migrator, err = rulesetmigration.NewMigrator(rulesets)
+		Expect(err).To(BeNil())
+		migrator.FirewallRules, err = firewallrulesv1.NewFirewallRulesV1(&firewallrulesv1.FirewallRulesV1Options{URL: server.URL, Authenticator: authenticator})
+		Expect(err).To(BeNil())
+		migrator.Filters, err = filtersv1.NewFiltersV1(&filtersv1.FiltersV1Options{URL: server.URL, Authenticator: authenticator})
+		Expect(err).To(BeNil())
+		migrator.RateLimits, err = zoneratelimitsv1.NewZoneRateLimitsV1(&zoneratelimitsv1.ZoneRateLimitsV1Options{URL: server.URL, Authenticator: authenticator, Crn: crn, ZoneIdentifier: zoneID})
+		Expect(err).To(BeNil())
+		migrator.Lockdowns, err = zonelockdownv1.NewZoneLockdownV1(&zonelockdownv1.ZoneLockdownV1Options{URL: server.URL, Authenticator: authenticator, Crn: crn, ZoneIdentifier: zoneID})
+		Expect(err).To(BeNil())
+		migrator.UserAgentRules, err = useragentblockingrulesv1.NewUserAgentBlockingRulesV1(&useragentblockingrulesv1.UserAgentBlockingRulesV1Options{URL: server.URL, Authenticator: authenticator, Crn: crn, ZoneIdentifier: zoneID})
+		Expect(err).To(BeNil())
+		migrator.XAuthUserToken, migrator.Crn, migrator.ZoneID = "token", "crn-1", "zone-1"
+		migrator.PageSize = 1
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Report the conversion without changes in dry-run mode`, func() {
+		report, err := migrator.Migrate(&rulesetmigration.MigrateOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(zone.writes()).To(BeEmpty())
+		Expect(refs(report.Rules)).To(Equal([]string{
+			"firewall_rule:fw-allow", "firewall_rule:fw-block", "lockdown:ld-2", "lockdown:ld-1", "user_agent_rule:ua-1", "rate_limit:rl-1", "rate_limit:rl-2",
+		}))
+		Expect(report.Untranslatable()).To(HaveLen(1))
+		Expect(report.Untranslatable()[0].ID).To(Equal("fw-drop"))
+		Expect(report.Findings).To(ContainElement(rulesetmigration.Finding{
+			Source:   rulesetmigration.Source_Filter,
+			ID:       "f-orphan",
+			Severity: rulesetmigration.Finding_Severity_Info,
+			Message:  "the filter is not used by any firewall rule and is not migrated",
+		}))
+		Expect(report.Created).To(BeEmpty())
+		Expect(report.Disabled).To(BeEmpty())
+	})
+	It(`Create the rules before disabling the legacy objects`, func() {
+		report, err := migrator.Migrate(nil)
+		Expect(err).To(BeNil())
+		Expect(report.Created).To(Equal([]string{"firewall_rule:fw-allow", "firewall_rule:fw-block", "lockdown:ld-2", "lockdown:ld-1", "user_agent_rule:ua-1", "rate_limit:rl-2"}))
+		Expect(report.Existing).To(Equal([]string{"rate_limit:rl-1"}))
+		Expect(report.Disabled).To(Equal([]string{"firewall_rule:fw-block", "firewall_rule:fw-allow", "lockdown:ld-1", "user_agent_rule:ua-1", "rate_limit:rl-1", "rate_limit:rl-2"}))
+		Expect(zone.writes()).To(Equal([]string{
+			"PUT /rulesets/phases/http_request_firewall_custom/entrypoint",
+			"POST /rulesets/rs-ratelimit/rules",
+			"PUT /firewall/rules/fw-block",
+			"PUT /firewall/rules/fw-allow",
+			"PUT /firewall/lockdowns/ld-1",
+			"PUT /firewall/ua_rules/ua-1",
+			"PUT /rate_limits/rl-1",
+			"PUT /rate_limits/rl-2",
+		}))
+
+		custom := zone.entrypoints["http_request_firewall_custom"]
+		Expect(custom["kind"]).To(Equal("zone"))
+		Expect(custom["rules"]).To(HaveLen(5))
+		Expect(zone.updates["/firewall/rules/fw-block"]).To(Equal(map[string]interface{}{
+			"action": "block", "paused": true, "filter": map[string]interface{}{"id": "f-1"},
+		}))
+		Expect(zone.updates["/firewall/lockdowns/ld-1"]["paused"]).To(BeTrue())
+		Expect(zone.updates["/firewall/lockdowns/ld-1"]["urls"]).To(Equal([]interface{}{"example.com/admin/*"}))
+		Expect(zone.updates["/rate_limits/rl-2"]["disabled"]).To(BeTrue())
+		Expect(zone.updates["/rate_limits/rl-2"]["match"]).To(Equal(map[string]interface{}{
+			"request": map[string]interface{}{"url": "*/login", "methods": []interface{}{"POST"}},
+		}))
+	})
+	It(`Leave the legacy objects in place when a rule cannot be created`, func() {
+		zone.failCreate = true
+		_, err := migrator.Migrate(&rulesetmigration.MigrateOptions{})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("creating rule rate_limit:rl-2: "))
+		for _, write := range zone.writes() {
+			Expect(write).To(ContainSubstring("/rulesets/"))
+		}
+	})
+	It(`Require the firewall rules parameters`, func() {
+		migrator.ZoneID = ""
+		_, err := migrator.Migrate(&rulesetmigration.MigrateOptions{DryRun: true})
+		Expect(err).ToNot(BeNil())
+
+		_, err = rulesetmigration.NewMigrator(nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
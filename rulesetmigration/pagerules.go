@@ -0,0 +1,334 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetmigration
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/expr"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+)
+
+// configSettings maps the on and off page rule actions to the settings of the set_config action.
+var configSettings = map[string]string{
+	pageruleapiv1.PageRulesBodyActionsItem_ID_AutomaticHttpsRewrites:  "automatic_https_rewrites",
+	pageruleapiv1.PageRulesBodyActionsItem_ID_BrowserCheck:            "bic",
+	pageruleapiv1.PageRulesBodyActionsItem_ID_EmailObfuscation:        "email_obfuscation",
+	pageruleapiv1.PageRulesBodyActionsItem_ID_OpportunisticEncryption: "opportunistic_encryption",
+	pageruleapiv1.PageRulesBodyActionsItem_ID_ServerSideExclude:       "server_side_excludes",
+}
+
+// placeholder matches the $1 to $9 references of a forwarding URL to the wildcards of the URL pattern.
+var placeholder = regexp.MustCompile(`\$([1-9])`)
+
+// pageRuleAction : A page rule action with its value still encoded. Actions listed by the API and actions built
+// from the typed models have the same JSON shape.
+type pageRuleAction struct {
+	ID    string          `json:"id"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ConvertPageRules converts the page rules of a zone. Only the first matching page rule applies to a request, while
+// every matching settings rule applies, so the rules of a page rule exclude the URLs of the active page rules of
+// higher priority that overlap its pattern. The settings rules are still added by ascending priority and the
+// redirect rules by descending priority. Page rules that never apply because a rule of higher priority matches all
+// their URLs are reported.
+func ConvertPageRules(rules []pageruleapiv1.PageRuleResult) *Report {
+	report := &Report{}
+	set := pageruleapiv1.NewPageRuleSet(rules)
+	ordered := set.Rules()
+
+	var redirects, settings []Rule
+	for i := len(ordered) - 1; i >= 0; i-- {
+		converted, findings := convertPageRule(&ordered[i], set.Preceding(i))
+		for _, rule := range converted {
+			if rule.Phase == Phase_HttpRequestDynamicRedirect {
+				redirects = append([]Rule{rule}, redirects...)
+			} else {
+				settings = append(settings, rule)
+			}
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+	report.Rules = append(settings, redirects...)
+
+	for _, finding := range set.Analyze() {
+		if finding.Kind == pageruleapiv1.PageRuleFinding_Kind_Shadowed {
+			report.Findings = append(report.Findings, Finding{
+				Source:   Source_PageRule,
+				ID:       finding.RuleID,
+				Severity: Finding_Severity_Info,
+				Message:  fmt.Sprintf("the page rule never applies as page rule %s matches all its URLs", finding.By),
+			})
+		}
+	}
+	return report
+}
+
+// ConvertPageRule converts a page rule to the rules of the phases its actions move to: forwarding_url and
+// always_use_https become a redirect rule of the http_request_dynamic_redirect phase, the cache actions a rule of
+// the http_request_cache_settings phase and the other settings a rule of the http_config_settings phase. Actions
+// with no ruleset equivalent are reported as untranslatable; the other actions of the rule are still converted.
+func ConvertPageRule(rule *pageruleapiv1.PageRuleResult) (rules []Rule, findings []Finding) {
+	return convertPageRule(rule, nil)
+}
+
+// convertPageRule converts a page rule, leaving out the URLs of the page rules that apply before it.
+func convertPageRule(rule *pageruleapiv1.PageRuleResult, preceding []pageruleapiv1.PageRuleResult) (rules []Rule, findings []Finding) {
+	c := &converter{source: Source_PageRule, id: core.StringNilMapper(rule.ID)}
+	pattern := pageruleapiv1.PageRuleURL(*rule)
+	if strings.TrimSpace(pattern) == "" {
+		c.finding(Finding_Severity_Untranslatable, "the page rule has no URL target")
+		return nil, c.findings
+	}
+	condition := c.pageRuleCondition(pattern)
+	var excluded []expr.Expr
+	for i := range preceding {
+		if other := pageruleapiv1.PageRuleURL(preceding[i]); strings.TrimSpace(other) != "" {
+			// The findings about the pattern of the other rule are reported with that rule.
+			excluded = append(excluded, (&converter{}).pageRuleCondition(other))
+		}
+	}
+	if len(excluded) > 0 {
+		condition = expr.And(condition, expr.Not(expr.Or(excluded...)))
+	}
+	if err := expr.Check(condition); err != nil {
+		c.finding(Finding_Severity_Untranslatable, "the converted expression is invalid: %s", err)
+		return nil, c.findings
+	}
+	buffer, err := json.Marshal(rule.Actions)
+	var actions []pageRuleAction
+	if err == nil {
+		err = json.Unmarshal(buffer, &actions)
+	}
+	if err != nil {
+		c.finding(Finding_Severity_Untranslatable, "the actions cannot be read: %s", err)
+		return nil, c.findings
+	}
+
+	enabled := core.StringNilMapper(rule.Status) == pageruleapiv1.CreatePageRuleOptions_Status_Active
+	newRule := func(phase string, action string, ref string, e expr.Expr, parameters *rulesetsv1.ActionParameters) Rule {
+		return Rule{Source: c.source, SourceID: c.id, Phase: phase, RuleCreate: &rulesetsv1.RuleCreate{
+			Action:           core.StringPtr(action),
+			ActionParameters: parameters,
+			Expression:       core.StringPtr(expr.Format(e)),
+			Description:      core.StringPtr(fmt.Sprintf("Page rule %s: %s", c.id, pattern)),
+			Enabled:          core.BoolPtr(enabled),
+			Ref:              core.StringPtr(ref),
+		}}
+	}
+	var cache, config *rulesetsv1.ActionParameters
+	cacheSettings := func() *rulesetsv1.ActionParameters {
+		if cache == nil {
+			cache = &rulesetsv1.ActionParameters{}
+		}
+		return cache
+	}
+	cacheKey := func() *rulesetsv1.ActionParametersCacheKey {
+		if cacheSettings().CacheKey == nil {
+			cache.CacheKey = &rulesetsv1.ActionParametersCacheKey{}
+		}
+		return cache.CacheKey
+	}
+	configSetting := func(name string, value interface{}) {
+		if config == nil {
+			config = &rulesetsv1.ActionParameters{}
+		}
+		config.SetProperty(name, value)
+	}
+
+	for _, action := range actions {
+		switch action.ID {
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_ForwardingURL:
+			var value struct {
+				URL        string `json:"url"`
+				StatusCode int64  `json:"status_code"`
+			}
+			if !c.decode(action, &value) {
+				continue
+			}
+			redirect := &rulesetsv1.ActionParametersFromValue{StatusCode: core.Int64Ptr(value.StatusCode), TargetURL: &rulesetsv1.ActionParametersTargetURL{}}
+			if placeholder.MatchString(value.URL) {
+				redirect.TargetURL.Expression = core.StringPtr(forwardingExpression(pattern, value.URL))
+				redirect.PreserveQueryString = core.BoolPtr(false)
+			} else {
+				redirect.TargetURL.Value = core.StringPtr(value.URL)
+				redirect.PreserveQueryString = core.BoolPtr(true)
+			}
+			rules = append(rules, newRule(Phase_HttpRequestDynamicRedirect, "redirect", Ref(c.source, c.id), condition,
+				&rulesetsv1.ActionParameters{FromValue: redirect}))
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_AlwaysUseHttps:
+			target := expr.Call("concat", "https://", expr.Field("http.host"), expr.Field("http.request.uri.path"))
+			insecure := condition
+			if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(pattern)), "http://") {
+				insecure = expr.And(condition, expr.Not(expr.Field("ssl")))
+			}
+			rules = append(rules, newRule(Phase_HttpRequestDynamicRedirect, "redirect", Ref(c.source, c.id), insecure,
+				&rulesetsv1.ActionParameters{FromValue: &rulesetsv1.ActionParametersFromValue{
+					StatusCode:          core.Int64Ptr(301),
+					TargetURL:           &rulesetsv1.ActionParametersTargetURL{Expression: core.StringPtr(expr.Format(target))},
+					PreserveQueryString: core.BoolPtr(true),
+				}}))
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_CacheLevel:
+			var value string
+			if !c.decode(action, &value) {
+				continue
+			}
+			switch value {
+			case pageruleapiv1.PageRulesBodyActionsItemActionsCacheLevel_Value_Bypass:
+				cacheSettings().Cache = core.BoolPtr(false)
+			case pageruleapiv1.PageRulesBodyActionsItemActionsCacheLevel_Value_CacheEverything:
+				cacheSettings().Cache = core.BoolPtr(true)
+			case pageruleapiv1.PageRulesBodyActionsItemActionsCacheLevel_Value_Simplified:
+				cacheKey().CustomKey = &rulesetsv1.ActionParametersCustomKey{
+					QueryString: &rulesetsv1.CustomKeyQueryString{Exclude: &rulesetsv1.CustomKeyList{All: core.BoolPtr(true)}},
+				}
+			case pageruleapiv1.PageRulesBodyActionsItemActionsCacheLevel_Value_Aggressive:
+				c.finding(Finding_Severity_Info, "the aggressive cache level is the default and needs no rule")
+			default:
+				c.finding(Finding_Severity_Untranslatable, "cache level %q has no ruleset equivalent", value)
+			}
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_EdgeCacheTTL:
+			var value int64
+			if c.decode(action, &value) {
+				cacheSettings().EdgeTTL = &rulesetsv1.ActionParametersEdgeTTL{
+					Mode:    core.StringPtr(rulesetsv1.ActionParametersEdgeTTL_Mode_OverrideOrigin),
+					Default: core.Int64Ptr(value),
+				}
+			}
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_BrowserCacheTTL:
+			var value int64
+			if !c.decode(action, &value) {
+				continue
+			}
+			ttl := &rulesetsv1.ActionParametersBrowserTTL{Mode: core.StringPtr(rulesetsv1.ActionParametersBrowserTTL_Mode_RespectOrigin)}
+			if value > 0 {
+				ttl = &rulesetsv1.ActionParametersBrowserTTL{Mode: core.StringPtr(rulesetsv1.ActionParametersBrowserTTL_Mode_OverrideOrigin), Default: core.Int64Ptr(value)}
+			}
+			cacheSettings().BrowserTTL = ttl
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_BypassCacheOnCookie:
+			var value string
+			if !c.decode(action, &value) {
+				continue
+			}
+			rules = append(rules, newRule(Phase_HttpRequestCacheSettings, "set_cache_settings", Ref(c.source, c.id)+":"+action.ID,
+				expr.And(condition, expr.Field("http.cookie").Matches(value)), &rulesetsv1.ActionParameters{Cache: core.BoolPtr(false)}))
+			c.finding(Finding_Severity_Approximation, "the cookie pattern is matched against the whole Cookie header")
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_CacheDeceptionArmor, pageruleapiv1.PageRulesBodyActionsItem_ID_ExplicitCacheControl:
+			var value string
+			if !c.decode(action, &value) {
+				continue
+			}
+			on := core.BoolPtr(value == pageruleapiv1.PageRulesBodyActionsItemActionsSecurityOptions_Value_On)
+			if action.ID == pageruleapiv1.PageRulesBodyActionsItem_ID_ExplicitCacheControl {
+				cacheSettings().OriginCacheControl = on
+				continue
+			}
+			cacheKey().CacheDeceptionArmor = on
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_SecurityLevel, pageruleapiv1.PageRulesBodyActionsItem_ID_Ssl:
+			var value string
+			if c.decode(action, &value) {
+				configSetting(action.ID, value)
+			}
+		case pageruleapiv1.PageRulesBodyActionsItem_ID_AutomaticHttpsRewrites, pageruleapiv1.PageRulesBodyActionsItem_ID_BrowserCheck,
+			pageruleapiv1.PageRulesBodyActionsItem_ID_EmailObfuscation, pageruleapiv1.PageRulesBodyActionsItem_ID_OpportunisticEncryption,
+			pageruleapiv1.PageRulesBodyActionsItem_ID_ServerSideExclude:
+			var value string
+			if c.decode(action, &value) {
+				configSetting(configSettings[action.ID], value == pageruleapiv1.PageRulesBodyActionsItemActionsSecurityOptions_Value_On)
+			}
+		default:
+			c.finding(Finding_Severity_Untranslatable, "action %q has no ruleset equivalent", action.ID)
+		}
+	}
+
+	if cache != nil {
+		rules = append(rules, newRule(Phase_HttpRequestCacheSettings, "set_cache_settings", Ref(c.source, c.id), condition, cache))
+	}
+	if config != nil {
+		rules = append(rules, newRule(Phase_HttpConfigSettings, "set_config", Ref(c.source, c.id), condition, config))
+	}
+	return rules, c.findings
+}
+
+// decode decodes the value of an action, and reports the values it cannot decode.
+func (c *converter) decode(action pageRuleAction, value interface{}) bool {
+	if err := json.Unmarshal(action.Value, value); err != nil {
+		c.finding(Finding_Severity_Untranslatable, "the value of action %q cannot be read: %s", action.ID, err)
+		return false
+	}
+	return true
+}
+
+// pageRuleCondition converts a page rule URL pattern such as https://*.example.com/images/* to a condition on the
+// scheme, the host and the URI. Unlike legacy security rules, page rules match the query string: a pattern that
+// does not end with * only matches URLs without one.
+func (c *converter) pageRuleCondition(pattern string) expr.Expr {
+	parsed := pageruleapiv1.ParsePageRulePattern(pattern)
+	var conditions []expr.Expr
+	switch secure, insecure := parsed.MatchesScheme("https"), parsed.MatchesScheme("http"); {
+	case secure && insecure:
+	case secure:
+		conditions = append(conditions, expr.Field("ssl"))
+	case insecure:
+		conditions = append(conditions, expr.Not(expr.Field("ssl")))
+	default:
+		c.finding(Finding_Severity_Approximation, "the %q scheme of the URL pattern is ignored", strings.Join(parsed.Schemes, ", "))
+	}
+
+	switch host := parsed.Host; {
+	case host == "" || host == "*":
+	case strings.Contains(host, "*"):
+		conditions = append(conditions, expr.Field("http.host").Wildcard(host))
+	default:
+		conditions = append(conditions, expr.Field("http.host").Eq(host))
+	}
+	switch uri := parsed.Path; {
+	case uri == "/*":
+	case strings.Contains(uri, "*"):
+		conditions = append(conditions, expr.Field("http.request.uri").StrictWildcard(uri))
+	default:
+		conditions = append(conditions, expr.Field("http.request.uri").Eq(uri))
+	}
+	return expr.And(conditions...)
+}
+
+// forwardingExpression returns the expression of a forwarding URL that refers to the wildcards of the URL pattern
+// with $1 to $9, which wildcard_replace writes ${1} to ${9}. A pattern without a scheme gets a *:// prefix to match
+// the full URI, which shifts the references by one.
+func forwardingExpression(pattern string, url string) string {
+	pattern = strings.TrimSpace(pattern)
+	shift := 0
+	if !strings.Contains(pattern, "://") {
+		pattern = "*://" + pattern
+		shift = 1
+	}
+	url = placeholder.ReplaceAllStringFunc(url, func(reference string) string {
+		index, _ := strconv.Atoi(reference[1:])
+		return "${" + strconv.Itoa(index+shift) + "}"
+	})
+	if rest := pattern[strings.Index(pattern, "://")+3:]; !strings.ContainsAny(rest, "/?") {
+		pattern += "/"
+	}
+	return expr.Format(expr.Call("wildcard_replace", expr.Field("http.request.full_uri"), pattern, url))
+}
@@ -0,0 +1,215 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetmigration_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetmigration"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func pageRule(id string, pattern string, priority int64, status string, actions ...pageruleapiv1.PageRulesBodyActionsItemIntf) pageruleapiv1.PageRuleResult {
+	return pageruleapiv1.PageRuleResult{
+		ID:     core.StringPtr(id),
+		Status: core.StringPtr(status),
+		Targets: []pageruleapiv1.TargetsItem{{
+			Target:     core.StringPtr("url"),
+			Constraint: &pageruleapiv1.TargetsItemConstraint{Operator: core.StringPtr("matches"), Value: core.StringPtr(pattern)},
+		}},
+		Actions:  actions,
+		Priority: core.Int64Ptr(priority),
+	}
+}
+
+func pageRuleAction(id string, value interface{}) *pageruleapiv1.PageRulesBodyActionsItem {
+	return &pageruleapiv1.PageRulesBodyActionsItem{ID: core.StringPtr(id), Value: value}
+}
+
+func actionParameters(rule rulesetmigration.Rule) map[string]interface{} {
+	buffer, err := json.Marshal(rule.RuleCreate.ActionParameters)
+	Expect(err).To(BeNil())
+	var parameters map[string]interface{}
+	Expect(json.Unmarshal(buffer, &parameters)).To(Succeed())
+	return parameters
+}
+
+var _ = Describe(`Page rules`, func() {
+	Describe(`ConvertPageRule(rule)`, func() {
+		It(`Turn forwarding URLs into redirect rules`, func() {
+			legacy := pageRule("pr-1", "example.com/old/*", 1, "active",
+				pageRuleAction("forwarding_url", map[string]interface{}{"url": "https://www.example.com/new/$1", "status_code": 301}))
+			rules, findings := rulesetmigration.ConvertPageRule(&legacy)
+			Expect(findings).To(BeEmpty())
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].Phase).To(Equal(rulesetmigration.Phase_HttpRequestDynamicRedirect))
+			Expect(*rules[0].RuleCreate.Action).To(Equal("redirect"))
+			Expect(*rules[0].RuleCreate.Ref).To(Equal("page_rule:pr-1"))
+			Expect(*rules[0].RuleCreate.Expression).To(Equal(`http.host eq "example.com" and http.request.uri strict wildcard "/old/*"`))
+			Expect(actionParameters(rules[0])).To(Equal(map[string]interface{}{"from_value": map[string]interface{}{
+				"status_code":           float64(301),
+				"preserve_query_string": false,
+				"target_url": map[string]interface{}{
+					"expression": `wildcard_replace(http.request.full_uri, "*://example.com/old/*", "https://www.example.com/new/${2}")`,
+				},
+			}}))
+
+			legacy = pageRule("pr-2", "http://*example.com", 1, "active", &pageruleapiv1.PageRulesBodyActionsItemActionsSecurity{ID: core.StringPtr("always_use_https")})
+			rules, findings = rulesetmigration.ConvertPageRule(&legacy)
+			Expect(findings).To(BeEmpty())
+			Expect(*rules[0].RuleCreate.Expression).To(Equal(`not ssl and http.host wildcard "*example.com" and http.request.uri eq "/"`))
+			Expect(actionParameters(rules[0])["from_value"]).To(HaveKeyWithValue("target_url", map[string]interface{}{
+				"expression": `concat("https://", http.host, http.request.uri.path)`,
+			}))
+		})
+		It(`Split the settings between the cache and config phases`, func() {
+			legacy := pageRule("pr-1", "*example.com/static/*", 1, "disabled",
+				pageRuleAction("cache_level", "cache_everything"),
+				pageRuleAction("edge_cache_ttl", float64(7200)),
+				pageRuleAction("browser_cache_ttl", float64(0)),
+				pageRuleAction("cache_deception_armor", "on"),
+				pageRuleAction("bypass_cache_on_cookie", "session=.*"),
+				pageRuleAction("ssl", "strict"),
+				pageRuleAction("browser_check", "off"),
+				pageRuleAction("waf", "off"))
+			rules, findings := rulesetmigration.ConvertPageRule(&legacy)
+			Expect(severities(findings)).To(Equal([]string{rulesetmigration.Finding_Severity_Approximation, rulesetmigration.Finding_Severity_Untranslatable}))
+			Expect(findings[1].Message).To(Equal(`action "waf" has no ruleset equivalent`))
+			Expect(refs(rules)).To(Equal([]string{"page_rule:pr-1:bypass_cache_on_cookie", "page_rule:pr-1", "page_rule:pr-1"}))
+
+			Expect(*rules[0].RuleCreate.Expression).To(Equal(`http.host wildcard "*example.com" and http.request.uri strict wildcard "/static/*" and http.cookie matches "session=.*"`))
+			Expect(actionParameters(rules[0])).To(Equal(map[string]interface{}{"cache": false}))
+
+			Expect(rules[1].Phase).To(Equal(rulesetmigration.Phase_HttpRequestCacheSettings))
+			Expect(*rules[1].RuleCreate.Action).To(Equal("set_cache_settings"))
+			Expect(*rules[1].RuleCreate.Enabled).To(BeFalse())
+			Expect(actionParameters(rules[1])).To(Equal(map[string]interface{}{
+				"cache":       true,
+				"edge_ttl":    map[string]interface{}{"mode": "override_origin", "default": float64(7200)},
+				"browser_ttl": map[string]interface{}{"mode": "respect_origin"},
+				"cache_key":   map[string]interface{}{"cache_deception_armor": true},
+			}))
+
+			Expect(rules[2].Phase).To(Equal(rulesetmigration.Phase_HttpConfigSettings))
+			Expect(*rules[2].RuleCreate.Action).To(Equal("set_config"))
+			Expect(actionParameters(rules[2])).To(Equal(map[string]interface{}{"ssl": "strict", "bic": false}))
+		})
+		It(`Match the schemes of the URL pattern as globs`, func() {
+			for pattern, expression := range map[string]string{
+				"*://example.com/*":      `http.host eq "example.com"`,
+				"http*://example.com/*":  `http.host eq "example.com"`,
+				"HTTPS://example.com/*":  `ssl and http.host eq "example.com"`,
+				"http://Example.com/a?b": `not ssl and http.host eq "example.com" and http.request.uri eq "/a?b"`,
+			} {
+				legacy := pageRule("pr-1", pattern, 1, "active", pageRuleAction("ssl", "full"))
+				rules, findings := rulesetmigration.ConvertPageRule(&legacy)
+				Expect(findings).To(BeEmpty())
+				Expect(*rules[0].RuleCreate.Expression).To(Equal(expression), pattern)
+			}
+			legacy := pageRule("pr-1", "ftp://example.com/*", 1, "active", pageRuleAction("ssl", "full"))
+			_, findings := rulesetmigration.ConvertPageRule(&legacy)
+			Expect(severities(findings)).To(Equal([]string{rulesetmigration.Finding_Severity_Approximation}))
+		})
+		It(`Report the rules without a URL`, func() {
+			rules, findings := rulesetmigration.ConvertPageRule(&pageruleapiv1.PageRuleResult{ID: core.StringPtr("pr-1")})
+			Expect(rules).To(BeNil())
+			Expect(severities(findings)).To(Equal([]string{rulesetmigration.Finding_Severity_Untranslatable}))
+		})
+	})
+
+	Describe(`ConvertPageRules(rules)`, func() {
+		It(`Order the settings by ascending priority and the redirects by descending priority`, func() {
+			report := rulesetmigration.ConvertPageRules([]pageruleapiv1.PageRuleResult{
+				pageRule("low", "*example.com/*", 1, "active", pageRuleAction("security_level", "high")),
+				pageRule("high", "*example.com/api/*", 3, "active", pageRuleAction("security_level", "essentially_off")),
+				pageRule("docs", "example.com/docs/*", 4, "active", pageRuleAction("forwarding_url", map[string]interface{}{"url": "https://docs.example.com/", "status_code": 302})),
+				pageRule("shadowed", "example.com/api/v1/*", 2, "active", pageRuleAction("forwarding_url", map[string]interface{}{"url": "https://example.com/api/v2/", "status_code": 301})),
+			})
+			Expect(refs(report.Rules)).To(Equal([]string{"page_rule:low", "page_rule:high", "page_rule:docs", "page_rule:shadowed"}))
+			Expect(report.Findings).To(Equal([]rulesetmigration.Finding{{
+				Source:   rulesetmigration.Source_PageRule,
+				ID:       "shadowed",
+				Severity: rulesetmigration.Finding_Severity_Info,
+				Message:  "the page rule never applies as page rule high matches all its URLs",
+			}}))
+		})
+		It(`Leave out the URLs of the overlapping page rules of higher priority`, func() {
+			report := rulesetmigration.ConvertPageRules([]pageruleapiv1.PageRuleResult{
+				pageRule("images", "example.com/images/*", 2, "active", pageRuleAction("cache_level", "cache_everything")),
+				pageRule("site", "example.com/*", 1, "active", pageRuleAction("ssl", "flexible")),
+				pageRule("blog", "https://blog.example.com/*", 3, "active", pageRuleAction("security_level", "high")),
+				pageRule("draft", "example.com/*", 4, "disabled", pageRuleAction("browser_check", "off")),
+			})
+			Expect(report.Findings).To(BeEmpty())
+			Expect(refs(report.Rules)).To(Equal([]string{"page_rule:site", "page_rule:images", "page_rule:blog", "page_rule:draft"}))
+			Expect(*report.Rules[0].RuleCreate.Expression).To(Equal(`http.host eq "example.com" and not (http.host eq "example.com" and http.request.uri strict wildcard "/images/*")`))
+			Expect(*report.Rules[1].RuleCreate.Expression).To(Equal(`http.host eq "example.com" and http.request.uri strict wildcard "/images/*"`))
+			Expect(*report.Rules[2].RuleCreate.Expression).To(Equal(`ssl and http.host eq "blog.example.com"`))
+			Expect(*report.Rules[3].RuleCreate.Expression).To(Equal(`http.host eq "example.com"`))
+		})
+	})
+
+	Describe(`Migrator`, func() {
+		var zone *fakeZone
+		var server *httptest.Server
+		var migrator *rulesetmigration.Migrator
+
+		BeforeEach(func() {
+			zone = newFakeZone()
+			server = httptest.NewServer(zone)
+			authenticator := &core.NoAuthAuthenticator{}
+			crn, zoneID := core.StringPtr("crn-1"), core.StringPtr("zone-1")
+			rulesets, err := rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{URL: server.URL, Authenticator: authenticator, Crn: crn, ZoneIdentifier: zoneID})
+			Expect(err).To(BeNil())
+			migrator, err = rulesetmigration.NewMigrator(rulesets)
+			Expect(err).To(BeNil())
+			migrator.PageRules, err = pageruleapiv1.NewPageRuleApiV1(&pageruleapiv1.PageRuleApiV1Options{URL: server.URL, Authenticator: authenticator, Crn: crn, ZoneID: zoneID})
+			Expect(err).To(BeNil())
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It(`Pause the page rules only after their rules are deployed`, func() {
+			report, err := migrator.Migrate(&rulesetmigration.MigrateOptions{DeployOnly: true})
+			Expect(err).To(BeNil())
+			Expect(zone.writes()).To(Equal([]string{
+				"PUT /rulesets/phases/http_request_dynamic_redirect/entrypoint",
+				"PUT /rulesets/phases/http_request_cache_settings/entrypoint",
+				"PUT /rulesets/phases/http_config_settings/entrypoint",
+			}))
+			Expect(report.Created).To(Equal([]string{"page_rule:pr-forward", "page_rule:pr-static", "page_rule:pr-all"}))
+			Expect(report.Disabled).To(BeEmpty())
+			Expect(report.Untranslatable()).To(HaveLen(1))
+			Expect(report.Untranslatable()[0].ID).To(Equal("pr-all"))
+
+			zone.requests = nil
+			report, err = migrator.Migrate(nil)
+			Expect(err).To(BeNil())
+			Expect(report.Created).To(BeEmpty())
+			Expect(report.Existing).To(Equal([]string{"page_rule:pr-forward", "page_rule:pr-static", "page_rule:pr-all"}))
+			Expect(report.Disabled).To(Equal([]string{"page_rule:pr-forward", "page_rule:pr-static"}))
+			Expect(zone.writes()).To(Equal([]string{"PATCH /pagerules/pr-forward", "PATCH /pagerules/pr-static"}))
+			Expect(zone.updates["/pagerules/pr-forward"]).To(Equal(map[string]interface{}{"status": "disabled"}))
+		})
+	})
+})
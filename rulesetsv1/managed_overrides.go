@@ -0,0 +1,379 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetsv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	common "github.com/IBM/networking-go-sdk/common"
+)
+
+// Constants associated with the EffectiveRule.Source property.
+// Where the effective action and status of a managed rule come from.
+const (
+	EffectiveRule_Source_Category = "category"
+	EffectiveRule_Source_Default  = "default"
+	EffectiveRule_Source_Rule     = "rule"
+	EffectiveRule_Source_Ruleset  = "ruleset"
+)
+
+// OverrideComposer : Composes the overrides of a managed ruleset deployed with an execute rule.
+//
+// The setters check that the rules and categories they override exist in the managed ruleset. Errors are collected
+// and returned by Err and ExecuteRule, so that the setters can be chained.
+type OverrideComposer struct {
+	// The managed ruleset.
+	Ruleset *RulesetDetails
+
+	overrides  Overrides
+	rules      map[string]int
+	categories map[string]bool
+	errs       []string
+}
+
+// EffectiveRule : A rule of a managed ruleset, with the overrides applied.
+type EffectiveRule struct {
+	// The rule of the managed ruleset.
+	Rule *RuleDetails
+
+	// The effective action.
+	Action string
+
+	// Whether the rule runs.
+	Enabled bool
+
+	// The effective sensitivity level, if any.
+	SensitivityLevel string
+
+	// The effective score threshold, if any.
+	ScoreThreshold int64
+
+	// Where the effective action and status come from, as one of the EffectiveRule_Source constants. For
+	// categories, Category holds the overridden category.
+	Source   string
+	Category string
+}
+
+// NewOverrideComposer : Instantiate OverrideComposer
+// Compose overrides for a managed ruleset, as returned by GetZoneRuleset or GetInstanceRuleset.
+func NewOverrideComposer(ruleset *RulesetDetails) (composer *OverrideComposer, err error) {
+	if ruleset == nil || ruleset.ID == nil {
+		err = core.SDKErrorf(nil, "ruleset cannot be nil and must have an ID", "invalid-ruleset", common.GetComponentInfo())
+		return
+	}
+	composer = &OverrideComposer{Ruleset: ruleset, rules: map[string]int{}, categories: map[string]bool{}}
+	for i := range ruleset.Rules {
+		composer.rules[core.StringNilMapper(ruleset.Rules[i].ID)] = i
+		for _, category := range ruleset.Rules[i].Categories {
+			composer.categories[category] = true
+		}
+	}
+	return
+}
+
+// LoadOverrideComposer : Load a managed ruleset into an OverrideComposer
+// Get a ruleset of the zone with GetZoneRuleset and compose overrides for it.
+func (rulesets *RulesetsV1) LoadOverrideComposer(rulesetID string) (composer *OverrideComposer, response *core.DetailedResponse, err error) {
+	return rulesets.LoadOverrideComposerWithContext(context.Background(), rulesetID)
+}
+
+// LoadOverrideComposerWithContext is an alternate form of the LoadOverrideComposer method which supports a Context parameter
+func (rulesets *RulesetsV1) LoadOverrideComposerWithContext(ctx context.Context, rulesetID string) (composer *OverrideComposer, response *core.DetailedResponse, err error) {
+	result, response, err := rulesets.GetZoneRulesetWithContext(ctx, rulesets.NewGetZoneRulesetOptions(rulesetID))
+	if err != nil {
+		err = core.RepurposeSDKProblem(err, "get-ruleset-error")
+		return
+	}
+	composer, err = NewOverrideComposer(result.Result)
+	return
+}
+
+func (composer *OverrideComposer) fail(format string, args ...interface{}) {
+	composer.errs = append(composer.errs, fmt.Sprintf(format, args...))
+}
+
+// rule returns the override of a rule, adding it when needed, or nil when the rule does not exist.
+func (composer *OverrideComposer) rule(id string) *RulesOverride {
+	if _, ok := composer.rules[id]; !ok {
+		composer.fail("rule %s is not in ruleset %s", id, *composer.Ruleset.ID)
+		return nil
+	}
+	for i := range composer.overrides.Rules {
+		if *composer.overrides.Rules[i].ID == id {
+			return &composer.overrides.Rules[i]
+		}
+	}
+	composer.overrides.Rules = append(composer.overrides.Rules, RulesOverride{ID: core.StringPtr(id)})
+	return &composer.overrides.Rules[len(composer.overrides.Rules)-1]
+}
+
+// category returns the override of a category, adding it when needed, or nil when no rule has the category.
+func (composer *OverrideComposer) category(category string) *CategoriesOverride {
+	if !composer.categories[category] {
+		composer.fail("no rule of ruleset %s has category %s", *composer.Ruleset.ID, category)
+		return nil
+	}
+	for i := range composer.overrides.Categories {
+		if *composer.overrides.Categories[i].Category == category {
+			return &composer.overrides.Categories[i]
+		}
+	}
+	composer.overrides.Categories = append(composer.overrides.Categories, CategoriesOverride{Category: core.StringPtr(category)})
+	return &composer.overrides.Categories[len(composer.overrides.Categories)-1]
+}
+
+func (composer *OverrideComposer) checkSensitivityLevel(level string) bool {
+	switch level {
+	case Overrides_SensitivityLevel_High, Overrides_SensitivityLevel_Medium, Overrides_SensitivityLevel_Low:
+		return true
+	}
+	composer.fail("sensitivity level %q is not one of high, medium and low", level)
+	return false
+}
+
+// SetAction : Override the action of every rule of the ruleset.
+func (composer *OverrideComposer) SetAction(action string) *OverrideComposer {
+	composer.overrides.Action = core.StringPtr(action)
+	return composer
+}
+
+// SetEnabled : Enable or disable every rule of the ruleset.
+func (composer *OverrideComposer) SetEnabled(enabled bool) *OverrideComposer {
+	composer.overrides.Enabled = core.BoolPtr(enabled)
+	return composer
+}
+
+// SetSensitivityLevel : Override the sensitivity level of every rule of the ruleset.
+func (composer *OverrideComposer) SetSensitivityLevel(level string) *OverrideComposer {
+	if composer.checkSensitivityLevel(level) {
+		composer.overrides.SensitivityLevel = core.StringPtr(level)
+	}
+	return composer
+}
+
+// SetCategoryAction : Override the action of the rules of a category.
+func (composer *OverrideComposer) SetCategoryAction(category string, action string) *OverrideComposer {
+	if override := composer.category(category); override != nil {
+		override.Action = core.StringPtr(action)
+	}
+	return composer
+}
+
+// SetCategoryEnabled : Enable or disable the rules of a category.
+func (composer *OverrideComposer) SetCategoryEnabled(category string, enabled bool) *OverrideComposer {
+	if override := composer.category(category); override != nil {
+		override.Enabled = core.BoolPtr(enabled)
+	}
+	return composer
+}
+
+// DisableCategory : Disable the rules of a category.
+func (composer *OverrideComposer) DisableCategory(category string) *OverrideComposer {
+	return composer.SetCategoryEnabled(category, false)
+}
+
+// SetRuleAction : Override the action of a rule.
+func (composer *OverrideComposer) SetRuleAction(ruleID string, action string) *OverrideComposer {
+	if override := composer.rule(ruleID); override != nil {
+		override.Action = core.StringPtr(action)
+	}
+	return composer
+}
+
+// SetRuleEnabled : Enable or disable a rule.
+func (composer *OverrideComposer) SetRuleEnabled(ruleID string, enabled bool) *OverrideComposer {
+	if override := composer.rule(ruleID); override != nil {
+		override.Enabled = core.BoolPtr(enabled)
+	}
+	return composer
+}
+
+// DisableRule : Disable a rule.
+func (composer *OverrideComposer) DisableRule(ruleID string) *OverrideComposer {
+	return composer.SetRuleEnabled(ruleID, false)
+}
+
+// SetRuleSensitivityLevel : Override the sensitivity level of a rule.
+func (composer *OverrideComposer) SetRuleSensitivityLevel(ruleID string, level string) *OverrideComposer {
+	if override := composer.rule(ruleID); override != nil && composer.checkSensitivityLevel(level) {
+		override.SensitivityLevel = core.StringPtr(level)
+	}
+	return composer
+}
+
+// SetRuleScoreThreshold : Override the score threshold of a rule, such as the OWASP anomaly score rule.
+func (composer *OverrideComposer) SetRuleScoreThreshold(ruleID string, threshold int64) *OverrideComposer {
+	if override := composer.rule(ruleID); override != nil {
+		override.ScoreThreshold = core.Int64Ptr(threshold)
+	}
+	return composer
+}
+
+// Err returns the errors of the setters, or nil.
+func (composer *OverrideComposer) Err() error {
+	if len(composer.errs) == 0 {
+		return nil
+	}
+	return core.SDKErrorf(nil, strings.Join(composer.errs, "; "), "invalid-override", common.GetComponentInfo())
+}
+
+// Overrides returns a copy of the composed overrides, or nil when nothing is overridden.
+func (composer *OverrideComposer) Overrides() *Overrides {
+	overrides := composer.overrides
+	if overrides.Action == nil && overrides.Enabled == nil && overrides.SensitivityLevel == nil && len(overrides.Rules) == 0 && len(overrides.Categories) == 0 {
+		return nil
+	}
+	overrides.Rules = append([]RulesOverride(nil), overrides.Rules...)
+	overrides.Categories = append([]CategoriesOverride(nil), overrides.Categories...)
+	return &overrides
+}
+
+// ExecuteRule returns the rule that deploys the managed ruleset with the composed overrides, for the requests
+// matching expression, or for all requests when expression is empty.
+func (composer *OverrideComposer) ExecuteRule(expression string) (rule *RuleCreate, err error) {
+	err = composer.Err()
+	if err != nil {
+		return
+	}
+	if expression == "" {
+		expression = "true"
+	}
+	description := "Execute " + *composer.Ruleset.ID
+	if composer.Ruleset.Name != nil {
+		description = "Execute " + *composer.Ruleset.Name
+	}
+	rule = &RuleCreate{
+		Action:      core.StringPtr("execute"),
+		Description: core.StringPtr(description),
+		Enabled:     core.BoolPtr(true),
+		Expression:  core.StringPtr(expression),
+		ActionParameters: &ActionParameters{
+			ID:        composer.Ruleset.ID,
+			Overrides: composer.Overrides(),
+		},
+	}
+	return
+}
+
+// EffectiveRules returns the rules of the managed ruleset with the overrides applied. A rule override takes
+// precedence over the overrides of its categories, in the order they were added, which take precedence over the
+// overrides of the ruleset.
+func (composer *OverrideComposer) EffectiveRules() (effective []EffectiveRule) {
+	for i := range composer.Ruleset.Rules {
+		rule := &composer.Ruleset.Rules[i]
+		result := EffectiveRule{
+			Rule:    rule,
+			Action:  core.StringNilMapper(rule.Action),
+			Enabled: rule.Enabled == nil || *rule.Enabled,
+			Source:  EffectiveRule_Source_Default,
+		}
+		if composer.overrides.Action != nil || composer.overrides.Enabled != nil {
+			result.Source = EffectiveRule_Source_Ruleset
+		}
+		if composer.overrides.Action != nil {
+			result.Action = *composer.overrides.Action
+		}
+		if composer.overrides.Enabled != nil {
+			result.Enabled = *composer.overrides.Enabled
+		}
+		result.SensitivityLevel = core.StringNilMapper(composer.overrides.SensitivityLevel)
+
+		for _, override := range composer.overrides.Categories {
+			if !hasCategory(rule, *override.Category) {
+				continue
+			}
+			result.Source, result.Category = EffectiveRule_Source_Category, *override.Category
+			if override.Action != nil {
+				result.Action = *override.Action
+			}
+			if override.Enabled != nil {
+				result.Enabled = *override.Enabled
+			}
+		}
+		for _, override := range composer.overrides.Rules {
+			if *override.ID != core.StringNilMapper(rule.ID) {
+				continue
+			}
+			result.Source, result.Category = EffectiveRule_Source_Rule, ""
+			if override.Action != nil {
+				result.Action = *override.Action
+			}
+			if override.Enabled != nil {
+				result.Enabled = *override.Enabled
+			}
+			if override.SensitivityLevel != nil {
+				result.SensitivityLevel = *override.SensitivityLevel
+			}
+			if override.ScoreThreshold != nil {
+				result.ScoreThreshold = *override.ScoreThreshold
+			}
+		}
+		effective = append(effective, result)
+	}
+	return
+}
+
+func hasCategory(rule *RuleDetails, category string) bool {
+	for _, c := range rule.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns a readable description of the effective policy, with one line per rule.
+func (composer *OverrideComposer) Summary() string {
+	var summary strings.Builder
+	name := *composer.Ruleset.ID
+	if composer.Ruleset.Name != nil {
+		name = fmt.Sprintf("%s (%s)", *composer.Ruleset.Name, *composer.Ruleset.ID)
+	}
+	effective := composer.EffectiveRules()
+	enabled := 0
+	for _, rule := range effective {
+		if rule.Enabled {
+			enabled++
+		}
+	}
+	fmt.Fprintf(&summary, "%s: %d of %d rules enabled\n", name, enabled, len(effective))
+	for _, rule := range effective {
+		status := "disabled"
+		if rule.Enabled {
+			status = rule.Action
+		}
+		source := rule.Source
+		if rule.Source == EffectiveRule_Source_Category {
+			source += " " + rule.Category
+		}
+		if rule.SensitivityLevel != "" {
+			source += ", sensitivity " + rule.SensitivityLevel
+		}
+		if rule.ScoreThreshold != 0 {
+			source += fmt.Sprintf(", score threshold %d", rule.ScoreThreshold)
+		}
+		fmt.Fprintf(&summary, "  %s %s [%s]", core.StringNilMapper(rule.Rule.ID), status, source)
+		if rule.Rule.Description != nil {
+			fmt.Fprintf(&summary, " %s", *rule.Rule.Description)
+		}
+		summary.WriteString("\n")
+	}
+	return summary.String()
+}
@@ -0,0 +1,117 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetsv1_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`OverrideComposer`, func() {
+	managed := &rulesetsv1.RulesetDetails{
+		ID:   core.StringPtr("managed-1"),
+		Name: core.StringPtr("Managed Ruleset"),
+		Rules: []rulesetsv1.RuleDetails{
+			{ID: core.StringPtr("wp-1"), Action: core.StringPtr("block"), Categories: []string{"wordpress", "cms"}, Description: core.StringPtr("WordPress XML-RPC")},
+			{ID: core.StringPtr("drupal-1"), Action: core.StringPtr("block"), Categories: []string{"drupal", "cms"}},
+			{ID: core.StringPtr("sqli-1"), Action: core.StringPtr("block"), Categories: []string{"sqli"}, Enabled: core.BoolPtr(false)},
+		},
+	}
+
+	It(`Compose overrides and the execute rule`, func() {
+		composer, err := rulesetsv1.NewOverrideComposer(managed)
+		Expect(err).To(BeNil())
+		Expect(composer.Overrides()).To(BeNil())
+
+		composer.SetCategoryAction("cms", "log").DisableCategory("wordpress").SetRuleEnabled("sqli-1", true).SetRuleAction("sqli-1", "managed_challenge")
+		rule, err := composer.ExecuteRule(`http.host eq "www.example.com"`)
+		Expect(err).To(BeNil())
+		Expect(*rule.Action).To(Equal("execute"))
+		Expect(*rule.Description).To(Equal("Execute Managed Ruleset"))
+		Expect(*rule.Expression).To(Equal(`http.host eq "www.example.com"`))
+		Expect(*rule.ActionParameters.ID).To(Equal("managed-1"))
+		Expect(rule.ActionParameters.Overrides).To(Equal(&rulesetsv1.Overrides{
+			Rules: []rulesetsv1.RulesOverride{{ID: core.StringPtr("sqli-1"), Enabled: core.BoolPtr(true), Action: core.StringPtr("managed_challenge")}},
+			Categories: []rulesetsv1.CategoriesOverride{
+				{Category: core.StringPtr("cms"), Action: core.StringPtr("log")},
+				{Category: core.StringPtr("wordpress"), Enabled: core.BoolPtr(false)},
+			},
+		}))
+
+		Expect(composer.Summary()).To(Equal("Managed Ruleset (managed-1): 2 of 3 rules enabled\n" +
+			"  wp-1 disabled [category wordpress] WordPress XML-RPC\n" +
+			"  drupal-1 log [category cms]\n" +
+			"  sqli-1 managed_challenge [rule]\n"))
+	})
+	It(`Apply ruleset overrides below category and rule overrides`, func() {
+		composer, err := rulesetsv1.NewOverrideComposer(managed)
+		Expect(err).To(BeNil())
+		composer.SetAction("log").SetSensitivityLevel("low").SetRuleScoreThreshold("drupal-1", 40).SetCategoryEnabled("sqli", true)
+		effective := composer.EffectiveRules()
+		Expect(effective[0].Action).To(Equal("log"))
+		Expect(effective[0].Source).To(Equal(rulesetsv1.EffectiveRule_Source_Ruleset))
+		Expect(effective[1].Source).To(Equal(rulesetsv1.EffectiveRule_Source_Rule))
+		Expect(effective[1].ScoreThreshold).To(Equal(int64(40)))
+		Expect(effective[2].Enabled).To(BeTrue())
+		Expect(effective[2].SensitivityLevel).To(Equal("low"))
+		Expect(composer.Summary()).To(ContainSubstring("  drupal-1 log [rule, sensitivity low, score threshold 40]\n"))
+	})
+	It(`Reject unknown rules, categories and sensitivity levels`, func() {
+		composer, err := rulesetsv1.NewOverrideComposer(managed)
+		Expect(err).To(BeNil())
+		composer.SetRuleAction("nope", "log").DisableCategory("joomla").SetSensitivityLevel("extreme").DisableRule("wp-1")
+		_, err = composer.ExecuteRule("")
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`rule nope is not in ruleset managed-1; no rule of ruleset managed-1 has category joomla; ` +
+			`sensitivity level "extreme" is not one of high, medium and low`))
+
+		_, err = rulesetsv1.NewOverrideComposer(nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Load the managed ruleset of a zone`, func() {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.URL.EscapedPath()).To(Equal("/v1/crn/zones/zone/rulesets/managed-1"))
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"success": true, "errors": [], "messages": [], "result": {"id": "managed-1", "name": "Managed", "kind": "managed", `+
+				`"rules": [{"id": "wp-1", "action": "block", "categories": ["wordpress"]}]}}`)
+		}))
+		defer testServer.Close()
+		rulesetsService, err := rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{
+			URL:            testServer.URL,
+			Authenticator:  &core.NoAuthAuthenticator{},
+			Crn:            core.StringPtr("crn"),
+			ZoneIdentifier: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+
+		composer, response, err := rulesetsService.LoadOverrideComposer("managed-1")
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+		rule, err := composer.DisableCategory("wordpress").ExecuteRule("")
+		Expect(err).To(BeNil())
+		Expect(*rule.Expression).To(Equal("true"))
+		Expect(rule.ActionParameters.Overrides.Categories).To(HaveLen(1))
+	})
+})
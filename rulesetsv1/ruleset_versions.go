@@ -0,0 +1,309 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetsv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	common "github.com/IBM/networking-go-sdk/common"
+)
+
+// RulesetDiff : The differences between two versions of a ruleset.
+type RulesetDiff struct {
+	// The version compared from.
+	FromVersion string `json:"from_version,omitempty"`
+
+	// The version compared to.
+	ToVersion string `json:"to_version,omitempty"`
+
+	// The rules only in the version compared to, in their order.
+	Added []RuleChange `json:"added,omitempty"`
+
+	// The rules only in the version compared from, in their order.
+	Removed []RuleChange `json:"removed,omitempty"`
+
+	// The rules in both versions that moved relative to the other rules, in their new order.
+	Reordered []RuleChange `json:"reordered,omitempty"`
+
+	// The rules in both versions with different properties, in their new order.
+	Changed []RuleChange `json:"changed,omitempty"`
+}
+
+// RuleChange : A rule that differs between two versions of a ruleset.
+type RuleChange struct {
+	// The key matching the rule across versions: its ref, or its ID when it has no ref.
+	Key string `json:"key"`
+
+	// The rule in the version compared from. Nil for an added rule.
+	From *RuleDetails `json:"from,omitempty"`
+
+	// The rule in the version compared to. Nil for a removed rule.
+	To *RuleDetails `json:"to,omitempty"`
+
+	// The position of the rule in the version compared from, or -1 for an added rule.
+	FromPosition int `json:"from_position"`
+
+	// The position of the rule in the version compared to, or -1 for a removed rule.
+	ToPosition int `json:"to_position"`
+
+	// The properties that differ. Only set for changed rules.
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// FieldChange : A rule property that differs between two versions of a ruleset.
+type FieldChange struct {
+	// The JSON path of the property, such as 'action_parameters.response.status_code'.
+	Path string `json:"path"`
+
+	// The value in the version compared from, as decoded from JSON. Nil when the property is not set.
+	From interface{} `json:"from,omitempty"`
+
+	// The value in the version compared to, as decoded from JSON. Nil when the property is not set.
+	To interface{} `json:"to,omitempty"`
+}
+
+// Empty returns true when both versions have the same rules in the same order.
+func (diff *RulesetDiff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Reordered) == 0 && len(diff.Changed) == 0
+}
+
+// NewRuleCreateFromDetails returns a copy of a rule of a ruleset as a rule to create, as when adding the rules read
+// from one ruleset to another. The copy shares no value with the rule.
+func NewRuleCreateFromDetails(rule *RuleDetails) (ruleCreate *RuleCreate, err error) {
+	buffer, err := json.Marshal(&RuleCreate{
+		Action:           rule.Action,
+		ActionParameters: rule.ActionParameters,
+		Ratelimit:        rule.Ratelimit,
+		Description:      rule.Description,
+		Enabled:          rule.Enabled,
+		Expression:       rule.Expression,
+		Logging:          rule.Logging,
+		Ref:              rule.Ref,
+	})
+	var raw map[string]json.RawMessage
+	if err == nil {
+		err = json.Unmarshal(buffer, &raw)
+	}
+	if err == nil {
+		err = UnmarshalRuleCreate(raw, &ruleCreate)
+	}
+	if err != nil {
+		err = core.SDKErrorf(err, fmt.Sprintf("copying rule %s: %s", core.StringNilMapper(rule.ID), err.Error()), "copy-rule-error", common.GetComponentInfo())
+	}
+	return
+}
+
+// RuleKey returns the key that matches a rule across the versions of a ruleset: its ref, or its ID when it has none.
+func RuleKey(rule *RuleDetails) string {
+	if rule.Ref != nil && *rule.Ref != "" {
+		return *rule.Ref
+	}
+	return core.StringNilMapper(rule.ID)
+}
+
+// DiffRulesetVersions compares two versions of a ruleset. Rules are matched by ref, or by ID when they have none.
+// The version, last update time and ID of the rules are not compared. Either version may be nil, which stands for a
+// ruleset without rules.
+func DiffRulesetVersions(from *RulesetDetails, to *RulesetDetails) (diff *RulesetDiff) {
+	diff = &RulesetDiff{}
+	var fromRules, toRules []RuleDetails
+	if from != nil {
+		diff.FromVersion = core.StringNilMapper(from.Version)
+		fromRules = from.Rules
+	}
+	if to != nil {
+		diff.ToVersion = core.StringNilMapper(to.Version)
+		toRules = to.Rules
+	}
+
+	fromPositions := map[string]int{}
+	for i := range fromRules {
+		fromPositions[RuleKey(&fromRules[i])] = i
+	}
+	toPositions := map[string]int{}
+	for i := range toRules {
+		toPositions[RuleKey(&toRules[i])] = i
+	}
+
+	for i := range fromRules {
+		key := RuleKey(&fromRules[i])
+		if _, ok := toPositions[key]; !ok {
+			diff.Removed = append(diff.Removed, RuleChange{Key: key, From: &fromRules[i], FromPosition: i, ToPosition: -1})
+		}
+	}
+	var commonFrom, commonTo []string
+	for i := range fromRules {
+		if key := RuleKey(&fromRules[i]); hasKey(toPositions, key) {
+			commonFrom = append(commonFrom, key)
+		}
+	}
+	for i := range toRules {
+		key := RuleKey(&toRules[i])
+		if !hasKey(fromPositions, key) {
+			diff.Added = append(diff.Added, RuleChange{Key: key, To: &toRules[i], FromPosition: -1, ToPosition: i})
+			continue
+		}
+		commonTo = append(commonTo, key)
+	}
+
+	// The rules outside the longest common subsequence of the two orders are the ones that moved.
+	kept := longestCommonSubsequence(commonFrom, commonTo)
+	for _, key := range commonTo {
+		fromRule, toRule := &fromRules[fromPositions[key]], &toRules[toPositions[key]]
+		change := RuleChange{Key: key, From: fromRule, To: toRule, FromPosition: fromPositions[key], ToPosition: toPositions[key]}
+		if !kept[key] {
+			diff.Reordered = append(diff.Reordered, change)
+		}
+		if change.Fields = diffRules(fromRule, toRule); len(change.Fields) > 0 {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	return
+}
+
+func hasKey(positions map[string]int, key string) bool {
+	_, ok := positions[key]
+	return ok
+}
+
+// longestCommonSubsequence returns the keys of a longest common subsequence of a and b.
+func longestCommonSubsequence(a []string, b []string) map[string]bool {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	kept := map[string]bool{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			kept[a[i]] = true
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return kept
+}
+
+// diffRules returns the properties that differ between two versions of a rule, sorted by path. Nested objects are
+// compared property by property, arrays as a whole.
+func diffRules(from *RuleDetails, to *RuleDetails) (changes []FieldChange) {
+	fromFields, toFields := flattenRule(from), flattenRule(to)
+	for path, fromValue := range fromFields {
+		if toValue, ok := toFields[path]; !ok || !reflect.DeepEqual(fromValue, toValue) {
+			changes = append(changes, FieldChange{Path: path, From: fromValue, To: toFields[path]})
+		}
+	}
+	for path, toValue := range toFields {
+		if _, ok := fromFields[path]; !ok {
+			changes = append(changes, FieldChange{Path: path, To: toValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return
+}
+
+// flattenRule maps the JSON paths of the compared properties of a rule to their values.
+func flattenRule(rule *RuleDetails) map[string]interface{} {
+	buffer, _ := json.Marshal(rule)
+	var properties map[string]interface{}
+	_ = json.Unmarshal(buffer, &properties)
+	for _, ignored := range []string{"id", "version", "last_updated"} {
+		delete(properties, ignored)
+	}
+	fields := map[string]interface{}{}
+	flatten("", properties, fields)
+	return fields
+}
+
+func flatten(prefix string, properties map[string]interface{}, fields map[string]interface{}) {
+	for name, value := range properties {
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flatten(path, nested, fields)
+		} else {
+			fields[path] = value
+		}
+	}
+}
+
+// RollbackToVersion : Roll back a zone entry point ruleset to an earlier version
+// Rebuild the entry point ruleset of a phase from the rules of one of its versions, through
+// UpdateZoneEntrypointRuleset. Rules keep their ref, or get their former ID as ref, so that the new version can be
+// compared with DiffRulesetVersions. A version without rules empties the entry point. The rollback creates a new
+// version; the versions after the restored one are kept.
+func (rulesets *RulesetsV1) RollbackToVersion(ctx context.Context, rulesetPhase string, rulesetVersion string) (result *RulesetResp, response *core.DetailedResponse, err error) {
+	version, response, err := rulesets.GetZoneEntryPointRulesetVersionWithContext(ctx, rulesets.NewGetZoneEntryPointRulesetVersionOptions(rulesetPhase, rulesetVersion))
+	if err != nil {
+		err = core.RepurposeSDKProblem(err, "get-version-error")
+		return
+	}
+	if version.Result == nil {
+		err = core.SDKErrorf(nil, fmt.Sprintf("version %s of the %s entry point ruleset has no result", rulesetVersion, rulesetPhase), "empty-version", common.GetComponentInfo())
+		return
+	}
+
+	options := rulesets.NewUpdateZoneEntrypointRulesetOptions(rulesetPhase)
+	options.Description = version.Result.Description
+	options.Kind = version.Result.Kind
+	options.Name = version.Result.Name
+	options.Phase = version.Result.Phase
+	// A version without rules empties the entry point, so the rules are sent even when there are none.
+	options.Rules = []RuleCreate{}
+	for i := range version.Result.Rules {
+		rule := &version.Result.Rules[i]
+		options.Rules = append(options.Rules, RuleCreate{
+			Action:           rule.Action,
+			ActionParameters: rule.ActionParameters,
+			Ratelimit:        rule.Ratelimit,
+			Description:      rule.Description,
+			Enabled:          rule.Enabled,
+			Expression:       rule.Expression,
+			Logging:          rule.Logging,
+			Ref:              core.StringPtr(RuleKey(rule)),
+		})
+	}
+	result, response, err = rulesets.UpdateZoneEntrypointRulesetWithContext(ctx, options)
+	if err != nil {
+		err = core.RepurposeSDKProblem(err, "rollback-error")
+	}
+	return
+}
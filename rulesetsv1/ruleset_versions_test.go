@@ -0,0 +1,178 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesetsv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func versionRule(id string, ref string, action string, expression string) rulesetsv1.RuleDetails {
+	rule := rulesetsv1.RuleDetails{ID: core.StringPtr(id), Action: core.StringPtr(action), Expression: core.StringPtr(expression), Enabled: core.BoolPtr(true)}
+	if ref != "" {
+		rule.Ref = core.StringPtr(ref)
+	}
+	return rule
+}
+
+func changeKeys(changes []rulesetsv1.RuleChange) (keys []string) {
+	for _, change := range changes {
+		keys = append(keys, change.Key)
+	}
+	return
+}
+
+var _ = Describe(`Ruleset versions`, func() {
+	Describe(`DiffRulesetVersions(from, to)`, func() {
+		It(`Report added, removed, reordered and changed rules`, func() {
+			blockWithPage := versionRule("r-4", "", "block", `ssl`)
+			blockWithPage.ActionParameters = &rulesetsv1.ActionParameters{Response: &rulesetsv1.ActionParametersResponse{StatusCode: core.Int64Ptr(403)}}
+			from := &rulesetsv1.RulesetDetails{Version: core.StringPtr("3"), Rules: []rulesetsv1.RuleDetails{
+				versionRule("r-1", "allow-office", "skip", `ip.src in $office`),
+				versionRule("r-2", "log-admin", "log", `http.request.uri.path eq "/admin"`),
+				versionRule("r-3", "challenge", "challenge", `cf.threat_score gt 10`),
+				blockWithPage,
+			}}
+
+			blockWithPage.ActionParameters = &rulesetsv1.ActionParameters{Response: &rulesetsv1.ActionParametersResponse{StatusCode: core.Int64Ptr(429)}}
+			blockWithPage.Version = core.StringPtr("5")
+			challenge := versionRule("r-3b", "challenge", "managed_challenge", `cf.threat_score gt 10`)
+			challenge.Enabled = core.BoolPtr(false)
+			to := &rulesetsv1.RulesetDetails{Version: core.StringPtr("5"), Rules: []rulesetsv1.RuleDetails{
+				blockWithPage,
+				versionRule("r-1", "allow-office", "skip", `ip.src in $office`),
+				challenge,
+				versionRule("r-5", "new", "log", `true`),
+			}}
+
+			diff := rulesetsv1.DiffRulesetVersions(from, to)
+			Expect(diff.FromVersion).To(Equal("3"))
+			Expect(diff.ToVersion).To(Equal("5"))
+			Expect(diff.Empty()).To(BeFalse())
+			Expect(changeKeys(diff.Added)).To(Equal([]string{"new"}))
+			Expect(diff.Added[0].ToPosition).To(Equal(3))
+			Expect(changeKeys(diff.Removed)).To(Equal([]string{"log-admin"}))
+			Expect(diff.Removed[0].FromPosition).To(Equal(1))
+			Expect(changeKeys(diff.Reordered)).To(Equal([]string{"r-4"}))
+			Expect(diff.Reordered[0].FromPosition).To(Equal(3))
+			Expect(diff.Reordered[0].ToPosition).To(Equal(0))
+			Expect(changeKeys(diff.Changed)).To(Equal([]string{"r-4", "challenge"}))
+			Expect(diff.Changed[0].Fields).To(Equal([]rulesetsv1.FieldChange{
+				{Path: "action_parameters.response.status_code", From: float64(403), To: float64(429)},
+			}))
+			Expect(diff.Changed[1].Fields).To(Equal([]rulesetsv1.FieldChange{
+				{Path: "action", From: "challenge", To: "managed_challenge"},
+				{Path: "enabled", From: true, To: false},
+			}))
+
+			Expect(rulesetsv1.DiffRulesetVersions(to, to).Empty()).To(BeTrue())
+			Expect(changeKeys(rulesetsv1.DiffRulesetVersions(nil, to).Added)).To(HaveLen(4))
+		})
+	})
+	Describe(`NewRuleCreateFromDetails(rule)`, func() {
+		It(`Copy a rule without sharing its values`, func() {
+			rule := versionRule("r1", "block-bots", "block", `cf.client.bot`)
+			rule.ActionParameters = &rulesetsv1.ActionParameters{ID: core.StringPtr("managed")}
+			copied, err := rulesetsv1.NewRuleCreateFromDetails(&rule)
+			Expect(err).To(BeNil())
+			Expect(*copied.Ref).To(Equal("block-bots"))
+			Expect(*copied.Expression).To(Equal(`cf.client.bot`))
+			Expect(*copied.ActionParameters.ID).To(Equal("managed"))
+			*rule.ActionParameters.ID = "changed"
+			Expect(*copied.ActionParameters.ID).To(Equal("managed"))
+		})
+	})
+	Describe(`RollbackToVersion(ctx, phase, version)`, func() {
+		var testServer *httptest.Server
+		var updated map[string]interface{}
+		var rulesetsService *rulesetsv1.RulesetsV1
+
+		BeforeEach(func() {
+			updated = nil
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				res.Header().Set("Content-type", "application/json")
+				switch {
+				case req.Method == "GET" && req.URL.EscapedPath() == "/v1/crn/zones/zone/rulesets/phases/http_request_firewall_custom/entrypoint/versions/2":
+					res.WriteHeader(200)
+					fmt.Fprint(res, `{"success": true, "errors": [], "messages": [], "result": {"id": "rs-1", "name": "default", "description": "Custom rules", `+
+						`"kind": "zone", "phase": "http_request_firewall_custom", "version": "2", "last_updated": "2025-01-01T00:00:00Z", "rules": [`+
+						`{"id": "r-1", "ref": "allow-office", "version": "1", "action": "skip", "action_parameters": {"ruleset": "current"}, "expression": "ip.src in $office", "enabled": true}, `+
+						`{"id": "r-2", "version": "2", "action": "block", "expression": "ssl", "enabled": false, "description": "Block"}]}}`)
+				case req.Method == "GET" && req.URL.EscapedPath() == "/v1/crn/zones/zone/rulesets/phases/http_request_firewall_custom/entrypoint/versions/1":
+					res.WriteHeader(200)
+					fmt.Fprint(res, `{"success": true, "errors": [], "messages": [], "result": {"id": "rs-1", "name": "default", "description": "Custom rules", `+
+						`"kind": "zone", "phase": "http_request_firewall_custom", "version": "1", "last_updated": "2025-01-01T00:00:00Z", "rules": []}}`)
+				case req.Method == "GET":
+					res.WriteHeader(404)
+					fmt.Fprint(res, `{"success": false, "errors": [{"code": 10003, "message": "not found"}], "messages": []}`)
+				case req.Method == "PUT" && req.URL.EscapedPath() == "/v1/crn/zones/zone/rulesets/phases/http_request_firewall_custom/entrypoint":
+					Expect(json.NewDecoder(req.Body).Decode(&updated)).To(Succeed())
+					res.WriteHeader(200)
+					fmt.Fprint(res, `{"success": true, "errors": [], "messages": [], "result": {"id": "rs-1", "version": "6", "rules": []}}`)
+				}
+			}))
+			var err error
+			rulesetsService, err = rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{
+				URL:            testServer.URL,
+				Authenticator:  &core.NoAuthAuthenticator{},
+				Crn:            core.StringPtr("crn"),
+				ZoneIdentifier: core.StringPtr("zone"),
+			})
+			Expect(err).To(BeNil())
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It(`Rebuild the entry point from the rules of the version`, func() {
+			result, response, err := rulesetsService.RollbackToVersion(context.Background(), "http_request_firewall_custom", "2")
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(*result.Result.Version).To(Equal("6"))
+			Expect(updated).To(Equal(map[string]interface{}{
+				"name":        "default",
+				"description": "Custom rules",
+				"kind":        "zone",
+				"phase":       "http_request_firewall_custom",
+				"rules": []interface{}{
+					map[string]interface{}{"ref": "allow-office", "action": "skip", "action_parameters": map[string]interface{}{"ruleset": "current"}, "expression": "ip.src in $office", "enabled": true},
+					map[string]interface{}{"ref": "r-2", "action": "block", "expression": "ssl", "enabled": false, "description": "Block"},
+				},
+			}))
+		})
+		It(`Empty the entry point when the version has no rules`, func() {
+			_, _, err := rulesetsService.RollbackToVersion(context.Background(), "http_request_firewall_custom", "1")
+			Expect(err).To(BeNil())
+			Expect(updated).To(HaveKeyWithValue("rules", []interface{}{}))
+		})
+		It(`Fail without changes when the version cannot be read`, func() {
+			_, response, err := rulesetsService.RollbackToVersion(context.Background(), "http_request_firewall_custom", "9")
+			Expect(err).ToNot(BeNil())
+			Expect(response.StatusCode).To(Equal(404))
+			Expect(updated).To(BeNil())
+		})
+	})
+})
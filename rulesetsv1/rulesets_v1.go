@@ -1034,6 +1034,9 @@ func (rulesets *RulesetsV1) CreateInstanceRulesetRuleWithContext(ctx context.Con
 	if createInstanceRulesetRuleOptions.ActionParameters != nil {
 		body["action_parameters"] = createInstanceRulesetRuleOptions.ActionParameters
 	}
+	if createInstanceRulesetRuleOptions.Ratelimit != nil {
+		body["ratelimit"] = createInstanceRulesetRuleOptions.Ratelimit
+	}
 	if createInstanceRulesetRuleOptions.Description != nil {
 		body["description"] = createInstanceRulesetRuleOptions.Description
 	}
@@ -1140,6 +1143,9 @@ func (rulesets *RulesetsV1) UpdateInstanceRulesetRuleWithContext(ctx context.Con
 	if updateInstanceRulesetRuleOptions.ActionParameters != nil {
 		body["action_parameters"] = updateInstanceRulesetRuleOptions.ActionParameters
 	}
+	if updateInstanceRulesetRuleOptions.Ratelimit != nil {
+		body["ratelimit"] = updateInstanceRulesetRuleOptions.Ratelimit
+	}
 	if updateInstanceRulesetRuleOptions.Description != nil {
 		body["description"] = updateInstanceRulesetRuleOptions.Description
 	}
@@ -2193,6 +2199,9 @@ func (rulesets *RulesetsV1) CreateZoneRulesetRuleWithContext(ctx context.Context
 	if createZoneRulesetRuleOptions.ActionParameters != nil {
 		body["action_parameters"] = createZoneRulesetRuleOptions.ActionParameters
 	}
+	if createZoneRulesetRuleOptions.Ratelimit != nil {
+		body["ratelimit"] = createZoneRulesetRuleOptions.Ratelimit
+	}
 	if createZoneRulesetRuleOptions.Description != nil {
 		body["description"] = createZoneRulesetRuleOptions.Description
 	}
@@ -2300,6 +2309,9 @@ func (rulesets *RulesetsV1) UpdateZoneRulesetRuleWithContext(ctx context.Context
 	if updateZoneRulesetRuleOptions.ActionParameters != nil {
 		body["action_parameters"] = updateZoneRulesetRuleOptions.ActionParameters
 	}
+	if updateZoneRulesetRuleOptions.Ratelimit != nil {
+		body["ratelimit"] = updateZoneRulesetRuleOptions.Ratelimit
+	}
 	if updateZoneRulesetRuleOptions.Description != nil {
 		body["description"] = updateZoneRulesetRuleOptions.Description
 	}
@@ -2484,6 +2496,9 @@ type CreateInstanceRulesetRuleOptions struct {
 
 	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
 
+	// The rate limiting parameters, for rules in the http_ratelimit phase.
+	Ratelimit *Ratelimit `json:"ratelimit,omitempty"`
+
 	Description *string `json:"description,omitempty"`
 
 	Enabled *bool `json:"enabled,omitempty"`
@@ -2529,6 +2544,12 @@ func (_options *CreateInstanceRulesetRuleOptions) SetActionParameters(actionPara
 	return _options
 }
 
+// SetRatelimit : Allow user to set Ratelimit
+func (_options *CreateInstanceRulesetRuleOptions) SetRatelimit(ratelimit *Ratelimit) *CreateInstanceRulesetRuleOptions {
+	_options.Ratelimit = ratelimit
+	return _options
+}
+
 // SetDescription : Allow user to set Description
 func (_options *CreateInstanceRulesetRuleOptions) SetDescription(description string) *CreateInstanceRulesetRuleOptions {
 	_options.Description = core.StringPtr(description)
@@ -2587,6 +2608,9 @@ type CreateZoneRulesetRuleOptions struct {
 
 	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
 
+	// The rate limiting parameters, for rules in the http_ratelimit phase.
+	Ratelimit *Ratelimit `json:"ratelimit,omitempty"`
+
 	Description *string `json:"description,omitempty"`
 
 	Enabled *bool `json:"enabled,omitempty"`
@@ -2632,6 +2656,12 @@ func (_options *CreateZoneRulesetRuleOptions) SetActionParameters(actionParamete
 	return _options
 }
 
+// SetRatelimit : Allow user to set Ratelimit
+func (_options *CreateZoneRulesetRuleOptions) SetRatelimit(ratelimit *Ratelimit) *CreateZoneRulesetRuleOptions {
+	_options.Ratelimit = ratelimit
+	return _options
+}
+
 // SetDescription : Allow user to set Description
 func (_options *CreateZoneRulesetRuleOptions) SetDescription(description string) *CreateZoneRulesetRuleOptions {
 	_options.Description = core.StringPtr(description)
@@ -3762,6 +3792,9 @@ type UpdateInstanceRulesetRuleOptions struct {
 
 	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
 
+	// The rate limiting parameters, for rules in the http_ratelimit phase.
+	Ratelimit *Ratelimit `json:"ratelimit,omitempty"`
+
 	Description *string `json:"description,omitempty"`
 
 	Enabled *bool `json:"enabled,omitempty"`
@@ -3814,6 +3847,12 @@ func (_options *UpdateInstanceRulesetRuleOptions) SetActionParameters(actionPara
 	return _options
 }
 
+// SetRatelimit : Allow user to set Ratelimit
+func (_options *UpdateInstanceRulesetRuleOptions) SetRatelimit(ratelimit *Ratelimit) *UpdateInstanceRulesetRuleOptions {
+	_options.Ratelimit = ratelimit
+	return _options
+}
+
 // SetDescription : Allow user to set Description
 func (_options *UpdateInstanceRulesetRuleOptions) SetDescription(description string) *UpdateInstanceRulesetRuleOptions {
 	_options.Description = core.StringPtr(description)
@@ -4108,6 +4147,9 @@ type UpdateZoneRulesetRuleOptions struct {
 
 	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
 
+	// The rate limiting parameters, for rules in the http_ratelimit phase.
+	Ratelimit *Ratelimit `json:"ratelimit,omitempty"`
+
 	Description *string `json:"description,omitempty"`
 
 	Enabled *bool `json:"enabled,omitempty"`
@@ -4160,6 +4202,12 @@ func (_options *UpdateZoneRulesetRuleOptions) SetActionParameters(actionParamete
 	return _options
 }
 
+// SetRatelimit : Allow user to set Ratelimit
+func (_options *UpdateZoneRulesetRuleOptions) SetRatelimit(ratelimit *Ratelimit) *UpdateZoneRulesetRuleOptions {
+	_options.Ratelimit = ratelimit
+	return _options
+}
+
 // SetDescription : Allow user to set Description
 func (_options *UpdateZoneRulesetRuleOptions) SetDescription(description string) *UpdateZoneRulesetRuleOptions {
 	_options.Description = core.StringPtr(description)
@@ -4208,7 +4256,9 @@ func (options *UpdateZoneRulesetRuleOptions) SetHeaders(param map[string]string)
 	return options
 }
 
-// ActionParameters : ActionParameters struct
+// ActionParameters : The parameters of a rule action. Which parameters apply depends on the action and on the phase of
+// the ruleset. Parameters that the SDK does not model are kept as additional properties, so a rule read from the service
+// can be sent back unchanged.
 type ActionParameters struct {
 	// unique ID of the ruleset.
 	ID *string `json:"id,omitempty"`
@@ -4225,6 +4275,183 @@ type ActionParameters struct {
 	Rulesets []string `json:"rulesets,omitempty"`
 
 	Response *ActionParametersResponse `json:"response,omitempty"`
+
+	// The phases to skip, for the skip action.
+	Phases []string `json:"phases,omitempty"`
+
+	// The legacy security products to skip, for the skip action.
+	Products []string `json:"products,omitempty"`
+
+	// The rules to skip, by ruleset ID, for the skip action.
+	Rules map[string][]string `json:"rules,omitempty"`
+
+	// The URI rewrite, for the rewrite action in the http_request_transform phase.
+	URI *ActionParametersURI `json:"uri,omitempty"`
+
+	// The header transformations by header name, for the rewrite action in the http_request_transform,
+	// http_request_late_transform and http_response_headers_transform phases.
+	Headers map[string]ActionParametersHeader `json:"headers,omitempty"`
+
+	// The redirect, for the redirect action in the http_request_dynamic_redirect phase.
+	FromValue *ActionParametersFromValue `json:"from_value,omitempty"`
+
+	// Whether the response is eligible for caching, in the http_request_cache_settings phase.
+	Cache *bool `json:"cache,omitempty"`
+
+	// The edge TTL, in the http_request_cache_settings phase.
+	EdgeTTL *ActionParametersEdgeTTL `json:"edge_ttl,omitempty"`
+
+	// The browser TTL, in the http_request_cache_settings phase.
+	BrowserTTL *ActionParametersBrowserTTL `json:"browser_ttl,omitempty"`
+
+	// Serving of stale content, in the http_request_cache_settings phase.
+	ServeStale *ActionParametersServeStale `json:"serve_stale,omitempty"`
+
+	// Respect strong ETags, in the http_request_cache_settings phase.
+	RespectStrongEtags *bool `json:"respect_strong_etags,omitempty"`
+
+	// The cache key, in the http_request_cache_settings phase.
+	CacheKey *ActionParametersCacheKey `json:"cache_key,omitempty"`
+
+	// Pass error pages of the origin through, in the http_request_cache_settings phase.
+	OriginErrorPagePassthru *bool `json:"origin_error_page_passthru,omitempty"`
+
+	// Honour the Cache-Control directives of the origin, in the http_request_cache_settings phase.
+	OriginCacheControl *bool `json:"origin_cache_control,omitempty"`
+
+	// Ports other than 80 and 443 whose responses are cacheable, in the http_request_cache_settings phase.
+	AdditionalCacheablePorts []int64 `json:"additional_cacheable_ports,omitempty"`
+
+	// The time in seconds to wait for a response from the origin, in the http_request_cache_settings phase.
+	ReadTimeout *int64 `json:"read_timeout,omitempty"`
+
+	// The Host header sent to the origin, for the route action in the http_request_origin phase.
+	HostHeader *string `json:"host_header,omitempty"`
+
+	// The origin, for the route action in the http_request_origin phase.
+	Origin *ActionParametersOrigin `json:"origin,omitempty"`
+
+	// The Server Name Indication, for the route action in the http_request_origin phase.
+	Sni *ActionParametersSni `json:"sni,omitempty"`
+
+	// Allows users to set arbitrary properties
+	additionalProperties map[string]interface{}
+}
+
+// SetProperty allows the user to set an arbitrary property on an instance of ActionParameters
+func (o *ActionParameters) SetProperty(key string, value interface{}) {
+	if o.additionalProperties == nil {
+		o.additionalProperties = make(map[string]interface{})
+	}
+	o.additionalProperties[key] = value
+}
+
+// SetProperties allows the user to set a map of arbitrary properties on an instance of ActionParameters
+func (o *ActionParameters) SetProperties(m map[string]interface{}) {
+	o.additionalProperties = make(map[string]interface{})
+	for k, v := range m {
+		o.additionalProperties[k] = v
+	}
+}
+
+// GetProperty allows the user to retrieve an arbitrary property from an instance of ActionParameters
+func (o *ActionParameters) GetProperty(key string) interface{} {
+	return o.additionalProperties[key]
+}
+
+// GetProperties allows the user to retrieve the map of arbitrary properties from an instance of ActionParameters
+func (o *ActionParameters) GetProperties() map[string]interface{} {
+	return o.additionalProperties
+}
+
+// MarshalJSON performs custom serialization for instances of ActionParameters
+func (o *ActionParameters) MarshalJSON() (buffer []byte, err error) {
+	m := make(map[string]interface{})
+	if len(o.additionalProperties) > 0 {
+		for k, v := range o.additionalProperties {
+			m[k] = v
+		}
+	}
+	if o.ID != nil {
+		m["id"] = o.ID
+	}
+	if o.Overrides != nil {
+		m["overrides"] = o.Overrides
+	}
+	if o.Version != nil {
+		m["version"] = o.Version
+	}
+	if o.Ruleset != nil {
+		m["ruleset"] = o.Ruleset
+	}
+	if o.Rulesets != nil {
+		m["rulesets"] = o.Rulesets
+	}
+	if o.Response != nil {
+		m["response"] = o.Response
+	}
+	if o.Phases != nil {
+		m["phases"] = o.Phases
+	}
+	if o.Products != nil {
+		m["products"] = o.Products
+	}
+	if o.Rules != nil {
+		m["rules"] = o.Rules
+	}
+	if o.URI != nil {
+		m["uri"] = o.URI
+	}
+	if o.Headers != nil {
+		m["headers"] = o.Headers
+	}
+	if o.FromValue != nil {
+		m["from_value"] = o.FromValue
+	}
+	if o.Cache != nil {
+		m["cache"] = o.Cache
+	}
+	if o.EdgeTTL != nil {
+		m["edge_ttl"] = o.EdgeTTL
+	}
+	if o.BrowserTTL != nil {
+		m["browser_ttl"] = o.BrowserTTL
+	}
+	if o.ServeStale != nil {
+		m["serve_stale"] = o.ServeStale
+	}
+	if o.RespectStrongEtags != nil {
+		m["respect_strong_etags"] = o.RespectStrongEtags
+	}
+	if o.CacheKey != nil {
+		m["cache_key"] = o.CacheKey
+	}
+	if o.OriginErrorPagePassthru != nil {
+		m["origin_error_page_passthru"] = o.OriginErrorPagePassthru
+	}
+	if o.OriginCacheControl != nil {
+		m["origin_cache_control"] = o.OriginCacheControl
+	}
+	if o.AdditionalCacheablePorts != nil {
+		m["additional_cacheable_ports"] = o.AdditionalCacheablePorts
+	}
+	if o.ReadTimeout != nil {
+		m["read_timeout"] = o.ReadTimeout
+	}
+	if o.HostHeader != nil {
+		m["host_header"] = o.HostHeader
+	}
+	if o.Origin != nil {
+		m["origin"] = o.Origin
+	}
+	if o.Sni != nil {
+		m["sni"] = o.Sni
+	}
+	buffer, err = json.Marshal(m)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "model-marshal", common.GetComponentInfo())
+	}
+	return
 }
 
 // UnmarshalActionParameters unmarshals an instance of ActionParameters from the specified map of raw messages.
@@ -4235,200 +4462,929 @@ func UnmarshalActionParameters(m map[string]json.RawMessage, result interface{})
 		err = core.SDKErrorf(err, "", "id-error", common.GetComponentInfo())
 		return
 	}
+	delete(m, "id")
 	err = core.UnmarshalModel(m, "overrides", &obj.Overrides, UnmarshalOverrides)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "overrides-error", common.GetComponentInfo())
 		return
 	}
+	delete(m, "overrides")
 	err = core.UnmarshalPrimitive(m, "version", &obj.Version)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "version-error", common.GetComponentInfo())
 		return
 	}
+	delete(m, "version")
 	err = core.UnmarshalPrimitive(m, "ruleset", &obj.Ruleset)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "ruleset-error", common.GetComponentInfo())
 		return
 	}
+	delete(m, "ruleset")
 	err = core.UnmarshalPrimitive(m, "rulesets", &obj.Rulesets)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "rulesets-error", common.GetComponentInfo())
 		return
 	}
+	delete(m, "rulesets")
 	err = core.UnmarshalModel(m, "response", &obj.Response, UnmarshalActionParametersResponse)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "response-error", common.GetComponentInfo())
 		return
 	}
+	delete(m, "response")
+	err = core.UnmarshalPrimitive(m, "phases", &obj.Phases)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "phases-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "phases")
+	err = core.UnmarshalPrimitive(m, "products", &obj.Products)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "products-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "products")
+	err = core.UnmarshalPrimitive(m, "rules", &obj.Rules)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "rules-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "rules")
+	err = core.UnmarshalModel(m, "uri", &obj.URI, UnmarshalActionParametersURI)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "uri-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "uri")
+	err = core.UnmarshalModel(m, "headers", &obj.Headers, UnmarshalActionParametersHeader)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "headers-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "headers")
+	err = core.UnmarshalModel(m, "from_value", &obj.FromValue, UnmarshalActionParametersFromValue)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "from_value-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "from_value")
+	err = core.UnmarshalPrimitive(m, "cache", &obj.Cache)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "cache-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "cache")
+	err = core.UnmarshalModel(m, "edge_ttl", &obj.EdgeTTL, UnmarshalActionParametersEdgeTTL)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "edge_ttl-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "edge_ttl")
+	err = core.UnmarshalModel(m, "browser_ttl", &obj.BrowserTTL, UnmarshalActionParametersBrowserTTL)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "browser_ttl-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "browser_ttl")
+	err = core.UnmarshalModel(m, "serve_stale", &obj.ServeStale, UnmarshalActionParametersServeStale)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "serve_stale-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "serve_stale")
+	err = core.UnmarshalPrimitive(m, "respect_strong_etags", &obj.RespectStrongEtags)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "respect_strong_etags-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "respect_strong_etags")
+	err = core.UnmarshalModel(m, "cache_key", &obj.CacheKey, UnmarshalActionParametersCacheKey)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "cache_key-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "cache_key")
+	err = core.UnmarshalPrimitive(m, "origin_error_page_passthru", &obj.OriginErrorPagePassthru)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "origin_error_page_passthru-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "origin_error_page_passthru")
+	err = core.UnmarshalPrimitive(m, "origin_cache_control", &obj.OriginCacheControl)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "origin_cache_control-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "origin_cache_control")
+	err = core.UnmarshalPrimitive(m, "additional_cacheable_ports", &obj.AdditionalCacheablePorts)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "additional_cacheable_ports-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "additional_cacheable_ports")
+	err = core.UnmarshalPrimitive(m, "read_timeout", &obj.ReadTimeout)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "read_timeout-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "read_timeout")
+	err = core.UnmarshalPrimitive(m, "host_header", &obj.HostHeader)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "host_header-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "host_header")
+	err = core.UnmarshalModel(m, "origin", &obj.Origin, UnmarshalActionParametersOrigin)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "origin-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "origin")
+	err = core.UnmarshalModel(m, "sni", &obj.Sni, UnmarshalActionParametersSni)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "sni-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "sni")
+	for k := range m {
+		var v interface{}
+		e := core.UnmarshalPrimitive(m, k, &v)
+		if e != nil {
+			err = core.SDKErrorf(e, "", "additional-properties-error", common.GetComponentInfo())
+			return
+		}
+		obj.SetProperty(k, v)
+	}
 	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
 	return
 }
 
-// CategoriesOverride : CategoriesOverride struct
-type CategoriesOverride struct {
-	// The category tag name to override.
-	Category *string `json:"category,omitempty"`
-
-	Enabled *bool `json:"enabled,omitempty"`
+// ActionParametersBrowserTTL : How long browsers may cache the response. Used in the http_request_cache_settings phase.
+type ActionParametersBrowserTTL struct {
+	// How the browser TTL is determined.
+	Mode *string `json:"mode,omitempty"`
 
-	// What happens when theres a match for the rule expression.
-	Action *string `json:"action,omitempty"`
+	// The browser TTL in seconds, for mode override_origin.
+	Default *int64 `json:"default,omitempty"`
 }
 
-// UnmarshalCategoriesOverride unmarshals an instance of CategoriesOverride from the specified map of raw messages.
-func UnmarshalCategoriesOverride(m map[string]json.RawMessage, result interface{}) (err error) {
-	obj := new(CategoriesOverride)
-	err = core.UnmarshalPrimitive(m, "category", &obj.Category)
-	if err != nil {
-		err = core.SDKErrorf(err, "", "category-error", common.GetComponentInfo())
-		return
-	}
-	err = core.UnmarshalPrimitive(m, "enabled", &obj.Enabled)
+// Constants associated with the ActionParametersBrowserTTL.Mode property.
+// How the browser TTL is determined.
+const (
+	ActionParametersBrowserTTL_Mode_Bypass         = "bypass"
+	ActionParametersBrowserTTL_Mode_OverrideOrigin = "override_origin"
+	ActionParametersBrowserTTL_Mode_RespectOrigin  = "respect_origin"
+)
+
+// UnmarshalActionParametersBrowserTTL unmarshals an instance of ActionParametersBrowserTTL from the specified map of raw messages.
+func UnmarshalActionParametersBrowserTTL(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersBrowserTTL)
+	err = core.UnmarshalPrimitive(m, "mode", &obj.Mode)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "enabled-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "mode-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalPrimitive(m, "action", &obj.Action)
+	err = core.UnmarshalPrimitive(m, "default", &obj.Default)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "action-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "default-error", common.GetComponentInfo())
 		return
 	}
 	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
 	return
 }
 
-// ListRulesetsResp : List rulesets response.
-type ListRulesetsResp struct {
-	// Was operation successful.
-	Success *bool `json:"success" validate:"required"`
+// ActionParametersCacheKey : How the cache key of a request is built. Used in the http_request_cache_settings phase.
+type ActionParametersCacheKey struct {
+	// Separate cached content by device type.
+	CacheByDeviceType *bool `json:"cache_by_device_type,omitempty"`
 
-	// Array of errors encountered.
-	Errors []Message `json:"errors" validate:"required"`
+	// Protect from web cache deception attacks while still allowing static assets to be cached.
+	CacheDeceptionArmor *bool `json:"cache_deception_armor,omitempty"`
 
-	// Array of messages returned.
-	Messages []Message `json:"messages" validate:"required"`
+	// Treat query strings with the same parameters in a different order as the same.
+	IgnoreQueryStringsOrder *bool `json:"ignore_query_strings_order,omitempty"`
 
-	// Container for response information.
-	Result []ListedRuleset `json:"result" validate:"required"`
+	// The parts of the request included in the cache key.
+	CustomKey *ActionParametersCustomKey `json:"custom_key,omitempty"`
 }
 
-// UnmarshalListRulesetsResp unmarshals an instance of ListRulesetsResp from the specified map of raw messages.
-func UnmarshalListRulesetsResp(m map[string]json.RawMessage, result interface{}) (err error) {
-	obj := new(ListRulesetsResp)
-	err = core.UnmarshalPrimitive(m, "success", &obj.Success)
+// UnmarshalActionParametersCacheKey unmarshals an instance of ActionParametersCacheKey from the specified map of raw messages.
+func UnmarshalActionParametersCacheKey(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersCacheKey)
+	err = core.UnmarshalPrimitive(m, "cache_by_device_type", &obj.CacheByDeviceType)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "success-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "cache_by_device_type-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalModel(m, "errors", &obj.Errors, UnmarshalMessage)
+	err = core.UnmarshalPrimitive(m, "cache_deception_armor", &obj.CacheDeceptionArmor)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "errors-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "cache_deception_armor-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalModel(m, "messages", &obj.Messages, UnmarshalMessage)
+	err = core.UnmarshalPrimitive(m, "ignore_query_strings_order", &obj.IgnoreQueryStringsOrder)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "messages-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "ignore_query_strings_order-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalModel(m, "result", &obj.Result, UnmarshalListedRuleset)
+	err = core.UnmarshalModel(m, "custom_key", &obj.CustomKey, UnmarshalActionParametersCustomKey)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "result-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "custom_key-error", common.GetComponentInfo())
 		return
 	}
 	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
 	return
 }
 
-// ListedRuleset : ListedRuleset struct
-type ListedRuleset struct {
-	// description of the ruleset.
-	Description *string `json:"description" validate:"required"`
-
-	// unique ID of the ruleset.
-	ID *string `json:"id" validate:"required"`
-
-	Kind *string `json:"kind" validate:"required"`
+// ActionParametersCustomKey : The parts of the request included in the cache key.
+type ActionParametersCustomKey struct {
+	QueryString *CustomKeyQueryString `json:"query_string,omitempty"`
 
-	// The timestamp of when the resource was last modified.
-	LastUpdated *string `json:"last_updated" validate:"required"`
+	Header *CustomKeyHeader `json:"header,omitempty"`
 
-	// human readable name of the ruleset.
-	Name *string `json:"name" validate:"required"`
+	Cookie *CustomKeyCookie `json:"cookie,omitempty"`
 
-	// The phase of the ruleset.
-	Phase *string `json:"phase" validate:"required"`
+	User *CustomKeyUser `json:"user,omitempty"`
 
-	// The version of the ruleset.
-	Version *string `json:"version" validate:"required"`
+	Host *CustomKeyHost `json:"host,omitempty"`
 }
 
-// Constants associated with the ListedRuleset.Kind property.
-const (
-	ListedRuleset_Kind_Custom  = "custom"
-	ListedRuleset_Kind_Managed = "managed"
-	ListedRuleset_Kind_Root    = "root"
-	ListedRuleset_Kind_Zone    = "zone"
-)
-
-// Constants associated with the ListedRuleset.Phase property.
-// The phase of the ruleset.
-const (
-	ListedRuleset_Phase_DdosL4                         = "ddos_l4"
-	ListedRuleset_Phase_DdosL7                         = "ddos_l7"
-	ListedRuleset_Phase_HttpConfigSettings             = "http_config_settings"
-	ListedRuleset_Phase_HttpCustomErrors               = "http_custom_errors"
-	ListedRuleset_Phase_HttpLogCustomFields            = "http_log_custom_fields"
-	ListedRuleset_Phase_HttpRatelimit                  = "http_ratelimit"
-	ListedRuleset_Phase_HttpRequestCacheSettings       = "http_request_cache_settings"
-	ListedRuleset_Phase_HttpRequestDynamicRedirect     = "http_request_dynamic_redirect"
-	ListedRuleset_Phase_HttpRequestFirewallCustom      = "http_request_firewall_custom"
-	ListedRuleset_Phase_HttpRequestFirewallManaged     = "http_request_firewall_managed"
-	ListedRuleset_Phase_HttpRequestLateTransform       = "http_request_late_transform"
-	ListedRuleset_Phase_HttpRequestOrigin              = "http_request_origin"
-	ListedRuleset_Phase_HttpRequestRedirect            = "http_request_redirect"
-	ListedRuleset_Phase_HttpRequestSanitize            = "http_request_sanitize"
-	ListedRuleset_Phase_HttpRequestSbfm                = "http_request_sbfm"
-	ListedRuleset_Phase_HttpRequestSelectConfiguration = "http_request_select_configuration"
-	ListedRuleset_Phase_HttpRequestTransform           = "http_request_transform"
-	ListedRuleset_Phase_HttpResponseCompression        = "http_response_compression"
-	ListedRuleset_Phase_HttpResponseFirewallManaged    = "http_response_firewall_managed"
-	ListedRuleset_Phase_HttpResponseHeadersTransform   = "http_response_headers_transform"
-)
-
-// UnmarshalListedRuleset unmarshals an instance of ListedRuleset from the specified map of raw messages.
-func UnmarshalListedRuleset(m map[string]json.RawMessage, result interface{}) (err error) {
-	obj := new(ListedRuleset)
-	err = core.UnmarshalPrimitive(m, "description", &obj.Description)
-	if err != nil {
-		err = core.SDKErrorf(err, "", "description-error", common.GetComponentInfo())
-		return
-	}
-	err = core.UnmarshalPrimitive(m, "id", &obj.ID)
+// UnmarshalActionParametersCustomKey unmarshals an instance of ActionParametersCustomKey from the specified map of raw messages.
+func UnmarshalActionParametersCustomKey(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersCustomKey)
+	err = core.UnmarshalModel(m, "query_string", &obj.QueryString, UnmarshalCustomKeyQueryString)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "id-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "query_string-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalPrimitive(m, "kind", &obj.Kind)
+	err = core.UnmarshalModel(m, "header", &obj.Header, UnmarshalCustomKeyHeader)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "kind-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "header-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalPrimitive(m, "last_updated", &obj.LastUpdated)
+	err = core.UnmarshalModel(m, "cookie", &obj.Cookie, UnmarshalCustomKeyCookie)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "last_updated-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "cookie-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalPrimitive(m, "name", &obj.Name)
+	err = core.UnmarshalModel(m, "user", &obj.User, UnmarshalCustomKeyUser)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "name-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "user-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalPrimitive(m, "phase", &obj.Phase)
+	err = core.UnmarshalModel(m, "host", &obj.Host, UnmarshalCustomKeyHost)
 	if err != nil {
-		err = core.SDKErrorf(err, "", "phase-error", common.GetComponentInfo())
+		err = core.SDKErrorf(err, "", "host-error", common.GetComponentInfo())
 		return
 	}
-	err = core.UnmarshalPrimitive(m, "version", &obj.Version)
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersEdgeTTL : How long the edge caches the response. Used in the http_request_cache_settings phase.
+type ActionParametersEdgeTTL struct {
+	// How the edge TTL is determined.
+	Mode *string `json:"mode,omitempty"`
+
+	// The edge TTL in seconds, for mode override_origin.
+	Default *int64 `json:"default,omitempty"`
+
+	// Edge TTLs for specific status codes or ranges of status codes.
+	StatusCodeTTL []ActionParametersStatusCodeTTL `json:"status_code_ttl,omitempty"`
+}
+
+// Constants associated with the ActionParametersEdgeTTL.Mode property.
+// How the edge TTL is determined.
+const (
+	ActionParametersEdgeTTL_Mode_BypassByDefault = "bypass_by_default"
+	ActionParametersEdgeTTL_Mode_OverrideOrigin  = "override_origin"
+	ActionParametersEdgeTTL_Mode_RespectOrigin   = "respect_origin"
+)
+
+// UnmarshalActionParametersEdgeTTL unmarshals an instance of ActionParametersEdgeTTL from the specified map of raw messages.
+func UnmarshalActionParametersEdgeTTL(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersEdgeTTL)
+	err = core.UnmarshalPrimitive(m, "mode", &obj.Mode)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "mode-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "default", &obj.Default)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "default-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "status_code_ttl", &obj.StatusCodeTTL, UnmarshalActionParametersStatusCodeTTL)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "status_code_ttl-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersFromValue : The redirect target. Used in the http_request_dynamic_redirect phase.
+type ActionParametersFromValue struct {
+	// The status code of the redirect.
+	StatusCode *int64 `json:"status_code,omitempty"`
+
+	TargetURL *ActionParametersTargetURL `json:"target_url,omitempty"`
+
+	// Keep the query string of the original request.
+	PreserveQueryString *bool `json:"preserve_query_string,omitempty"`
+}
+
+// UnmarshalActionParametersFromValue unmarshals an instance of ActionParametersFromValue from the specified map of raw messages.
+func UnmarshalActionParametersFromValue(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersFromValue)
+	err = core.UnmarshalPrimitive(m, "status_code", &obj.StatusCode)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "status_code-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "target_url", &obj.TargetURL, UnmarshalActionParametersTargetURL)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "target_url-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "preserve_query_string", &obj.PreserveQueryString)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "preserve_query_string-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersHeader : A header transformation. Used in the http_request_transform, http_request_late_transform and
+// http_response_headers_transform phases.
+type ActionParametersHeader struct {
+	// The operation applied to the header.
+	Operation *string `json:"operation,omitempty"`
+
+	// The static value of the header, for the set and add operations.
+	Value *string `json:"value,omitempty"`
+
+	// An expression evaluated to the value of the header, for the set and add operations.
+	Expression *string `json:"expression,omitempty"`
+}
+
+// Constants associated with the ActionParametersHeader.Operation property.
+// The operation applied to the header.
+const (
+	ActionParametersHeader_Operation_Add    = "add"
+	ActionParametersHeader_Operation_Remove = "remove"
+	ActionParametersHeader_Operation_Set    = "set"
+)
+
+// UnmarshalActionParametersHeader unmarshals an instance of ActionParametersHeader from the specified map of raw messages.
+func UnmarshalActionParametersHeader(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersHeader)
+	err = core.UnmarshalPrimitive(m, "operation", &obj.Operation)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "operation-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "value-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "expression", &obj.Expression)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "expression-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersOrigin : The origin that requests are sent to. Used in the http_request_origin phase.
+type ActionParametersOrigin struct {
+	// The host name of the origin.
+	Host *string `json:"host,omitempty"`
+
+	// The port of the origin.
+	Port *int64 `json:"port,omitempty"`
+}
+
+// UnmarshalActionParametersOrigin unmarshals an instance of ActionParametersOrigin from the specified map of raw messages.
+func UnmarshalActionParametersOrigin(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersOrigin)
+	err = core.UnmarshalPrimitive(m, "host", &obj.Host)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "host-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "port", &obj.Port)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "port-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersServeStale : Serving of stale content. Used in the http_request_cache_settings phase.
+type ActionParametersServeStale struct {
+	// Do not serve stale content while the cache is updated.
+	DisableStaleWhileUpdating *bool `json:"disable_stale_while_updating,omitempty"`
+}
+
+// UnmarshalActionParametersServeStale unmarshals an instance of ActionParametersServeStale from the specified map of raw messages.
+func UnmarshalActionParametersServeStale(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersServeStale)
+	err = core.UnmarshalPrimitive(m, "disable_stale_while_updating", &obj.DisableStaleWhileUpdating)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "disable_stale_while_updating-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersSni : The Server Name Indication sent to the origin. Used in the http_request_origin phase.
+type ActionParametersSni struct {
+	// The server name.
+	Value *string `json:"value,omitempty"`
+}
+
+// UnmarshalActionParametersSni unmarshals an instance of ActionParametersSni from the specified map of raw messages.
+func UnmarshalActionParametersSni(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersSni)
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "value-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersStatusCodeRange : An inclusive range of status codes.
+type ActionParametersStatusCodeRange struct {
+	// The first status code of the range.
+	From *int64 `json:"from,omitempty"`
+
+	// The last status code of the range.
+	To *int64 `json:"to,omitempty"`
+}
+
+// UnmarshalActionParametersStatusCodeRange unmarshals an instance of ActionParametersStatusCodeRange from the specified map of raw messages.
+func UnmarshalActionParametersStatusCodeRange(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersStatusCodeRange)
+	err = core.UnmarshalPrimitive(m, "from", &obj.From)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "from-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "to", &obj.To)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "to-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersStatusCodeTTL : The edge TTL of a status code or of a range of status codes.
+type ActionParametersStatusCodeTTL struct {
+	// A single status code.
+	StatusCode *int64 `json:"status_code,omitempty"`
+
+	StatusCodeRange *ActionParametersStatusCodeRange `json:"status_code_range,omitempty"`
+
+	// The TTL in seconds. Use -1 to not cache and 0 to respect the origin.
+	Value *int64 `json:"value,omitempty"`
+}
+
+// UnmarshalActionParametersStatusCodeTTL unmarshals an instance of ActionParametersStatusCodeTTL from the specified map of raw messages.
+func UnmarshalActionParametersStatusCodeTTL(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersStatusCodeTTL)
+	err = core.UnmarshalPrimitive(m, "status_code", &obj.StatusCode)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "status_code-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "status_code_range", &obj.StatusCodeRange, UnmarshalActionParametersStatusCodeRange)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "status_code_range-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "value-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersTargetURL : The URL a request is redirected to. Set either a static value or an expression.
+type ActionParametersTargetURL struct {
+	// The static URL.
+	Value *string `json:"value,omitempty"`
+
+	// An expression evaluated to the URL.
+	Expression *string `json:"expression,omitempty"`
+}
+
+// UnmarshalActionParametersTargetURL unmarshals an instance of ActionParametersTargetURL from the specified map of raw messages.
+func UnmarshalActionParametersTargetURL(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersTargetURL)
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "value-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "expression", &obj.Expression)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "expression-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersURI : The URI rewrite. Used in the http_request_transform phase.
+type ActionParametersURI struct {
+	Path *ActionParametersURIComponent `json:"path,omitempty"`
+
+	Query *ActionParametersURIComponent `json:"query,omitempty"`
+}
+
+// UnmarshalActionParametersURI unmarshals an instance of ActionParametersURI from the specified map of raw messages.
+func UnmarshalActionParametersURI(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersURI)
+	err = core.UnmarshalModel(m, "path", &obj.Path, UnmarshalActionParametersURIComponent)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "path-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "query", &obj.Query, UnmarshalActionParametersURIComponent)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "query-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ActionParametersURIComponent : The new value of a part of the URI. Set either a static value or an expression.
+type ActionParametersURIComponent struct {
+	// The static value.
+	Value *string `json:"value,omitempty"`
+
+	// An expression evaluated to the value.
+	Expression *string `json:"expression,omitempty"`
+}
+
+// UnmarshalActionParametersURIComponent unmarshals an instance of ActionParametersURIComponent from the specified map of raw messages.
+func UnmarshalActionParametersURIComponent(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ActionParametersURIComponent)
+	err = core.UnmarshalPrimitive(m, "value", &obj.Value)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "value-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "expression", &obj.Expression)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "expression-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CategoriesOverride : CategoriesOverride struct
+type CategoriesOverride struct {
+	// The category tag name to override.
+	Category *string `json:"category,omitempty"`
+
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// What happens when theres a match for the rule expression.
+	Action *string `json:"action,omitempty"`
+}
+
+// UnmarshalCategoriesOverride unmarshals an instance of CategoriesOverride from the specified map of raw messages.
+func UnmarshalCategoriesOverride(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CategoriesOverride)
+	err = core.UnmarshalPrimitive(m, "category", &obj.Category)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "category-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "enabled", &obj.Enabled)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "enabled-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "action", &obj.Action)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "action-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CustomKeyCookie : The cookies included in the cache key.
+type CustomKeyCookie struct {
+	// Cookies whose value is included in the cache key.
+	Include []string `json:"include,omitempty"`
+
+	// Cookies whose presence is included in the cache key.
+	CheckPresence []string `json:"check_presence,omitempty"`
+}
+
+// UnmarshalCustomKeyCookie unmarshals an instance of CustomKeyCookie from the specified map of raw messages.
+func UnmarshalCustomKeyCookie(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CustomKeyCookie)
+	err = core.UnmarshalPrimitive(m, "include", &obj.Include)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "include-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "check_presence", &obj.CheckPresence)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "check_presence-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CustomKeyHeader : The headers included in the cache key.
+type CustomKeyHeader struct {
+	// Headers whose value is included in the cache key.
+	Include []string `json:"include,omitempty"`
+
+	// Headers whose presence is included in the cache key.
+	CheckPresence []string `json:"check_presence,omitempty"`
+
+	// Exclude the origin header from the cache key.
+	ExcludeOrigin *bool `json:"exclude_origin,omitempty"`
+}
+
+// UnmarshalCustomKeyHeader unmarshals an instance of CustomKeyHeader from the specified map of raw messages.
+func UnmarshalCustomKeyHeader(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CustomKeyHeader)
+	err = core.UnmarshalPrimitive(m, "include", &obj.Include)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "include-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "check_presence", &obj.CheckPresence)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "check_presence-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "exclude_origin", &obj.ExcludeOrigin)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "exclude_origin-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CustomKeyHost : The host included in the cache key.
+type CustomKeyHost struct {
+	// Use the resolved host instead of the Host header.
+	Resolved *bool `json:"resolved,omitempty"`
+}
+
+// UnmarshalCustomKeyHost unmarshals an instance of CustomKeyHost from the specified map of raw messages.
+func UnmarshalCustomKeyHost(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CustomKeyHost)
+	err = core.UnmarshalPrimitive(m, "resolved", &obj.Resolved)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "resolved-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CustomKeyList : A list of names, or all names.
+type CustomKeyList struct {
+	// Match all names.
+	All *bool `json:"all,omitempty"`
+
+	// The names to match.
+	List []string `json:"list,omitempty"`
+}
+
+// UnmarshalCustomKeyList unmarshals an instance of CustomKeyList from the specified map of raw messages.
+func UnmarshalCustomKeyList(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CustomKeyList)
+	err = core.UnmarshalPrimitive(m, "all", &obj.All)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "all-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "list", &obj.List)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "list-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CustomKeyQueryString : The query string parameters included in the cache key.
+type CustomKeyQueryString struct {
+	// Parameters included in the cache key.
+	Include *CustomKeyList `json:"include,omitempty"`
+
+	// Parameters excluded from the cache key.
+	Exclude *CustomKeyList `json:"exclude,omitempty"`
+}
+
+// UnmarshalCustomKeyQueryString unmarshals an instance of CustomKeyQueryString from the specified map of raw messages.
+func UnmarshalCustomKeyQueryString(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CustomKeyQueryString)
+	err = core.UnmarshalModel(m, "include", &obj.Include, UnmarshalCustomKeyList)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "include-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "exclude", &obj.Exclude, UnmarshalCustomKeyList)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "exclude-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// CustomKeyUser : The user features included in the cache key.
+type CustomKeyUser struct {
+	// Include the device type.
+	DeviceType *bool `json:"device_type,omitempty"`
+
+	// Include the country of the visitor.
+	Geo *bool `json:"geo,omitempty"`
+
+	// Include the first language of the Accept-Language header.
+	Lang *bool `json:"lang,omitempty"`
+}
+
+// UnmarshalCustomKeyUser unmarshals an instance of CustomKeyUser from the specified map of raw messages.
+func UnmarshalCustomKeyUser(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(CustomKeyUser)
+	err = core.UnmarshalPrimitive(m, "device_type", &obj.DeviceType)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "device_type-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "geo", &obj.Geo)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "geo-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "lang", &obj.Lang)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "lang-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ListRulesetsResp : List rulesets response.
+type ListRulesetsResp struct {
+	// Was operation successful.
+	Success *bool `json:"success" validate:"required"`
+
+	// Array of errors encountered.
+	Errors []Message `json:"errors" validate:"required"`
+
+	// Array of messages returned.
+	Messages []Message `json:"messages" validate:"required"`
+
+	// Container for response information.
+	Result []ListedRuleset `json:"result" validate:"required"`
+}
+
+// UnmarshalListRulesetsResp unmarshals an instance of ListRulesetsResp from the specified map of raw messages.
+func UnmarshalListRulesetsResp(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ListRulesetsResp)
+	err = core.UnmarshalPrimitive(m, "success", &obj.Success)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "success-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "errors", &obj.Errors, UnmarshalMessage)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "errors-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "messages", &obj.Messages, UnmarshalMessage)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "messages-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalModel(m, "result", &obj.Result, UnmarshalListedRuleset)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "result-error", common.GetComponentInfo())
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+// ListedRuleset : ListedRuleset struct
+type ListedRuleset struct {
+	// description of the ruleset.
+	Description *string `json:"description" validate:"required"`
+
+	// unique ID of the ruleset.
+	ID *string `json:"id" validate:"required"`
+
+	Kind *string `json:"kind" validate:"required"`
+
+	// The timestamp of when the resource was last modified.
+	LastUpdated *string `json:"last_updated" validate:"required"`
+
+	// human readable name of the ruleset.
+	Name *string `json:"name" validate:"required"`
+
+	// The phase of the ruleset.
+	Phase *string `json:"phase" validate:"required"`
+
+	// The version of the ruleset.
+	Version *string `json:"version" validate:"required"`
+}
+
+// Constants associated with the ListedRuleset.Kind property.
+const (
+	ListedRuleset_Kind_Custom  = "custom"
+	ListedRuleset_Kind_Managed = "managed"
+	ListedRuleset_Kind_Root    = "root"
+	ListedRuleset_Kind_Zone    = "zone"
+)
+
+// Constants associated with the ListedRuleset.Phase property.
+// The phase of the ruleset.
+const (
+	ListedRuleset_Phase_DdosL4                         = "ddos_l4"
+	ListedRuleset_Phase_DdosL7                         = "ddos_l7"
+	ListedRuleset_Phase_HttpConfigSettings             = "http_config_settings"
+	ListedRuleset_Phase_HttpCustomErrors               = "http_custom_errors"
+	ListedRuleset_Phase_HttpLogCustomFields            = "http_log_custom_fields"
+	ListedRuleset_Phase_HttpRatelimit                  = "http_ratelimit"
+	ListedRuleset_Phase_HttpRequestCacheSettings       = "http_request_cache_settings"
+	ListedRuleset_Phase_HttpRequestDynamicRedirect     = "http_request_dynamic_redirect"
+	ListedRuleset_Phase_HttpRequestFirewallCustom      = "http_request_firewall_custom"
+	ListedRuleset_Phase_HttpRequestFirewallManaged     = "http_request_firewall_managed"
+	ListedRuleset_Phase_HttpRequestLateTransform       = "http_request_late_transform"
+	ListedRuleset_Phase_HttpRequestOrigin              = "http_request_origin"
+	ListedRuleset_Phase_HttpRequestRedirect            = "http_request_redirect"
+	ListedRuleset_Phase_HttpRequestSanitize            = "http_request_sanitize"
+	ListedRuleset_Phase_HttpRequestSbfm                = "http_request_sbfm"
+	ListedRuleset_Phase_HttpRequestSelectConfiguration = "http_request_select_configuration"
+	ListedRuleset_Phase_HttpRequestTransform           = "http_request_transform"
+	ListedRuleset_Phase_HttpResponseCompression        = "http_response_compression"
+	ListedRuleset_Phase_HttpResponseFirewallManaged    = "http_response_firewall_managed"
+	ListedRuleset_Phase_HttpResponseHeadersTransform   = "http_response_headers_transform"
+)
+
+// UnmarshalListedRuleset unmarshals an instance of ListedRuleset from the specified map of raw messages.
+func UnmarshalListedRuleset(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(ListedRuleset)
+	err = core.UnmarshalPrimitive(m, "description", &obj.Description)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "description-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "id", &obj.ID)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "id-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "kind", &obj.Kind)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "kind-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "last_updated", &obj.LastUpdated)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "last_updated-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "name", &obj.Name)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "name-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "phase", &obj.Phase)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "phase-error", common.GetComponentInfo())
+		return
+	}
+	err = core.UnmarshalPrimitive(m, "version", &obj.Version)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "version-error", common.GetComponentInfo())
 		return
@@ -4589,6 +5545,168 @@ func UnmarshalPosition(m map[string]json.RawMessage, result interface{}) (err er
 	return
 }
 
+// Ratelimit : The rate limiting parameters of a rule in the http_ratelimit phase. Parameters that the SDK does not model are
+// kept as additional properties.
+type Ratelimit struct {
+	// The request properties used to count requests, such as ip.src or cf.colo.id. All rate limiting rules
+	// must include cf.colo.id.
+	Characteristics []string `json:"characteristics,omitempty"`
+
+	// The period in seconds over which requests are counted.
+	Period *int64 `json:"period,omitempty"`
+
+	// The number of requests over the period that triggers the rule.
+	RequestsPerPeriod *int64 `json:"requests_per_period,omitempty"`
+
+	// The score over the period that triggers the rule, for complexity based rate limiting.
+	ScorePerPeriod *int64 `json:"score_per_period,omitempty"`
+
+	// The response header of the origin that holds the score of a request, for complexity based rate limiting.
+	ScoreResponseHeaderName *string `json:"score_response_header_name,omitempty"`
+
+	// The time in seconds the action applies once the rule triggers. Use 0 to only apply it while the rate is
+	// exceeded.
+	MitigationTimeout *int64 `json:"mitigation_timeout,omitempty"`
+
+	// The expression selecting the requests that are counted. Defaults to the rule expression.
+	CountingExpression *string `json:"counting_expression,omitempty"`
+
+	// Only count requests that reach the origin.
+	RequestsToOrigin *bool `json:"requests_to_origin,omitempty"`
+
+	// Allows users to set arbitrary properties
+	additionalProperties map[string]interface{}
+}
+
+// SetProperty allows the user to set an arbitrary property on an instance of Ratelimit
+func (o *Ratelimit) SetProperty(key string, value interface{}) {
+	if o.additionalProperties == nil {
+		o.additionalProperties = make(map[string]interface{})
+	}
+	o.additionalProperties[key] = value
+}
+
+// SetProperties allows the user to set a map of arbitrary properties on an instance of Ratelimit
+func (o *Ratelimit) SetProperties(m map[string]interface{}) {
+	o.additionalProperties = make(map[string]interface{})
+	for k, v := range m {
+		o.additionalProperties[k] = v
+	}
+}
+
+// GetProperty allows the user to retrieve an arbitrary property from an instance of Ratelimit
+func (o *Ratelimit) GetProperty(key string) interface{} {
+	return o.additionalProperties[key]
+}
+
+// GetProperties allows the user to retrieve the map of arbitrary properties from an instance of Ratelimit
+func (o *Ratelimit) GetProperties() map[string]interface{} {
+	return o.additionalProperties
+}
+
+// MarshalJSON performs custom serialization for instances of Ratelimit
+func (o *Ratelimit) MarshalJSON() (buffer []byte, err error) {
+	m := make(map[string]interface{})
+	if len(o.additionalProperties) > 0 {
+		for k, v := range o.additionalProperties {
+			m[k] = v
+		}
+	}
+	if o.Characteristics != nil {
+		m["characteristics"] = o.Characteristics
+	}
+	if o.Period != nil {
+		m["period"] = o.Period
+	}
+	if o.RequestsPerPeriod != nil {
+		m["requests_per_period"] = o.RequestsPerPeriod
+	}
+	if o.ScorePerPeriod != nil {
+		m["score_per_period"] = o.ScorePerPeriod
+	}
+	if o.ScoreResponseHeaderName != nil {
+		m["score_response_header_name"] = o.ScoreResponseHeaderName
+	}
+	if o.MitigationTimeout != nil {
+		m["mitigation_timeout"] = o.MitigationTimeout
+	}
+	if o.CountingExpression != nil {
+		m["counting_expression"] = o.CountingExpression
+	}
+	if o.RequestsToOrigin != nil {
+		m["requests_to_origin"] = o.RequestsToOrigin
+	}
+	buffer, err = json.Marshal(m)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "model-marshal", common.GetComponentInfo())
+	}
+	return
+}
+
+// UnmarshalRatelimit unmarshals an instance of Ratelimit from the specified map of raw messages.
+func UnmarshalRatelimit(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(Ratelimit)
+	err = core.UnmarshalPrimitive(m, "characteristics", &obj.Characteristics)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "characteristics-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "characteristics")
+	err = core.UnmarshalPrimitive(m, "period", &obj.Period)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "period-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "period")
+	err = core.UnmarshalPrimitive(m, "requests_per_period", &obj.RequestsPerPeriod)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "requests_per_period-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "requests_per_period")
+	err = core.UnmarshalPrimitive(m, "score_per_period", &obj.ScorePerPeriod)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "score_per_period-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "score_per_period")
+	err = core.UnmarshalPrimitive(m, "score_response_header_name", &obj.ScoreResponseHeaderName)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "score_response_header_name-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "score_response_header_name")
+	err = core.UnmarshalPrimitive(m, "mitigation_timeout", &obj.MitigationTimeout)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "mitigation_timeout-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "mitigation_timeout")
+	err = core.UnmarshalPrimitive(m, "counting_expression", &obj.CountingExpression)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "counting_expression-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "counting_expression")
+	err = core.UnmarshalPrimitive(m, "requests_to_origin", &obj.RequestsToOrigin)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "requests_to_origin-error", common.GetComponentInfo())
+		return
+	}
+	delete(m, "requests_to_origin")
+	for k := range m {
+		var v interface{}
+		e := core.UnmarshalPrimitive(m, k, &v)
+		if e != nil {
+			err = core.SDKErrorf(e, "", "additional-properties-error", common.GetComponentInfo())
+			return
+		}
+		obj.SetProperty(k, v)
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
 // RuleCreate : RuleCreate struct
 type RuleCreate struct {
 	// What happens when theres a match for the rule expression.
@@ -4596,6 +5714,9 @@ type RuleCreate struct {
 
 	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
 
+	// The rate limiting parameters, for rules in the http_ratelimit phase.
+	Ratelimit *Ratelimit `json:"ratelimit,omitempty"`
+
 	Description *string `json:"description,omitempty"`
 
 	Enabled *bool `json:"enabled,omitempty"`
@@ -4639,6 +5760,11 @@ func UnmarshalRuleCreate(m map[string]json.RawMessage, result interface{}) (err
 		err = core.SDKErrorf(err, "", "action_parameters-error", common.GetComponentInfo())
 		return
 	}
+	err = core.UnmarshalModel(m, "ratelimit", &obj.Ratelimit, UnmarshalRatelimit)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "ratelimit-error", common.GetComponentInfo())
+		return
+	}
 	err = core.UnmarshalPrimitive(m, "description", &obj.Description)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "description-error", common.GetComponentInfo())
@@ -4691,6 +5817,9 @@ type RuleDetails struct {
 
 	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
 
+	// The rate limiting parameters, for rules in the http_ratelimit phase.
+	Ratelimit *Ratelimit `json:"ratelimit,omitempty"`
+
 	// List of categories for the rule.
 	Categories []string `json:"categories,omitempty"`
 
@@ -4735,6 +5864,11 @@ func UnmarshalRuleDetails(m map[string]json.RawMessage, result interface{}) (err
 		err = core.SDKErrorf(err, "", "action_parameters-error", common.GetComponentInfo())
 		return
 	}
+	err = core.UnmarshalModel(m, "ratelimit", &obj.Ratelimit, UnmarshalRatelimit)
+	if err != nil {
+		err = core.SDKErrorf(err, "", "ratelimit-error", common.GetComponentInfo())
+		return
+	}
 	err = core.UnmarshalPrimitive(m, "categories", &obj.Categories)
 	if err != nil {
 		err = core.SDKErrorf(err, "", "categories-error", common.GetComponentInfo())
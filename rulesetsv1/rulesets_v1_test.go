@@ -10059,6 +10059,112 @@ var _ = Describe(`RulesetsV1`, func() {
 			Expect(result).ToNot(BeNil())
 			Expect(result).To(Equal(model))
 		})
+		It(`Invoke UnmarshalActionParameters with phase specific parameters successfully`, func() {
+			// Construct an instance of the model.
+			model := new(rulesetsv1.ActionParameters)
+			model.URI = &rulesetsv1.ActionParametersURI{
+				Path:  &rulesetsv1.ActionParametersURIComponent{Value: core.StringPtr("/new")},
+				Query: &rulesetsv1.ActionParametersURIComponent{Expression: core.StringPtr("regex_replace(http.request.uri.query, \"^a=\", \"b=\")")},
+			}
+			model.Headers = map[string]rulesetsv1.ActionParametersHeader{
+				"X-Source": {Operation: core.StringPtr(rulesetsv1.ActionParametersHeader_Operation_Set), Value: core.StringPtr("cis")},
+				"X-Debug":  {Operation: core.StringPtr(rulesetsv1.ActionParametersHeader_Operation_Remove)},
+			}
+			model.FromValue = &rulesetsv1.ActionParametersFromValue{
+				StatusCode:          core.Int64Ptr(int64(301)),
+				TargetURL:           &rulesetsv1.ActionParametersTargetURL{Value: core.StringPtr("https://example.com")},
+				PreserveQueryString: core.BoolPtr(true),
+			}
+			model.Cache = core.BoolPtr(true)
+			model.EdgeTTL = &rulesetsv1.ActionParametersEdgeTTL{
+				Mode:    core.StringPtr(rulesetsv1.ActionParametersEdgeTTL_Mode_OverrideOrigin),
+				Default: core.Int64Ptr(int64(3600)),
+				StatusCodeTTL: []rulesetsv1.ActionParametersStatusCodeTTL{{
+					StatusCodeRange: &rulesetsv1.ActionParametersStatusCodeRange{From: core.Int64Ptr(int64(500)), To: core.Int64Ptr(int64(599))},
+					Value:           core.Int64Ptr(int64(-1)),
+				}},
+			}
+			model.BrowserTTL = &rulesetsv1.ActionParametersBrowserTTL{Mode: core.StringPtr(rulesetsv1.ActionParametersBrowserTTL_Mode_RespectOrigin)}
+			model.ServeStale = &rulesetsv1.ActionParametersServeStale{DisableStaleWhileUpdating: core.BoolPtr(true)}
+			model.CacheKey = &rulesetsv1.ActionParametersCacheKey{
+				IgnoreQueryStringsOrder: core.BoolPtr(true),
+				CustomKey: &rulesetsv1.ActionParametersCustomKey{
+					QueryString: &rulesetsv1.CustomKeyQueryString{Exclude: &rulesetsv1.CustomKeyList{All: core.BoolPtr(true)}},
+					Header:      &rulesetsv1.CustomKeyHeader{Include: []string{"x-tenant"}},
+					User:        &rulesetsv1.CustomKeyUser{DeviceType: core.BoolPtr(true)},
+				},
+			}
+			model.AdditionalCacheablePorts = []int64{8443}
+			model.HostHeader = core.StringPtr("origin.example.com")
+			model.Origin = &rulesetsv1.ActionParametersOrigin{Host: core.StringPtr("origin.example.com"), Port: core.Int64Ptr(int64(8443))}
+			model.Sni = &rulesetsv1.ActionParametersSni{Value: core.StringPtr("origin.example.com")}
+			model.Phases = []string{"http_ratelimit"}
+			model.Rules = map[string][]string{"testString": {"testString"}}
+
+			b, err := json.Marshal(model)
+			Expect(err).To(BeNil())
+
+			var raw map[string]json.RawMessage
+			err = json.Unmarshal(b, &raw)
+			Expect(err).To(BeNil())
+
+			var result *rulesetsv1.ActionParameters
+			err = rulesetsv1.UnmarshalActionParameters(raw, &result)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			Expect(result).To(Equal(model))
+			Expect(result.GetProperties()).To(BeEmpty())
+		})
+		It(`Invoke UnmarshalActionParameters and keep unknown parameters`, func() {
+			var raw map[string]json.RawMessage
+			err := json.Unmarshal([]byte(`{"version":"latest","compression":{"algorithms":[{"name":"gzip"}]},"polish":"lossless"}`), &raw)
+			Expect(err).To(BeNil())
+
+			var result *rulesetsv1.ActionParameters
+			err = rulesetsv1.UnmarshalActionParameters(raw, &result)
+			Expect(err).To(BeNil())
+			Expect(*result.Version).To(Equal("latest"))
+			Expect(result.GetProperty("polish")).To(Equal("lossless"))
+
+			b, err := json.Marshal(result)
+			Expect(err).To(BeNil())
+			Expect(b).To(MatchJSON(`{"version":"latest","compression":{"algorithms":[{"name":"gzip"}]},"polish":"lossless"}`))
+		})
+		It(`Invoke UnmarshalRatelimit successfully`, func() {
+			// Construct an instance of the model.
+			model := new(rulesetsv1.Ratelimit)
+			model.Characteristics = []string{"ip.src", "cf.colo.id"}
+			model.Period = core.Int64Ptr(int64(60))
+			model.RequestsPerPeriod = core.Int64Ptr(int64(100))
+			model.MitigationTimeout = core.Int64Ptr(int64(600))
+			model.CountingExpression = core.StringPtr("http.request.uri.path eq \"/login\"")
+			model.RequestsToOrigin = core.BoolPtr(false)
+			model.SetProperty("score_per_period_mode", "testString")
+
+			b, err := json.Marshal(model)
+			Expect(err).To(BeNil())
+
+			var raw map[string]json.RawMessage
+			err = json.Unmarshal(b, &raw)
+			Expect(err).To(BeNil())
+
+			var result *rulesetsv1.Ratelimit
+			err = rulesetsv1.UnmarshalRatelimit(raw, &result)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			Expect(result).To(Equal(model))
+		})
+		It(`Invoke UnmarshalRuleDetails with rate limiting parameters successfully`, func() {
+			var raw map[string]json.RawMessage
+			err := json.Unmarshal([]byte(`{"id":"testString","action":"block","expression":"true","ratelimit":{"characteristics":["cf.colo.id"],"period":10,"requests_per_period":5}}`), &raw)
+			Expect(err).To(BeNil())
+
+			var result *rulesetsv1.RuleDetails
+			err = rulesetsv1.UnmarshalRuleDetails(raw, &result)
+			Expect(err).To(BeNil())
+			Expect(result.Ratelimit.Characteristics).To(Equal([]string{"cf.colo.id"}))
+			Expect(*result.Ratelimit.RequestsPerPeriod).To(Equal(int64(5)))
+		})
 		It(`Invoke UnmarshalCategoriesOverride successfully`, func() {
 			// Construct an instance of the model.
 			model := new(rulesetsv1.CategoriesOverride)
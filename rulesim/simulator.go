@@ -0,0 +1,437 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rulesim reports which filters, firewall rules and ruleset rules would match a synthetic request, without
+// calling the API. It evaluates their expressions with the expr package and applies the phase order and the
+// first-match termination of the edge, so that rule sets can be covered by table-driven tests.
+package rulesim
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/expr"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+)
+
+// Sources of matches.
+const (
+	Match_Source_Filter       = "filter"
+	Match_Source_FirewallRule = "firewall_rule"
+	Match_Source_RulesetRule  = "ruleset_rule"
+)
+
+// Rule actions with a special meaning for the simulation.
+const (
+	Action_Allow            = "allow"
+	Action_Block            = "block"
+	Action_Bypass           = "bypass"
+	Action_Challenge        = "challenge"
+	Action_Execute          = "execute"
+	Action_JsChallenge      = "js_challenge"
+	Action_Log              = "log"
+	Action_ManagedChallenge = "managed_challenge"
+	Action_Redirect         = "redirect"
+	Action_ServeError       = "serve_error"
+	Action_Skip             = "skip"
+)
+
+// PhaseOrder lists the request and response phases in the order in which the edge runs them. Rulesets of
+// other phases run last, in name order.
+var PhaseOrder = []string{
+	"http_request_sanitize",
+	"http_request_dynamic_redirect",
+	"http_request_transform",
+	"http_request_select_configuration",
+	"http_config_settings",
+	"http_request_origin",
+	"ddos_l7",
+	"http_request_firewall_custom",
+	"http_ratelimit",
+	"http_request_firewall_managed",
+	"http_request_sbfm",
+	"http_request_redirect",
+	"http_request_late_transform",
+	"http_request_cache_settings",
+	"http_custom_errors",
+	"http_response_headers_transform",
+	"http_response_compression",
+	"http_response_firewall_managed",
+	"http_log_custom_fields",
+}
+
+// terminatingActions stop the evaluation of the request when their rule matches.
+var terminatingActions = map[string]bool{
+	Action_Block:            true,
+	Action_Challenge:        true,
+	Action_JsChallenge:      true,
+	Action_ManagedChallenge: true,
+	Action_Redirect:         true,
+	Action_ServeError:       true,
+}
+
+// firewallActionOrder is the order in which legacy firewall rules run, by action.
+var firewallActionOrder = map[string]int{
+	Action_Log:              0,
+	Action_Bypass:           1,
+	Action_Allow:            2,
+	Action_Challenge:        3,
+	Action_JsChallenge:      4,
+	Action_ManagedChallenge: 5,
+	Action_Block:            6,
+}
+
+// Match : A filter or rule that matches the request.
+type Match struct {
+	// One of the Match_Source constants.
+	Source string
+
+	// The phase of the ruleset, for ruleset rules.
+	Phase string
+
+	// The ruleset holding the rule, for ruleset rules.
+	RulesetID string
+
+	// The ID of the filter or rule.
+	ID string
+
+	Description string
+
+	// The action of the rule, after the overrides of the execute rule that ran its ruleset. Empty for filters.
+	Action string
+
+	// True when the match stopped the evaluation of the request.
+	Terminating bool
+}
+
+// Result : The outcome of a simulation.
+type Result struct {
+	// The matches, in evaluation order.
+	Matches []Match
+
+	// The match that stopped the evaluation, or nil when the request went through every rule.
+	Final *Match
+}
+
+// Action returns the action of the final match, or "" when the request went through.
+func (result *Result) Action() string {
+	if result.Final == nil {
+		return ""
+	}
+	return result.Final.Action
+}
+
+// MatchedIDs returns the IDs of the matched filters and rules, in evaluation order.
+func (result *Result) MatchedIDs() []string {
+	ids := make([]string, len(result.Matches))
+	for i, match := range result.Matches {
+		ids[i] = match.ID
+	}
+	return ids
+}
+
+// Simulator evaluates filters, firewall rules and rulesets against synthetic requests. Parsed expressions are
+// cached, so a Simulator should be reused across the requests of a test table.
+type Simulator struct {
+	// The evaluator, which holds the named lists used by the expressions.
+	Evaluator *expr.Evaluator
+
+	// Rulesets that execute rules can run, such as managed rulesets, by ID. An execute rule whose ruleset is
+	// missing is reported as a match and does nothing else.
+	Rulesets map[string]*rulesetsv1.RulesetDetails
+
+	mu          sync.Mutex
+	expressions map[string]expr.Expr
+}
+
+// NewSimulator : Instantiate Simulator
+func NewSimulator() *Simulator {
+	return &Simulator{
+		Evaluator: &expr.Evaluator{},
+		Rulesets:  map[string]*rulesetsv1.RulesetDetails{},
+	}
+}
+
+// matches parses an expression, once per expression string, and evaluates it.
+func (simulator *Simulator) matches(expression string, request *expr.Request) (bool, error) {
+	simulator.mu.Lock()
+	if simulator.expressions == nil {
+		simulator.expressions = map[string]expr.Expr{}
+	}
+	e, ok := simulator.expressions[expression]
+	simulator.mu.Unlock()
+	if !ok {
+		var err error
+		if e, err = expr.Parse(expression); err != nil {
+			return false, err
+		}
+		simulator.mu.Lock()
+		simulator.expressions[expression] = e
+		simulator.mu.Unlock()
+	}
+	evaluator := simulator.Evaluator
+	if evaluator == nil {
+		evaluator = &expr.Evaluator{}
+	}
+	return evaluator.Evaluate(e, request)
+}
+
+// MatchFilters returns the filters that match the request. Paused filters are ignored.
+func (simulator *Simulator) MatchFilters(filters []filtersv1.FilterObject, request *expr.Request) (matches []Match, err error) {
+	for _, filter := range filters {
+		if filter.Expression == nil || (filter.Paused != nil && *filter.Paused) {
+			continue
+		}
+		matched, err := simulator.matches(*filter.Expression, request)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s: %w", core.StringNilMapper(filter.ID), err)
+		}
+		if matched {
+			matches = append(matches, Match{
+				Source:      Match_Source_Filter,
+				ID:          core.StringNilMapper(filter.ID),
+				Description: core.StringNilMapper(filter.Description),
+			})
+		}
+	}
+	return
+}
+
+// EvaluateFirewallRules runs firewall rules against the request. Like the edge, it runs them by action: log,
+// bypass, allow, challenge, js_challenge, managed_challenge and then block, keeping the given order for rules
+// with the same action. The first allow, challenge or block match stops the evaluation. Paused rules and rules
+// with a paused filter are ignored.
+func (simulator *Simulator) EvaluateFirewallRules(rules []firewallrulesv1.FirewallRuleObject, request *expr.Request) (*Result, error) {
+	ordered := make([]firewallrulesv1.FirewallRuleObject, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Paused != nil && *rule.Paused {
+			continue
+		}
+		if rule.Filter == nil || rule.Filter.Expression == nil || (rule.Filter.Paused != nil && *rule.Filter.Paused) {
+			continue
+		}
+		ordered = append(ordered, rule)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return firewallActionOrder[core.StringNilMapper(ordered[i].Action)] < firewallActionOrder[core.StringNilMapper(ordered[j].Action)]
+	})
+
+	result := &Result{}
+	for _, rule := range ordered {
+		matched, err := simulator.matches(*rule.Filter.Expression, request)
+		if err != nil {
+			return nil, fmt.Errorf("firewall rule %s: %w", core.StringNilMapper(rule.ID), err)
+		}
+		if !matched {
+			continue
+		}
+		action := core.StringNilMapper(rule.Action)
+		result.Matches = append(result.Matches, Match{
+			Source:      Match_Source_FirewallRule,
+			ID:          core.StringNilMapper(rule.ID),
+			Description: core.StringNilMapper(rule.Description),
+			Action:      action,
+			Terminating: action != Action_Log && action != Action_Bypass,
+		})
+		if result.Matches[len(result.Matches)-1].Terminating {
+			result.Final = &result.Matches[len(result.Matches)-1]
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// rulesetRun holds the state of an EvaluateRulesets call.
+type rulesetRun struct {
+	simulator *Simulator
+	request   *expr.Request
+	result    *Result
+
+	// Phases, rulesets and rules skipped by skip rules.
+	skippedPhases   map[string]bool
+	skippedRulesets map[string]bool
+	skippedRules    map[string]map[string]bool
+}
+
+// EvaluateRulesets runs the entry point rulesets of a zone or an instance against the request, phase by phase in
+// PhaseOrder. Within a phase, rulesets run in the given order and their rules in ruleset order.
+//
+// A block, challenge, js_challenge, managed_challenge, redirect or serve_error match stops the evaluation. Skip
+// rules skip the rest of the current ruleset, later phases, rulesets and rules as their action parameters say.
+// Execute rules run the referenced ruleset from Simulator.Rulesets with their overrides applied. Rate limiting
+// rules are reported when they match but never stop the evaluation, because they depend on the request rate.
+func (simulator *Simulator) EvaluateRulesets(rulesets []rulesetsv1.RulesetDetails, request *expr.Request) (*Result, error) {
+	rank := map[string]int{}
+	for i, phase := range PhaseOrder {
+		rank[phase] = i
+	}
+	ordered := append([]rulesetsv1.RulesetDetails(nil), rulesets...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		phaseI, phaseJ := core.StringNilMapper(ordered[i].Phase), core.StringNilMapper(ordered[j].Phase)
+		rankI, knownI := rank[phaseI]
+		rankJ, knownJ := rank[phaseJ]
+		switch {
+		case knownI && knownJ:
+			return rankI < rankJ
+		case knownI != knownJ:
+			return knownI
+		}
+		return phaseI < phaseJ
+	})
+
+	run := &rulesetRun{
+		simulator:       simulator,
+		request:         request,
+		result:          &Result{},
+		skippedPhases:   map[string]bool{},
+		skippedRulesets: map[string]bool{},
+		skippedRules:    map[string]map[string]bool{},
+	}
+	for i := range ordered {
+		ruleset := &ordered[i]
+		if run.skippedPhases[core.StringNilMapper(ruleset.Phase)] || run.skippedRulesets[core.StringNilMapper(ruleset.ID)] {
+			continue
+		}
+		terminated, err := run.ruleset(ruleset, core.StringNilMapper(ruleset.Phase), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if terminated {
+			break
+		}
+	}
+	return run.result, nil
+}
+
+// ruleset runs the rules of a ruleset and returns true when a rule stopped the evaluation.
+func (run *rulesetRun) ruleset(ruleset *rulesetsv1.RulesetDetails, phase string, overrides *rulesetsv1.Overrides, depth int) (bool, error) {
+	rulesetID := core.StringNilMapper(ruleset.ID)
+	for _, rule := range ruleset.Rules {
+		ruleID := core.StringNilMapper(rule.ID)
+		enabled, action := applyOverrides(&rule, overrides)
+		if !enabled || rule.Expression == nil || run.skippedRules[rulesetID][ruleID] {
+			continue
+		}
+		matched, err := run.simulator.matches(*rule.Expression, run.request)
+		if err != nil {
+			return false, fmt.Errorf("ruleset %s rule %s: %w", rulesetID, ruleID, err)
+		}
+		if !matched {
+			continue
+		}
+
+		match := Match{
+			Source:      Match_Source_RulesetRule,
+			Phase:       phase,
+			RulesetID:   rulesetID,
+			ID:          ruleID,
+			Description: core.StringNilMapper(rule.Description),
+			Action:      action,
+			Terminating: terminatingActions[action] && rule.Ratelimit == nil,
+		}
+		run.result.Matches = append(run.result.Matches, match)
+		if match.Terminating {
+			run.result.Final = &run.result.Matches[len(run.result.Matches)-1]
+			return true, nil
+		}
+
+		params := rule.ActionParameters
+		switch action {
+		case Action_Skip:
+			if params == nil {
+				continue
+			}
+			for _, skipped := range params.Phases {
+				run.skippedPhases[skipped] = true
+			}
+			for _, skipped := range params.Rulesets {
+				run.skippedRulesets[skipped] = true
+			}
+			for skippedRuleset, ruleIDs := range params.Rules {
+				if run.skippedRules[skippedRuleset] == nil {
+					run.skippedRules[skippedRuleset] = map[string]bool{}
+				}
+				for _, skippedRule := range ruleIDs {
+					run.skippedRules[skippedRuleset][skippedRule] = true
+				}
+			}
+			if params.Ruleset != nil && *params.Ruleset == "current" {
+				return false, nil
+			}
+		case Action_Execute:
+			if params == nil || params.ID == nil || run.skippedRulesets[*params.ID] {
+				continue
+			}
+			executed, ok := run.simulator.Rulesets[*params.ID]
+			if !ok {
+				continue
+			}
+			if depth >= 8 {
+				return false, fmt.Errorf("ruleset %s rule %s: too many nested execute rules", rulesetID, ruleID)
+			}
+			terminated, err := run.ruleset(executed, phase, params.Overrides, depth+1)
+			if err != nil || terminated {
+				return terminated, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// applyOverrides returns whether a rule is enabled and its action, after the overrides of an execute rule: the
+// ruleset-wide ones first, then the ones of its categories, then the ones of the rule itself.
+func applyOverrides(rule *rulesetsv1.RuleDetails, overrides *rulesetsv1.Overrides) (enabled bool, action string) {
+	enabled = rule.Enabled == nil || *rule.Enabled
+	action = core.StringNilMapper(rule.Action)
+	if overrides == nil {
+		return
+	}
+	if overrides.Enabled != nil {
+		enabled = *overrides.Enabled
+	}
+	if overrides.Action != nil {
+		action = *overrides.Action
+	}
+	for _, category := range overrides.Categories {
+		for _, ruleCategory := range rule.Categories {
+			if category.Category == nil || *category.Category != ruleCategory {
+				continue
+			}
+			if category.Enabled != nil {
+				enabled = *category.Enabled
+			}
+			if category.Action != nil {
+				action = *category.Action
+			}
+		}
+	}
+	for _, ruleOverride := range overrides.Rules {
+		if ruleOverride.ID == nil || *ruleOverride.ID != core.StringNilMapper(rule.ID) {
+			continue
+		}
+		if ruleOverride.Enabled != nil {
+			enabled = *ruleOverride.Enabled
+		}
+		if ruleOverride.Action != nil {
+			action = *ruleOverride.Action
+		}
+	}
+	return
+}
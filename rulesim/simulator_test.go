@@ -0,0 +1,172 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rulesim_test
+
+import (
+	"net/netip"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/expr"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/rulesim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func rule(id string, action string, expression string) rulesetsv1.RuleDetails {
+	return rulesetsv1.RuleDetails{ID: core.StringPtr(id), Action: core.StringPtr(action), Expression: core.StringPtr(expression)}
+}
+
+func firewallRule(id string, action string, expression string) firewallrulesv1.FirewallRuleObject {
+	return firewallrulesv1.FirewallRuleObject{
+		ID:     core.StringPtr(id),
+		Paused: core.BoolPtr(false),
+		Action: core.StringPtr(action),
+		Filter: &firewallrulesv1.FirewallRuleObjectFilter{Paused: core.BoolPtr(false), Expression: core.StringPtr(expression)},
+	}
+}
+
+var _ = Describe(`Simulator`, func() {
+	var simulator *rulesim.Simulator
+	office := &expr.Request{URL: "https://example.com/admin", IP: netip.MustParseAddr("203.0.113.9"), Country: "FR"}
+	attacker := &expr.Request{URL: "https://example.com/admin?id=1'--", IP: netip.MustParseAddr("192.0.2.66"), Country: "XX", BotScore: 2}
+	visitor := &expr.Request{URL: "https://example.com/", IP: netip.MustParseAddr("198.51.100.1"), Country: "FR", BotScore: 80}
+
+	BeforeEach(func() {
+		simulator = rulesim.NewSimulator()
+		simulator.Evaluator.Lists = map[string][]string{"office": {"203.0.113.0/24"}}
+	})
+
+	Describe(`MatchFilters(filters, request)`, func() {
+		It(`Return the active filters that match`, func() {
+			filters := []filtersv1.FilterObject{
+				{ID: core.StringPtr("f-admin"), Paused: core.BoolPtr(false), Expression: core.StringPtr(`http.request.uri.path eq "/admin"`)},
+				{ID: core.StringPtr("f-paused"), Paused: core.BoolPtr(true), Expression: core.StringPtr(`ssl`)},
+				{ID: core.StringPtr("f-office"), Paused: core.BoolPtr(false), Expression: core.StringPtr(`ip.src in $office`)},
+			}
+			matches, err := simulator.MatchFilters(filters, office)
+			Expect(err).To(BeNil())
+			Expect(matches).To(HaveLen(2))
+			Expect(matches[0].ID).To(Equal("f-admin"))
+			Expect(matches[1].ID).To(Equal("f-office"))
+
+			filters[0].Expression = core.StringPtr(`http.request.uri.path eq`)
+			_, err = simulator.MatchFilters(filters, office)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(HavePrefix("filter f-admin: 1:"))
+		})
+	})
+	Describe(`EvaluateFirewallRules(rules, request)`, func() {
+		It(`Run rules by action and stop at the first terminating match`, func() {
+			rules := []firewallrulesv1.FirewallRuleObject{
+				firewallRule("block-admin", "block", `http.request.uri.path eq "/admin"`),
+				firewallRule("allow-office", "allow", `ip.src in $office`),
+				firewallRule("log-all", "log", `true`),
+				firewallRule("challenge-bots", "challenge", `cf.bot_management.score lt 10`),
+			}
+			for _, test := range []struct {
+				request *expr.Request
+				action  string
+				matched []string
+			}{
+				{office, "allow", []string{"log-all", "allow-office"}},
+				{attacker, "challenge", []string{"log-all", "challenge-bots"}},
+				{visitor, "", []string{"log-all"}},
+			} {
+				result, err := simulator.EvaluateFirewallRules(rules, test.request)
+				Expect(err).To(BeNil())
+				Expect(result.Action()).To(Equal(test.action))
+				Expect(result.MatchedIDs()).To(Equal(test.matched))
+			}
+		})
+	})
+	Describe(`EvaluateRulesets(rulesets, request)`, func() {
+		var rulesets []rulesetsv1.RulesetDetails
+
+		BeforeEach(func() {
+			skipOffice := rule("skip-office", "skip", `ip.src in $office`)
+			skipOffice.ActionParameters = &rulesetsv1.ActionParameters{
+				Ruleset: core.StringPtr("current"),
+				Phases:  []string{"http_request_firewall_managed"},
+			}
+			executeManaged := rule("execute-managed", "execute", `true`)
+			executeManaged.ActionParameters = &rulesetsv1.ActionParameters{
+				ID: core.StringPtr("managed"),
+				Overrides: &rulesetsv1.Overrides{
+					Rules: []rulesetsv1.RulesOverride{{ID: core.StringPtr("sqli"), Action: core.StringPtr("block")}},
+				},
+			}
+			rateLimit := rule("rate-limit", "block", `true`)
+			rateLimit.Ratelimit = &rulesetsv1.Ratelimit{Period: core.Int64Ptr(60), RequestsPerPeriod: core.Int64Ptr(100)}
+
+			// The rulesets are out of phase order on purpose.
+			rulesets = []rulesetsv1.RulesetDetails{
+				{ID: core.StringPtr("managed-entry"), Phase: core.StringPtr("http_request_firewall_managed"), Rules: []rulesetsv1.RuleDetails{executeManaged}},
+				{ID: core.StringPtr("ratelimit-entry"), Phase: core.StringPtr("http_ratelimit"), Rules: []rulesetsv1.RuleDetails{rateLimit}},
+				{ID: core.StringPtr("custom-entry"), Phase: core.StringPtr("http_request_firewall_custom"), Rules: []rulesetsv1.RuleDetails{
+					skipOffice,
+					rule("log-admin", "log", `http.request.uri.path eq "/admin"`),
+					rule("challenge-bots", "managed_challenge", `cf.bot_management.score lt 30 and ip.src.country ne "FR"`),
+				}},
+			}
+			simulator.Rulesets["managed"] = &rulesetsv1.RulesetDetails{ID: core.StringPtr("managed"), Rules: []rulesetsv1.RuleDetails{
+				rule("sqli", "log", `http.request.uri.query contains "'--"`),
+				rule("scanner", "block", `http.user_agent contains "sqlmap"`),
+			}}
+		})
+
+		It(`Skip the rest of the ruleset and later phases`, func() {
+			result, err := simulator.EvaluateRulesets(rulesets, office)
+			Expect(err).To(BeNil())
+			Expect(result.Final).To(BeNil())
+			Expect(result.MatchedIDs()).To(Equal([]string{"skip-office", "rate-limit"}))
+			Expect(result.Matches[1].Phase).To(Equal("http_ratelimit"))
+			Expect(result.Matches[1].Terminating).To(BeFalse())
+		})
+		It(`Stop at the first terminating match`, func() {
+			result, err := simulator.EvaluateRulesets(rulesets, attacker)
+			Expect(err).To(BeNil())
+			Expect(result.Action()).To(Equal("managed_challenge"))
+			Expect(result.MatchedIDs()).To(Equal([]string{"log-admin", "challenge-bots"}))
+			Expect(result.Final.RulesetID).To(Equal("custom-entry"))
+		})
+		It(`Run executed rulesets with their overrides`, func() {
+			attacker.Country = "FR"
+			defer func() { attacker.Country = "XX" }()
+			result, err := simulator.EvaluateRulesets(rulesets, attacker)
+			Expect(err).To(BeNil())
+			Expect(result.MatchedIDs()).To(Equal([]string{"log-admin", "rate-limit", "execute-managed", "sqli"}))
+			Expect(result.Action()).To(Equal("block"))
+			Expect(result.Final.RulesetID).To(Equal("managed"))
+			Expect(result.Final.Phase).To(Equal("http_request_firewall_managed"))
+
+			delete(simulator.Rulesets, "managed")
+			result, err = simulator.EvaluateRulesets(rulesets, attacker)
+			Expect(err).To(BeNil())
+			Expect(result.Final).To(BeNil())
+			Expect(result.MatchedIDs()).To(ContainElement("execute-managed"))
+		})
+		It(`Ignore disabled rules`, func() {
+			rulesets[2].Rules[2].Enabled = core.BoolPtr(false)
+			result, err := simulator.EvaluateRulesets(rulesets, visitor)
+			Expect(err).To(BeNil())
+			Expect(result.MatchedIDs()).To(Equal([]string{"rate-limit", "execute-managed"}))
+		})
+	})
+})
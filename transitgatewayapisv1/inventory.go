@@ -0,0 +1,557 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transitgatewayapisv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/go-openapi/strfmt"
+)
+
+// InventorySchemaVersion is the version of the snapshot document produced by WriteInventory.
+// ReadInventory rejects snapshots written with a newer schema version.
+const InventorySchemaVersion = 1
+
+// DefaultInventoryConcurrency is the number of gateways collected in parallel when
+// InventoryOptions.Concurrency is not set.
+const DefaultInventoryConcurrency = 4
+
+// Inventory : A point-in-time snapshot of every Transit Gateway resource in an account.
+type Inventory struct {
+	// The version of the snapshot document format.
+	SchemaVersion int `json:"schema_version"`
+
+	// The date and time that the snapshot was collected.
+	CollectedAt *strfmt.DateTime `json:"collected_at"`
+
+	// The gateways in the account, sorted by ID.
+	Gateways []GatewayInventory `json:"gateways"`
+}
+
+// GatewayInventory : A Transit Gateway together with its connections and latest route report.
+type GatewayInventory struct {
+	// The Transit Gateway.
+	Gateway *TransitGateway `json:"gateway"`
+
+	// The connections of the gateway, sorted by ID.
+	Connections []ConnectionInventory `json:"connections"`
+
+	// The most recent completed route report of the gateway, if any.
+	RouteReport *RouteReport `json:"route_report,omitempty"`
+}
+
+// ConnectionInventory : A Transit Gateway connection together with its prefix filters and GRE tunnels.
+type ConnectionInventory struct {
+	// The connection.
+	Connection *TransitGatewayConnectionCust `json:"connection"`
+
+	// The prefix filters of the connection. Not collected for 'redundant_gre' connections.
+	PrefixFilters []PrefixFilterCust `json:"prefix_filters,omitempty"`
+
+	// The tunnels of a 'redundant_gre' connection.
+	GreTunnels []RedundantGRETunnelReference `json:"gre_tunnels,omitempty"`
+}
+
+// InventoryOptions : The CollectInventory options.
+type InventoryOptions struct {
+	// The maximum number of gateways collected in parallel. Defaults to DefaultInventoryConcurrency.
+	Concurrency int
+
+	// Skip fetching route reports.
+	SkipRouteReports bool
+}
+
+// CollectInventory : Collect a snapshot of all Transit Gateway resources
+// Walk every gateway in the account through TransitGatewaysPager and TransitGatewayConnectionsPager and collect the
+// connections, prefix filters, GRE tunnels and the latest completed route report of each gateway.
+func (transitGatewayApis *TransitGatewayApisV1) CollectInventory(options *InventoryOptions) (result *Inventory, err error) {
+	return transitGatewayApis.CollectInventoryWithContext(context.Background(), options)
+}
+
+// CollectInventoryWithContext is an alternate form of the CollectInventory method which supports a Context parameter
+func (transitGatewayApis *TransitGatewayApisV1) CollectInventoryWithContext(ctx context.Context, options *InventoryOptions) (result *Inventory, err error) {
+	if options == nil {
+		options = &InventoryOptions{}
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultInventoryConcurrency
+	}
+
+	pager, err := transitGatewayApis.NewTransitGatewaysPager(&ListTransitGatewaysOptions{})
+	if err != nil {
+		return
+	}
+	gateways, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	collected := make([]GatewayInventory, len(gateways))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	for i := range gateways {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			gateway := gateways[i]
+			item, gatewayErr := transitGatewayApis.collectGatewayInventory(ctx, &gateway, options)
+			if gatewayErr != nil {
+				// Only the first failure is reported; the ones that follow are caused by the cancellation.
+				errOnce.Do(func() {
+					err = fmt.Errorf("transit gateway %s: %w", *gateway.ID, gatewayErr)
+					cancel()
+				})
+				return
+			}
+			collected[i] = *item
+		}(i)
+	}
+	wg.Wait()
+	if err != nil {
+		return
+	}
+
+	sort.Slice(collected, func(i, j int) bool {
+		return *collected[i].Gateway.ID < *collected[j].Gateway.ID
+	})
+	collectedAt := strfmt.DateTime(time.Now().UTC())
+	result = &Inventory{
+		SchemaVersion: InventorySchemaVersion,
+		CollectedAt:   &collectedAt,
+		Gateways:      collected,
+	}
+	return
+}
+
+func (transitGatewayApis *TransitGatewayApisV1) collectGatewayInventory(ctx context.Context, gateway *TransitGateway, options *InventoryOptions) (result *GatewayInventory, err error) {
+	pager, err := transitGatewayApis.NewTransitGatewayConnectionsPager(transitGatewayApis.NewListTransitGatewayConnectionsOptions(*gateway.ID))
+	if err != nil {
+		return
+	}
+	connections, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return
+	}
+
+	result = &GatewayInventory{
+		Gateway:     gateway,
+		Connections: make([]ConnectionInventory, 0, len(connections)),
+	}
+	for i := range connections {
+		connection := connections[i]
+		item := ConnectionInventory{Connection: &connection}
+		if connection.NetworkType != nil && *connection.NetworkType == TransitGatewayConnectionCust_NetworkType_RedundantGre {
+			tunnels, _, tunnelErr := transitGatewayApis.GetTransitGatewayGreTunnelWithContext(ctx,
+				transitGatewayApis.NewGetTransitGatewayGreTunnelOptions(*gateway.ID, *connection.ID))
+			if tunnelErr != nil {
+				err = fmt.Errorf("connection %s: %w", *connection.ID, tunnelErr)
+				return
+			}
+			item.GreTunnels = tunnels.Tunnels
+		} else {
+			filters, _, filterErr := transitGatewayApis.ListTransitGatewayConnectionPrefixFiltersWithContext(ctx,
+				transitGatewayApis.NewListTransitGatewayConnectionPrefixFiltersOptions(*gateway.ID, *connection.ID))
+			if filterErr != nil {
+				err = fmt.Errorf("connection %s: %w", *connection.ID, filterErr)
+				return
+			}
+			item.PrefixFilters = filters.PrefixFilters
+		}
+		result.Connections = append(result.Connections, item)
+	}
+	sort.Slice(result.Connections, func(i, j int) bool {
+		return *result.Connections[i].Connection.ID < *result.Connections[j].Connection.ID
+	})
+
+	if options.SkipRouteReports {
+		return
+	}
+	reports, _, err := transitGatewayApis.ListTransitGatewayRouteReportsWithContext(ctx,
+		transitGatewayApis.NewListTransitGatewayRouteReportsOptions(*gateway.ID))
+	if err != nil {
+		return
+	}
+	result.RouteReport = latestRouteReport(reports.RouteReports)
+	return
+}
+
+// latestRouteReport returns the most recently created completed route report.
+func latestRouteReport(reports []RouteReport) (latest *RouteReport) {
+	for i := range reports {
+		report := &reports[i]
+		if report.Status == nil || *report.Status != RouteReport_Status_Complete || report.CreatedAt == nil {
+			continue
+		}
+		if latest == nil || time.Time(*report.CreatedAt).After(time.Time(*latest.CreatedAt)) {
+			latest = report
+		}
+	}
+	return
+}
+
+// WriteInventory writes the inventory to the writer as an indented JSON document.
+func WriteInventory(writer io.Writer, inventory *Inventory) error {
+	if inventory == nil {
+		return fmt.Errorf("inventory cannot be nil")
+	}
+	if inventory.SchemaVersion == 0 {
+		inventory.SchemaVersion = InventorySchemaVersion
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(inventory)
+}
+
+// ReadInventory reads an inventory document written by WriteInventory.
+func ReadInventory(reader io.Reader) (inventory *Inventory, err error) {
+	inventory = new(Inventory)
+	err = json.NewDecoder(reader).Decode(inventory)
+	if err != nil {
+		inventory = nil
+		return
+	}
+	if inventory.SchemaVersion > InventorySchemaVersion {
+		err = fmt.Errorf("unsupported inventory schema version %d (supported up to %d)", inventory.SchemaVersion, InventorySchemaVersion)
+		inventory = nil
+	}
+	return
+}
+
+// Constants associated with the InventoryChange.Kind property.
+const (
+	InventoryChange_Kind_Added        = "added"
+	InventoryChange_Kind_FieldChanged = "field_changed"
+	InventoryChange_Kind_Removed      = "removed"
+)
+
+// Constants associated with the InventoryChange.ResourceType property.
+const (
+	InventoryChange_ResourceType_Connection   = "connection"
+	InventoryChange_ResourceType_Gateway      = "gateway"
+	InventoryChange_ResourceType_GreTunnel    = "gre_tunnel"
+	InventoryChange_ResourceType_PrefixFilter = "prefix_filter"
+	InventoryChange_ResourceType_Route        = "route"
+)
+
+// InventoryChange : A single difference between two inventories.
+type InventoryChange struct {
+	// The kind of change.
+	Kind string `json:"kind"`
+
+	// The type of resource that changed.
+	ResourceType string `json:"resource_type"`
+
+	// The identifier of the resource that changed. Routes are identified by their prefix.
+	ResourceID string `json:"resource_id"`
+
+	// A human readable location of the resource, for example "gateway-name/connection-name".
+	Path string `json:"path"`
+
+	// The name of the changed field. Only set for field_changed changes.
+	Field string `json:"field,omitempty"`
+
+	// The previous value of the field. Only set for field_changed changes.
+	OldValue interface{} `json:"old_value,omitempty"`
+
+	// The new value of the field. Only set for field_changed changes.
+	NewValue interface{} `json:"new_value,omitempty"`
+}
+
+// String returns a one-line description of the change suitable for posting to chat.
+func (change InventoryChange) String() string {
+	switch change.Kind {
+	case InventoryChange_Kind_FieldChanged:
+		return fmt.Sprintf("~ %s %s (%s): %s %v -> %v", change.ResourceType, change.Path, change.ResourceID,
+			change.Field, formatInventoryValue(change.OldValue), formatInventoryValue(change.NewValue))
+	case InventoryChange_Kind_Added:
+		return fmt.Sprintf("+ %s %s (%s)", change.ResourceType, change.Path, change.ResourceID)
+	default:
+		return fmt.Sprintf("- %s %s (%s)", change.ResourceType, change.Path, change.ResourceID)
+	}
+}
+
+func formatInventoryValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// inventoryIgnoredFields are fields that change on every modification and would only add noise to a diff.
+var inventoryIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// Diff compares two inventories and returns the list of added, removed and changed resources. Changes are ordered
+// by gateway, then by resource. Either inventory may be nil, which is treated as empty.
+func Diff(oldInventory *Inventory, newInventory *Inventory) (changes []InventoryChange) {
+	oldGateways := map[string]*GatewayInventory{}
+	newGateways := map[string]*GatewayInventory{}
+	var ids []string
+	if oldInventory != nil {
+		for i := range oldInventory.Gateways {
+			gateway := &oldInventory.Gateways[i]
+			oldGateways[*gateway.Gateway.ID] = gateway
+			ids = append(ids, *gateway.Gateway.ID)
+		}
+	}
+	if newInventory != nil {
+		for i := range newInventory.Gateways {
+			gateway := &newInventory.Gateways[i]
+			newGateways[*gateway.Gateway.ID] = gateway
+			if _, ok := oldGateways[*gateway.Gateway.ID]; !ok {
+				ids = append(ids, *gateway.Gateway.ID)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		oldGateway, newGateway := oldGateways[id], newGateways[id]
+		switch {
+		case newGateway == nil:
+			changes = append(changes, InventoryChange{
+				Kind:         InventoryChange_Kind_Removed,
+				ResourceType: InventoryChange_ResourceType_Gateway,
+				ResourceID:   id,
+				Path:         core.StringNilMapper(oldGateway.Gateway.Name),
+			})
+		case oldGateway == nil:
+			changes = append(changes, InventoryChange{
+				Kind:         InventoryChange_Kind_Added,
+				ResourceType: InventoryChange_ResourceType_Gateway,
+				ResourceID:   id,
+				Path:         core.StringNilMapper(newGateway.Gateway.Name),
+			})
+		default:
+			changes = append(changes, diffGatewayInventory(oldGateway, newGateway)...)
+		}
+	}
+	return
+}
+
+func diffGatewayInventory(oldGateway *GatewayInventory, newGateway *GatewayInventory) (changes []InventoryChange) {
+	gatewayPath := core.StringNilMapper(newGateway.Gateway.Name)
+	changes = append(changes, diffFields(InventoryChange_ResourceType_Gateway, *newGateway.Gateway.ID, gatewayPath,
+		oldGateway.Gateway, newGateway.Gateway)...)
+
+	oldConnections := map[string]*ConnectionInventory{}
+	for i := range oldGateway.Connections {
+		oldConnections[*oldGateway.Connections[i].Connection.ID] = &oldGateway.Connections[i]
+	}
+	newConnections := map[string]*ConnectionInventory{}
+	for i := range newGateway.Connections {
+		newConnections[*newGateway.Connections[i].Connection.ID] = &newGateway.Connections[i]
+	}
+	for _, id := range unionKeys(oldConnections, newConnections) {
+		oldConnection, newConnection := oldConnections[id], newConnections[id]
+		switch {
+		case newConnection == nil:
+			changes = append(changes, InventoryChange{
+				Kind:         InventoryChange_Kind_Removed,
+				ResourceType: InventoryChange_ResourceType_Connection,
+				ResourceID:   id,
+				Path:         gatewayPath + "/" + core.StringNilMapper(oldConnection.Connection.Name),
+			})
+		case oldConnection == nil:
+			changes = append(changes, InventoryChange{
+				Kind:         InventoryChange_Kind_Added,
+				ResourceType: InventoryChange_ResourceType_Connection,
+				ResourceID:   id,
+				Path:         gatewayPath + "/" + core.StringNilMapper(newConnection.Connection.Name),
+			})
+		default:
+			changes = append(changes, diffConnectionInventory(gatewayPath, oldConnection, newConnection)...)
+		}
+	}
+
+	changes = append(changes, diffRouteReports(gatewayPath, oldGateway.RouteReport, newGateway.RouteReport)...)
+	return
+}
+
+func diffConnectionInventory(gatewayPath string, oldConnection *ConnectionInventory, newConnection *ConnectionInventory) (changes []InventoryChange) {
+	connectionPath := gatewayPath + "/" + core.StringNilMapper(newConnection.Connection.Name)
+	changes = append(changes, diffFields(InventoryChange_ResourceType_Connection, *newConnection.Connection.ID, connectionPath,
+		oldConnection.Connection, newConnection.Connection, "prefix_filters", "tunnels")...)
+
+	oldFilters := map[string]interface{}{}
+	for i := range oldConnection.PrefixFilters {
+		oldFilters[*oldConnection.PrefixFilters[i].ID] = &oldConnection.PrefixFilters[i]
+	}
+	newFilters := map[string]interface{}{}
+	for i := range newConnection.PrefixFilters {
+		newFilters[*newConnection.PrefixFilters[i].ID] = &newConnection.PrefixFilters[i]
+	}
+	changes = append(changes, diffResourceSet(InventoryChange_ResourceType_PrefixFilter, connectionPath, oldFilters, newFilters,
+		func(resource interface{}) string { return core.StringNilMapper(resource.(*PrefixFilterCust).Prefix) })...)
+
+	oldTunnels := map[string]interface{}{}
+	for i := range oldConnection.GreTunnels {
+		oldTunnels[*oldConnection.GreTunnels[i].ID] = &oldConnection.GreTunnels[i]
+	}
+	newTunnels := map[string]interface{}{}
+	for i := range newConnection.GreTunnels {
+		newTunnels[*newConnection.GreTunnels[i].ID] = &newConnection.GreTunnels[i]
+	}
+	changes = append(changes, diffResourceSet(InventoryChange_ResourceType_GreTunnel, connectionPath, oldTunnels, newTunnels,
+		func(resource interface{}) string {
+			return core.StringNilMapper(resource.(*RedundantGRETunnelReference).Name)
+		})...)
+	return
+}
+
+// diffResourceSet diffs two sets of leaf resources keyed by ID.
+func diffResourceSet(resourceType string, parentPath string, oldSet map[string]interface{}, newSet map[string]interface{}, name func(interface{}) string) (changes []InventoryChange) {
+	for _, id := range unionKeys(oldSet, newSet) {
+		oldResource, inOld := oldSet[id]
+		newResource, inNew := newSet[id]
+		switch {
+		case !inNew:
+			changes = append(changes, InventoryChange{
+				Kind:         InventoryChange_Kind_Removed,
+				ResourceType: resourceType,
+				ResourceID:   id,
+				Path:         parentPath + "/" + name(oldResource),
+			})
+		case !inOld:
+			changes = append(changes, InventoryChange{
+				Kind:         InventoryChange_Kind_Added,
+				ResourceType: resourceType,
+				ResourceID:   id,
+				Path:         parentPath + "/" + name(newResource),
+			})
+		default:
+			changes = append(changes, diffFields(resourceType, id, parentPath+"/"+name(newResource), oldResource, newResource)...)
+		}
+	}
+	return
+}
+
+// diffRouteReports reports routes learned or withdrawn per connection between two route reports. Nothing is
+// reported when either side has no route report, since the absence of a report says nothing about the routes.
+func diffRouteReports(gatewayPath string, oldReport *RouteReport, newReport *RouteReport) (changes []InventoryChange) {
+	if oldReport == nil || newReport == nil {
+		return
+	}
+	routeSet := func(report *RouteReport) map[string]string {
+		routes := map[string]string{}
+		for _, connection := range report.Connections {
+			connectionName := core.StringNilMapper(connection.Name)
+			if connectionName == "" {
+				connectionName = core.StringNilMapper(connection.ID)
+			}
+			for _, route := range connection.Routes {
+				routes[core.StringNilMapper(connection.ID)+" "+core.StringNilMapper(route.Prefix)] = connectionName
+			}
+		}
+		return routes
+	}
+	oldRoutes, newRoutes := routeSet(oldReport), routeSet(newReport)
+	for _, key := range unionKeys(oldRoutes, newRoutes) {
+		prefix := key[strings.Index(key, " ")+1:]
+		if connectionName, ok := oldRoutes[key]; ok {
+			if _, ok := newRoutes[key]; !ok {
+				changes = append(changes, InventoryChange{
+					Kind:         InventoryChange_Kind_Removed,
+					ResourceType: InventoryChange_ResourceType_Route,
+					ResourceID:   prefix,
+					Path:         gatewayPath + "/" + connectionName,
+				})
+			}
+			continue
+		}
+		changes = append(changes, InventoryChange{
+			Kind:         InventoryChange_Kind_Added,
+			ResourceType: InventoryChange_ResourceType_Route,
+			ResourceID:   prefix,
+			Path:         gatewayPath + "/" + newRoutes[key],
+		})
+	}
+	return
+}
+
+// diffFields compares the JSON representation of two models field by field.
+func diffFields(resourceType string, id string, path string, oldModel interface{}, newModel interface{}, skip ...string) (changes []InventoryChange) {
+	oldFields, newFields := toFieldMap(oldModel), toFieldMap(newModel)
+	for _, field := range unionKeys(oldFields, newFields) {
+		if inventoryIgnoredFields[field] || slices.Contains(skip, field) {
+			continue
+		}
+		oldValue, newValue := oldFields[field], newFields[field]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, InventoryChange{
+			Kind:         InventoryChange_Kind_FieldChanged,
+			ResourceType: resourceType,
+			ResourceID:   id,
+			Path:         path,
+			Field:        field,
+			OldValue:     oldValue,
+			NewValue:     newValue,
+		})
+	}
+	return
+}
+
+func toFieldMap(model interface{}) (fields map[string]interface{}) {
+	fields = map[string]interface{}{}
+	b, err := json.Marshal(model)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(b, &fields)
+	return
+}
+
+func unionKeys[V any](a map[string]V, b map[string]V) (keys []string) {
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
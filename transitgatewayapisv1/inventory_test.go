@@ -0,0 +1,255 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transitgatewayapisv1_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/transitgatewayapisv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`TransitGatewayApisV1 inventory`, func() {
+	var testServer *httptest.Server
+	version := "testString"
+
+	gatewayJSON := `{"id":"gw-1","crn":"crn:v1:bluemix:public:transit:dal03:a/123::gateway:gw-1","name":"gateway-1","location":"us-south","created_at":"2019-01-01T12:00:00.000Z","global":true,"status":"available","updated_at":"2019-01-01T12:00:00.000Z"}`
+	vpcConnectionJSON := `{"id":"conn-vpc","name":"vpc-connection","network_type":"vpc","network_id":"crn:v1:bluemix:public:is:us-south:a/123::vpc:vpc-1","created_at":"2019-01-01T12:00:00.000Z","prefix_filters_default":"permit","request_status":"approved","status":"attached","updated_at":"2019-01-01T12:00:00.000Z"}`
+	greConnectionJSON := `{"id":"conn-gre","name":"gre-connection","network_type":"redundant_gre","base_network_type":"classic","created_at":"2019-01-01T12:00:00.000Z","request_status":"approved","status":"attached","updated_at":"2019-01-01T12:00:00.000Z"}`
+	prefixFiltersJSON := `{"prefix_filters":[{"action":"permit","created_at":"2019-01-01T12:00:00.000Z","id":"pf-1","prefix":"10.0.0.0/16","updated_at":"2019-01-01T12:00:00.000Z"}]}`
+	tunnelsJSON := `{"tunnels":[{"base_network_type":"classic","created_at":"2019-01-01T12:00:00.000Z","id":"tun-1","local_bgp_asn":11,"local_gateway_ip":"10.242.63.12","local_tunnel_ip":"192.168.100.20","mtu":9000,"name":"gre1","remote_bgp_asn":65010,"remote_gateway_ip":"10.242.33.22","remote_tunnel_ip":"192.168.100.21","status":"attached","updated_at":"2019-01-01T12:00:00.000Z","zone":{"name":"us-south-1"}}]}`
+	routeReportsJSON := `{"route_reports":[` +
+		`{"connections":[{"id":"conn-vpc","name":"vpc-connection","routes":[{"prefix":"10.0.0.0/16"}],"type":"vpc"}],"created_at":"2019-01-01T12:00:00.000Z","id":"rr-old","overlapping_routes":[],"status":"complete"},` +
+		`{"connections":[{"id":"conn-vpc","name":"vpc-connection","routes":[{"prefix":"10.1.0.0/16"}],"type":"vpc"}],"created_at":"2019-01-02T12:00:00.000Z","id":"rr-new","overlapping_routes":[],"status":"complete"},` +
+		`{"connections":[],"created_at":"2019-01-03T12:00:00.000Z","id":"rr-pending","overlapping_routes":[],"status":"pending"}]}`
+
+	Describe(`CollectInventory(options *InventoryOptions)`, func() {
+		Context(`Using mock server endpoint`, func() {
+			var requests []string
+			BeforeEach(func() {
+				requests = nil
+				testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+					defer GinkgoRecover()
+
+					Expect(req.Method).To(Equal("GET"))
+					requests = append(requests, req.URL.EscapedPath())
+					res.Header().Set("Content-type", "application/json")
+					switch req.URL.EscapedPath() {
+					case "/transit_gateways":
+						res.WriteHeader(200)
+						fmt.Fprintf(res, `{"transit_gateways":[%s],"limit":50}`, gatewayJSON)
+					case "/transit_gateways/gw-1/connections":
+						res.WriteHeader(200)
+						fmt.Fprintf(res, `{"connections":[%s,%s],"limit":50}`, vpcConnectionJSON, greConnectionJSON)
+					case "/transit_gateways/gw-1/connections/conn-vpc/prefix_filters":
+						res.WriteHeader(200)
+						fmt.Fprint(res, prefixFiltersJSON)
+					case "/transit_gateways/gw-1/connections/conn-gre/tunnels":
+						res.WriteHeader(200)
+						fmt.Fprint(res, tunnelsJSON)
+					case "/transit_gateways/gw-1/route_reports":
+						res.WriteHeader(200)
+						fmt.Fprint(res, routeReportsJSON)
+					default:
+						res.WriteHeader(404)
+					}
+				}))
+			})
+			It(`Invoke CollectInventory successfully`, func() {
+				transitGatewayApisService, serviceErr := transitgatewayapisv1.NewTransitGatewayApisV1(&transitgatewayapisv1.TransitGatewayApisV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+					Version:       core.StringPtr(version),
+				})
+				Expect(serviceErr).To(BeNil())
+
+				inventory, err := transitGatewayApisService.CollectInventory(nil)
+				Expect(err).To(BeNil())
+				Expect(inventory).ToNot(BeNil())
+				Expect(inventory.SchemaVersion).To(Equal(transitgatewayapisv1.InventorySchemaVersion))
+				Expect(inventory.CollectedAt).ToNot(BeNil())
+				Expect(inventory.Gateways).To(HaveLen(1))
+
+				gateway := inventory.Gateways[0]
+				Expect(*gateway.Gateway.ID).To(Equal("gw-1"))
+				Expect(gateway.Connections).To(HaveLen(2))
+				Expect(*gateway.Connections[0].Connection.ID).To(Equal("conn-gre"))
+				Expect(gateway.Connections[0].GreTunnels).To(HaveLen(1))
+				Expect(gateway.Connections[0].PrefixFilters).To(BeEmpty())
+				Expect(*gateway.Connections[1].Connection.ID).To(Equal("conn-vpc"))
+				Expect(gateway.Connections[1].PrefixFilters).To(HaveLen(1))
+				Expect(gateway.RouteReport).ToNot(BeNil())
+				Expect(*gateway.RouteReport.ID).To(Equal("rr-new"))
+			})
+			It(`Invoke CollectInventory without route reports`, func() {
+				transitGatewayApisService, serviceErr := transitgatewayapisv1.NewTransitGatewayApisV1(&transitgatewayapisv1.TransitGatewayApisV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+					Version:       core.StringPtr(version),
+				})
+				Expect(serviceErr).To(BeNil())
+
+				inventory, err := transitGatewayApisService.CollectInventory(&transitgatewayapisv1.InventoryOptions{
+					Concurrency:      1,
+					SkipRouteReports: true,
+				})
+				Expect(err).To(BeNil())
+				Expect(inventory.Gateways[0].RouteReport).To(BeNil())
+				Expect(requests).ToNot(ContainElement("/transit_gateways/gw-1/route_reports"))
+			})
+			AfterEach(func() {
+				testServer.Close()
+			})
+		})
+		Context(`Using mock server endpoint with a failing sub-resource`, func() {
+			BeforeEach(func() {
+				testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+					defer GinkgoRecover()
+
+					res.Header().Set("Content-type", "application/json")
+					switch req.URL.EscapedPath() {
+					case "/transit_gateways":
+						res.WriteHeader(200)
+						fmt.Fprintf(res, `{"transit_gateways":[%s],"limit":50}`, gatewayJSON)
+					case "/transit_gateways/gw-1/connections":
+						res.WriteHeader(200)
+						fmt.Fprintf(res, `{"connections":[%s],"limit":50}`, vpcConnectionJSON)
+					default:
+						res.WriteHeader(500)
+						fmt.Fprint(res, `{"errors":[{"message":"boom"}]}`)
+					}
+				}))
+			})
+			It(`Invoke CollectInventory with error`, func() {
+				transitGatewayApisService, serviceErr := transitgatewayapisv1.NewTransitGatewayApisV1(&transitgatewayapisv1.TransitGatewayApisV1Options{
+					URL:           testServer.URL,
+					Authenticator: &core.NoAuthAuthenticator{},
+					Version:       core.StringPtr(version),
+				})
+				Expect(serviceErr).To(BeNil())
+
+				inventory, err := transitGatewayApisService.CollectInventory(nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("gw-1"))
+				Expect(inventory).To(BeNil())
+			})
+			AfterEach(func() {
+				testServer.Close()
+			})
+		})
+	})
+	Describe(`WriteInventory and ReadInventory`, func() {
+		It(`Round trip an inventory`, func() {
+			inventory := &transitgatewayapisv1.Inventory{
+				Gateways: []transitgatewayapisv1.GatewayInventory{
+					{Gateway: &transitgatewayapisv1.TransitGateway{ID: core.StringPtr("gw-1"), Name: core.StringPtr("gateway-1")}},
+				},
+			}
+			var buffer bytes.Buffer
+			Expect(transitgatewayapisv1.WriteInventory(&buffer, inventory)).To(Succeed())
+			Expect(buffer.String()).To(ContainSubstring(`"schema_version": 1`))
+
+			restored, err := transitgatewayapisv1.ReadInventory(&buffer)
+			Expect(err).To(BeNil())
+			Expect(*restored.Gateways[0].Gateway.ID).To(Equal("gw-1"))
+			Expect(transitgatewayapisv1.Diff(inventory, restored)).To(BeEmpty())
+		})
+		It(`Reject a snapshot with a newer schema version`, func() {
+			restored, err := transitgatewayapisv1.ReadInventory(strings.NewReader(`{"schema_version": 99, "gateways": []}`))
+			Expect(err).ToNot(BeNil())
+			Expect(restored).To(BeNil())
+		})
+		It(`Reject a nil inventory`, func() {
+			Expect(transitgatewayapisv1.WriteInventory(&bytes.Buffer{}, nil)).ToNot(Succeed())
+		})
+	})
+	Describe(`Diff(oldInventory *Inventory, newInventory *Inventory)`, func() {
+		newInventory := func() *transitgatewayapisv1.Inventory {
+			return &transitgatewayapisv1.Inventory{
+				SchemaVersion: transitgatewayapisv1.InventorySchemaVersion,
+				Gateways: []transitgatewayapisv1.GatewayInventory{
+					{
+						Gateway: &transitgatewayapisv1.TransitGateway{ID: core.StringPtr("gw-1"), Name: core.StringPtr("gateway-1"), Global: core.BoolPtr(false)},
+						Connections: []transitgatewayapisv1.ConnectionInventory{
+							{
+								Connection: &transitgatewayapisv1.TransitGatewayConnectionCust{ID: core.StringPtr("conn-1"), Name: core.StringPtr("vpc-connection"), Status: core.StringPtr("attached")},
+								PrefixFilters: []transitgatewayapisv1.PrefixFilterCust{
+									{ID: core.StringPtr("pf-1"), Action: core.StringPtr("permit"), Prefix: core.StringPtr("10.0.0.0/16")},
+								},
+							},
+						},
+						RouteReport: &transitgatewayapisv1.RouteReport{
+							Connections: []transitgatewayapisv1.RouteReportConnection{
+								{ID: core.StringPtr("conn-1"), Name: core.StringPtr("vpc-connection"), Routes: []transitgatewayapisv1.RouteReportConnectionRoute{{Prefix: core.StringPtr("10.0.0.0/16")}}},
+							},
+						},
+					},
+				},
+			}
+		}
+		It(`Report nothing for identical inventories`, func() {
+			Expect(transitgatewayapisv1.Diff(newInventory(), newInventory())).To(BeEmpty())
+		})
+		It(`Report added and removed gateways`, func() {
+			changes := transitgatewayapisv1.Diff(nil, newInventory())
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Kind).To(Equal(transitgatewayapisv1.InventoryChange_Kind_Added))
+			Expect(changes[0].ResourceType).To(Equal(transitgatewayapisv1.InventoryChange_ResourceType_Gateway))
+			Expect(changes[0].String()).To(Equal("+ gateway gateway-1 (gw-1)"))
+
+			changes = transitgatewayapisv1.Diff(newInventory(), &transitgatewayapisv1.Inventory{})
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Kind).To(Equal(transitgatewayapisv1.InventoryChange_Kind_Removed))
+		})
+		It(`Report field changes and sub-resource drift`, func() {
+			oldInventory := newInventory()
+			current := newInventory()
+			gateway := &current.Gateways[0]
+			gateway.Gateway.Global = core.BoolPtr(true)
+			gateway.Gateway.UpdatedAt = CreateMockDateTime("2020-01-01T00:00:00Z")
+			gateway.Connections[0].Connection.Status = core.StringPtr("detached")
+			gateway.Connections[0].PrefixFilters[0].Action = core.StringPtr("deny")
+			gateway.Connections[0].PrefixFilters = append(gateway.Connections[0].PrefixFilters,
+				transitgatewayapisv1.PrefixFilterCust{ID: core.StringPtr("pf-2"), Action: core.StringPtr("deny"), Prefix: core.StringPtr("10.9.0.0/16")})
+			gateway.Connections = append(gateway.Connections, transitgatewayapisv1.ConnectionInventory{
+				Connection: &transitgatewayapisv1.TransitGatewayConnectionCust{ID: core.StringPtr("conn-2"), Name: core.StringPtr("classic-connection")},
+			})
+			gateway.RouteReport.Connections[0].Routes = []transitgatewayapisv1.RouteReportConnectionRoute{{Prefix: core.StringPtr("10.1.0.0/16")}}
+
+			changes := transitgatewayapisv1.Diff(oldInventory, current)
+			var lines []string
+			for _, change := range changes {
+				lines = append(lines, change.String())
+			}
+			Expect(lines).To(Equal([]string{
+				`~ gateway gateway-1 (gw-1): global false -> true`,
+				`~ connection gateway-1/vpc-connection (conn-1): status "attached" -> "detached"`,
+				`~ prefix_filter gateway-1/vpc-connection/10.0.0.0/16 (pf-1): action "permit" -> "deny"`,
+				`+ prefix_filter gateway-1/vpc-connection/10.9.0.0/16 (pf-2)`,
+				`+ connection gateway-1/classic-connection (conn-2)`,
+				`- route gateway-1/vpc-connection (10.0.0.0/16)`,
+				`+ route gateway-1/vpc-connection (10.1.0.0/16)`,
+			}))
+		})
+	})
+})
@@ -0,0 +1,236 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wafconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/networking-go-sdk/wafrulesapiv1"
+)
+
+// Constants associated with the Change.Kind property.
+const (
+	Change_Kind_Group   = "group"
+	Change_Kind_Package = "package"
+	Change_Kind_Rule    = "rule"
+)
+
+// Change : A setting to update.
+type Change struct {
+	// One of the Change_Kind constants.
+	Kind string
+
+	PackageID string
+
+	// The group of group changes.
+	GroupID string
+
+	// The rule of rule changes.
+	RuleID string
+
+	// The anomaly detection packages need OWASP rule updates.
+	anomaly bool
+
+	// For package changes, the sensitivity and action mode to set, empty when they do not change.
+	Sensitivity string
+	ActionMode  string
+
+	// For group and rule changes, the mode to set.
+	Mode string
+
+	// The error of the update.
+	Err error
+}
+
+func (change Change) String() string {
+	switch change.Kind {
+	case Change_Kind_Package:
+		var settings []string
+		if change.Sensitivity != "" {
+			settings = append(settings, "sensitivity "+change.Sensitivity)
+		}
+		if change.ActionMode != "" {
+			settings = append(settings, "action mode "+change.ActionMode)
+		}
+		return fmt.Sprintf("package %s: %s", change.PackageID, strings.Join(settings, ", "))
+	case Change_Kind_Group:
+		return fmt.Sprintf("group %s/%s: %s", change.PackageID, change.GroupID, change.Mode)
+	}
+	return fmt.Sprintf("rule %s/%s: %s", change.PackageID, change.RuleID, change.Mode)
+}
+
+// Diff returns the updates that turn the current configuration into the desired one. The desired snapshot may be
+// partial: packages are found by ID or name, groups by ID or name within their package and rules by ID within their
+// package, and empty settings are left as they are.
+func Diff(current *Snapshot, desired *Snapshot) (changes []Change, err error) {
+	var problems []string
+	for _, wantedPackage := range desired.Packages {
+		pkg := findPackage(current, &wantedPackage)
+		if pkg == nil {
+			problems = append(problems, fmt.Sprintf("package %s is not in the zone", firstOf(wantedPackage.ID, wantedPackage.Name)))
+			continue
+		}
+		change := Change{Kind: Change_Kind_Package, PackageID: pkg.ID}
+		if wantedPackage.Sensitivity != "" && wantedPackage.Sensitivity != pkg.Sensitivity {
+			change.Sensitivity = wantedPackage.Sensitivity
+		}
+		if wantedPackage.ActionMode != "" && wantedPackage.ActionMode != pkg.ActionMode {
+			change.ActionMode = wantedPackage.ActionMode
+		}
+		if change.Sensitivity != "" || change.ActionMode != "" {
+			changes = append(changes, change)
+		}
+
+		rules := map[string]*Rule{}
+		for i := range pkg.Groups {
+			for j := range pkg.Groups[i].Rules {
+				rules[pkg.Groups[i].Rules[j].ID] = &pkg.Groups[i].Rules[j]
+			}
+		}
+		anomaly := pkg.DetectionMode == Package_DetectionMode_Anomaly
+		for _, wantedGroup := range wantedPackage.Groups {
+			if wantedGroup.ID != "" || wantedGroup.Name != "" {
+				group := findGroup(pkg, &wantedGroup)
+				switch {
+				case group == nil:
+					problems = append(problems, fmt.Sprintf("group %s is not in package %s", firstOf(wantedGroup.ID, wantedGroup.Name), pkg.ID))
+				case wantedGroup.Mode == "" || wantedGroup.Mode == group.Mode:
+				case !allows(group.AllowedModes, wantedGroup.Mode):
+					problems = append(problems, fmt.Sprintf("group %s does not allow mode %s", group.ID, wantedGroup.Mode))
+				default:
+					changes = append(changes, Change{Kind: Change_Kind_Group, PackageID: pkg.ID, GroupID: group.ID, Mode: wantedGroup.Mode})
+				}
+			}
+			for _, wantedRule := range wantedGroup.Rules {
+				rule := rules[wantedRule.ID]
+				switch {
+				case rule == nil:
+					problems = append(problems, fmt.Sprintf("rule %s is not in package %s", wantedRule.ID, pkg.ID))
+				case wantedRule.Mode == "" || wantedRule.Mode == rule.Mode:
+				case !allows(rule.AllowedModes, wantedRule.Mode):
+					problems = append(problems, fmt.Sprintf("rule %s does not allow mode %s", rule.ID, wantedRule.Mode))
+				default:
+					changes = append(changes, Change{Kind: Change_Kind_Rule, PackageID: pkg.ID, RuleID: rule.ID, Mode: wantedRule.Mode, anomaly: anomaly})
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		err = fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func findPackage(snapshot *Snapshot, wanted *Package) *Package {
+	for i := range snapshot.Packages {
+		pkg := &snapshot.Packages[i]
+		if (wanted.ID != "" && pkg.ID == wanted.ID) || (wanted.ID == "" && pkg.Name == wanted.Name) {
+			return pkg
+		}
+	}
+	return nil
+}
+
+func findGroup(pkg *Package, wanted *Group) *Group {
+	for i := range pkg.Groups {
+		group := &pkg.Groups[i]
+		if (wanted.ID != "" && group.ID == wanted.ID) || (wanted.ID == "" && group.Name == wanted.Name) {
+			return group
+		}
+	}
+	return nil
+}
+
+// ApplyOptions : The ApplyOptions options.
+type ApplyOptions struct {
+	// Only compute the changes.
+	DryRun bool
+}
+
+// Apply : Apply a WAF configuration to the zone
+// Take a snapshot of the zone, compare it with the desired configuration and make the update call of each setting
+// that differs, several at a time. All the changes are attempted; the returned changes carry the error of the
+// updates that failed, and the error tells how many failed.
+func (manager *Manager) Apply(desired *Snapshot, applyOptions *ApplyOptions) (changes []Change, err error) {
+	return manager.ApplyWithContext(context.Background(), desired, applyOptions)
+}
+
+// ApplyWithContext is an alternate form of the Apply method which supports a Context parameter
+func (manager *Manager) ApplyWithContext(ctx context.Context, desired *Snapshot, applyOptions *ApplyOptions) (changes []Change, err error) {
+	current, err := manager.SnapshotWithContext(ctx)
+	if err != nil {
+		return
+	}
+	changes, err = Diff(current, desired)
+	if err != nil || (applyOptions != nil && applyOptions.DryRun) {
+		return
+	}
+	_ = manager.forEach(len(changes), func(i int) error {
+		changes[i].Err = manager.update(ctx, &changes[i])
+		return nil
+	})
+	failed := 0
+	var first error
+	for _, change := range changes {
+		if change.Err != nil {
+			if first == nil {
+				first = fmt.Errorf("%s: %w", change, change.Err)
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		err = fmt.Errorf("%d of %d WAF updates failed, the first: %w", failed, len(changes), first)
+	}
+	return
+}
+
+func (manager *Manager) update(ctx context.Context, change *Change) (err error) {
+	switch change.Kind {
+	case Change_Kind_Package:
+		options := manager.Packages.NewUpdateWafPackageOptions(change.PackageID)
+		if change.Sensitivity != "" {
+			options.SetSensitivity(change.Sensitivity)
+		}
+		if change.ActionMode != "" {
+			options.SetActionMode(change.ActionMode)
+		}
+		_, _, err = manager.Packages.UpdateWafPackageWithContext(ctx, options)
+	case Change_Kind_Group:
+		_, _, err = manager.Groups.UpdateWafRuleGroupWithContext(ctx, manager.Groups.NewUpdateWafRuleGroupOptions(change.PackageID, change.GroupID).SetMode(change.Mode))
+	case Change_Kind_Rule:
+		options := manager.Rules.NewUpdateWafRuleOptions(change.PackageID, change.RuleID)
+		if change.anomaly {
+			options.SetOwasp(&wafrulesapiv1.WafRuleBodyOwasp{Mode: &change.Mode})
+		} else {
+			options.SetCis(&wafrulesapiv1.WafRuleBodyCis{Mode: &change.Mode})
+		}
+		_, _, err = manager.Rules.UpdateWafRuleWithContext(ctx, options)
+	}
+	return
+}
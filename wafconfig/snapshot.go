@@ -0,0 +1,325 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wafconfig handles the legacy WAF configuration of a zone as one document.
+//
+// A Snapshot holds the WAF packages of a zone with their sensitivity and action mode, their rule groups with their
+// mode and the rules of each group with their mode. A Manager takes snapshots, and applies a desired snapshot with
+// the update calls of the settings that differ only, several at a time. The snapshot helpers change the modes of
+// many groups or rules at once, such as every rule whose description matches a regular expression.
+package wafconfig
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/common"
+	"github.com/IBM/networking-go-sdk/wafrulegroupsapiv1"
+	"github.com/IBM/networking-go-sdk/wafrulepackagesapiv1"
+	"github.com/IBM/networking-go-sdk/wafrulesapiv1"
+)
+
+// DefaultConcurrency is the number of calls made at the same time when the Manager does not say otherwise.
+const DefaultConcurrency = 8
+
+// DefaultPageSize is the page size used to list packages, groups and rules when the Manager does not say otherwise.
+const DefaultPageSize = 100
+
+// Constants associated with the Package.DetectionMode property.
+const (
+	Package_DetectionMode_Anomaly     = "anomaly"
+	Package_DetectionMode_Traditional = "traditional"
+)
+
+// Snapshot : The WAF configuration of a zone.
+type Snapshot struct {
+	Packages []Package `json:"packages"`
+}
+
+// Package : A WAF package. The sensitivity and action mode only apply to anomaly detection packages.
+type Package struct {
+	ID string `json:"id,omitempty"`
+
+	Name string `json:"name,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	// One of the Package_DetectionMode constants.
+	DetectionMode string `json:"detection_mode,omitempty"`
+
+	// One of high, medium, low and off.
+	Sensitivity string `json:"sensitivity,omitempty"`
+
+	// One of simulate, block and challenge.
+	ActionMode string `json:"action_mode,omitempty"`
+
+	Groups []Group `json:"groups,omitempty"`
+}
+
+// Group : A rule group of a WAF package.
+type Group struct {
+	ID string `json:"id,omitempty"`
+
+	Name string `json:"name,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	// On or off.
+	Mode string `json:"mode,omitempty"`
+
+	AllowedModes []string `json:"allowed_modes,omitempty"`
+
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Rule : A WAF rule.
+type Rule struct {
+	ID string `json:"id"`
+
+	Description string `json:"description,omitempty"`
+
+	Priority string `json:"priority,omitempty"`
+
+	// On or off for the rules of anomaly detection packages, and default, disable, simulate, block or challenge for
+	// the rules of traditional packages.
+	Mode string `json:"mode,omitempty"`
+
+	AllowedModes []string `json:"allowed_modes,omitempty"`
+}
+
+// allows returns true when the mode is allowed, or when the allowed modes are not known.
+func allows(allowedModes []string, mode string) bool {
+	if len(allowedModes) == 0 {
+		return true
+	}
+	for _, allowed := range allowedModes {
+		if allowed == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// SetGroupMode sets the mode of the groups whose name matches a regular expression and that allow the mode, and
+// returns the number of groups changed.
+func (snapshot *Snapshot) SetGroupMode(name *regexp.Regexp, mode string) (changed int) {
+	for i := range snapshot.Packages {
+		for j := range snapshot.Packages[i].Groups {
+			group := &snapshot.Packages[i].Groups[j]
+			if name.MatchString(group.Name) && group.Mode != mode && allows(group.AllowedModes, mode) {
+				group.Mode = mode
+				changed++
+			}
+		}
+	}
+	return
+}
+
+// SetRuleMode sets the mode of the rules whose description matches a regular expression and that allow the mode,
+// and returns the number of rules changed.
+func (snapshot *Snapshot) SetRuleMode(description *regexp.Regexp, mode string) (changed int) {
+	for i := range snapshot.Packages {
+		for j := range snapshot.Packages[i].Groups {
+			rules := snapshot.Packages[i].Groups[j].Rules
+			for k := range rules {
+				if description.MatchString(rules[k].Description) && rules[k].Mode != mode && allows(rules[k].AllowedModes, mode) {
+					rules[k].Mode = mode
+					changed++
+				}
+			}
+		}
+	}
+	return
+}
+
+// Rules returns the number of rules of the snapshot.
+func (snapshot *Snapshot) Rules() (count int) {
+	for _, pkg := range snapshot.Packages {
+		for _, group := range pkg.Groups {
+			count += len(group.Rules)
+		}
+	}
+	return
+}
+
+// Manager : Reads and updates the WAF configuration of a zone.
+type Manager struct {
+	Packages *wafrulepackagesapiv1.WafRulePackagesApiV1
+
+	Groups *wafrulegroupsapiv1.WafRuleGroupsApiV1
+
+	Rules *wafrulesapiv1.WafRulesApiV1
+
+	// The number of calls made at the same time.
+	Concurrency int
+
+	PageSize int64
+}
+
+// NewManager : Instantiate Manager
+func NewManager(packages *wafrulepackagesapiv1.WafRulePackagesApiV1, groups *wafrulegroupsapiv1.WafRuleGroupsApiV1, rules *wafrulesapiv1.WafRulesApiV1) (manager *Manager, err error) {
+	if packages == nil || groups == nil || rules == nil {
+		err = fmt.Errorf("the packages, groups and rules clients are required")
+		return
+	}
+	manager = &Manager{Packages: packages, Groups: groups, Rules: rules, Concurrency: DefaultConcurrency, PageSize: DefaultPageSize}
+	return
+}
+
+func (manager *Manager) pageSize() int64 {
+	if manager.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return manager.PageSize
+}
+
+// forEach calls f for 0 to count-1, at most Concurrency calls at a time, and returns the first error.
+func (manager *Manager) forEach(count int, f func(i int) error) error {
+	concurrency := manager.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	semaphore := make(chan struct{}, concurrency)
+	errs := make([]error, count)
+	var group sync.WaitGroup
+	for i := 0; i < count; i++ {
+		group.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				group.Done()
+			}()
+			errs[i] = f(i)
+		}(i)
+	}
+	group.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshot : Read the WAF configuration of the zone
+// Read the packages, their groups and their rules, all pages, several packages at a time.
+func (manager *Manager) Snapshot() (snapshot *Snapshot, err error) {
+	return manager.SnapshotWithContext(context.Background())
+}
+
+// SnapshotWithContext is an alternate form of the Snapshot method which supports a Context parameter
+func (manager *Manager) SnapshotWithContext(ctx context.Context) (snapshot *Snapshot, err error) {
+	snapshot = &Snapshot{}
+	for page := int64(1); ; page++ {
+		options := manager.Packages.NewListWafPackagesOptions().SetPage(page).SetPerPage(manager.pageSize())
+		result, _, listErr := manager.Packages.ListWafPackagesWithContext(ctx, options)
+		if listErr != nil {
+			return nil, fmt.Errorf("listing WAF packages: %w", listErr)
+		}
+		for _, item := range result.Result {
+			snapshot.Packages = append(snapshot.Packages, Package{
+				ID:            core.StringNilMapper(item.ID),
+				Name:          core.StringNilMapper(item.Name),
+				Description:   core.StringNilMapper(item.Description),
+				DetectionMode: core.StringNilMapper(item.DetectionMode),
+			})
+		}
+		if result.ResultInfo == nil || common.LastPage(page, manager.pageSize(), len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+	err = manager.forEach(len(snapshot.Packages), func(i int) error {
+		return manager.readPackage(ctx, &snapshot.Packages[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// readPackage reads the settings, groups and rules of a package.
+func (manager *Manager) readPackage(ctx context.Context, pkg *Package) error {
+	result, _, err := manager.Packages.GetWafPackageWithContext(ctx, manager.Packages.NewGetWafPackageOptions(pkg.ID))
+	if err != nil {
+		return fmt.Errorf("getting WAF package %s: %w", pkg.ID, err)
+	}
+	if result.Result != nil {
+		pkg.Sensitivity = core.StringNilMapper(result.Result.Sensitivity)
+		pkg.ActionMode = core.StringNilMapper(result.Result.ActionMode)
+	}
+
+	groups := map[string]int{}
+	for page := int64(1); ; page++ {
+		options := manager.Groups.NewListWafRuleGroupsOptions(pkg.ID).SetPage(page).SetPerPage(manager.pageSize())
+		result, _, err := manager.Groups.ListWafRuleGroupsWithContext(ctx, options)
+		if err != nil {
+			return fmt.Errorf("listing the groups of WAF package %s: %w", pkg.ID, err)
+		}
+		for _, group := range result.Result {
+			groups[core.StringNilMapper(group.ID)] = len(pkg.Groups)
+			pkg.Groups = append(pkg.Groups, Group{
+				ID:           core.StringNilMapper(group.ID),
+				Name:         core.StringNilMapper(group.Name),
+				Description:  core.StringNilMapper(group.Description),
+				Mode:         core.StringNilMapper(group.Mode),
+				AllowedModes: group.AllowedModes,
+			})
+		}
+		if result.ResultInfo == nil || common.LastPage(page, manager.pageSize(), len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+
+	for page := int64(1); ; page++ {
+		options := manager.Rules.NewListWafRulesOptions(pkg.ID).SetPage(page).SetPerPage(manager.pageSize())
+		result, _, err := manager.Rules.ListWafRulesWithContext(ctx, options)
+		if err != nil {
+			return fmt.Errorf("listing the rules of WAF package %s: %w", pkg.ID, err)
+		}
+		for _, item := range result.Result {
+			rule := Rule{
+				ID:           core.StringNilMapper(item.ID),
+				Description:  core.StringNilMapper(item.Description),
+				Priority:     core.StringNilMapper(item.Priority),
+				Mode:         core.StringNilMapper(item.Mode),
+				AllowedModes: item.AllowedModes,
+			}
+			var groupID string
+			if item.Group != nil {
+				groupID = core.StringNilMapper(item.Group.ID)
+			}
+			index, ok := groups[groupID]
+			if !ok {
+				index = len(pkg.Groups)
+				groups[groupID] = index
+				group := Group{ID: groupID}
+				if item.Group != nil {
+					group.Name = core.StringNilMapper(item.Group.Name)
+				}
+				pkg.Groups = append(pkg.Groups, group)
+			}
+			pkg.Groups[index].Rules = append(pkg.Groups[index].Rules, rule)
+		}
+		if result.ResultInfo == nil || common.LastPage(page, manager.pageSize(), len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+	return nil
+}
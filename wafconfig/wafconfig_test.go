@@ -0,0 +1,259 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wafconfig_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/wafconfig"
+	"github.com/IBM/networking-go-sdk/wafrulegroupsapiv1"
+	"github.com/IBM/networking-go-sdk/wafrulepackagesapiv1"
+	"github.com/IBM/networking-go-sdk/wafrulesapiv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var wafPath = regexp.MustCompile(`^/v1/crn/zones/zone/firewall/waf/packages(?:/([^/]+))?(?:/(groups|rules)(?:/([^/]+))?)?$`)
+
+// fakeWAF keeps the packages, groups and rules of a zone.
+type fakeWAF struct {
+	sync.Mutex
+	packages []map[string]interface{}
+	groups   map[string][]map[string]interface{}
+	rules    map[string][]map[string]interface{}
+	writes   []string
+	fail     string
+}
+
+func (api *fakeWAF) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	matches := wafPath.FindStringSubmatch(req.URL.EscapedPath())
+	Expect(matches).ToNot(BeNil())
+	packageID, kind, id := matches[1], matches[2], matches[3]
+	res.Header().Set("Content-type", "application/json")
+	if req.Method == "PATCH" {
+		var body map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		if kind == "" {
+			kind = "packages"
+		}
+		api.writes = append(api.writes, fmt.Sprintf("%s %s/%s %v", kind, packageID, id, body))
+		if api.fail != "" && (id == api.fail || (kind == "packages" && packageID == api.fail)) {
+			res.WriteHeader(500)
+			fmt.Fprint(res, `{"success": false, "errors": [{"code": 1000, "message": "failed"}], "messages": []}`)
+			return
+		}
+		res.WriteHeader(200)
+		fmt.Fprint(res, `{"success": true, "errors": [], "messages": [], "result": {}}`)
+		return
+	}
+
+	var objects []map[string]interface{}
+	switch kind {
+	case "":
+		if packageID != "" {
+			for _, pkg := range api.packages {
+				if pkg["id"] == packageID {
+					json.NewEncoder(res).Encode(map[string]interface{}{"success": true, "errors": []string{}, "messages": []string{}, "result": pkg})
+					return
+				}
+			}
+			res.WriteHeader(404)
+			return
+		}
+		objects = api.packages
+	case "groups":
+		objects = api.groups[packageID]
+	case "rules":
+		objects = api.rules[packageID]
+	}
+	page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+	start, end := (page-1)*perPage, page*perPage
+	if start > len(objects) {
+		start = len(objects)
+	}
+	if end > len(objects) {
+		end = len(objects)
+	}
+	json.NewEncoder(res).Encode(map[string]interface{}{
+		"success":     true,
+		"errors":      []string{},
+		"messages":    []string{},
+		"result":      objects[start:end],
+		"result_info": map[string]interface{}{"page": page, "per_page": perPage, "count": end - start, "total_count": len(objects)},
+	})
+}
+
+func newFakeWAF() *fakeWAF {
+	api := &fakeWAF{
+		packages: []map[string]interface{}{
+			{"id": "owasp", "name": "OWASP ModSecurity Core Rule Set", "detection_mode": "anomaly", "sensitivity": "low", "action_mode": "simulate"},
+			{"id": "cis", "name": "CIS Managed Ruleset", "detection_mode": "traditional"},
+		},
+		groups: map[string][]map[string]interface{}{
+			"owasp": {{"id": "og1", "name": "OWASP Bad Robots", "mode": "on", "allowed_modes": []string{"on", "off"}}},
+			"cis": {
+				{"id": "cg1", "name": "CIS Specials", "mode": "on", "allowed_modes": []string{"on", "off"}},
+				{"id": "cg2", "name": "CIS WordPress", "mode": "off", "allowed_modes": []string{"on", "off"}},
+			},
+		},
+		rules: map[string][]map[string]interface{}{},
+	}
+	for i := 0; i < 5; i++ {
+		api.rules["owasp"] = append(api.rules["owasp"], map[string]interface{}{
+			"id": fmt.Sprintf("o%d", i), "description": fmt.Sprintf("Bad robot %d", i), "priority": "1", "mode": "on",
+			"allowed_modes": []string{"on", "off"}, "group": map[string]interface{}{"id": "og1", "name": "OWASP Bad Robots"}, "package_id": "owasp",
+		})
+	}
+	for i := 0; i < 7; i++ {
+		group, description := "cg1", fmt.Sprintf("XSS attack %d", i)
+		if i%2 == 1 {
+			group, description = "cg2", fmt.Sprintf("WordPress exploit %d", i)
+		}
+		api.rules["cis"] = append(api.rules["cis"], map[string]interface{}{
+			"id": fmt.Sprintf("c%d", i), "description": description, "priority": "5", "mode": "default",
+			"allowed_modes": []string{"default", "disable", "simulate", "block", "challenge"}, "group": map[string]interface{}{"id": group}, "package_id": "cis",
+		})
+	}
+	return api
+}
+
+var _ = Describe(`Manager`, func() {
+	var (
+		api     *fakeWAF
+		server  *httptest.Server
+		manager *wafconfig.Manager
+	)
+
+	BeforeEach(func() {
+		api = newFakeWAF()
+		server = httptest.NewServer(api)
+		packages, err := wafrulepackagesapiv1.NewWafRulePackagesApiV1(&wafrulepackagesapiv1.WafRulePackagesApiV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneID: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		groups, err := wafrulegroupsapiv1.NewWafRuleGroupsApiV1(&wafrulegroupsapiv1.WafRuleGroupsApiV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneID: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		rules, err := wafrulesapiv1.NewWafRulesApiV1(&wafrulesapiv1.WafRulesApiV1Options{
+			URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn"), ZoneID: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+		manager, err = wafconfig.NewManager(packages, groups, rules)
+		Expect(err).To(BeNil())
+		manager.PageSize = 2
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Takes a snapshot across all pages`, func() {
+		snapshot, err := manager.Snapshot()
+		Expect(err).To(BeNil())
+		Expect(snapshot.Packages).To(HaveLen(2))
+		Expect(snapshot.Rules()).To(Equal(12))
+
+		owasp := snapshot.Packages[0]
+		Expect(owasp.Sensitivity).To(Equal("low"))
+		Expect(owasp.ActionMode).To(Equal("simulate"))
+		Expect(owasp.Groups).To(HaveLen(1))
+		Expect(owasp.Groups[0].Rules).To(HaveLen(5))
+
+		cis := snapshot.Packages[1]
+		Expect(cis.Groups).To(HaveLen(2))
+		Expect(cis.Groups[0].Name).To(Equal("CIS Specials"))
+		Expect(cis.Groups[0].Rules).To(HaveLen(4))
+		Expect(cis.Groups[1].Rules).To(HaveLen(3))
+		Expect(cis.Groups[1].Rules[0].Description).To(Equal("WordPress exploit 1"))
+	})
+
+	It(`Applies bulk mode changes with the update calls needed`, func() {
+		desired, err := manager.Snapshot()
+		Expect(err).To(BeNil())
+		Expect(desired.SetRuleMode(regexp.MustCompile(`^WordPress`), "simulate")).To(Equal(3))
+		Expect(desired.SetRuleMode(regexp.MustCompile(`robot [12]$`), "off")).To(Equal(2))
+		Expect(desired.SetRuleMode(regexp.MustCompile(`robot`), "simulate")).To(Equal(0))
+		Expect(desired.SetGroupMode(regexp.MustCompile(`WordPress`), "on")).To(Equal(1))
+		desired.Packages[0].Sensitivity = "high"
+
+		changes, err := manager.Apply(desired, &wafconfig.ApplyOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(7))
+		Expect(api.writes).To(BeEmpty())
+
+		changes, err = manager.Apply(desired, nil)
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(7))
+		sort.Strings(api.writes)
+		Expect(api.writes).To(Equal([]string{
+			"groups cis/cg2 map[mode:on]",
+			"packages owasp/ map[sensitivity:high]",
+			"rules cis/c1 map[cis:map[mode:simulate]]",
+			"rules cis/c3 map[cis:map[mode:simulate]]",
+			"rules cis/c5 map[cis:map[mode:simulate]]",
+			"rules owasp/o1 map[owasp:map[mode:off]]",
+			"rules owasp/o2 map[owasp:map[mode:off]]",
+		}))
+	})
+
+	It(`Applies partial documents`, func() {
+		changes, err := manager.Apply(&wafconfig.Snapshot{Packages: []wafconfig.Package{{
+			Name:   "CIS Managed Ruleset",
+			Groups: []wafconfig.Group{{Rules: []wafconfig.Rule{{ID: "c0", Mode: "block"}, {ID: "c2", Mode: "default"}}}},
+		}}}, nil)
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].String()).To(Equal("rule cis/c0: block"))
+		Expect(api.writes).To(Equal([]string{"rules cis/c0 map[cis:map[mode:block]]"}))
+	})
+
+	It(`Rejects unknown entries and modes that are not allowed`, func() {
+		_, err := manager.Apply(&wafconfig.Snapshot{Packages: []wafconfig.Package{
+			{ID: "missing"},
+			{ID: "owasp", Groups: []wafconfig.Group{{ID: "og1", Mode: "block", Rules: []wafconfig.Rule{{ID: "c0", Mode: "off"}}}}},
+		}}, nil)
+		Expect(err).To(MatchError("package missing is not in the zone; group og1 does not allow mode block; rule c0 is not in package owasp"))
+		Expect(api.writes).To(BeEmpty())
+	})
+
+	It(`Attempts every change and reports the failures`, func() {
+		api.fail = "c1"
+		changes, err := manager.Apply(&wafconfig.Snapshot{Packages: []wafconfig.Package{{
+			ID:     "cis",
+			Groups: []wafconfig.Group{{Rules: []wafconfig.Rule{{ID: "c0", Mode: "block"}, {ID: "c1", Mode: "block"}}}},
+		}}}, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("1 of 2 WAF updates failed, the first: rule cis/c1: block: "))
+		Expect(changes[0].Err).To(BeNil())
+		Expect(changes[1].Err).ToNot(BeNil())
+		Expect(api.writes).To(HaveLen(2))
+	})
+})
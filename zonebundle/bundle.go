@@ -0,0 +1,345 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package zonebundle backs up the configuration of a zone to a portable bundle and restores it.
+//
+// A bundle is a set of JSON files with a manifest: the zone settings, page rules, filters and firewall rules, rate
+// limits, lockdowns, user agent rules, rulesets, the global load balancers of the zone with their pools and
+// monitors, the metadata of the certificates, the logpush jobs, and the edge function triggers with their scripts.
+// DNS records are not part of a bundle. Export reads a zone into a bundle, which is written to a directory or to a
+// gzipped tarball; Import restores a bundle into an empty zone in dependency order, such as filters before firewall
+// rules and pools before load balancers, and maps the IDs of the bundle to the IDs of the objects it creates.
+package zonebundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SchemaVersion is the version of the bundle format written by Export. Bundles of a later version cannot be read.
+const SchemaVersion = 1
+
+// ManifestFile is the path of the manifest in a bundle.
+const ManifestFile = "manifest.json"
+
+// MaxFileSize is the size of the largest file that ReadTar reads from a tarball.
+const MaxFileSize = 16 << 20
+
+// Constants for the resources of a bundle. The file of a resource is named after it, as in page_rules.json; the edge
+// function scripts are kept apart, as edge_functions/<script>.js.
+const (
+	Resource_Certificates   = "certificates"
+	Resource_EdgeFunctions  = "edge_functions"
+	Resource_Filters        = "filters"
+	Resource_FirewallRules  = "firewall_rules"
+	Resource_GlbMonitors    = "glb_monitors"
+	Resource_GlbPools       = "glb_pools"
+	Resource_LoadBalancers  = "load_balancers"
+	Resource_Lockdowns      = "lockdowns"
+	Resource_LogpushJobs    = "logpush_jobs"
+	Resource_PageRules      = "page_rules"
+	Resource_RateLimits     = "rate_limits"
+	Resource_Rulesets       = "rulesets"
+	Resource_Settings       = "settings"
+	Resource_UserAgentRules = "user_agent_rules"
+)
+
+// Manifest : The table of contents of a bundle.
+type Manifest struct {
+	// The version of the bundle format.
+	SchemaVersion int `json:"schema_version"`
+
+	// The name of the zone the bundle was exported from.
+	Zone string `json:"zone"`
+
+	// The time of the export, in RFC 3339 format.
+	ExportedAt string `json:"exported_at"`
+
+	Files []ManifestEntry `json:"files"`
+
+	// What the export could not read, such as the settings the plan of the zone does not include.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ManifestEntry : A file of a bundle.
+type ManifestEntry struct {
+	// The slash separated path of the file in the bundle.
+	Path string `json:"path"`
+
+	// One of the Resource constants.
+	Resource string `json:"resource"`
+
+	// The number of objects in the file.
+	Count int `json:"count"`
+
+	// The hex encoded SHA-256 checksum of the file.
+	Sha256 string `json:"sha256"`
+}
+
+// Bundle : The configuration of a zone.
+type Bundle struct {
+	Manifest Manifest
+
+	files map[string][]byte
+}
+
+// NewBundle : Instantiate Bundle
+func NewBundle(zone string) *Bundle {
+	return &Bundle{Manifest: Manifest{SchemaVersion: SchemaVersion, Zone: zone}, files: map[string][]byte{}}
+}
+
+// File returns the content of a file of the bundle.
+func (bundle *Bundle) File(filePath string) (content []byte, ok bool) {
+	content, ok = bundle.files[filePath]
+	return
+}
+
+// Entry returns the manifest entry of a file, or nil.
+func (bundle *Bundle) Entry(filePath string) *ManifestEntry {
+	for i := range bundle.Manifest.Files {
+		if bundle.Manifest.Files[i].Path == filePath {
+			return &bundle.Manifest.Files[i]
+		}
+	}
+	return nil
+}
+
+// Put adds a file to the bundle, or replaces it, and records it in the manifest.
+func (bundle *Bundle) Put(filePath string, resource string, count int, content []byte) {
+	sum := sha256.Sum256(content)
+	entry := ManifestEntry{Path: filePath, Resource: resource, Count: count, Sha256: hex.EncodeToString(sum[:])}
+	if existing := bundle.Entry(filePath); existing != nil {
+		*existing = entry
+	} else {
+		bundle.Manifest.Files = append(bundle.Manifest.Files, entry)
+	}
+	bundle.files[filePath] = content
+}
+
+// putJSON adds the JSON form of a value as the file of a resource.
+func (bundle *Bundle) putJSON(resource string, count int, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", resource, err)
+	}
+	bundle.Put(resource+".json", resource, count, append(content, '\n'))
+	return nil
+}
+
+// getJSON decodes the file of a resource, and returns false when the bundle does not have it.
+func (bundle *Bundle) getJSON(resource string, value interface{}) (ok bool, err error) {
+	content, ok := bundle.files[resource+".json"]
+	if !ok {
+		return
+	}
+	err = json.Unmarshal(content, value)
+	if err != nil {
+		err = fmt.Errorf("decoding %s: %w", resource, err)
+	}
+	return
+}
+
+// validPath returns an error when a path of the manifest could escape the bundle.
+func validPath(filePath string) error {
+	parts := strings.Split(filePath, "/")
+	if filePath == "" || filePath == ManifestFile || path.IsAbs(filePath) || path.Clean(filePath) != filePath || slices.Contains(parts, "..") || slices.Contains(parts, ".") {
+		return fmt.Errorf("invalid file path %q in the manifest", filePath)
+	}
+	return nil
+}
+
+// readManifest decodes a manifest and checks its version.
+func readManifest(content []byte) (manifest Manifest, err error) {
+	err = json.Unmarshal(content, &manifest)
+	if err != nil {
+		err = fmt.Errorf("decoding the manifest: %w", err)
+		return
+	}
+	if manifest.SchemaVersion < 1 || manifest.SchemaVersion > SchemaVersion {
+		err = fmt.Errorf("unsupported bundle schema version %d, this version reads versions 1 to %d", manifest.SchemaVersion, SchemaVersion)
+	}
+	for _, entry := range manifest.Files {
+		if err == nil {
+			err = validPath(entry.Path)
+		}
+	}
+	return
+}
+
+// check returns an error when a file of the manifest is missing or does not match its checksum.
+func (bundle *Bundle) check() error {
+	for _, entry := range bundle.Manifest.Files {
+		content, ok := bundle.files[entry.Path]
+		if !ok {
+			return fmt.Errorf("the bundle has no file %s", entry.Path)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != entry.Sha256 {
+			return fmt.Errorf("the checksum of %s does not match the manifest", entry.Path)
+		}
+	}
+	return nil
+}
+
+func (bundle *Bundle) manifestContent() ([]byte, error) {
+	sort.Slice(bundle.Manifest.Files, func(i, j int) bool {
+		return bundle.Manifest.Files[i].Path < bundle.Manifest.Files[j].Path
+	})
+	content, err := json.MarshalIndent(&bundle.Manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// WriteDir writes the bundle to a directory, which is created when needed.
+func (bundle *Bundle) WriteDir(dir string) error {
+	manifest, err := bundle.manifestContent()
+	if err != nil {
+		return err
+	}
+	for _, entry := range bundle.Manifest.Files {
+		target := filepath.Join(dir, filepath.FromSlash(entry.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, bundle.files[entry.Path], 0o600); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	// The manifest is written last, so that a directory with a manifest holds a complete bundle.
+	return os.WriteFile(filepath.Join(dir, ManifestFile), manifest, 0o600)
+}
+
+// ReadDir reads a bundle written by WriteDir, and checks the files against the manifest.
+func ReadDir(dir string) (bundle *Bundle, err error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readManifest(content)
+	if err != nil {
+		return nil, err
+	}
+	bundle = &Bundle{Manifest: manifest, files: map[string][]byte{}}
+	for _, entry := range manifest.Files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(entry.Path)))
+		if err != nil {
+			return nil, err
+		}
+		bundle.files[entry.Path] = content
+	}
+	if err = bundle.check(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// WriteTar writes the bundle as a gzipped tarball, with the manifest first.
+func (bundle *Bundle) WriteTar(writer io.Writer) error {
+	manifest, err := bundle.manifestContent()
+	if err != nil {
+		return err
+	}
+	compressed := gzip.NewWriter(writer)
+	archive := tar.NewWriter(compressed)
+	write := func(name string, content []byte) error {
+		err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err == nil {
+			_, err = archive.Write(content)
+		}
+		return err
+	}
+	if err := write(ManifestFile, manifest); err != nil {
+		return err
+	}
+	for _, entry := range bundle.Manifest.Files {
+		if err := write(entry.Path, bundle.files[entry.Path]); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
+
+// ReadTar reads a bundle written by WriteTar, and checks the files against the manifest. The manifest must come
+// first, as WriteTar writes it; the files that it does not list are skipped, and files larger than MaxFileSize are
+// rejected.
+func ReadTar(reader io.Reader) (bundle *Bundle, err error) {
+	compressed, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	archive := tar.NewReader(compressed)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if bundle == nil && header.Name != ManifestFile {
+			return nil, fmt.Errorf("the tarball does not start with %s", ManifestFile)
+		}
+		if bundle != nil && bundle.Entry(header.Name) == nil {
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(archive, MaxFileSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(content) > MaxFileSize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", header.Name, MaxFileSize)
+		}
+		if bundle == nil {
+			manifest, err := readManifest(content)
+			if err != nil {
+				return nil, err
+			}
+			bundle = &Bundle{Manifest: manifest, files: map[string][]byte{}}
+			continue
+		}
+		bundle.files[header.Name] = content
+	}
+	if bundle == nil {
+		return nil, fmt.Errorf("the tarball has no %s", ManifestFile)
+	}
+	if err = bundle.check(); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,479 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonebundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/common"
+	"github.com/IBM/networking-go-sdk/edgefunctionsapiv1"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/globalloadbalancermonitorv1"
+	"github.com/IBM/networking-go-sdk/globalloadbalancerpoolsv0"
+	"github.com/IBM/networking-go-sdk/globalloadbalancerv1"
+	"github.com/IBM/networking-go-sdk/logpushjobsapiv1"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/sslcertificateapiv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+)
+
+// PageSize is the page size used to list the rate limits, lockdowns and user agent rules.
+const PageSize = 100
+
+// Zone : The clients of a zone. A nil client leaves its resources out of the export, and makes the import skip them.
+type Zone struct {
+	// The domain name of the zone, recorded in the manifest.
+	Name string
+
+	Settings *zonessettingsv1.ZonesSettingsV1
+
+	PageRules *pageruleapiv1.PageRuleApiV1
+
+	Filters *filtersv1.FiltersV1
+
+	FirewallRules *firewallrulesv1.FirewallRulesV1
+
+	// The IAM token, CRN and zone identifier passed to the filters and firewall rules APIs.
+	XAuthUserToken string
+	Crn            string
+	ZoneID         string
+
+	RateLimits *zoneratelimitsv1.ZoneRateLimitsV1
+
+	Lockdowns *zonelockdownv1.ZoneLockdownV1
+
+	UserAgentRules *useragentblockingrulesv1.UserAgentBlockingRulesV1
+
+	Rulesets *rulesetsv1.RulesetsV1
+
+	// The monitors and pools belong to the instance. Only the ones that the load balancers of the zone use are
+	// exported.
+	Monitors *globalloadbalancermonitorv1.GlobalLoadBalancerMonitorV1
+
+	Pools *globalloadbalancerpoolsv0.GlobalLoadBalancerPoolsV0
+
+	LoadBalancers *globalloadbalancerv1.GlobalLoadBalancerV1
+
+	Certificates *sslcertificateapiv1.SslCertificateApiV1
+
+	Logpush *logpushjobsapiv1.LogpushJobsApiV1
+
+	// The scripts belong to the instance. Only the ones that the triggers of the zone run are exported.
+	EdgeFunctions *edgefunctionsapiv1.EdgeFunctionsApiV1
+}
+
+// checkFilters returns an error when the zone has a filters or firewall rules client without the parameters their
+// APIs take.
+func (zone *Zone) checkFilters() error {
+	if (zone.Filters != nil || zone.FirewallRules != nil) && (zone.XAuthUserToken == "" || zone.Crn == "" || zone.ZoneID == "") {
+		return fmt.Errorf("XAuthUserToken, Crn and ZoneID are required for filters and firewall rules")
+	}
+	return nil
+}
+
+// rulesetsFile is the content of rulesets.json: the entry point rulesets of the zone, one for each phase it uses,
+// and the custom rulesets of the zone. The rulesets are kept as the API returns them.
+type rulesetsFile struct {
+	Entrypoints []json.RawMessage `json:"entrypoints"`
+	Custom      []json.RawMessage `json:"custom"`
+}
+
+// certificatesFile is the content of certificates.json.
+type certificatesFile struct {
+	CertificatePacks   []sslcertificateapiv1.DedicatedCertificatePack `json:"certificate_packs"`
+	CustomCertificates []sslcertificateapiv1.CustomCertPack           `json:"custom_certificates"`
+}
+
+// Export : Read the configuration of a zone into a bundle
+// Read the resources for which the zone has a client. A setting that cannot be read, as when the plan of the zone
+// does not include it, is left out and recorded as a warning of the manifest; any other failure stops the export.
+// The logpush jobs are exported with their destination, which may hold credentials: keep the bundle private.
+func Export(zone *Zone) (bundle *Bundle, err error) {
+	return ExportWithContext(context.Background(), zone)
+}
+
+// ExportWithContext is an alternate form of the Export method which supports a Context parameter
+func ExportWithContext(ctx context.Context, zone *Zone) (bundle *Bundle, err error) {
+	if zone == nil {
+		return nil, fmt.Errorf("zone cannot be nil")
+	}
+	if err = zone.checkFilters(); err != nil {
+		return nil, err
+	}
+	exporter := &exporter{ctx: ctx, zone: zone, bundle: NewBundle(zone.Name)}
+	exporter.bundle.Manifest.ExportedAt = time.Now().UTC().Format(time.RFC3339)
+	for _, step := range []struct {
+		resource string
+		enabled  bool
+		export   func() error
+	}{
+		{Resource_Settings, zone.Settings != nil, exporter.exportSettings},
+		{Resource_PageRules, zone.PageRules != nil, exporter.exportPageRules},
+		{Resource_Filters, zone.Filters != nil, exporter.exportFilters},
+		{Resource_FirewallRules, zone.FirewallRules != nil, exporter.exportFirewallRules},
+		{Resource_RateLimits, zone.RateLimits != nil, exporter.exportRateLimits},
+		{Resource_Lockdowns, zone.Lockdowns != nil, exporter.exportLockdowns},
+		{Resource_UserAgentRules, zone.UserAgentRules != nil, exporter.exportUserAgentRules},
+		{Resource_Rulesets, zone.Rulesets != nil, exporter.exportRulesets},
+		{Resource_LoadBalancers, zone.LoadBalancers != nil, exporter.exportLoadBalancers},
+		{Resource_Certificates, zone.Certificates != nil, exporter.exportCertificates},
+		{Resource_LogpushJobs, zone.Logpush != nil, exporter.exportLogpushJobs},
+		{Resource_EdgeFunctions, zone.EdgeFunctions != nil, exporter.exportEdgeFunctions},
+	} {
+		if !step.enabled {
+			continue
+		}
+		if err = step.export(); err != nil {
+			return nil, fmt.Errorf("exporting %s: %w", step.resource, err)
+		}
+	}
+	return exporter.bundle, nil
+}
+
+// exporter holds the state of one export.
+type exporter struct {
+	ctx    context.Context
+	zone   *Zone
+	bundle *Bundle
+}
+
+func (exporter *exporter) warn(format string, args ...interface{}) {
+	exporter.bundle.Manifest.Warnings = append(exporter.bundle.Manifest.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (exporter *exporter) exportSettings() error {
+	profile, err := exporter.zone.Settings.SnapshotProfile(exporter.ctx)
+	if err != nil {
+		// The profile holds the settings that could be read.
+		exporter.warn("%s: %s", Resource_Settings, err.Error())
+	}
+	buffer, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	var settings map[string]json.RawMessage
+	if err = json.Unmarshal(buffer, &settings); err != nil {
+		return err
+	}
+	return exporter.bundle.putJSON(Resource_Settings, len(settings), profile)
+}
+
+func (exporter *exporter) exportPageRules() error {
+	client := exporter.zone.PageRules
+	result, _, err := client.ListPageRulesWithContext(exporter.ctx, client.NewListPageRulesOptions())
+	if err != nil {
+		return err
+	}
+	return exporter.bundle.putJSON(Resource_PageRules, len(result.Result), result.Result)
+}
+
+func (exporter *exporter) exportFilters() error {
+	zone := exporter.zone
+	options := zone.Filters.NewListAllFiltersOptions(zone.XAuthUserToken, zone.Crn, zone.ZoneID)
+	result, _, err := zone.Filters.ListAllFiltersWithContext(exporter.ctx, options)
+	if err != nil {
+		return err
+	}
+	return exporter.bundle.putJSON(Resource_Filters, len(result.Result), result.Result)
+}
+
+func (exporter *exporter) exportFirewallRules() error {
+	zone := exporter.zone
+	options := zone.FirewallRules.NewListAllFirewallRulesOptions(zone.XAuthUserToken, zone.Crn, zone.ZoneID)
+	result, _, err := zone.FirewallRules.ListAllFirewallRulesWithContext(exporter.ctx, options)
+	if err != nil {
+		return err
+	}
+	return exporter.bundle.putJSON(Resource_FirewallRules, len(result.Result), result.Result)
+}
+
+func (exporter *exporter) exportRateLimits() error {
+	client := exporter.zone.RateLimits
+	var rateLimits []zoneratelimitsv1.RatelimitObject
+	for page := int64(1); ; page++ {
+		options := client.NewListAllZoneRateLimitsOptions().SetPage(page).SetPerPage(PageSize)
+		result, _, err := client.ListAllZoneRateLimitsWithContext(exporter.ctx, options)
+		if err != nil {
+			return err
+		}
+		rateLimits = append(rateLimits, result.Result...)
+		if result.ResultInfo == nil || common.LastPage(page, PageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+	return exporter.bundle.putJSON(Resource_RateLimits, len(rateLimits), rateLimits)
+}
+
+func (exporter *exporter) exportLockdowns() error {
+	client := exporter.zone.Lockdowns
+	var lockdowns []zonelockdownv1.LockdownObject
+	for page := int64(1); ; page++ {
+		options := client.NewListAllZoneLockownRulesOptions().SetPage(page).SetPerPage(PageSize)
+		result, _, err := client.ListAllZoneLockownRulesWithContext(exporter.ctx, options)
+		if err != nil {
+			return err
+		}
+		lockdowns = append(lockdowns, result.Result...)
+		if result.ResultInfo == nil || common.LastPage(page, PageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+	return exporter.bundle.putJSON(Resource_Lockdowns, len(lockdowns), lockdowns)
+}
+
+func (exporter *exporter) exportUserAgentRules() error {
+	client := exporter.zone.UserAgentRules
+	var rules []useragentblockingrulesv1.UseragentRuleObject
+	for page := int64(1); ; page++ {
+		options := client.NewListAllZoneUserAgentRulesOptions().SetPage(page).SetPerPage(PageSize)
+		result, _, err := client.ListAllZoneUserAgentRulesWithContext(exporter.ctx, options)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, result.Result...)
+		if result.ResultInfo == nil || common.LastPage(page, PageSize, len(result.Result), result.ResultInfo.TotalCount) {
+			break
+		}
+	}
+	return exporter.bundle.putJSON(Resource_UserAgentRules, len(rules), rules)
+}
+
+// exportRulesets reads the entry point ruleset of each phase that the zone uses, and the custom rulesets. The
+// managed rulesets are the same in every zone and are left out.
+func (exporter *exporter) exportRulesets() error {
+	client := exporter.zone.Rulesets
+	listed, _, err := client.GetZoneRulesetsWithContext(exporter.ctx, client.NewGetZoneRulesetsOptions())
+	if err != nil {
+		return err
+	}
+	file := rulesetsFile{Entrypoints: []json.RawMessage{}, Custom: []json.RawMessage{}}
+	count := 0
+	for _, ruleset := range listed.Result {
+		var details *rulesetsv1.RulesetDetails
+		switch core.StringNilMapper(ruleset.Kind) {
+		case rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Zone:
+			result, _, err := client.GetZoneEntrypointRulesetWithContext(exporter.ctx, client.NewGetZoneEntrypointRulesetOptions(core.StringNilMapper(ruleset.Phase)))
+			if err != nil {
+				return err
+			}
+			details = result.Result
+		case rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Custom:
+			result, _, err := client.GetZoneRulesetWithContext(exporter.ctx, client.NewGetZoneRulesetOptions(core.StringNilMapper(ruleset.ID)))
+			if err != nil {
+				return err
+			}
+			details = result.Result
+		default:
+			continue
+		}
+		if details == nil {
+			continue
+		}
+		buffer, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		if core.StringNilMapper(ruleset.Kind) == rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Zone {
+			file.Entrypoints = append(file.Entrypoints, buffer)
+		} else {
+			file.Custom = append(file.Custom, buffer)
+		}
+		count++
+	}
+	return exporter.bundle.putJSON(Resource_Rulesets, count, &file)
+}
+
+// exportLoadBalancers reads the load balancers of the zone, the pools they use, and the monitors of those pools.
+func (exporter *exporter) exportLoadBalancers() error {
+	zone := exporter.zone
+	loadBalancers, _, err := zone.LoadBalancers.ListAllLoadBalancersWithContext(exporter.ctx, zone.LoadBalancers.NewListAllLoadBalancersOptions())
+	if err != nil {
+		return err
+	}
+	usedPools := map[string]bool{}
+	for i := range loadBalancers.Result {
+		for _, id := range loadBalancerPools(&loadBalancers.Result[i]) {
+			usedPools[id] = true
+		}
+	}
+	if len(usedPools) > 0 && zone.Pools == nil {
+		exporter.warn("%s: the zone has no pools client, the pools of the load balancers are not exported", Resource_GlbPools)
+	}
+	if zone.Pools != nil {
+		result, _, err := zone.Pools.ListAllLoadBalancerPoolsWithContext(exporter.ctx, zone.Pools.NewListAllLoadBalancerPoolsOptions())
+		if err != nil {
+			return fmt.Errorf("listing the pools: %w", err)
+		}
+		pools := []globalloadbalancerpoolsv0.LoadBalancerPoolPack{}
+		usedMonitors := map[string]bool{}
+		for _, pool := range result.Result {
+			if usedPools[core.StringNilMapper(pool.ID)] {
+				pools = append(pools, pool)
+				if pool.Monitor != nil {
+					usedMonitors[*pool.Monitor] = true
+				}
+			}
+		}
+		if len(usedMonitors) > 0 && zone.Monitors == nil {
+			exporter.warn("%s: the zone has no monitors client, the monitors of the pools are not exported", Resource_GlbMonitors)
+		}
+		if zone.Monitors != nil {
+			result, _, err := zone.Monitors.ListAllLoadBalancerMonitorsWithContext(exporter.ctx, zone.Monitors.NewListAllLoadBalancerMonitorsOptions())
+			if err != nil {
+				return fmt.Errorf("listing the monitors: %w", err)
+			}
+			monitors := []globalloadbalancermonitorv1.MonitorPack{}
+			for _, monitor := range result.Result {
+				if usedMonitors[core.StringNilMapper(monitor.ID)] {
+					monitors = append(monitors, monitor)
+				}
+			}
+			if err = exporter.bundle.putJSON(Resource_GlbMonitors, len(monitors), monitors); err != nil {
+				return err
+			}
+		}
+		if err = exporter.bundle.putJSON(Resource_GlbPools, len(pools), pools); err != nil {
+			return err
+		}
+	}
+	return exporter.bundle.putJSON(Resource_LoadBalancers, len(loadBalancers.Result), loadBalancers.Result)
+}
+
+// loadBalancerPools returns the IDs of the pools that a load balancer uses, sorted.
+func loadBalancerPools(loadBalancer *globalloadbalancerv1.LoadBalancerPack) []string {
+	ids := map[string]bool{}
+	if loadBalancer.FallbackPool != nil {
+		ids[*loadBalancer.FallbackPool] = true
+	}
+	for _, id := range loadBalancer.DefaultPools {
+		ids[id] = true
+	}
+	for _, pools := range []interface{}{loadBalancer.RegionPools, loadBalancer.PopPools} {
+		mapPools(pools, func(id string) string {
+			ids[id] = true
+			return id
+		})
+	}
+	var sorted []string
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// mapPools applies a function to the pool IDs of the region or PoP pools of a load balancer, a map of pool ID lists
+// in its JSON form, and returns the map with the IDs it returns.
+func mapPools(pools interface{}, f func(id string) string) interface{} {
+	buffer, err := json.Marshal(pools)
+	if err != nil {
+		return pools
+	}
+	var byRegion map[string][]string
+	if json.Unmarshal(buffer, &byRegion) != nil || byRegion == nil {
+		return pools
+	}
+	for region, ids := range byRegion {
+		for i := range ids {
+			ids[i] = f(ids[i])
+		}
+		byRegion[region] = ids
+	}
+	return byRegion
+}
+
+// exportCertificates records the certificate packs and custom certificates of the zone. Their private keys cannot be
+// read: the bundle only tells what to order or upload again.
+func (exporter *exporter) exportCertificates() error {
+	client := exporter.zone.Certificates
+	packs, _, err := client.ListCertificatesWithContext(exporter.ctx, client.NewListCertificatesOptions())
+	if err != nil {
+		return err
+	}
+	custom, _, err := client.ListCustomCertificatesWithContext(exporter.ctx, client.NewListCustomCertificatesOptions())
+	if err != nil {
+		return err
+	}
+	file := certificatesFile{CertificatePacks: packs.Result, CustomCertificates: custom.Result}
+	return exporter.bundle.putJSON(Resource_Certificates, len(file.CertificatePacks)+len(file.CustomCertificates), &file)
+}
+
+func (exporter *exporter) exportLogpushJobs() error {
+	client := exporter.zone.Logpush
+	result, _, err := client.GetLogpushJobsV2WithContext(exporter.ctx, client.NewGetLogpushJobsV2Options())
+	if err != nil {
+		return err
+	}
+	return exporter.bundle.putJSON(Resource_LogpushJobs, len(result.Result), result.Result)
+}
+
+// scriptPath returns the path of a script in the bundle.
+func scriptPath(script string) (string, error) {
+	if script == "" || script == "." || script == ".." || strings.ContainsAny(script, "/\\") {
+		return "", fmt.Errorf("invalid script name %q", script)
+	}
+	return Resource_EdgeFunctions + "/" + script + ".js", nil
+}
+
+// exportEdgeFunctions reads the triggers of the zone and the scripts they run.
+func (exporter *exporter) exportEdgeFunctions() error {
+	client := exporter.zone.EdgeFunctions
+	triggers, _, err := client.ListEdgeFunctionsTriggersWithContext(exporter.ctx, client.NewListEdgeFunctionsTriggersOptions())
+	if err != nil {
+		return err
+	}
+	scripts := map[string]bool{}
+	for _, trigger := range triggers.Result {
+		if trigger.Script != nil {
+			scripts[*trigger.Script] = true
+		}
+	}
+	var names []string
+	for name := range scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		filePath, err := scriptPath(name)
+		if err != nil {
+			return err
+		}
+		reader, _, err := client.GetEdgeFunctionsActionWithContext(exporter.ctx, client.NewGetEdgeFunctionsActionOptions(name))
+		if err != nil {
+			return fmt.Errorf("reading script %s: %w", name, err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("reading script %s: %w", name, err)
+		}
+		exporter.bundle.Put(filePath, Resource_EdgeFunctions, 1, content)
+	}
+	return exporter.bundle.putJSON(Resource_EdgeFunctions, len(triggers.Result), triggers.Result)
+}
@@ -0,0 +1,700 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonebundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/edgefunctionsapiv1"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/globalloadbalancerv1"
+	"github.com/IBM/networking-go-sdk/logpushjobsapiv1"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+)
+
+// Item : A setting or an object of a bundle.
+type Item struct {
+	// One of the Resource constants.
+	Resource string
+
+	// The name of the setting, or the ID of the object in the bundle.
+	Name string
+
+	// Why the item was skipped, or how it was restored.
+	Reason string
+
+	// The error of the items that failed.
+	Err error
+}
+
+func (item Item) String() string {
+	text := item.Resource
+	if item.Name != "" {
+		text += " " + item.Name
+	}
+	if item.Err != nil {
+		return text + ": " + item.Err.Error()
+	}
+	if item.Reason != "" {
+		return text + ": " + item.Reason
+	}
+	return text
+}
+
+// Report : The outcome of an import.
+type Report struct {
+	// The items written to the zone.
+	Restored []Item
+
+	// The monitors and pools that the instance already had, which are shared by its zones and used as they are.
+	Existing []Item
+
+	// The items that cannot be restored, with the reason.
+	Skipped []Item
+
+	// The items that could not be written, with the error.
+	Failed []Item
+
+	// The IDs of the objects of the zone by resource and bundle ID, for the objects restored or found in the
+	// instance.
+	IDs map[string]map[string]string
+}
+
+func (report *Report) String() string {
+	var lines []string
+	for _, section := range []struct {
+		title string
+		items []Item
+	}{
+		{"restored", report.Restored},
+		{"existing", report.Existing},
+		{"skipped", report.Skipped},
+		{"failed", report.Failed},
+	} {
+		for _, item := range section.items {
+			lines = append(lines, section.title+": "+item.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// id returns the ID in the zone of an object of the bundle, and false when it was not restored.
+func (report *Report) id(resource string, bundleID string) (id string, ok bool) {
+	id, ok = report.IDs[resource][bundleID]
+	return
+}
+
+func (report *Report) mapID(resource string, bundleID string, id string) {
+	if bundleID == "" || id == "" {
+		return
+	}
+	if report.IDs[resource] == nil {
+		report.IDs[resource] = map[string]string{}
+	}
+	report.IDs[resource][bundleID] = id
+}
+
+// Import : Restore a bundle into a zone
+// Write the resources of the bundle for which the zone has a client, in dependency order: the settings, the filters
+// and then the firewall rules that use them, the rate limits, lockdowns and user agent rules, the rulesets, the page
+// rules, the monitors, pools and load balancers, the edge function scripts and their triggers, and the logpush jobs.
+// An object whose dependency was not restored is skipped. The objects are created as new objects, so the zone should
+// be empty: an import into a configured zone adds to its configuration. The monitors and pools that the instance
+// already has under the same ID are used as they are, and the scripts replace the scripts of the same name. Zone
+// custom rulesets cannot be created through the API, and certificates are only recorded by a bundle: both are
+// reported as skipped. A failure does not stop the import: the report lists the items that failed, and the error
+// tells how many failed.
+func Import(zone *Zone, bundle *Bundle) (report *Report, err error) {
+	return ImportWithContext(context.Background(), zone, bundle)
+}
+
+// ImportWithContext is an alternate form of the Import method which supports a Context parameter
+func ImportWithContext(ctx context.Context, zone *Zone, bundle *Bundle) (report *Report, err error) {
+	if zone == nil || bundle == nil {
+		return nil, fmt.Errorf("the zone and the bundle are required")
+	}
+	if bundle.Manifest.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("unsupported bundle schema version %d, this version reads versions 1 to %d", bundle.Manifest.SchemaVersion, SchemaVersion)
+	}
+	if err = zone.checkFilters(); err != nil {
+		return nil, err
+	}
+	importer := &importer{ctx: ctx, zone: zone, bundle: bundle, report: &Report{IDs: map[string]map[string]string{}}}
+	for _, step := range []struct {
+		resource string
+		enabled  bool
+		restore  func()
+	}{
+		{Resource_Settings, zone.Settings != nil, importer.importSettings},
+		{Resource_Filters, zone.Filters != nil, importer.importFilters},
+		{Resource_FirewallRules, zone.FirewallRules != nil, importer.importFirewallRules},
+		{Resource_RateLimits, zone.RateLimits != nil, importer.importRateLimits},
+		{Resource_Lockdowns, zone.Lockdowns != nil, importer.importLockdowns},
+		{Resource_UserAgentRules, zone.UserAgentRules != nil, importer.importUserAgentRules},
+		{Resource_Rulesets, zone.Rulesets != nil, importer.importRulesets},
+		{Resource_PageRules, zone.PageRules != nil, importer.importPageRules},
+		{Resource_GlbMonitors, zone.Monitors != nil, importer.importMonitors},
+		{Resource_GlbPools, zone.Pools != nil, importer.importPools},
+		{Resource_LoadBalancers, zone.LoadBalancers != nil, importer.importLoadBalancers},
+		{Resource_EdgeFunctions, zone.EdgeFunctions != nil, importer.importEdgeFunctions},
+		{Resource_LogpushJobs, zone.Logpush != nil, importer.importLogpushJobs},
+		{Resource_Certificates, true, importer.importCertificates},
+	} {
+		if _, ok := bundle.File(step.resource + ".json"); !ok {
+			continue
+		}
+		if !step.enabled {
+			importer.skipped(step.resource, "", "the zone has no client for this resource")
+			continue
+		}
+		step.restore()
+	}
+	report = importer.report
+	if len(report.Failed) > 0 {
+		first := report.Failed[0]
+		err = fmt.Errorf("%d zone configuration items could not be restored, the first: %s: %w", len(report.Failed), Item{Resource: first.Resource, Name: first.Name}, first.Err)
+	}
+	return
+}
+
+// importer holds the state of one import.
+type importer struct {
+	ctx    context.Context
+	zone   *Zone
+	bundle *Bundle
+	report *Report
+}
+
+func (importer *importer) restored(resource string, name string, reason string) {
+	importer.report.Restored = append(importer.report.Restored, Item{Resource: resource, Name: name, Reason: reason})
+}
+
+func (importer *importer) existing(resource string, name string) {
+	importer.report.Existing = append(importer.report.Existing, Item{Resource: resource, Name: name})
+}
+
+func (importer *importer) skipped(resource string, name string, reason string) {
+	importer.report.Skipped = append(importer.report.Skipped, Item{Resource: resource, Name: name, Reason: reason})
+}
+
+func (importer *importer) failed(resource string, name string, err error) {
+	importer.report.Failed = append(importer.report.Failed, Item{Resource: resource, Name: name, Err: err})
+}
+
+// read decodes the file of a resource, and records its failure.
+func (importer *importer) read(resource string, value interface{}) bool {
+	_, err := importer.bundle.getJSON(resource, value)
+	if err != nil {
+		importer.failed(resource, "", err)
+		return false
+	}
+	return true
+}
+
+// objectID returns the ID of an object in its JSON form.
+func objectID(object json.RawMessage) string {
+	var fields struct {
+		ID *string `json:"id"`
+	}
+	json.Unmarshal(object, &fields)
+	return core.StringNilMapper(fields.ID)
+}
+
+// errExisting is returned by the create functions of createObjects for the objects that the instance already has.
+var errExisting = errors.New("the instance has the object already")
+
+// skipError is returned by the create functions of createObjects for the objects whose dependency was not restored.
+type skipError struct {
+	reason string
+}
+
+func (err *skipError) Error() string {
+	return err.reason
+}
+
+// createObjects creates the objects of a resource one at a time.
+func (importer *importer) createObjects(resource string, create func(object json.RawMessage) (id string, err error)) {
+	var objects []json.RawMessage
+	if !importer.read(resource, &objects) {
+		return
+	}
+	for _, object := range objects {
+		bundleID := objectID(object)
+		id, err := create(object)
+		var skip *skipError
+		switch {
+		case errors.Is(err, errExisting):
+			importer.report.mapID(resource, bundleID, bundleID)
+			importer.existing(resource, bundleID)
+			continue
+		case errors.As(err, &skip):
+			importer.skipped(resource, bundleID, skip.reason)
+			continue
+		case err != nil:
+			importer.failed(resource, bundleID, err)
+			continue
+		}
+		importer.report.mapID(resource, bundleID, id)
+		importer.restored(resource, bundleID, "created as "+id)
+	}
+}
+
+// importSettings applies the settings of the bundle. DNSSEC is left out: it needs DS records at the registrar.
+func (importer *importer) importSettings() {
+	profile := &zonessettingsv1.ZoneSettingsProfile{}
+	if !importer.read(Resource_Settings, profile) {
+		return
+	}
+	if profile.ZoneDnssec != nil {
+		profile.ZoneDnssec = nil
+		importer.skipped(Resource_Settings, "dnssec", "DNSSEC needs DS records at the registrar of the domain")
+	}
+	changes, err := importer.zone.Settings.ApplyProfile(importer.ctx, profile)
+	if err != nil && len(changes) == 0 {
+		importer.failed(Resource_Settings, "", err)
+		return
+	}
+	for _, change := range changes {
+		if change.Err != nil {
+			importer.failed(Resource_Settings, change.Setting, change.Err)
+		} else {
+			importer.restored(Resource_Settings, change.Setting, change.String())
+		}
+	}
+}
+
+func (importer *importer) importFilters() {
+	zone := importer.zone
+	var filters []filtersv1.FilterObject
+	if !importer.read(Resource_Filters, &filters) {
+		return
+	}
+	for _, filter := range filters {
+		bundleID := core.StringNilMapper(filter.ID)
+		options := zone.Filters.NewCreateFilterOptions(zone.XAuthUserToken, zone.Crn, zone.ZoneID)
+		options.FilterInput = []filtersv1.FilterInput{{Expression: filter.Expression, Paused: filter.Paused, Description: filter.Description}}
+		result, _, err := zone.Filters.CreateFilterWithContext(importer.ctx, options)
+		if err != nil {
+			importer.failed(Resource_Filters, bundleID, err)
+			continue
+		}
+		if len(result.Result) == 0 {
+			importer.failed(Resource_Filters, bundleID, fmt.Errorf("the response has no filter"))
+			continue
+		}
+		id := core.StringNilMapper(result.Result[0].ID)
+		importer.report.mapID(Resource_Filters, bundleID, id)
+		importer.restored(Resource_Filters, bundleID, "created as "+id)
+	}
+}
+
+// importFirewallRules creates the firewall rules with the filters restored in their place. The rules are created in
+// the order of the bundle, which is the order the API listed them in.
+func (importer *importer) importFirewallRules() {
+	zone := importer.zone
+	var rules []firewallrulesv1.FirewallRuleObject
+	if !importer.read(Resource_FirewallRules, &rules) {
+		return
+	}
+	for _, rule := range rules {
+		bundleID := core.StringNilMapper(rule.ID)
+		var filterID string
+		if rule.Filter != nil {
+			filterID = core.StringNilMapper(rule.Filter.ID)
+		}
+		id, ok := importer.report.id(Resource_Filters, filterID)
+		if !ok {
+			importer.skipped(Resource_FirewallRules, bundleID, fmt.Sprintf("its filter %s was not restored", filterID))
+			continue
+		}
+		filter, _ := zone.FirewallRules.NewFirewallRuleInputFilterID(id)
+		options := zone.FirewallRules.NewCreateFirewallRulesOptions(zone.XAuthUserToken, zone.Crn, zone.ZoneID)
+		options.FirewallRuleInput = []firewallrulesv1.FirewallRuleInput{{Filter: filter, Action: rule.Action, Description: rule.Description, Paused: rule.Paused}}
+		result, _, err := zone.FirewallRules.CreateFirewallRulesWithContext(importer.ctx, options)
+		if err != nil {
+			importer.failed(Resource_FirewallRules, bundleID, err)
+			continue
+		}
+		if len(result.Result) == 0 {
+			importer.failed(Resource_FirewallRules, bundleID, fmt.Errorf("the response has no firewall rule"))
+			continue
+		}
+		id = core.StringNilMapper(result.Result[0].ID)
+		importer.report.mapID(Resource_FirewallRules, bundleID, id)
+		importer.restored(Resource_FirewallRules, bundleID, "created as "+id)
+	}
+}
+
+func (importer *importer) importRateLimits() {
+	client := importer.zone.RateLimits
+	importer.createObjects(Resource_RateLimits, func(object json.RawMessage) (id string, err error) {
+		options := client.NewCreateZoneRateLimitsOptions()
+		if err = json.Unmarshal(object, options); err != nil {
+			return
+		}
+		result, _, err := client.CreateZoneRateLimitsWithContext(importer.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+func (importer *importer) importLockdowns() {
+	client := importer.zone.Lockdowns
+	importer.createObjects(Resource_Lockdowns, func(object json.RawMessage) (id string, err error) {
+		options := client.NewCreateZoneLockdownRuleOptions()
+		if err = json.Unmarshal(object, options); err != nil {
+			return
+		}
+		result, _, err := client.CreateZoneLockdownRuleWithContext(importer.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+func (importer *importer) importUserAgentRules() {
+	client := importer.zone.UserAgentRules
+	importer.createObjects(Resource_UserAgentRules, func(object json.RawMessage) (id string, err error) {
+		options := client.NewCreateZoneUserAgentRuleOptions()
+		if err = json.Unmarshal(object, options); err != nil {
+			return
+		}
+		result, _, err := client.CreateZoneUserAgentRuleWithContext(importer.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+// unmarshalRuleset decodes a ruleset of the bundle with the rulesets unmarshaller, which keeps the action
+// parameters that the model does not name.
+func unmarshalRuleset(object json.RawMessage) (ruleset *rulesetsv1.RulesetDetails, err error) {
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal(object, &raw); err != nil {
+		return
+	}
+	err = rulesetsv1.UnmarshalRulesetDetails(raw, &ruleset)
+	return
+}
+
+// importRulesets replaces the entry point ruleset of each phase of the bundle. The custom rulesets of the bundle
+// cannot be created: a rule that executes one is kept when the zone has a custom ruleset of the same name, and
+// skipped otherwise.
+func (importer *importer) importRulesets() {
+	client := importer.zone.Rulesets
+	var file rulesetsFile
+	if !importer.read(Resource_Rulesets, &file) {
+		return
+	}
+	customNames := map[string]string{}
+	for _, object := range file.Custom {
+		ruleset, err := unmarshalRuleset(object)
+		if err != nil {
+			importer.failed(Resource_Rulesets, objectID(object), err)
+			continue
+		}
+		customNames[core.StringNilMapper(ruleset.ID)] = core.StringNilMapper(ruleset.Name)
+		importer.skipped(Resource_Rulesets, core.StringNilMapper(ruleset.ID), fmt.Sprintf("the custom ruleset %q cannot be created through the API", core.StringNilMapper(ruleset.Name)))
+	}
+	customRulesets := map[string]string{}
+	if len(customNames) > 0 {
+		listed, _, err := client.GetZoneRulesetsWithContext(importer.ctx, client.NewGetZoneRulesetsOptions())
+		if err != nil {
+			importer.failed(Resource_Rulesets, "", fmt.Errorf("listing the rulesets of the zone: %w", err))
+			return
+		}
+		for _, ruleset := range listed.Result {
+			if core.StringNilMapper(ruleset.Kind) == rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Custom {
+				customRulesets[core.StringNilMapper(ruleset.Name)] = core.StringNilMapper(ruleset.ID)
+			}
+		}
+	}
+	for _, object := range file.Entrypoints {
+		entrypoint, err := unmarshalRuleset(object)
+		if err != nil {
+			importer.failed(Resource_Rulesets, objectID(object), err)
+			continue
+		}
+		phase := core.StringNilMapper(entrypoint.Phase)
+		var rules []rulesetsv1.RuleCreate
+		for i := range entrypoint.Rules {
+			var rule *rulesetsv1.RuleCreate
+			rule, err = rulesetsv1.NewRuleCreateFromDetails(&entrypoint.Rules[i])
+			if err != nil {
+				importer.failed(Resource_Rulesets, phase, err)
+				break
+			}
+			if core.StringNilMapper(rule.Action) == "execute" && rule.ActionParameters != nil && rule.ActionParameters.ID != nil {
+				if name, ok := customNames[*rule.ActionParameters.ID]; ok {
+					id, ok := customRulesets[name]
+					if !ok {
+						importer.skipped(Resource_Rulesets, phase+"/"+core.StringNilMapper(entrypoint.Rules[i].ID), fmt.Sprintf("the zone has no custom ruleset named %q", name))
+						continue
+					}
+					importer.report.mapID(Resource_Rulesets, *rule.ActionParameters.ID, id)
+					rule.ActionParameters.ID = core.StringPtr(id)
+				}
+			}
+			rules = append(rules, *rule)
+		}
+		if err != nil || (len(rules) == 0 && len(entrypoint.Rules) > 0) {
+			// A rule could not be copied, or all the rules were skipped.
+			continue
+		}
+		options := client.NewUpdateZoneEntrypointRulesetOptions(phase)
+		options.SetKind(rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Zone).SetPhase(phase).SetRules(rules)
+		options.Name = entrypoint.Name
+		options.Description = entrypoint.Description
+		_, _, err = client.UpdateZoneEntrypointRulesetWithContext(importer.ctx, options)
+		if err != nil {
+			importer.failed(Resource_Rulesets, phase, err)
+			continue
+		}
+		importer.restored(Resource_Rulesets, phase, fmt.Sprintf("%d rules", len(rules)))
+	}
+}
+
+func (importer *importer) importPageRules() {
+	client := importer.zone.PageRules
+	importer.createObjects(Resource_PageRules, func(object json.RawMessage) (id string, err error) {
+		var rule pageruleapiv1.PageRuleContent
+		if err = json.Unmarshal(object, &rule); err != nil {
+			return
+		}
+		result, _, err := client.CreatePageRuleWithContext(importer.ctx, rule.CreateOptions())
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+// importMonitors creates the monitors of the bundle that the instance does not have under the same ID.
+func (importer *importer) importMonitors() {
+	client := importer.zone.Monitors
+	listed, _, err := client.ListAllLoadBalancerMonitorsWithContext(importer.ctx, client.NewListAllLoadBalancerMonitorsOptions())
+	if err != nil {
+		importer.failed(Resource_GlbMonitors, "", fmt.Errorf("listing the monitors of the instance: %w", err))
+		return
+	}
+	existing := map[string]bool{}
+	for _, monitor := range listed.Result {
+		existing[core.StringNilMapper(monitor.ID)] = true
+	}
+	importer.createObjects(Resource_GlbMonitors, func(object json.RawMessage) (id string, err error) {
+		if bundleID := objectID(object); existing[bundleID] {
+			return bundleID, errExisting
+		}
+		options := client.NewCreateLoadBalancerMonitorOptions()
+		if err = json.Unmarshal(object, options); err != nil {
+			return
+		}
+		result, _, err := client.CreateLoadBalancerMonitorWithContext(importer.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+// importPools creates the pools of the bundle that the instance does not have under the same ID, with the monitors
+// restored in their place.
+func (importer *importer) importPools() {
+	client := importer.zone.Pools
+	listed, _, err := client.ListAllLoadBalancerPoolsWithContext(importer.ctx, client.NewListAllLoadBalancerPoolsOptions())
+	if err != nil {
+		importer.failed(Resource_GlbPools, "", fmt.Errorf("listing the pools of the instance: %w", err))
+		return
+	}
+	existing := map[string]bool{}
+	for _, pool := range listed.Result {
+		existing[core.StringNilMapper(pool.ID)] = true
+	}
+	importer.createObjects(Resource_GlbPools, func(object json.RawMessage) (id string, err error) {
+		if bundleID := objectID(object); existing[bundleID] {
+			return bundleID, errExisting
+		}
+		options := client.NewCreateLoadBalancerPoolOptions()
+		if err = json.Unmarshal(object, options); err != nil {
+			return
+		}
+		if options.Monitor != nil {
+			monitor, ok := importer.report.id(Resource_GlbMonitors, *options.Monitor)
+			if !ok {
+				return "", &skipError{fmt.Sprintf("its monitor %s was not restored", *options.Monitor)}
+			}
+			options.Monitor = core.StringPtr(monitor)
+		}
+		result, _, err := client.CreateLoadBalancerPoolWithContext(importer.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+// importLoadBalancers creates the load balancers with the pools restored in their place.
+func (importer *importer) importLoadBalancers() {
+	client := importer.zone.LoadBalancers
+	importer.createObjects(Resource_LoadBalancers, func(object json.RawMessage) (id string, err error) {
+		var loadBalancer globalloadbalancerv1.LoadBalancerPack
+		if err = json.Unmarshal(object, &loadBalancer); err != nil {
+			return
+		}
+		for _, pool := range loadBalancerPools(&loadBalancer) {
+			if _, ok := importer.report.id(Resource_GlbPools, pool); !ok {
+				return "", &skipError{fmt.Sprintf("its pool %s was not restored", pool)}
+			}
+		}
+		pool := func(id string) string {
+			id, _ = importer.report.id(Resource_GlbPools, id)
+			return id
+		}
+		options := client.NewCreateLoadBalancerOptions()
+		if err = json.Unmarshal(object, options); err != nil {
+			return
+		}
+		if options.FallbackPool != nil {
+			options.FallbackPool = core.StringPtr(pool(*options.FallbackPool))
+		}
+		for i := range options.DefaultPools {
+			options.DefaultPools[i] = pool(options.DefaultPools[i])
+		}
+		if options.RegionPools != nil {
+			options.RegionPools = mapPools(options.RegionPools, pool)
+		}
+		if options.PopPools != nil {
+			options.PopPools = mapPools(options.PopPools, pool)
+		}
+		result, _, err := client.CreateLoadBalancerWithContext(importer.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	})
+}
+
+// importEdgeFunctions uploads the scripts of the bundle, and creates the triggers of the scripts that were uploaded.
+func (importer *importer) importEdgeFunctions() {
+	client := importer.zone.EdgeFunctions
+	var triggers []edgefunctionsapiv1.EdgeFunctionsTriggerResp
+	if !importer.read(Resource_EdgeFunctions, &triggers) {
+		return
+	}
+	uploaded := map[string]bool{}
+	for _, entry := range importer.bundle.Manifest.Files {
+		if entry.Resource != Resource_EdgeFunctions || !strings.HasSuffix(entry.Path, ".js") {
+			continue
+		}
+		content, _ := importer.bundle.File(entry.Path)
+		name := strings.TrimSuffix(strings.TrimPrefix(entry.Path, Resource_EdgeFunctions+"/"), ".js")
+		options := client.NewUpdateEdgeFunctionsActionOptions(name)
+		options.EdgeFunctionsAction = io.NopCloser(bytes.NewReader(content))
+		_, _, err := client.UpdateEdgeFunctionsActionWithContext(importer.ctx, options)
+		if err != nil {
+			importer.failed(Resource_EdgeFunctions, entry.Path, err)
+			continue
+		}
+		uploaded[name] = true
+		importer.restored(Resource_EdgeFunctions, entry.Path, fmt.Sprintf("%d bytes", len(content)))
+	}
+	for _, trigger := range triggers {
+		bundleID := core.StringNilMapper(trigger.ID)
+		if trigger.Script != nil && !uploaded[*trigger.Script] {
+			importer.skipped(Resource_EdgeFunctions, bundleID, fmt.Sprintf("its script %s was not restored", *trigger.Script))
+			continue
+		}
+		options := client.NewCreateEdgeFunctionsTriggerOptions()
+		options.Pattern = trigger.Pattern
+		options.Script = trigger.Script
+		result, _, err := client.CreateEdgeFunctionsTriggerWithContext(importer.ctx, options)
+		if err != nil {
+			importer.failed(Resource_EdgeFunctions, bundleID, err)
+			continue
+		}
+		var id string
+		if result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		importer.report.mapID(Resource_EdgeFunctions, bundleID, id)
+		importer.restored(Resource_EdgeFunctions, bundleID, "created as "+id)
+	}
+}
+
+func (importer *importer) importLogpushJobs() {
+	client := importer.zone.Logpush
+	var jobs []logpushjobsapiv1.LogpushJobPack
+	if !importer.read(Resource_LogpushJobs, &jobs) {
+		return
+	}
+	for _, job := range jobs {
+		var bundleID string
+		if job.ID != nil {
+			bundleID = strconv.FormatInt(*job.ID, 10)
+		}
+		request, err := client.NewCreateLogpushJobV2RequestLogpushJobGenericReq(core.StringNilMapper(job.DestinationConf))
+		if err != nil {
+			importer.failed(Resource_LogpushJobs, bundleID, err)
+			continue
+		}
+		request.Name = job.Name
+		request.Enabled = job.Enabled
+		request.LogpullOptions = job.LogpullOptions
+		request.Dataset = job.Dataset
+		request.Frequency = job.Frequency
+		result, _, err := client.CreateLogpushJobV2WithContext(importer.ctx, client.NewCreateLogpushJobV2Options().SetCreateLogpushJobV2Request(request))
+		if err != nil {
+			importer.failed(Resource_LogpushJobs, bundleID, err)
+			continue
+		}
+		var id string
+		if result.Result != nil && result.Result.ID != nil {
+			id = strconv.FormatInt(*result.Result.ID, 10)
+		}
+		importer.report.mapID(Resource_LogpushJobs, bundleID, id)
+		importer.restored(Resource_LogpushJobs, bundleID, "created as "+id)
+	}
+}
+
+// importCertificates reports the certificates of the bundle, which must be ordered or uploaded again.
+func (importer *importer) importCertificates() {
+	var file certificatesFile
+	if !importer.read(Resource_Certificates, &file) {
+		return
+	}
+	for _, pack := range file.CertificatePacks {
+		importer.skipped(Resource_Certificates, core.StringNilMapper(pack.ID), fmt.Sprintf("order the %s certificate pack for %s again", core.StringNilMapper(pack.Type), strings.Join(pack.Hosts, ", ")))
+	}
+	for _, certificate := range file.CustomCertificates {
+		importer.skipped(Resource_Certificates, core.StringNilMapper(certificate.ID), fmt.Sprintf("upload the custom certificate for %s again", strings.Join(certificate.Hosts, ", ")))
+	}
+}
@@ -0,0 +1,465 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonebundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/edgefunctionsapiv1"
+	"github.com/IBM/networking-go-sdk/filtersv1"
+	"github.com/IBM/networking-go-sdk/firewallrulesv1"
+	"github.com/IBM/networking-go-sdk/globalloadbalancermonitorv1"
+	"github.com/IBM/networking-go-sdk/globalloadbalancerpoolsv0"
+	"github.com/IBM/networking-go-sdk/globalloadbalancerv1"
+	"github.com/IBM/networking-go-sdk/logpushjobsapiv1"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/sslcertificateapiv1"
+	"github.com/IBM/networking-go-sdk/zonebundle"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type object = map[string]interface{}
+
+// fakeCIS keeps the objects of an instance by collection, as in "zone filters" for the filters of a zone and
+// "instance load_balancers/pools" for the pools. The writes are logged as "METHOD collection".
+type fakeCIS struct {
+	sync.Mutex
+	lists       map[string][]object
+	rulesets    map[string]object
+	entrypoints map[string]object
+	scripts     map[string]string
+	writes      []string
+	created     int
+	fail        string
+}
+
+func newFakeCIS() *fakeCIS {
+	return &fakeCIS{lists: map[string][]object{}, rulesets: map[string]object{}, entrypoints: map[string]object{}, scripts: map[string]string{}}
+}
+
+func (api *fakeCIS) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	path := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, "/v1/crn/"), "/v2/crn/")
+	scope := "instance"
+	if strings.HasPrefix(path, "zones/") {
+		parts := strings.SplitN(strings.TrimPrefix(path, "zones/"), "/", 2)
+		scope, path = parts[0], parts[1]
+	}
+	collection := scope + " " + path
+	var body interface{}
+	if req.Method != "GET" {
+		api.writes = append(api.writes, req.Method+" "+collection)
+		if strings.HasPrefix(path, "workers/scripts/") {
+			content, err := io.ReadAll(req.Body)
+			Expect(err).To(BeNil())
+			body = string(content)
+		} else {
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		}
+	}
+	res.Header().Set("Content-type", "application/json")
+	if api.fail != "" && req.Method != "GET" && api.fail == collection {
+		res.WriteHeader(500)
+		fmt.Fprint(res, `{"success": false, "errors": [{"code": 1000, "message": "internal error"}], "messages": []}`)
+		return
+	}
+	reply := func(result interface{}) {
+		json.NewEncoder(res).Encode(object{"success": true, "errors": []string{}, "messages": []string{}, "result": result,
+			"result_info": object{"page": 1, "per_page": 100, "count": 1, "total_count": 1}})
+	}
+	create := func(item object) object {
+		api.created++
+		if path == "logpush/jobs" {
+			item["id"] = api.created
+		} else {
+			item["id"] = fmt.Sprintf("new-%d", api.created)
+		}
+		api.lists[collection] = append(api.lists[collection], item)
+		return item
+	}
+
+	switch {
+	case strings.HasPrefix(path, "workers/scripts/"):
+		name := strings.TrimPrefix(path, "workers/scripts/")
+		if req.Method == "PUT" {
+			api.scripts[name] = body.(string)
+			reply(object{"script": name})
+			return
+		}
+		res.Header().Set("Content-type", "application/javascript")
+		fmt.Fprint(res, api.scripts[name])
+	case strings.HasPrefix(path, "rulesets/phases/"):
+		phase := strings.Split(path, "/")[2]
+		if req.Method == "PUT" {
+			entrypoint := body.(object)
+			for i, rule := range entrypoint["rules"].([]interface{}) {
+				rule.(object)["id"] = fmt.Sprintf("rule-%d", i)
+			}
+			entrypoint["id"] = scope + "-" + phase
+			api.entrypoints[scope+" "+phase] = entrypoint
+		}
+		reply(api.entrypoints[scope+" "+phase])
+	case strings.HasPrefix(path, "rulesets/"):
+		reply(api.rulesets[strings.TrimPrefix(path, "rulesets/")])
+	case req.Method == "POST":
+		if items, ok := body.([]interface{}); ok {
+			var result []object
+			for _, item := range items {
+				result = append(result, create(item.(object)))
+			}
+			reply(result)
+			return
+		}
+		reply(create(body.(object)))
+	default:
+		list := api.lists[collection]
+		if list == nil {
+			list = []object{}
+		}
+		reply(list)
+	}
+}
+
+// newZone returns the clients of a zone of a fake.
+func newZone(url string, id string) *zonebundle.Zone {
+	crn, authenticator := core.StringPtr("crn"), &core.NoAuthAuthenticator{}
+	zone := &zonebundle.Zone{Name: "example.com", XAuthUserToken: "token", Crn: "crn", ZoneID: id}
+	var err error
+	zone.PageRules, err = pageruleapiv1.NewPageRuleApiV1(&pageruleapiv1.PageRuleApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneID: &id})
+	Expect(err).To(BeNil())
+	zone.Filters, err = filtersv1.NewFiltersV1(&filtersv1.FiltersV1Options{URL: url, Authenticator: authenticator})
+	Expect(err).To(BeNil())
+	zone.FirewallRules, err = firewallrulesv1.NewFirewallRulesV1(&firewallrulesv1.FirewallRulesV1Options{URL: url, Authenticator: authenticator})
+	Expect(err).To(BeNil())
+	zone.RateLimits, err = zoneratelimitsv1.NewZoneRateLimitsV1(&zoneratelimitsv1.ZoneRateLimitsV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Rulesets, err = rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Monitors, err = globalloadbalancermonitorv1.NewGlobalLoadBalancerMonitorV1(&globalloadbalancermonitorv1.GlobalLoadBalancerMonitorV1Options{URL: url, Authenticator: authenticator, Crn: crn})
+	Expect(err).To(BeNil())
+	zone.Pools, err = globalloadbalancerpoolsv0.NewGlobalLoadBalancerPoolsV0(&globalloadbalancerpoolsv0.GlobalLoadBalancerPoolsV0Options{URL: url, Authenticator: authenticator, Crn: crn})
+	Expect(err).To(BeNil())
+	zone.LoadBalancers, err = globalloadbalancerv1.NewGlobalLoadBalancerV1(&globalloadbalancerv1.GlobalLoadBalancerV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Certificates, err = sslcertificateapiv1.NewSslCertificateApiV1(&sslcertificateapiv1.SslCertificateApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Logpush, err = logpushjobsapiv1.NewLogpushJobsApiV1(&logpushjobsapiv1.LogpushJobsApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneID: &id, Dataset: core.StringPtr("http_requests")})
+	Expect(err).To(BeNil())
+	zone.EdgeFunctions, err = edgefunctionsapiv1.NewEdgeFunctionsApiV1(&edgefunctionsapiv1.EdgeFunctionsApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	return zone
+}
+
+// configure fills the zone "src" of a fake.
+func configure(api *fakeCIS) {
+	api.lists["src filters"] = []object{
+		{"id": "f1", "paused": false, "description": "office", "expression": "ip.src eq 198.51.100.4"},
+		{"id": "f2", "paused": true, "description": "bots", "expression": `http.user_agent contains "bot"`},
+	}
+	api.lists["src firewall/rules"] = []object{
+		{"id": "fw1", "paused": false, "description": "allow office", "action": "allow", "filter": api.lists["src filters"][0]},
+		{"id": "fw2", "paused": false, "description": "block bots", "action": "block", "filter": api.lists["src filters"][1]},
+	}
+	api.lists["src rate_limits"] = []object{{"id": "rl1", "disabled": false, "description": "login", "threshold": 100, "period": 60,
+		"action": object{"mode": "simulate"}, "match": object{"request": object{"url": "*.example.com/login"}}}}
+	api.lists["src pagerules"] = []object{{"id": "pr1", "priority": 1, "status": "active",
+		"targets": []object{{"target": "url", "constraint": object{"operator": "matches", "value": "*example.com/images/*"}}},
+		"actions": []object{{"id": "cache_level", "value": "cache_everything"}}}}
+	api.lists["src rulesets"] = []object{
+		{"id": "src-entry", "kind": "zone", "name": "default", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+		{"id": "local", "kind": "custom", "name": "local", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+		{"id": "managed", "kind": "managed", "name": "IBM Managed Ruleset", "phase": "http_request_firewall_managed", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+	}
+	api.entrypoints["src http_request_firewall_custom"] = object{
+		"id": "src-entry", "kind": "zone", "name": "default", "phase": "http_request_firewall_custom", "description": "entry", "version": "2", "last_updated": "2024-01-01T00:00:00Z",
+		"rules": []object{
+			{"id": "r1", "version": "1", "action": "block", "expression": "ip.src eq 203.0.113.9", "enabled": true},
+			{"id": "r2", "version": "1", "action": "execute", "action_parameters": object{"id": "managed"}, "expression": "true", "enabled": true},
+			{"id": "r3", "version": "1", "action": "execute", "action_parameters": object{"id": "local"}, "expression": "true", "enabled": true},
+		},
+	}
+	api.rulesets["local"] = object{"id": "local", "kind": "custom", "name": "local", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z",
+		"rules": []object{{"id": "l1", "version": "1", "action": "log", "expression": "true", "enabled": true}}}
+	api.lists["instance load_balancers/monitors"] = []object{
+		{"id": "m1", "type": "https", "description": "health", "path": "/health", "expected_codes": "200"},
+		{"id": "m-other", "type": "http", "description": "another zone"},
+	}
+	api.lists["instance load_balancers/pools"] = []object{
+		{"id": "p1", "name": "east", "monitor": "m1", "enabled": true, "origins": []object{{"name": "a", "address": "198.51.100.1", "enabled": true, "weight": 1, "healthy": true}}},
+		{"id": "p2", "name": "west", "enabled": true, "origins": []object{{"name": "b", "address": "198.51.100.2", "enabled": true, "weight": 1}}},
+		{"id": "p-other", "name": "another zone", "monitor": "m-other", "enabled": true, "origins": []object{}},
+	}
+	api.lists["src load_balancers"] = []object{{"id": "lb1", "name": "www.example.com", "ttl": 30, "proxied": true, "enabled": true,
+		"fallback_pool": "p1", "default_pools": []string{"p1", "p2"}, "region_pools": object{"WNAM": []string{"p2"}}}}
+	api.lists["src ssl/certificate_packs"] = []object{{"id": "c1", "type": "advanced", "hosts": []string{"example.com", "*.example.com"}, "status": "active"}}
+	api.lists["src custom_certificates"] = []object{}
+	api.lists["src logpush/jobs"] = []object{{"id": 7, "name": "requests", "enabled": true, "dataset": "http_requests", "frequency": "high",
+		"logpull_options": "fields=RayID", "destination_conf": "https://logs.example.net/push"}}
+	api.lists["src workers/routes"] = []object{{"id": "t1", "pattern": "example.com/api/*", "script": "api"}}
+	api.scripts["api"] = "addEventListener('fetch', event => {})"
+	api.scripts["unused"] = "// not routed"
+}
+
+func names(items []zonebundle.Item) (result []string) {
+	for _, item := range items {
+		result = append(result, item.Resource+" "+item.Name)
+	}
+	return
+}
+
+var _ = Describe(`Bundle`, func() {
+	newBundle := func() *zonebundle.Bundle {
+		bundle := zonebundle.NewBundle("example.com")
+		bundle.Put("filters.json", zonebundle.Resource_Filters, 1, []byte(`[{"id":"f1"}]`))
+		bundle.Put("edge_functions/api.js", zonebundle.Resource_EdgeFunctions, 1, []byte("// script"))
+		return bundle
+	}
+
+	It(`Writes and reads a directory`, func() {
+		dir, err := os.MkdirTemp("", "zonebundle")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+
+		Expect(newBundle().WriteDir(dir)).To(Succeed())
+		bundle, err := zonebundle.ReadDir(dir)
+		Expect(err).To(BeNil())
+		Expect(bundle.Manifest.Zone).To(Equal("example.com"))
+		Expect(bundle.Manifest.SchemaVersion).To(Equal(zonebundle.SchemaVersion))
+		content, ok := bundle.File("edge_functions/api.js")
+		Expect(ok).To(BeTrue())
+		Expect(string(content)).To(Equal("// script"))
+
+		Expect(os.WriteFile(filepath.Join(dir, "filters.json"), []byte(`[]`), 0o600)).To(Succeed())
+		_, err = zonebundle.ReadDir(dir)
+		Expect(err).To(MatchError("the checksum of filters.json does not match the manifest"))
+	})
+
+	It(`Writes and reads a tarball`, func() {
+		var buffer bytes.Buffer
+		Expect(newBundle().WriteTar(&buffer)).To(Succeed())
+		bundle, err := zonebundle.ReadTar(&buffer)
+		Expect(err).To(BeNil())
+		Expect(bundle.Manifest.Files).To(HaveLen(2))
+		Expect(bundle.Entry("filters.json").Count).To(Equal(1))
+		content, ok := bundle.File("filters.json")
+		Expect(ok).To(BeTrue())
+		Expect(string(content)).To(Equal(`[{"id":"f1"}]`))
+	})
+
+	It(`Rejects later schema versions and paths outside the bundle`, func() {
+		dir, err := os.MkdirTemp("", "zonebundle")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+
+		bundle := newBundle()
+		bundle.Manifest.SchemaVersion = zonebundle.SchemaVersion + 1
+		Expect(bundle.WriteDir(dir)).To(Succeed())
+		_, err = zonebundle.ReadDir(dir)
+		Expect(err).To(MatchError(fmt.Sprintf("unsupported bundle schema version %d, this version reads versions 1 to %d", zonebundle.SchemaVersion+1, zonebundle.SchemaVersion)))
+
+		for _, path := range []string{"../secrets", "..", "edge_functions/.."} {
+			manifest := fmt.Sprintf(`{"schema_version": %d, "files": [{"path": %q, "resource": "filters"}]}`, zonebundle.SchemaVersion, path)
+			Expect(os.WriteFile(filepath.Join(dir, zonebundle.ManifestFile), []byte(manifest), 0o600)).To(Succeed())
+			_, err = zonebundle.ReadDir(dir)
+			Expect(err).To(MatchError(fmt.Sprintf("invalid file path %q in the manifest", path)))
+		}
+	})
+
+	It(`Skips the files of a tarball that the manifest does not list and rejects large files`, func() {
+		tarball := func(entries ...string) *bytes.Buffer {
+			var buffer bytes.Buffer
+			compressed := gzip.NewWriter(&buffer)
+			archive := tar.NewWriter(compressed)
+			for i := 0; i < len(entries); i += 2 {
+				Expect(archive.WriteHeader(&tar.Header{Name: entries[i], Mode: 0o600, Size: int64(len(entries[i+1])), Typeflag: tar.TypeReg})).To(Succeed())
+				_, err := archive.Write([]byte(entries[i+1]))
+				Expect(err).To(BeNil())
+			}
+			Expect(archive.Close()).To(Succeed())
+			Expect(compressed.Close()).To(Succeed())
+			return &buffer
+		}
+		filters := `[{"id":"f1"}]`
+		sum := sha256.Sum256([]byte(filters))
+		manifest := fmt.Sprintf(`{"schema_version": %d, "files": [{"path": "filters.json", "resource": "filters", "count": 1, "sha256": %q}]}`,
+			zonebundle.SchemaVersion, hex.EncodeToString(sum[:]))
+		large := strings.Repeat(" ", zonebundle.MaxFileSize+1)
+
+		bundle, err := zonebundle.ReadTar(tarball(zonebundle.ManifestFile, manifest, "extra.bin", large, "filters.json", filters))
+		Expect(err).To(BeNil())
+		_, ok := bundle.File("extra.bin")
+		Expect(ok).To(BeFalse())
+		content, _ := bundle.File("filters.json")
+		Expect(string(content)).To(Equal(filters))
+
+		_, err = zonebundle.ReadTar(tarball(zonebundle.ManifestFile, manifest, "filters.json", large))
+		Expect(err).To(MatchError(fmt.Sprintf("filters.json is larger than %d bytes", zonebundle.MaxFileSize)))
+		_, err = zonebundle.ReadTar(tarball("filters.json", filters, zonebundle.ManifestFile, manifest))
+		Expect(err).To(MatchError("the tarball does not start with manifest.json"))
+	})
+})
+
+var _ = Describe(`Export and Import`, func() {
+	var (
+		source, target *fakeCIS
+		sourceServer   *httptest.Server
+		targetServer   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		source, target = newFakeCIS(), newFakeCIS()
+		configure(source)
+		sourceServer, targetServer = httptest.NewServer(source), httptest.NewServer(target)
+	})
+
+	AfterEach(func() {
+		sourceServer.Close()
+		targetServer.Close()
+	})
+
+	export := func() *zonebundle.Bundle {
+		bundle, err := zonebundle.Export(newZone(sourceServer.URL, "src"))
+		Expect(err).To(BeNil())
+		var buffer bytes.Buffer
+		Expect(bundle.WriteTar(&buffer)).To(Succeed())
+		bundle, err = zonebundle.ReadTar(&buffer)
+		Expect(err).To(BeNil())
+		return bundle
+	}
+
+	It(`Exports the configuration of a zone`, func() {
+		bundle := export()
+		Expect(bundle.Manifest.Zone).To(Equal("example.com"))
+		counts := map[string]int{}
+		for _, entry := range bundle.Manifest.Files {
+			counts[entry.Path] = entry.Count
+		}
+		Expect(counts).To(Equal(map[string]int{
+			"certificates.json":     1,
+			"edge_functions.json":   1,
+			"edge_functions/api.js": 1,
+			"filters.json":          2,
+			"firewall_rules.json":   2,
+			"glb_monitors.json":     1,
+			"glb_pools.json":        2,
+			"load_balancers.json":   1,
+			"logpush_jobs.json":     1,
+			"page_rules.json":       1,
+			"rate_limits.json":      1,
+			"rulesets.json":         2,
+		}))
+		content, _ := bundle.File("edge_functions/api.js")
+		Expect(string(content)).To(Equal("addEventListener('fetch', event => {})"))
+		Expect(source.writes).To(BeEmpty())
+	})
+
+	It(`Restores a bundle into an empty zone in dependency order`, func() {
+		report, err := zonebundle.Import(newZone(targetServer.URL, "dst"), export())
+		Expect(err).To(BeNil())
+		Expect(report.Failed).To(BeEmpty())
+
+		Expect(target.writes).To(Equal([]string{
+			"POST dst filters",
+			"POST dst filters",
+			"POST dst firewall/rules",
+			"POST dst firewall/rules",
+			"POST dst rate_limits",
+			"PUT dst rulesets/phases/http_request_firewall_custom/entrypoint",
+			"POST dst pagerules",
+			"POST instance load_balancers/monitors",
+			"POST instance load_balancers/pools",
+			"POST instance load_balancers/pools",
+			"POST dst load_balancers",
+			"PUT instance workers/scripts/api",
+			"POST dst workers/routes",
+			"POST dst logpush/jobs",
+		}))
+		filters := report.IDs[zonebundle.Resource_Filters]
+		Expect(target.lists["dst firewall/rules"][0]["filter"]).To(Equal(object{"id": filters["f1"]}))
+		Expect(target.lists["dst firewall/rules"][1]["filter"]).To(Equal(object{"id": filters["f2"]}))
+		Expect(target.lists["dst filters"][1]["paused"]).To(BeTrue())
+
+		pools := report.IDs[zonebundle.Resource_GlbPools]
+		Expect(target.lists["instance load_balancers/pools"][0]["monitor"]).To(Equal(report.IDs[zonebundle.Resource_GlbMonitors]["m1"]))
+		loadBalancer := target.lists["dst load_balancers"][0]
+		Expect(loadBalancer["fallback_pool"]).To(Equal(pools["p1"]))
+		Expect(loadBalancer["default_pools"]).To(Equal([]interface{}{pools["p1"], pools["p2"]}))
+		Expect(loadBalancer["region_pools"]).To(Equal(object{"WNAM": []interface{}{pools["p2"]}}))
+
+		rules := target.entrypoints["dst http_request_firewall_custom"]["rules"].([]interface{})
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[1].(object)["action_parameters"]).To(Equal(object{"id": "managed"}))
+		Expect(target.scripts["api"]).To(Equal("addEventListener('fetch', event => {})"))
+		Expect(target.lists["dst workers/routes"][0]["script"]).To(Equal("api"))
+		Expect(target.lists["dst logpush/jobs"][0]["destination_conf"]).To(Equal("https://logs.example.net/push"))
+		Expect(target.lists["dst pagerules"][0]["actions"]).To(Equal([]interface{}{object{"id": "cache_level", "value": "cache_everything"}}))
+
+		Expect(names(report.Skipped)).To(Equal([]string{
+			"rulesets local",
+			"rulesets http_request_firewall_custom/r3",
+			"certificates c1",
+		}))
+		Expect(report.Skipped[2].Reason).To(Equal("order the advanced certificate pack for example.com, *.example.com again"))
+	})
+
+	It(`Uses the monitors and pools the instance has`, func() {
+		report, err := zonebundle.Import(newZone(sourceServer.URL, "dst"), export())
+		Expect(err).To(BeNil())
+		Expect(names(report.Existing)).To(Equal([]string{"glb_monitors m1", "glb_pools p1", "glb_pools p2"}))
+		Expect(source.lists["instance load_balancers/pools"]).To(HaveLen(3))
+		Expect(source.lists["dst load_balancers"][0]["default_pools"]).To(Equal([]interface{}{"p1", "p2"}))
+	})
+
+	It(`Skips the objects whose dependency failed`, func() {
+		bundle := export()
+		target.fail = "instance load_balancers/monitors"
+		zone := newZone(targetServer.URL, "dst")
+		zone.Filters = nil
+		report, err := zonebundle.Import(zone, bundle)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("1 zone configuration items could not be restored, the first: glb_monitors m1: "))
+		Expect(names(report.Skipped)).To(ContainElements(
+			"filters ",
+			"firewall_rules fw1",
+			"firewall_rules fw2",
+			"glb_pools p1",
+			"load_balancers lb1",
+		))
+		Expect(target.lists["instance load_balancers/pools"]).To(HaveLen(1))
+		Expect(target.lists["dst load_balancers"]).To(BeEmpty())
+	})
+})
@@ -0,0 +1,471 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package zoneclone copies the configuration of a reference zone to another zone.
+//
+// A Zone gathers the clients of one zone, which may belong to any CIS instance. CloneZoneConfig reads the selected
+// resources of the source zone and writes them to the destination zone: zone settings, caching settings, custom
+// pages, ruleset entry points, rate limits, user agent rules, lockdowns, page rules and the origin pull setting. The
+// host names of the source zone are renamed to the destination zone, the rulesets that rules execute are remapped
+// to the rulesets of the destination zone. The rate limits, user agent rules, lockdowns and page rules are matched by
+// description or URL pattern, and the matching objects of the destination zone are updated rather than duplicated.
+// Nothing is deleted from the destination zone. The Report tells what was copied, what was already there, what was
+// skipped and why, and what failed.
+package zoneclone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/networking-go-sdk/authenticatedoriginpullapiv1"
+	"github.com/IBM/networking-go-sdk/cachingapiv1"
+	"github.com/IBM/networking-go-sdk/custompagesv1"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+)
+
+// PageSize is the page size used to list the rate limits, user agent rules and lockdowns.
+const PageSize = 100
+
+// Constants for the selectors of CloneZoneConfig, which are also the Item.Resource values.
+const (
+	Selector_Caching        = "caching"
+	Selector_CustomPages    = "custom_pages"
+	Selector_Lockdowns      = "lockdowns"
+	Selector_OriginPull     = "origin_pull"
+	Selector_PageRules      = "page_rules"
+	Selector_RateLimits     = "rate_limits"
+	Selector_Rulesets       = "rulesets"
+	Selector_Settings       = "settings"
+	Selector_UserAgentRules = "user_agent_rules"
+)
+
+// selectors lists the resources in the order they are cloned. The settings come first, and the rulesets before the
+// legacy rules.
+var selectors = []string{
+	Selector_Settings,
+	Selector_Caching,
+	Selector_CustomPages,
+	Selector_Rulesets,
+	Selector_RateLimits,
+	Selector_UserAgentRules,
+	Selector_Lockdowns,
+	Selector_PageRules,
+	Selector_OriginPull,
+}
+
+// Zone : The clients of a zone. A nil client leaves its resources out of the clone.
+type Zone struct {
+	// The domain name of the zone, used to rename the host names of the source zone. Host names are not renamed when
+	// the name of either zone is empty.
+	Name string
+
+	Settings *zonessettingsv1.ZonesSettingsV1
+
+	Caching *cachingapiv1.CachingApiV1
+
+	PageRules *pageruleapiv1.PageRuleApiV1
+
+	CustomPages *custompagesv1.CustomPagesV1
+
+	Rulesets *rulesetsv1.RulesetsV1
+
+	RateLimits *zoneratelimitsv1.ZoneRateLimitsV1
+
+	UserAgentRules *useragentblockingrulesv1.UserAgentBlockingRulesV1
+
+	Lockdowns *zonelockdownv1.ZoneLockdownV1
+
+	OriginPull *authenticatedoriginpullapiv1.AuthenticatedOriginPullApiV1
+}
+
+// has returns true when the zone has the client of a resource.
+func (zone *Zone) has(selector string) bool {
+	switch selector {
+	case Selector_Settings:
+		return zone.Settings != nil
+	case Selector_Caching:
+		return zone.Caching != nil
+	case Selector_CustomPages:
+		return zone.CustomPages != nil
+	case Selector_Rulesets:
+		return zone.Rulesets != nil
+	case Selector_RateLimits:
+		return zone.RateLimits != nil
+	case Selector_UserAgentRules:
+		return zone.UserAgentRules != nil
+	case Selector_Lockdowns:
+		return zone.Lockdowns != nil
+	case Selector_PageRules:
+		return zone.PageRules != nil
+	case Selector_OriginPull:
+		return zone.OriginPull != nil
+	}
+	return false
+}
+
+// Item : A setting or an object of the source zone.
+type Item struct {
+	// One of the Selector constants.
+	Resource string
+
+	// The name of the setting, or the ID of the object in the source zone.
+	Name string
+
+	// Why the item was skipped, or how it was copied.
+	Reason string
+
+	// The error of the items that failed.
+	Err error
+}
+
+func (item Item) String() string {
+	text := item.Resource
+	if item.Name != "" {
+		text += " " + item.Name
+	}
+	if item.Err != nil {
+		return text + ": " + item.Err.Error()
+	}
+	if item.Reason != "" {
+		return text + ": " + item.Reason
+	}
+	return text
+}
+
+// Report : The outcome of a clone.
+type Report struct {
+	// The items written to the destination zone.
+	Copied []Item
+
+	// The items that the destination zone already had.
+	Unchanged []Item
+
+	// The items that cannot be cloned, with the reason.
+	Skipped []Item
+
+	// The items that could not be read or written, with the error.
+	Failed []Item
+
+	// The IDs of the destination objects by resource and source ID, for the objects whose ID differs between the
+	// zones: the objects copied or found in the destination zone, and the custom rulesets that rules execute.
+	IDs map[string]map[string]string
+}
+
+func (report *Report) String() string {
+	var lines []string
+	for _, section := range []struct {
+		title string
+		items []Item
+	}{
+		{"copied", report.Copied},
+		{"unchanged", report.Unchanged},
+		{"skipped", report.Skipped},
+		{"failed", report.Failed},
+	} {
+		for _, item := range section.items {
+			lines = append(lines, section.title+": "+item.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (report *Report) mapID(resource string, sourceID string, destinationID string) {
+	if sourceID == "" || destinationID == "" || sourceID == destinationID {
+		return
+	}
+	if report.IDs[resource] == nil {
+		report.IDs[resource] = map[string]string{}
+	}
+	report.IDs[resource][sourceID] = destinationID
+}
+
+// CloneZoneConfig : Copy the configuration of a zone to another zone
+// Read the selected resources of the source zone and write them to the destination zone, one resource after the
+// other. Without selectors, every resource for which both zones have a client is cloned. A failure does not stop
+// the clone: the report lists the items that failed, and the error tells how many failed.
+func CloneZoneConfig(src *Zone, dst *Zone, selectors ...string) (report *Report, err error) {
+	return CloneZoneConfigWithContext(context.Background(), src, dst, selectors...)
+}
+
+// CloneZoneConfigWithContext is an alternate form of the CloneZoneConfig method which supports a Context parameter
+func CloneZoneConfigWithContext(ctx context.Context, src *Zone, dst *Zone, selectors ...string) (report *Report, err error) {
+	if src == nil || dst == nil {
+		err = fmt.Errorf("the source and destination zones are required")
+		return
+	}
+	selected, err := selectResources(src, dst, selectors)
+	if err != nil {
+		return
+	}
+	cloner := &cloner{
+		ctx:    ctx,
+		src:    src,
+		dst:    dst,
+		report: &Report{IDs: map[string]map[string]string{}},
+	}
+	for _, selector := range selected {
+		switch selector {
+		case Selector_Settings:
+			cloner.cloneSettings()
+		case Selector_Caching:
+			cloner.cloneCaching()
+		case Selector_CustomPages:
+			cloner.cloneCustomPages()
+		case Selector_Rulesets:
+			cloner.cloneRulesets()
+		case Selector_RateLimits:
+			cloner.cloneRateLimits()
+		case Selector_UserAgentRules:
+			cloner.cloneUserAgentRules()
+		case Selector_Lockdowns:
+			cloner.cloneLockdowns()
+		case Selector_PageRules:
+			cloner.clonePageRules()
+		case Selector_OriginPull:
+			cloner.cloneOriginPull()
+		}
+	}
+	report = cloner.report
+	if len(report.Failed) > 0 {
+		first := report.Failed[0]
+		err = fmt.Errorf("%d zone configuration items could not be cloned, the first: %s: %w", len(report.Failed), Item{Resource: first.Resource, Name: first.Name}, first.Err)
+	}
+	return
+}
+
+// selectResources returns the resources to clone, in clone order.
+func selectResources(src *Zone, dst *Zone, wanted []string) (selected []string, err error) {
+	if len(wanted) == 0 {
+		for _, selector := range selectors {
+			if src.has(selector) && dst.has(selector) {
+				selected = append(selected, selector)
+			}
+		}
+		return
+	}
+	known := map[string]bool{}
+	for _, selector := range selectors {
+		known[selector] = true
+	}
+	chosen := map[string]bool{}
+	for _, selector := range wanted {
+		if !known[selector] {
+			return nil, fmt.Errorf("unknown selector %q", selector)
+		}
+		if !src.has(selector) || !dst.has(selector) {
+			return nil, fmt.Errorf("both zones need a client to clone %s", selector)
+		}
+		chosen[selector] = true
+	}
+	for _, selector := range selectors {
+		if chosen[selector] {
+			selected = append(selected, selector)
+		}
+	}
+	return
+}
+
+// cloner holds the state of one clone.
+type cloner struct {
+	ctx    context.Context
+	src    *Zone
+	dst    *Zone
+	report *Report
+}
+
+func (cloner *cloner) copied(resource string, name string, reason string) {
+	cloner.report.Copied = append(cloner.report.Copied, Item{Resource: resource, Name: name, Reason: reason})
+}
+
+func (cloner *cloner) unchanged(resource string, name string) {
+	cloner.report.Unchanged = append(cloner.report.Unchanged, Item{Resource: resource, Name: name})
+}
+
+func (cloner *cloner) skipped(resource string, name string, reason string) {
+	cloner.report.Skipped = append(cloner.report.Skipped, Item{Resource: resource, Name: name, Reason: reason})
+}
+
+func (cloner *cloner) failed(resource string, name string, err error) {
+	cloner.report.Failed = append(cloner.report.Failed, Item{Resource: resource, Name: name, Err: err})
+}
+
+// renameHost replaces the name of the source zone with the name of the destination zone wherever it stands as a
+// host name or as the parent domain of one, as in www.example.com or https://example.com/path.
+func (cloner *cloner) renameHost(text string) string {
+	from, to := cloner.src.Name, cloner.dst.Name
+	if from == "" || to == "" || from == to {
+		return text
+	}
+	var builder strings.Builder
+	for {
+		index := strings.Index(text, from)
+		if index < 0 {
+			builder.WriteString(text)
+			return builder.String()
+		}
+		end := index + len(from)
+		boundary := (index == 0 || !isHostByte(text[index-1])) && (end == len(text) || (!isHostByte(text[end]) && text[end] != '.'))
+		builder.WriteString(text[:index])
+		if boundary {
+			builder.WriteString(to)
+		} else {
+			builder.WriteString(from)
+		}
+		text = text[end:]
+	}
+}
+
+func isHostByte(c byte) bool {
+	return c == '-' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// renameHosts renames the host names in the strings of a JSON value.
+func (cloner *cloner) renameHosts(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return cloner.renameHost(value)
+	case []interface{}:
+		for i := range value {
+			value[i] = cloner.renameHosts(value[i])
+		}
+	case map[string]interface{}:
+		for key := range value {
+			value[key] = cloner.renameHosts(value[key])
+		}
+	}
+	return value
+}
+
+// volatileFields are the fields of the listed objects that are specific to a zone.
+var volatileFields = []string{"id", "created_on", "modified_on"}
+
+// canonical returns the JSON form of an object without its volatile fields, with sorted keys, so that the same
+// object has the same form in both zones. The host names are renamed when rename is true.
+func (cloner *cloner) canonical(object interface{}, rename bool) (string, error) {
+	buffer, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(buffer, &fields)
+	if err != nil {
+		return "", err
+	}
+	for _, field := range volatileFields {
+		delete(fields, field)
+	}
+	if rename {
+		cloner.renameHosts(fields)
+	}
+	buffer, err = json.Marshal(fields)
+	return string(buffer), err
+}
+
+// listed is an object of a listed resource, with its ID and canonical form.
+type listed struct {
+	id        string
+	canonical string
+}
+
+// canonicalize returns the canonical form of the objects of a zone.
+func (cloner *cloner) canonicalize(ids []string, objects []interface{}, rename bool) (result []listed, err error) {
+	for i, object := range objects {
+		var form string
+		form, err = cloner.canonical(object, rename)
+		if err != nil {
+			return
+		}
+		result = append(result, listed{id: ids[i], canonical: form})
+	}
+	return
+}
+
+// objectKey returns the natural key of an object from its canonical form: the first of the fields, given as dotted
+// paths, that is set, with its value. An object without any of the fields is keyed by its whole canonical form.
+func objectKey(canonical string, paths ...string) string {
+	var fields map[string]interface{}
+	if json.Unmarshal([]byte(canonical), &fields) != nil {
+		return canonical
+	}
+	for _, path := range paths {
+		var value interface{} = fields
+		for _, name := range strings.Split(path, ".") {
+			object, _ := value.(map[string]interface{})
+			value = object[name]
+		}
+		if value == nil || value == "" {
+			continue
+		}
+		if list, ok := value.([]interface{}); ok && len(list) == 0 {
+			continue
+		}
+		buffer, _ := json.Marshal(value)
+		return path + "=" + string(buffer)
+	}
+	return canonical
+}
+
+// cloneObjects copies the source objects to the destination zone. Objects are matched by their natural key: a
+// destination object with the key of a source object is left alone when it is the same and updated otherwise, and a
+// source object without a match is created. A source object is skipped when its key is ambiguous, as when several
+// destination objects have it.
+func (cloner *cloner) cloneObjects(resource string, src []listed, dst []listed, key func(canonical string) string,
+	create func(canonical string) (id string, err error), update func(id string, canonical string) error) {
+	existing := map[string][]listed{}
+	for _, object := range dst {
+		objectKey := key(object.canonical)
+		existing[objectKey] = append(existing[objectKey], object)
+	}
+	claimed := map[string]string{}
+	for _, object := range src {
+		objectKey := key(object.canonical)
+		if other, ok := claimed[objectKey]; ok {
+			cloner.skipped(resource, object.id, fmt.Sprintf("the source object %s has the same %s", other, objectKey))
+			continue
+		}
+		claimed[objectKey] = object.id
+		matches := existing[objectKey]
+		switch {
+		case len(matches) > 1:
+			cloner.skipped(resource, object.id, fmt.Sprintf("%d objects of the destination zone have %s", len(matches), objectKey))
+		case len(matches) == 1 && matches[0].canonical == object.canonical:
+			cloner.unchanged(resource, object.id)
+			cloner.report.mapID(resource, object.id, matches[0].id)
+		case len(matches) == 1:
+			if err := update(matches[0].id, object.canonical); err != nil {
+				cloner.failed(resource, object.id, err)
+				continue
+			}
+			cloner.copied(resource, object.id, "updated "+matches[0].id)
+			cloner.report.mapID(resource, object.id, matches[0].id)
+		default:
+			id, err := create(object.canonical)
+			if err != nil {
+				cloner.failed(resource, object.id, err)
+				continue
+			}
+			cloner.copied(resource, object.id, "created as "+id)
+			cloner.report.mapID(resource, object.id, id)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zoneclone
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/common"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+)
+
+func sortedKeys(m map[string]json.RawMessage) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// cloneRulesets copies the rules of the zone entry point rulesets of the source zone, one phase at a time. The
+// managed rulesets that rules execute are the same in every zone; the custom rulesets are found by name in the
+// destination zone, and the rules executing a custom ruleset that the destination zone does not have are skipped.
+// The entry points of the phases that the source zone does not use are left alone.
+func (cloner *cloner) cloneRulesets() {
+	src, dst := cloner.src.Rulesets, cloner.dst.Rulesets
+	sourceRulesets, _, err := src.GetZoneRulesetsWithContext(cloner.ctx, src.NewGetZoneRulesetsOptions())
+	if err != nil {
+		cloner.failed(Selector_Rulesets, "", fmt.Errorf("listing the source zone: %w", err))
+		return
+	}
+	destinationRulesets, _, err := dst.GetZoneRulesetsWithContext(cloner.ctx, dst.NewGetZoneRulesetsOptions())
+	if err != nil {
+		cloner.failed(Selector_Rulesets, "", fmt.Errorf("listing the destination zone: %w", err))
+		return
+	}
+	rulesets := map[string]*rulesetsv1.ListedRuleset{}
+	for i := range sourceRulesets.Result {
+		rulesets[core.StringNilMapper(sourceRulesets.Result[i].ID)] = &sourceRulesets.Result[i]
+	}
+	customRulesets := map[string]string{}
+	for _, ruleset := range destinationRulesets.Result {
+		if core.StringNilMapper(ruleset.Kind) == rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Custom {
+			customRulesets[core.StringNilMapper(ruleset.Name)] = core.StringNilMapper(ruleset.ID)
+		}
+	}
+	for _, ruleset := range sourceRulesets.Result {
+		if core.StringNilMapper(ruleset.Kind) == rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Zone {
+			cloner.clonePhase(core.StringNilMapper(ruleset.Phase), rulesets, customRulesets)
+		}
+	}
+}
+
+// clonePhase replaces the rules of the destination entry point of a phase with the rules of the source entry point,
+// unless they are the same already.
+func (cloner *cloner) clonePhase(phase string, rulesets map[string]*rulesetsv1.ListedRuleset, customRulesets map[string]string) {
+	src, dst := cloner.src.Rulesets, cloner.dst.Rulesets
+	source, _, err := src.GetZoneEntrypointRulesetWithContext(cloner.ctx, src.NewGetZoneEntrypointRulesetOptions(phase))
+	if err != nil {
+		cloner.failed(Selector_Rulesets, phase, fmt.Errorf("reading the source zone: %w", err))
+		return
+	}
+	var current []rulesetsv1.RuleCreate
+	destination, response, err := dst.GetZoneEntrypointRulesetWithContext(cloner.ctx, dst.NewGetZoneEntrypointRulesetOptions(phase))
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		cloner.failed(Selector_Rulesets, phase, fmt.Errorf("reading the destination zone: %w", err))
+		return
+	}
+	if err == nil && destination.Result != nil {
+		for i := range destination.Result.Rules {
+			rule, err := cloner.ruleCreate(&destination.Result.Rules[i], false)
+			if err != nil {
+				cloner.failed(Selector_Rulesets, phase, err)
+				return
+			}
+			current = append(current, *rule)
+		}
+	}
+
+	var rules []rulesetsv1.RuleCreate
+	if source.Result != nil {
+		for i := range source.Result.Rules {
+			ruleID := core.StringNilMapper(source.Result.Rules[i].ID)
+			rule, err := cloner.ruleCreate(&source.Result.Rules[i], true)
+			if err != nil {
+				cloner.failed(Selector_Rulesets, phase, err)
+				return
+			}
+			if core.StringNilMapper(rule.Action) == "execute" && rule.ActionParameters != nil && rule.ActionParameters.ID != nil {
+				executed := rulesets[*rule.ActionParameters.ID]
+				if executed != nil && core.StringNilMapper(executed.Kind) == rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Custom {
+					id, ok := customRulesets[core.StringNilMapper(executed.Name)]
+					if !ok {
+						cloner.skipped(Selector_Rulesets, phase+"/"+ruleID, fmt.Sprintf("the destination zone has no custom ruleset named %q", core.StringNilMapper(executed.Name)))
+						continue
+					}
+					cloner.report.mapID(Selector_Rulesets, *rule.ActionParameters.ID, id)
+					rule.ActionParameters.ID = core.StringPtr(id)
+				}
+			}
+			rules = append(rules, *rule)
+		}
+	}
+
+	from, _ := json.Marshal(current)
+	to, _ := json.Marshal(rules)
+	if string(from) == string(to) {
+		cloner.unchanged(Selector_Rulesets, phase)
+		return
+	}
+	options := dst.NewUpdateZoneEntrypointRulesetOptions(phase)
+	options.SetName("default").SetKind(rulesetsv1.UpdateZoneEntrypointRulesetOptions_Kind_Zone).SetPhase(phase).SetRules(rules)
+	if source.Result != nil {
+		options.Description = source.Result.Description
+		options.Name = source.Result.Name
+	}
+	_, _, err = dst.UpdateZoneEntrypointRulesetWithContext(cloner.ctx, options)
+	if err != nil {
+		cloner.failed(Selector_Rulesets, phase, err)
+		return
+	}
+	cloner.copied(Selector_Rulesets, phase, fmt.Sprintf("%d rules", len(rules)))
+}
+
+// ruleCreate returns a copy of a rule as a rule to create, with its host names renamed when rename is true.
+func (cloner *cloner) ruleCreate(rule *rulesetsv1.RuleDetails, rename bool) (result *rulesetsv1.RuleCreate, err error) {
+	result, err = rulesetsv1.NewRuleCreateFromDetails(rule)
+	if err != nil || !rename {
+		return
+	}
+	buffer, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	var value interface{}
+	err = json.Unmarshal(buffer, &value)
+	if err != nil {
+		return
+	}
+	buffer, err = json.Marshal(cloner.renameHosts(value))
+	if err != nil {
+		return
+	}
+	var raw map[string]json.RawMessage
+	err = json.Unmarshal(buffer, &raw)
+	if err != nil {
+		return
+	}
+	err = rulesetsv1.UnmarshalRuleCreate(raw, &result)
+	if err != nil {
+		err = fmt.Errorf("copying rule %s: %w", core.StringNilMapper(rule.ID), err)
+	}
+	return
+}
+
+// cloneRateLimits copies the rate limits of the source zone, matched by description, or by URL without one.
+func (cloner *cloner) cloneRateLimits() {
+	src, dst := cloner.src.RateLimits, cloner.dst.RateLimits
+	list := func(client *zoneratelimitsv1.ZoneRateLimitsV1, rename bool) (objects []listed, err error) {
+		var ids []string
+		var values []interface{}
+		for page := int64(1); ; page++ {
+			options := client.NewListAllZoneRateLimitsOptions().SetPage(page).SetPerPage(PageSize)
+			result, _, listErr := client.ListAllZoneRateLimitsWithContext(cloner.ctx, options)
+			if listErr != nil {
+				return nil, listErr
+			}
+			for _, rateLimit := range result.Result {
+				ids = append(ids, core.StringNilMapper(rateLimit.ID))
+				values = append(values, rateLimit)
+			}
+			if result.ResultInfo == nil || common.LastPage(page, PageSize, len(result.Result), result.ResultInfo.TotalCount) {
+				break
+			}
+		}
+		return cloner.canonicalize(ids, values, rename)
+	}
+	source, destination, ok := cloner.listBoth(Selector_RateLimits, func() ([]listed, error) { return list(src, true) }, func() ([]listed, error) { return list(dst, false) })
+	if !ok {
+		return
+	}
+	key := func(canonical string) string { return objectKey(canonical, "description", "match.request.url") }
+	cloner.cloneObjects(Selector_RateLimits, source, destination, key, func(canonical string) (id string, err error) {
+		options := dst.NewCreateZoneRateLimitsOptions()
+		err = json.Unmarshal([]byte(canonical), options)
+		if err != nil {
+			return
+		}
+		result, _, err := dst.CreateZoneRateLimitsWithContext(cloner.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	}, func(id string, canonical string) error {
+		options := dst.NewUpdateRateLimitOptions(id)
+		if err := json.Unmarshal([]byte(canonical), options); err != nil {
+			return err
+		}
+		_, _, err := dst.UpdateRateLimitWithContext(cloner.ctx, options)
+		return err
+	})
+}
+
+// cloneUserAgentRules copies the user agent rules of the source zone, matched by description, or by user agent without
+// one.
+func (cloner *cloner) cloneUserAgentRules() {
+	src, dst := cloner.src.UserAgentRules, cloner.dst.UserAgentRules
+	list := func(client *useragentblockingrulesv1.UserAgentBlockingRulesV1, rename bool) (objects []listed, err error) {
+		var ids []string
+		var values []interface{}
+		for page := int64(1); ; page++ {
+			options := client.NewListAllZoneUserAgentRulesOptions().SetPage(page).SetPerPage(PageSize)
+			result, _, listErr := client.ListAllZoneUserAgentRulesWithContext(cloner.ctx, options)
+			if listErr != nil {
+				return nil, listErr
+			}
+			for _, rule := range result.Result {
+				ids = append(ids, core.StringNilMapper(rule.ID))
+				values = append(values, rule)
+			}
+			if result.ResultInfo == nil || common.LastPage(page, PageSize, len(result.Result), result.ResultInfo.TotalCount) {
+				break
+			}
+		}
+		return cloner.canonicalize(ids, values, rename)
+	}
+	source, destination, ok := cloner.listBoth(Selector_UserAgentRules, func() ([]listed, error) { return list(src, true) }, func() ([]listed, error) { return list(dst, false) })
+	if !ok {
+		return
+	}
+	key := func(canonical string) string { return objectKey(canonical, "description", "configuration.value") }
+	cloner.cloneObjects(Selector_UserAgentRules, source, destination, key, func(canonical string) (id string, err error) {
+		options := dst.NewCreateZoneUserAgentRuleOptions()
+		err = json.Unmarshal([]byte(canonical), options)
+		if err != nil {
+			return
+		}
+		result, _, err := dst.CreateZoneUserAgentRuleWithContext(cloner.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	}, func(id string, canonical string) error {
+		options := dst.NewUpdateUserAgentRuleOptions(id)
+		if err := json.Unmarshal([]byte(canonical), options); err != nil {
+			return err
+		}
+		_, _, err := dst.UpdateUserAgentRuleWithContext(cloner.ctx, options)
+		return err
+	})
+}
+
+// cloneLockdowns copies the lockdowns of the source zone, matched by description, or by URLs without one.
+func (cloner *cloner) cloneLockdowns() {
+	src, dst := cloner.src.Lockdowns, cloner.dst.Lockdowns
+	list := func(client *zonelockdownv1.ZoneLockdownV1, rename bool) (objects []listed, err error) {
+		var ids []string
+		var values []interface{}
+		for page := int64(1); ; page++ {
+			options := client.NewListAllZoneLockownRulesOptions().SetPage(page).SetPerPage(PageSize)
+			result, _, listErr := client.ListAllZoneLockownRulesWithContext(cloner.ctx, options)
+			if listErr != nil {
+				return nil, listErr
+			}
+			for _, lockdown := range result.Result {
+				ids = append(ids, core.StringNilMapper(lockdown.ID))
+				values = append(values, lockdown)
+			}
+			if result.ResultInfo == nil || common.LastPage(page, PageSize, len(result.Result), result.ResultInfo.TotalCount) {
+				break
+			}
+		}
+		return cloner.canonicalize(ids, values, rename)
+	}
+	source, destination, ok := cloner.listBoth(Selector_Lockdowns, func() ([]listed, error) { return list(src, true) }, func() ([]listed, error) { return list(dst, false) })
+	if !ok {
+		return
+	}
+	key := func(canonical string) string { return objectKey(canonical, "description", "urls") }
+	cloner.cloneObjects(Selector_Lockdowns, source, destination, key, func(canonical string) (id string, err error) {
+		options := dst.NewCreateZoneLockdownRuleOptions()
+		err = json.Unmarshal([]byte(canonical), options)
+		if err != nil {
+			return
+		}
+		result, _, err := dst.CreateZoneLockdownRuleWithContext(cloner.ctx, options)
+		if err == nil && result.Result != nil {
+			id = core.StringNilMapper(result.Result.ID)
+		}
+		return
+	}, func(id string, canonical string) error {
+		options := dst.NewUpdateLockdownRuleOptions(id)
+		if err := json.Unmarshal([]byte(canonical), options); err != nil {
+			return err
+		}
+		_, _, err := dst.UpdateLockdownRuleWithContext(cloner.ctx, options)
+		return err
+	})
+}
+
+// listBoth lists the objects of both zones, and records the failure of either list.
+func (cloner *cloner) listBoth(resource string, listSource func() ([]listed, error), listDestination func() ([]listed, error)) (source []listed, destination []listed, ok bool) {
+	source, err := listSource()
+	if err != nil {
+		cloner.failed(resource, "", fmt.Errorf("listing the source zone: %w", err))
+		return
+	}
+	destination, err = listDestination()
+	if err != nil {
+		cloner.failed(resource, "", fmt.Errorf("listing the destination zone: %w", err))
+		return
+	}
+	ok = true
+	return
+}
+
+// clonePageRules copies the page rules of the source zone. A page rule is found in the destination zone by its
+// targets: it is updated when its actions, priority or status differ, and created when no rule has its targets.
+func (cloner *cloner) clonePageRules() {
+	src, dst := cloner.src.PageRules, cloner.dst.PageRules
+	list := func(client *pageruleapiv1.PageRuleApiV1, rename bool) (objects []listed, err error) {
+		result, _, err := client.ListPageRulesWithContext(cloner.ctx, client.NewListPageRulesOptions())
+		if err != nil {
+			return
+		}
+		var ids []string
+		var values []interface{}
+		for _, rule := range result.Result {
+			ids = append(ids, core.StringNilMapper(rule.ID))
+			values = append(values, rule)
+		}
+		return cloner.canonicalize(ids, values, rename)
+	}
+	source, destination, ok := cloner.listBoth(Selector_PageRules, func() ([]listed, error) { return list(src, true) }, func() ([]listed, error) { return list(dst, false) })
+	if !ok {
+		return
+	}
+
+	targets := func(canonical string) (rule *pageruleapiv1.PageRuleContent, key string, err error) {
+		rule = &pageruleapiv1.PageRuleContent{}
+		err = json.Unmarshal([]byte(canonical), rule)
+		if err != nil {
+			return
+		}
+		buffer, err := json.Marshal(rule.Targets)
+		key = string(buffer)
+		return
+	}
+	existing := map[string]listed{}
+	for _, object := range destination {
+		_, key, err := targets(object.canonical)
+		if err != nil {
+			cloner.failed(Selector_PageRules, "", fmt.Errorf("reading the destination zone: %w", err))
+			return
+		}
+		existing[key] = object
+	}
+	for _, object := range source {
+		rule, key, err := targets(object.canonical)
+		if err != nil {
+			cloner.failed(Selector_PageRules, object.id, err)
+			continue
+		}
+		current, found := existing[key]
+		switch {
+		case found && current.canonical == object.canonical:
+			cloner.unchanged(Selector_PageRules, object.id)
+			cloner.report.mapID(Selector_PageRules, object.id, current.id)
+		case found:
+			_, _, err = dst.UpdatePageRuleWithContext(cloner.ctx, rule.UpdateOptions(current.id))
+			if err != nil {
+				cloner.failed(Selector_PageRules, object.id, err)
+				continue
+			}
+			cloner.copied(Selector_PageRules, object.id, "updated "+current.id)
+			cloner.report.mapID(Selector_PageRules, object.id, current.id)
+		default:
+			result, _, err := dst.CreatePageRuleWithContext(cloner.ctx, rule.CreateOptions())
+			if err != nil {
+				cloner.failed(Selector_PageRules, object.id, err)
+				continue
+			}
+			var id string
+			if result.Result != nil {
+				id = core.StringNilMapper(result.Result.ID)
+			}
+			existing[key] = listed{id: id, canonical: object.canonical}
+			cloner.copied(Selector_PageRules, object.id, "created as "+id)
+			cloner.report.mapID(Selector_PageRules, object.id, id)
+		}
+	}
+}
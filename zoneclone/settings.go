@@ -0,0 +1,268 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zoneclone
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/custompagesv1"
+)
+
+// cloneSettings applies the settings profile of the source zone to the destination zone. DNSSEC is left out: it
+// needs DS records at the registrar of the destination domain.
+func (cloner *cloner) cloneSettings() {
+	profile, err := cloner.src.Settings.SnapshotProfile(cloner.ctx)
+	if err != nil {
+		// The profile holds the settings that could be read.
+		cloner.failed(Selector_Settings, "", fmt.Errorf("reading the source zone: %w", err))
+	}
+	if profile.ZoneDnssec != nil {
+		profile.ZoneDnssec = nil
+		cloner.skipped(Selector_Settings, "dnssec", "DNSSEC needs DS records at the registrar of the destination domain")
+	}
+	changes, err := cloner.dst.Settings.ApplyProfile(cloner.ctx, profile)
+	if err != nil && len(changes) == 0 {
+		cloner.failed(Selector_Settings, "", err)
+		return
+	}
+	changed := map[string]bool{}
+	for _, change := range changes {
+		changed[change.Setting] = true
+		if change.Err != nil {
+			cloner.failed(Selector_Settings, change.Setting, change.Err)
+		} else {
+			cloner.copied(Selector_Settings, change.Setting, change.String())
+		}
+	}
+	buffer, err := json.Marshal(profile)
+	if err != nil {
+		return
+	}
+	var settings map[string]json.RawMessage
+	if json.Unmarshal(buffer, &settings) != nil {
+		return
+	}
+	for _, name := range sortedKeys(settings) {
+		if !changed[name] && string(settings[name]) != "null" {
+			cloner.unchanged(Selector_Settings, name)
+		}
+	}
+}
+
+// cloneCaching copies the caching settings. Development mode is skipped, as it turns itself off after a few hours.
+func (cloner *cloner) cloneCaching() {
+	src, dst := cloner.src.Caching, cloner.dst.Caching
+	ctx := cloner.ctx
+
+	cloner.cloneSetting(Selector_Caching, "browser_cache_ttl", func() (interface{}, error) {
+		result, _, err := src.GetBrowserCacheTTLWithContext(ctx, src.NewGetBrowserCacheTtlOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func() (interface{}, error) {
+		result, _, err := dst.GetBrowserCacheTTLWithContext(ctx, dst.NewGetBrowserCacheTtlOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func(value interface{}) error {
+		_, _, err := dst.UpdateBrowserCacheTTLWithContext(ctx, dst.NewUpdateBrowserCacheTtlOptions().SetValue(*value.(*int64)))
+		return err
+	})
+
+	cloner.cloneSetting(Selector_Caching, "serve_stale_content", func() (interface{}, error) {
+		result, _, err := src.GetServeStaleContentWithContext(ctx, src.NewGetServeStaleContentOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func() (interface{}, error) {
+		result, _, err := dst.GetServeStaleContentWithContext(ctx, dst.NewGetServeStaleContentOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func(value interface{}) error {
+		_, _, err := dst.UpdateServeStaleContentWithContext(ctx, dst.NewUpdateServeStaleContentOptions().SetValue(*value.(*string)))
+		return err
+	})
+
+	cloner.cloneSetting(Selector_Caching, "query_string_sort", func() (interface{}, error) {
+		result, _, err := src.GetQueryStringSortWithContext(ctx, src.NewGetQueryStringSortOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func() (interface{}, error) {
+		result, _, err := dst.GetQueryStringSortWithContext(ctx, dst.NewGetQueryStringSortOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func(value interface{}) error {
+		_, _, err := dst.UpdateQueryStringSortWithContext(ctx, dst.NewUpdateQueryStringSortOptions().SetValue(*value.(*string)))
+		return err
+	})
+
+	cloner.cloneSetting(Selector_Caching, "cache_level", func() (interface{}, error) {
+		result, _, err := src.GetCacheLevelWithContext(ctx, src.NewGetCacheLevelOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func() (interface{}, error) {
+		result, _, err := dst.GetCacheLevelWithContext(ctx, dst.NewGetCacheLevelOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Value, nil
+	}, func(value interface{}) error {
+		_, _, err := dst.UpdateCacheLevelWithContext(ctx, dst.NewUpdateCacheLevelOptions().SetValue(*value.(*string)))
+		return err
+	})
+
+	cloner.skipped(Selector_Caching, "development_mode", "development mode is temporary")
+}
+
+// cloneSetting reads a setting in both zones and updates the destination zone when they differ. The values are
+// pointers, and a nil source value is not copied.
+func (cloner *cloner) cloneSetting(resource string, name string, get func() (interface{}, error), getDestination func() (interface{}, error), update func(value interface{}) error) {
+	value, err := get()
+	if err != nil {
+		cloner.failed(resource, name, fmt.Errorf("reading the source zone: %w", err))
+		return
+	}
+	if isNil(value) {
+		cloner.skipped(resource, name, "the source zone has no value")
+		return
+	}
+	current, err := getDestination()
+	if err != nil {
+		cloner.failed(resource, name, fmt.Errorf("reading the destination zone: %w", err))
+		return
+	}
+	from, _ := json.Marshal(current)
+	to, _ := json.Marshal(value)
+	if string(from) == string(to) {
+		cloner.unchanged(resource, name)
+		return
+	}
+	err = update(value)
+	if err != nil {
+		cloner.failed(resource, name, err)
+		return
+	}
+	cloner.copied(resource, name, fmt.Sprintf("%s -> %s", from, to))
+}
+
+func isNil(value interface{}) bool {
+	switch value := value.(type) {
+	case *int64:
+		return value == nil
+	case *string:
+		return value == nil
+	case *bool:
+		return value == nil
+	}
+	return value == nil
+}
+
+// cloneCustomPages copies the URL and state of the custom pages. The pages have the same IDs in every zone.
+func (cloner *cloner) cloneCustomPages() {
+	src, dst := cloner.src.CustomPages, cloner.dst.CustomPages
+	result, _, err := src.ListZoneCustomPagesWithContext(cloner.ctx, src.NewListZoneCustomPagesOptions())
+	if err != nil {
+		cloner.failed(Selector_CustomPages, "", fmt.Errorf("listing the source zone: %w", err))
+		return
+	}
+	destination, _, err := dst.ListZoneCustomPagesWithContext(cloner.ctx, dst.NewListZoneCustomPagesOptions())
+	if err != nil {
+		cloner.failed(Selector_CustomPages, "", fmt.Errorf("listing the destination zone: %w", err))
+		return
+	}
+	pages := map[string]*custompagesv1.CustomPageObject{}
+	for i := range destination.Result {
+		pages[core.StringNilMapper(destination.Result[i].ID)] = &destination.Result[i]
+	}
+	for _, page := range result.Result {
+		id := core.StringNilMapper(page.ID)
+		state := core.StringNilMapper(page.State)
+		url := ""
+		if state == custompagesv1.CustomPageObject_State_Customized {
+			url = cloner.renameHost(core.StringNilMapper(page.URL))
+		}
+		current := pages[id]
+		if current == nil {
+			cloner.skipped(Selector_CustomPages, id, "the destination zone has no such page")
+			continue
+		}
+		currentURL := ""
+		if core.StringNilMapper(current.State) == custompagesv1.CustomPageObject_State_Customized {
+			currentURL = core.StringNilMapper(current.URL)
+		}
+		if core.StringNilMapper(current.State) == state && currentURL == url {
+			cloner.unchanged(Selector_CustomPages, id)
+			continue
+		}
+		options := dst.NewUpdateZoneCustomPageOptions(id).SetState(state).SetURL(url)
+		_, _, err := dst.UpdateZoneCustomPageWithContext(cloner.ctx, options)
+		if err != nil {
+			cloner.failed(Selector_CustomPages, id, err)
+			continue
+		}
+		if url == "" {
+			cloner.copied(Selector_CustomPages, id, state)
+		} else {
+			cloner.copied(Selector_CustomPages, id, url)
+		}
+	}
+}
+
+// cloneOriginPull copies the zone level origin pull setting. The client certificates are skipped, as their private
+// key cannot be read back.
+func (cloner *cloner) cloneOriginPull() {
+	src, dst := cloner.src.OriginPull, cloner.dst.OriginPull
+	ctx := cloner.ctx
+	cloner.cloneSetting(Selector_OriginPull, "enabled", func() (interface{}, error) {
+		result, _, err := src.GetZoneOriginPullSettingsWithContext(ctx, src.NewGetZoneOriginPullSettingsOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Enabled, nil
+	}, func() (interface{}, error) {
+		result, _, err := dst.GetZoneOriginPullSettingsWithContext(ctx, dst.NewGetZoneOriginPullSettingsOptions())
+		if err != nil || result.Result == nil {
+			return nil, err
+		}
+		return result.Result.Enabled, nil
+	}, func(value interface{}) error {
+		_, _, err := dst.SetZoneOriginPullSettingsWithContext(ctx, dst.NewSetZoneOriginPullSettingsOptions().SetEnabled(*value.(*bool)))
+		return err
+	})
+
+	certificates, _, err := src.ListZoneOriginPullCertificatesWithContext(ctx, src.NewListZoneOriginPullCertificatesOptions())
+	if err != nil {
+		cloner.failed(Selector_OriginPull, "certificates", fmt.Errorf("listing the source zone: %w", err))
+		return
+	}
+	for _, certificate := range certificates.Result {
+		cloner.skipped(Selector_OriginPull, core.StringNilMapper(certificate.ID), "the private key of a client certificate cannot be read, upload it to the destination zone")
+	}
+}
@@ -0,0 +1,442 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zoneclone_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/authenticatedoriginpullapiv1"
+	"github.com/IBM/networking-go-sdk/cachingapiv1"
+	"github.com/IBM/networking-go-sdk/custompagesv1"
+	"github.com/IBM/networking-go-sdk/pageruleapiv1"
+	"github.com/IBM/networking-go-sdk/rulesetsv1"
+	"github.com/IBM/networking-go-sdk/useragentblockingrulesv1"
+	"github.com/IBM/networking-go-sdk/zoneclone"
+	"github.com/IBM/networking-go-sdk/zonelockdownv1"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type object = map[string]interface{}
+
+// fakeZone keeps the configuration of a zone. The settings are kept by the last segment of their path.
+type fakeZone struct {
+	settings    map[string]interface{}
+	customPages []object
+	originPull  bool
+	certificate string
+	rulesets    []object
+	entrypoints map[string]object
+	lists       map[string][]object
+	created     int
+}
+
+func newFakeZone() *fakeZone {
+	zone := &fakeZone{settings: map[string]interface{}{}, entrypoints: map[string]object{}, lists: map[string][]object{}}
+	zone.settings["dnssec"] = "disabled"
+	zone.settings["retention"] = true
+	zone.settings["challenge_ttl"] = 1800
+	zone.settings["max_upload"] = 100
+	zone.settings["proxy_read_timeout"] = "100"
+	zone.settings["ciphers"] = []string{}
+	zone.settings["minify"] = object{"css": "off", "html": "off", "js": "off"}
+	zone.settings["mobile_redirect"] = object{"status": "off", "mobile_subdomain": "m", "strip_uri": false}
+	zone.settings["security_header"] = object{"strict_transport_security": object{
+		"enabled": false, "max_age": 0, "include_subdomains": false, "preload": false, "nosniff": false,
+	}}
+	zone.settings["browser_cache_ttl"] = 14400
+	zone.settings["cache_level"] = "aggressive"
+	zone.settings["always_online"] = "on"
+	zone.settings["sort_query_string_for_cache"] = "off"
+	zone.customPages = []object{
+		{"id": "basic_challenge", "state": "default", "url": ""},
+		{"id": "waf_block", "state": "default", "url": ""},
+	}
+	return zone
+}
+
+// fakeCIS serves the zones by their ID. The writes are logged as "zone METHOD path".
+type fakeCIS struct {
+	sync.Mutex
+	zones  map[string]*fakeZone
+	writes []string
+	fail   string
+}
+
+func (api *fakeCIS) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/v1/crn/zones/"), "/", 2)
+	zone := api.zones[parts[0]]
+	resource := parts[1]
+	var body object
+	if req.Method != "GET" {
+		api.writes = append(api.writes, fmt.Sprintf("%s %s %s", parts[0], req.Method, resource))
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+	}
+	res.Header().Set("Content-type", "application/json")
+	if api.fail != "" && req.Method != "GET" && api.fail == parts[0]+" "+resource {
+		res.WriteHeader(500)
+		fmt.Fprint(res, `{"success": false, "errors": [{"code": 1000, "message": "internal error"}], "messages": []}`)
+		return
+	}
+	reply := func(result interface{}) {
+		json.NewEncoder(res).Encode(object{"success": true, "errors": []string{}, "messages": []string{}, "result": result})
+	}
+
+	switch {
+	case strings.HasPrefix(resource, "settings/") || resource == "cache/origin_post_quantum_encryption" || resource == "dnssec" || resource == "logs/retention":
+		name := resource[strings.LastIndex(resource, "/")+1:]
+		for key, value := range body {
+			if name == "proxy_read_timeout" {
+				value = fmt.Sprint(value)
+			}
+			if key == "value" || key == "status" || key == "flag" {
+				zone.settings[name] = value
+			}
+		}
+		value, ok := zone.settings[name]
+		if !ok {
+			value = "off"
+		}
+		switch name {
+		case "dnssec":
+			reply(object{"status": value})
+		case "retention":
+			reply(object{"flag": value})
+		default:
+			reply(object{"id": name, "value": value, "editable": true, "modified_on": "2024-01-01T00:00:00Z"})
+		}
+	case resource == "custom_pages":
+		json.NewEncoder(res).Encode(object{"success": true, "errors": []string{}, "messages": []string{}, "result": zone.customPages,
+			"result_info": object{"page": 1, "per_page": 100, "count": len(zone.customPages), "total_count": len(zone.customPages)}})
+	case strings.HasPrefix(resource, "custom_pages/"):
+		for _, page := range zone.customPages {
+			if page["id"] == strings.TrimPrefix(resource, "custom_pages/") {
+				page["state"], page["url"] = body["state"], body["url"]
+				reply(page)
+			}
+		}
+	case resource == "origin_tls_client_auth/settings":
+		if req.Method == "PUT" {
+			zone.originPull = body["enabled"].(bool)
+		}
+		reply(object{"enabled": zone.originPull})
+	case resource == "origin_tls_client_auth":
+		certificates := []object{}
+		if zone.certificate != "" {
+			certificates = append(certificates, object{"id": zone.certificate, "status": "active"})
+		}
+		reply(certificates)
+	case resource == "rulesets":
+		reply(zone.rulesets)
+	case strings.HasPrefix(resource, "rulesets/phases/"):
+		phase := strings.Split(resource, "/")[2]
+		if req.Method == "PUT" {
+			rules := body["rules"].([]interface{})
+			for i, rule := range rules {
+				rule.(object)["id"] = fmt.Sprintf("%s-rule-%d", parts[0], i)
+			}
+			body["id"] = parts[0] + "-" + phase
+			zone.entrypoints[phase] = body
+		}
+		entrypoint, ok := zone.entrypoints[phase]
+		if !ok {
+			res.WriteHeader(404)
+			fmt.Fprint(res, `{"success": false, "errors": [{"code": 10003, "message": "not found"}], "messages": []}`)
+			return
+		}
+		reply(entrypoint)
+	default:
+		// The rate limits, user agent rules, lockdowns and page rules.
+		kind, id, _ := strings.Cut(resource, "/")
+		if kind == "firewall" {
+			kind, id, _ = strings.Cut(id, "/")
+		}
+		switch req.Method {
+		case "POST":
+			zone.created++
+			body["id"] = fmt.Sprintf("%s-%s-%d", parts[0], kind, zone.created)
+			zone.lists[kind] = append(zone.lists[kind], body)
+			reply(body)
+		case "PUT":
+			for i, existing := range zone.lists[kind] {
+				if existing["id"] == id {
+					body["id"] = id
+					zone.lists[kind][i] = body
+				}
+			}
+			reply(body)
+		default:
+			list := zone.lists[kind]
+			if list == nil {
+				list = []object{}
+			}
+			if kind == "pagerules" {
+				reply(list)
+				return
+			}
+			json.NewEncoder(res).Encode(object{"success": true, "errors": []string{}, "messages": []string{}, "result": list,
+				"result_info": object{"page": 1, "per_page": 100, "count": len(list), "total_count": len(list)}})
+		}
+	}
+}
+
+// newZone returns the clients of a zone of the fake.
+func newZone(url string, name string, id string) *zoneclone.Zone {
+	crn, authenticator := core.StringPtr("crn"), &core.NoAuthAuthenticator{}
+	zone := &zoneclone.Zone{Name: name}
+	var err error
+	zone.Settings, err = zonessettingsv1.NewZonesSettingsV1(&zonessettingsv1.ZonesSettingsV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Caching, err = cachingapiv1.NewCachingApiV1(&cachingapiv1.CachingApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneID: &id})
+	Expect(err).To(BeNil())
+	zone.PageRules, err = pageruleapiv1.NewPageRuleApiV1(&pageruleapiv1.PageRuleApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneID: &id})
+	Expect(err).To(BeNil())
+	zone.CustomPages, err = custompagesv1.NewCustomPagesV1(&custompagesv1.CustomPagesV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Rulesets, err = rulesetsv1.NewRulesetsV1(&rulesetsv1.RulesetsV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.RateLimits, err = zoneratelimitsv1.NewZoneRateLimitsV1(&zoneratelimitsv1.ZoneRateLimitsV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.UserAgentRules, err = useragentblockingrulesv1.NewUserAgentBlockingRulesV1(&useragentblockingrulesv1.UserAgentBlockingRulesV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.Lockdowns, err = zonelockdownv1.NewZoneLockdownV1(&zonelockdownv1.ZoneLockdownV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	zone.OriginPull, err = authenticatedoriginpullapiv1.NewAuthenticatedOriginPullApiV1(&authenticatedoriginpullapiv1.AuthenticatedOriginPullApiV1Options{URL: url, Authenticator: authenticator, Crn: crn, ZoneIdentifier: &id})
+	Expect(err).To(BeNil())
+	return zone
+}
+
+func names(items []zoneclone.Item) (result []string) {
+	for _, item := range items {
+		result = append(result, item.Resource+" "+item.Name)
+	}
+	return
+}
+
+func rateLimit(description string, url string) object {
+	return object{
+		"disabled": false, "description": description, "bypass": []object{}, "threshold": 100, "period": 60,
+		"action": object{"mode": "simulate"},
+		"match":  object{"request": object{"url": url, "schemes": []string{"HTTPS"}, "methods": []string{"GET"}}},
+	}
+}
+
+var _ = Describe(`CloneZoneConfig`, func() {
+	var (
+		api      *fakeCIS
+		server   *httptest.Server
+		src, dst *fakeZone
+		source   *zoneclone.Zone
+		target   *zoneclone.Zone
+	)
+
+	BeforeEach(func() {
+		src, dst = newFakeZone(), newFakeZone()
+		api = &fakeCIS{zones: map[string]*fakeZone{"src": src, "dst": dst}}
+		server = httptest.NewServer(api)
+		source = newZone(server.URL, "example.com", "src")
+		target = newZone(server.URL, "example.org", "dst")
+
+		src.settings["min_tls_version"] = "1.2"
+		src.settings["dnssec"] = "active"
+		src.settings["browser_cache_ttl"] = 7200
+		src.customPages[0]["state"] = "customized"
+		src.customPages[0]["url"] = "https://www.example.com/challenge.html"
+
+		src.rulesets = []object{
+			{"id": "src-entry", "kind": "zone", "name": "default", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+			{"id": "src-shared", "kind": "custom", "name": "shared", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+			{"id": "src-local", "kind": "custom", "name": "local", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+			{"id": "managed", "kind": "managed", "name": "IBM Managed Ruleset", "phase": "http_request_firewall_managed", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+		}
+		dst.rulesets = []object{
+			{"id": "dst-shared", "kind": "custom", "name": "shared", "phase": "http_request_firewall_custom", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+			{"id": "managed", "kind": "managed", "name": "IBM Managed Ruleset", "phase": "http_request_firewall_managed", "description": "", "version": "1", "last_updated": "2024-01-01T00:00:00Z"},
+		}
+		src.entrypoints["http_request_firewall_custom"] = object{
+			"id": "src-entry", "kind": "zone", "name": "default", "phase": "http_request_firewall_custom", "description": "entry", "version": "3", "last_updated": "2024-01-01T00:00:00Z",
+			"rules": []object{
+				{"id": "r1", "version": "1", "action": "block", "expression": `http.host eq "www.example.com"`, "enabled": true, "last_updated": "2024-01-01T00:00:00Z"},
+				{"id": "r2", "version": "1", "action": "execute", "action_parameters": object{"id": "managed"}, "expression": "true", "enabled": true},
+				{"id": "r3", "version": "1", "action": "execute", "action_parameters": object{"id": "src-shared"}, "expression": "true", "enabled": true},
+				{"id": "r4", "version": "1", "action": "execute", "action_parameters": object{"id": "src-local"}, "expression": "true", "enabled": true},
+			},
+		}
+
+		src.lists["rate_limits"] = []object{rateLimit("login", "*.example.com/login"), rateLimit("api", "example.com/api/*")}
+		src.lists["rate_limits"][0]["id"] = "rl1"
+		src.lists["rate_limits"][1]["id"] = "rl2"
+		dst.lists["rate_limits"] = []object{rateLimit("login", "*.example.org/login")}
+		dst.lists["rate_limits"][0]["id"] = "dst-rl"
+		src.lists["ua_rules"] = []object{{"id": "ua1", "paused": false, "description": "bots", "mode": "block",
+			"configuration": object{"target": "ua", "value": "BadBot/1.0"}}}
+		src.lists["lockdowns"] = []object{{"id": "ld1", "paused": false, "description": "admin", "priority": 1,
+			"urls":           []string{"example.com/admin*", "notexample.com/admin*", "example.com.evil.net/*"},
+			"configurations": []object{{"target": "ip", "value": "198.51.100.4"}}}}
+		src.lists["pagerules"] = []object{
+			{"id": "pr1", "targets": []object{{"target": "url", "constraint": object{"operator": "matches", "value": "*example.com/images/*"}}},
+				"actions": []object{{"id": "cache_level", "value": "cache_everything"}}, "priority": 1, "status": "active",
+				"created_on": "2024-01-01T00:00:00Z", "modified_on": "2024-01-01T00:00:00Z"},
+			{"id": "pr2", "targets": []object{{"target": "url", "constraint": object{"operator": "matches", "value": "http://example.com/*"}}},
+				"actions": []object{{"id": "always_use_https"}}, "priority": 2, "status": "active",
+				"created_on": "2024-01-01T00:00:00Z", "modified_on": "2024-01-01T00:00:00Z"},
+		}
+		dst.lists["pagerules"] = []object{
+			{"id": "dst-pr", "targets": []object{{"target": "url", "constraint": object{"operator": "matches", "value": "*example.org/images/*"}}},
+				"actions": []object{{"id": "cache_level", "value": "bypass"}}, "priority": 1, "status": "active",
+				"created_on": "2024-01-01T00:00:00Z", "modified_on": "2024-01-01T00:00:00Z"},
+		}
+		src.originPull = true
+		src.certificate = "cert"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Copies the configuration of a zone to another`, func() {
+		report, err := zoneclone.CloneZoneConfig(source, target)
+		Expect(err).To(BeNil())
+		Expect(report.Failed).To(BeEmpty())
+
+		Expect(dst.settings["min_tls_version"]).To(Equal("1.2"))
+		Expect(dst.settings["dnssec"]).To(Equal("disabled"))
+		Expect(dst.settings["browser_cache_ttl"]).To(BeNumerically("==", 7200))
+		Expect(dst.customPages[0]).To(Equal(object{"id": "basic_challenge", "state": "customized", "url": "https://www.example.org/challenge.html"}))
+		Expect(dst.originPull).To(BeTrue())
+
+		rules := dst.entrypoints["http_request_firewall_custom"]["rules"].([]interface{})
+		Expect(rules).To(HaveLen(3))
+		Expect(rules[0].(object)["expression"]).To(Equal(`http.host eq "www.example.org"`))
+		Expect(rules[1].(object)["action_parameters"]).To(Equal(object{"id": "managed"}))
+		Expect(rules[2].(object)["action_parameters"]).To(Equal(object{"id": "dst-shared"}))
+		Expect(dst.entrypoints["http_request_firewall_custom"]["description"]).To(Equal("entry"))
+
+		Expect(dst.lists["rate_limits"]).To(HaveLen(2))
+		Expect(dst.lists["rate_limits"][1]["match"].(object)["request"].(object)["url"]).To(Equal("example.org/api/*"))
+		Expect(dst.lists["ua_rules"]).To(HaveLen(1))
+		Expect(dst.lists["lockdowns"][0]["urls"]).To(Equal([]interface{}{"example.org/admin*", "notexample.com/admin*", "example.com.evil.net/*"}))
+		Expect(dst.lists["pagerules"]).To(HaveLen(2))
+		Expect(dst.lists["pagerules"][0]["actions"]).To(Equal([]interface{}{object{"id": "cache_level", "value": "cache_everything"}}))
+		Expect(dst.lists["pagerules"][1]["targets"].([]interface{})[0].(object)["constraint"]).To(Equal(object{"operator": "matches", "value": "http://example.org/*"}))
+
+		Expect(names(report.Skipped)).To(ConsistOf(
+			"settings dnssec",
+			"caching development_mode",
+			"rulesets http_request_firewall_custom/r4",
+			"origin_pull cert",
+		))
+		Expect(report.Skipped[2].Reason).To(ContainSubstring(`no custom ruleset named "local"`))
+		Expect(names(report.Copied)).To(ContainElements(
+			"settings min_tls_version",
+			"caching browser_cache_ttl",
+			"custom_pages basic_challenge",
+			"rulesets http_request_firewall_custom",
+			"rate_limits rl2",
+			"user_agent_rules ua1",
+			"lockdowns ld1",
+			"page_rules pr1",
+			"page_rules pr2",
+			"origin_pull enabled",
+		))
+		Expect(names(report.Unchanged)).To(ContainElements("caching cache_level", "rate_limits rl1", "settings waf"))
+		Expect(report.IDs[zoneclone.Selector_RateLimits]).To(Equal(map[string]string{"rl1": "dst-rl", "rl2": "dst-rate_limits-1"}))
+		Expect(report.IDs[zoneclone.Selector_PageRules]).To(Equal(map[string]string{"pr1": "dst-pr", "pr2": "dst-pagerules-4"}))
+		Expect(report.IDs[zoneclone.Selector_Rulesets]).To(Equal(map[string]string{"src-shared": "dst-shared"}))
+	})
+
+	It(`Writes nothing the second time`, func() {
+		_, err := zoneclone.CloneZoneConfig(source, target)
+		Expect(err).To(BeNil())
+		api.writes = nil
+
+		report, err := zoneclone.CloneZoneConfig(source, target)
+		Expect(err).To(BeNil())
+		Expect(api.writes).To(BeEmpty())
+		Expect(report.Copied).To(BeEmpty())
+		Expect(names(report.Unchanged)).To(ContainElements(
+			"rulesets http_request_firewall_custom",
+			"rate_limits rl1",
+			"rate_limits rl2",
+			"page_rules pr1",
+			"page_rules pr2",
+			"custom_pages basic_challenge",
+			"origin_pull enabled",
+		))
+	})
+
+	It(`Updates the objects that differ instead of adding copies`, func() {
+		dst.lists["rate_limits"] = append(dst.lists["rate_limits"], rateLimit("api", "example.org/api/*"))
+		dst.lists["rate_limits"][1]["id"] = "dst-api"
+		dst.lists["rate_limits"][1]["threshold"] = 10
+		dst.lists["lockdowns"] = []object{
+			{"id": "dst-ld1", "paused": false, "description": "admin", "priority": 1, "urls": []string{"example.org/admin*"}, "configurations": []object{}},
+			{"id": "dst-ld2", "paused": true, "description": "admin", "priority": 2, "urls": []string{"example.org/wp-admin*"}, "configurations": []object{}},
+		}
+		report, err := zoneclone.CloneZoneConfig(source, target, zoneclone.Selector_RateLimits, zoneclone.Selector_Lockdowns)
+		Expect(err).To(BeNil())
+		Expect(api.writes).To(Equal([]string{"dst PUT rate_limits/dst-api"}))
+		Expect(dst.lists["rate_limits"]).To(HaveLen(2))
+		Expect(dst.lists["rate_limits"][1]["threshold"]).To(BeNumerically("==", 100))
+		Expect(report.Copied).To(Equal([]zoneclone.Item{{Resource: zoneclone.Selector_RateLimits, Name: "rl2", Reason: "updated dst-api"}}))
+		Expect(report.Skipped).To(Equal([]zoneclone.Item{{Resource: zoneclone.Selector_Lockdowns, Name: "ld1", Reason: `2 objects of the destination zone have description="admin"`}}))
+		Expect(report.IDs[zoneclone.Selector_RateLimits]).To(Equal(map[string]string{"rl1": "dst-rl", "rl2": "dst-api"}))
+	})
+
+	It(`Clones the selected resources only`, func() {
+		report, err := zoneclone.CloneZoneConfig(source, target, zoneclone.Selector_Lockdowns, zoneclone.Selector_UserAgentRules)
+		Expect(err).To(BeNil())
+		Expect(names(report.Copied)).To(Equal([]string{"user_agent_rules ua1", "lockdowns ld1"}))
+		Expect(api.writes).To(Equal([]string{"dst POST firewall/ua_rules", "dst POST firewall/lockdowns"}))
+	})
+
+	It(`Rejects unknown selectors and missing clients`, func() {
+		_, err := zoneclone.CloneZoneConfig(source, target, "dns_records")
+		Expect(err).To(MatchError(`unknown selector "dns_records"`))
+
+		target.Lockdowns = nil
+		_, err = zoneclone.CloneZoneConfig(source, target, zoneclone.Selector_Lockdowns)
+		Expect(err).To(MatchError("both zones need a client to clone lockdowns"))
+
+		report, err := zoneclone.CloneZoneConfig(source, target)
+		Expect(err).To(BeNil())
+		Expect(dst.lists["lockdowns"]).To(BeEmpty())
+		Expect(names(report.Copied)).ToNot(ContainElement("lockdowns ld1"))
+	})
+
+	It(`Goes on after a failure`, func() {
+		api.fail = "dst rate_limits"
+		report, err := zoneclone.CloneZoneConfig(source, target)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("1 zone configuration items could not be cloned, the first: rate_limits rl2: "))
+		Expect(names(report.Failed)).To(Equal([]string{"rate_limits rl2"}))
+		Expect(dst.lists["lockdowns"]).To(HaveLen(1))
+		Expect(dst.lists["pagerules"]).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,489 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zoneratelimitsv1
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// LoggedRequest : A request of a request log.
+type LoggedRequest struct {
+	Time time.Time
+
+	// The client IP address.
+	IP string
+
+	Method string
+
+	// The URL, with or without its scheme and host.
+	URL string
+
+	// The response status.
+	Status int64
+
+	// The response headers, by lower case name.
+	Headers map[string]string
+}
+
+// ReadRequestLog reads a request log in the JSONL or the CSV format, telling them apart by their first character.
+//
+// JSONL lines are objects with the timestamp, ip, method, url and status properties and an optional headers object.
+// CSV logs start with a header row naming the timestamp, ip, method, url and status columns; the other columns are
+// response headers. Timestamps are RFC 3339 times or Unix times in seconds.
+func ReadRequestLog(reader io.Reader) (requests []LoggedRequest, err error) {
+	buffered := bufio.NewReader(reader)
+	start, _ := buffered.Peek(64)
+	if bytes.HasPrefix(bytes.TrimSpace(start), []byte("{")) {
+		return readJSONLRequestLog(buffered)
+	}
+	return readCSVRequestLog(buffered)
+}
+
+func readJSONLRequestLog(reader io.Reader) (requests []LoggedRequest, err error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry struct {
+			Timestamp json.RawMessage   `json:"timestamp"`
+			IP        string            `json:"ip"`
+			Method    string            `json:"method"`
+			URL       string            `json:"url"`
+			Status    int64             `json:"status"`
+			Headers   map[string]string `json:"headers"`
+		}
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			err = fmt.Errorf("line %d: %w", line, err)
+			return
+		}
+		request := LoggedRequest{IP: entry.IP, Method: entry.Method, URL: entry.URL, Status: entry.Status, Headers: map[string]string{}}
+		request.Time, err = parseLogTime(strings.Trim(string(entry.Timestamp), `"`))
+		if err != nil {
+			err = fmt.Errorf("line %d: %w", line, err)
+			return
+		}
+		for name, value := range entry.Headers {
+			request.Headers[strings.ToLower(name)] = value
+		}
+		requests = append(requests, request)
+	}
+	err = scanner.Err()
+	return
+}
+
+func readCSVRequestLog(reader io.Reader) (requests []LoggedRequest, err error) {
+	records := csv.NewReader(reader)
+	records.FieldsPerRecord = -1
+	header, err := records.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"timestamp", "ip", "method", "url", "status"} {
+		if _, ok := columns[name]; !ok {
+			err = fmt.Errorf("the request log has no %s column", name)
+			return
+		}
+	}
+	for line := 2; ; line++ {
+		record, readErr := records.Read()
+		if readErr == io.EOF {
+			return
+		}
+		if readErr != nil {
+			err = readErr
+			return
+		}
+		field := func(name string) string {
+			if columns[name] < len(record) {
+				return strings.TrimSpace(record[columns[name]])
+			}
+			return ""
+		}
+		request := LoggedRequest{IP: field("ip"), Method: field("method"), URL: field("url"), Headers: map[string]string{}}
+		request.Time, err = parseLogTime(field("timestamp"))
+		if err == nil && field("status") != "" {
+			request.Status, err = strconv.ParseInt(field("status"), 10, 64)
+		}
+		if err != nil {
+			err = fmt.Errorf("line %d: %w", line, err)
+			return
+		}
+		for name, i := range columns {
+			switch name {
+			case "timestamp", "ip", "method", "url", "status":
+			default:
+				if i < len(record) && record[i] != "" {
+					request.Headers[name] = record[i]
+				}
+			}
+		}
+		requests = append(requests, request)
+	}
+}
+
+func parseLogTime(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return parsed, nil
+}
+
+// RatelimitSimulator : Replays request logs against rate limits.
+type RatelimitSimulator struct {
+	Ratelimits []RatelimitObject
+
+	// Also simulate the disabled rate limits.
+	IncludeDisabled bool
+}
+
+// NewRatelimitSimulator : Instantiate RatelimitSimulator
+func NewRatelimitSimulator(ratelimits ...RatelimitObject) *RatelimitSimulator {
+	return &RatelimitSimulator{Ratelimits: ratelimits}
+}
+
+// AddInput adds a rate limit that is not created yet, identified by the given ID in the simulation.
+func (simulator *RatelimitSimulator) AddInput(id string, createZoneRateLimitsOptions *CreateZoneRateLimitsOptions) (err error) {
+	buffer, err := json.Marshal(createZoneRateLimitsOptions)
+	if err != nil {
+		return
+	}
+	var ratelimit RatelimitObject
+	err = json.Unmarshal(buffer, &ratelimit)
+	if err != nil {
+		return
+	}
+	ratelimit.ID = core.StringPtr(id)
+	simulator.Ratelimits = append(simulator.Ratelimits, ratelimit)
+	return
+}
+
+// RatelimitMitigation : A client limited by a rate limit.
+type RatelimitMitigation struct {
+	RatelimitID string
+
+	IP string
+
+	// The mode of the action of the rate limit; simulate when the rate limit would only have logged.
+	Mode string
+
+	// When the threshold was exceeded.
+	Start time.Time
+
+	// When the timeout of a ban or simulate action ran out, or, for challenges, the last challenged request.
+	End time.Time
+
+	// The number of requests mitigated.
+	Requests int64
+}
+
+// Duration returns how long the client was limited.
+func (mitigation *RatelimitMitigation) Duration() time.Duration {
+	return mitigation.End.Sub(mitigation.Start)
+}
+
+// RatelimitSimulation : The outcome of replaying a request log.
+type RatelimitSimulation struct {
+	// The number of requests replayed.
+	Requests int
+
+	// The number of requests counted by each rate limit, by ID.
+	Counted map[string]int64
+
+	// The mitigations, by start time.
+	Mitigations []RatelimitMitigation
+}
+
+// Clients returns the addresses of the clients limited by a rate limit, sorted.
+func (simulation *RatelimitSimulation) Clients(ratelimitID string) (clients []string) {
+	seen := map[string]bool{}
+	for _, mitigation := range simulation.Mitigations {
+		if mitigation.RatelimitID == ratelimitID && !seen[mitigation.IP] {
+			seen[mitigation.IP] = true
+			clients = append(clients, mitigation.IP)
+		}
+	}
+	sort.Strings(clients)
+	return
+}
+
+func (simulation *RatelimitSimulation) String() string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "%d requests, %d mitigations\n", simulation.Requests, len(simulation.Mitigations))
+	for _, mitigation := range simulation.Mitigations {
+		fmt.Fprintf(&summary, "  %s %s %s from %s for %s (%d requests)\n", mitigation.RatelimitID, mitigation.Mode, mitigation.IP,
+			mitigation.Start.Format(time.RFC3339), mitigation.Duration(), mitigation.Requests)
+	}
+	return summary.String()
+}
+
+// Simulate replays requests against the rate limits, in time order. Each rate limit counts the requests of each
+// client IP address that match it and are not bypassed, over a sliding window of its period; NAT correlation cannot
+// be told from a request log and counts by address too. The request that takes the count above the threshold starts
+// a mitigation and is mitigated. A ban or simulate action mitigates the requests that match the rate limit until its
+// timeout runs out, and the count starts over. A challenge mitigates each request that keeps the count above the
+// threshold.
+func (simulator *RatelimitSimulator) Simulate(requests []LoggedRequest) (simulation *RatelimitSimulation, err error) {
+	sorted := append([]LoggedRequest{}, requests...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+	simulation = &RatelimitSimulation{Requests: len(sorted), Counted: map[string]int64{}}
+	for i := range simulator.Ratelimits {
+		ratelimit := &simulator.Ratelimits[i]
+		if ratelimit.Disabled != nil && *ratelimit.Disabled && !simulator.IncludeDisabled {
+			continue
+		}
+		var state *ratelimitState
+		state, err = newRatelimitState(ratelimit)
+		if err != nil {
+			return nil, err
+		}
+		for j := range sorted {
+			state.replay(&sorted[j], simulation)
+		}
+	}
+	sort.SliceStable(simulation.Mitigations, func(i, j int) bool {
+		return simulation.Mitigations[i].Start.Before(simulation.Mitigations[j].Start)
+	})
+	return
+}
+
+// ratelimitState : The counters of a rate limit during a simulation.
+type ratelimitState struct {
+	ratelimit *RatelimitObject
+	id        string
+	url       *urlMatcher
+	bypass    []*urlMatcher
+	period    time.Duration
+	timeout   time.Duration
+	mode      string
+
+	// The times of the counted requests of each client, within the period.
+	counted map[string][]time.Time
+
+	// The index of the ongoing mitigation of each client in the simulation.
+	mitigations map[string]int
+}
+
+func newRatelimitState(ratelimit *RatelimitObject) (state *ratelimitState, err error) {
+	state = &ratelimitState{
+		ratelimit:   ratelimit,
+		id:          core.StringNilMapper(ratelimit.ID),
+		counted:     map[string][]time.Time{},
+		mitigations: map[string]int{},
+	}
+	if ratelimit.Threshold == nil || ratelimit.Period == nil || ratelimit.Action == nil || ratelimit.Action.Mode == nil {
+		err = fmt.Errorf("rate limit %s needs a threshold, a period and an action", state.id)
+		return
+	}
+	state.period = time.Duration(*ratelimit.Period) * time.Second
+	state.mode = *ratelimit.Action.Mode
+	if ratelimit.Action.Timeout != nil {
+		state.timeout = time.Duration(*ratelimit.Action.Timeout) * time.Second
+	}
+	if ratelimit.Match != nil && ratelimit.Match.Request != nil && ratelimit.Match.Request.URL != nil {
+		state.url = urlPattern(*ratelimit.Match.Request.URL)
+	}
+	for _, bypass := range ratelimit.Bypass {
+		if core.StringNilMapper(bypass.Name) == RatelimitObjectBypassItem_Name_URL {
+			state.bypass = append(state.bypass, urlPattern(core.StringNilMapper(bypass.Value)))
+		}
+	}
+	return
+}
+
+// urlMatcher : A rate limit URL pattern, compiled to match URLs with and without their host.
+type urlMatcher struct {
+	full *regexp.Regexp
+	path *regexp.Regexp
+}
+
+// urlPattern compiles a rate limit URL pattern, where * matches any characters. The scheme and the case of the host
+// are ignored.
+func urlPattern(pattern string) *urlMatcher {
+	if i := strings.Index(pattern, "://"); i >= 0 {
+		pattern = pattern[i+3:]
+	}
+	if pattern == "" || pattern == "*" {
+		return &urlMatcher{full: regexp.MustCompile(`^.*$`), path: regexp.MustCompile(`^.*$`)}
+	}
+	host, path, _ := strings.Cut(pattern, "/")
+	pathExpression := strings.ReplaceAll(regexp.QuoteMeta("/"+path), `\*`, `.*`)
+	if !strings.Contains(path, "?") {
+		pathExpression += `(\?.*)?`
+	}
+	hostExpression := strings.ReplaceAll(regexp.QuoteMeta(strings.ToLower(host)), `\*`, `.*`)
+	return &urlMatcher{
+		full: regexp.MustCompile(`^` + hostExpression + pathExpression + `$`),
+		path: regexp.MustCompile(`^` + pathExpression + `$`),
+	}
+}
+
+// matchURL matches the URL of a request, with its host when it has one and by path only otherwise.
+func matchURL(pattern *urlMatcher, requestURL string) bool {
+	parsed, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	if parsed.Host == "" && !strings.HasPrefix(requestURL, "/") {
+		parsed, err = url.Parse("//" + requestURL)
+		if err != nil {
+			return false
+		}
+	}
+	subject := parsed.EscapedPath()
+	if subject == "" {
+		subject = "/"
+	}
+	if parsed.RawQuery != "" {
+		subject += "?" + parsed.RawQuery
+	}
+	if parsed.Host == "" {
+		return pattern.path.MatchString(subject)
+	}
+	return pattern.full.MatchString(strings.ToLower(parsed.Host) + subject)
+}
+
+func listed(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, candidate := range values {
+		if candidate == "_ALL_" || strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRequest matches the request criteria of the rate limit: methods, schemes and URL.
+func (state *ratelimitState) matchesRequest(request *LoggedRequest) bool {
+	match := state.ratelimit.Match
+	if match == nil || match.Request == nil {
+		return true
+	}
+	if !listed(match.Request.Methods, request.Method) {
+		return false
+	}
+	if scheme, _, found := strings.Cut(request.URL, "://"); found && !listed(match.Request.Schemes, scheme) {
+		return false
+	}
+	return state.url == nil || matchURL(state.url, request.URL)
+}
+
+// matchesResponse matches the response criteria of the rate limit: statuses and headers.
+func (state *ratelimitState) matchesResponse(request *LoggedRequest) bool {
+	match := state.ratelimit.Match
+	if match == nil || match.Response == nil {
+		return true
+	}
+	if len(match.Response.Status) > 0 {
+		found := false
+		for _, status := range match.Response.Status {
+			found = found || status == request.Status
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, header := range match.Response.HeadersVar {
+		value, ok := request.Headers[strings.ToLower(core.StringNilMapper(header.Name))]
+		equal := ok && strings.EqualFold(value, core.StringNilMapper(header.Value))
+		if equal != (core.StringNilMapper(header.Op) == RatelimitObjectMatchResponseHeadersItem_Op_Eq) {
+			return false
+		}
+	}
+	return true
+}
+
+func (state *ratelimitState) replay(request *LoggedRequest, simulation *RatelimitSimulation) {
+	if !state.matchesRequest(request) {
+		return
+	}
+	for _, bypass := range state.bypass {
+		if matchURL(bypass, request.URL) {
+			return
+		}
+	}
+
+	index, limited := state.mitigations[request.IP]
+	timed := state.mode == RatelimitObjectAction_Mode_Ban || state.mode == RatelimitObjectAction_Mode_Simulate
+	if limited && timed {
+		if mitigation := &simulation.Mitigations[index]; request.Time.Before(mitigation.End) {
+			mitigation.Requests++
+			return
+		}
+		delete(state.mitigations, request.IP)
+		limited = false
+	}
+
+	if !state.matchesResponse(request) {
+		return
+	}
+	simulation.Counted[state.id]++
+	window := state.counted[request.IP]
+	for len(window) > 0 && !window[0].After(request.Time.Add(-state.period)) {
+		window = window[1:]
+	}
+	window = append(window, request.Time)
+	state.counted[request.IP] = window
+	if int64(len(window)) <= *state.ratelimit.Threshold {
+		delete(state.mitigations, request.IP)
+		return
+	}
+
+	if limited {
+		mitigation := &simulation.Mitigations[index]
+		mitigation.End = request.Time
+		mitigation.Requests++
+		return
+	}
+	mitigation := RatelimitMitigation{RatelimitID: state.id, IP: request.IP, Mode: state.mode, Start: request.Time, End: request.Time, Requests: 1}
+	if timed {
+		mitigation.End = request.Time.Add(state.timeout)
+		state.counted[request.IP] = nil
+	}
+	state.mitigations[request.IP] = len(simulation.Mitigations)
+	simulation.Mitigations = append(simulation.Mitigations, mitigation)
+}
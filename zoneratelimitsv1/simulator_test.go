@@ -0,0 +1,126 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zoneratelimitsv1_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/zoneratelimitsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`RatelimitSimulator`, func() {
+	start := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+	login := zoneratelimitsv1.RatelimitObject{
+		ID:        core.StringPtr("login"),
+		Disabled:  core.BoolPtr(false),
+		Threshold: core.Int64Ptr(3),
+		Period:    core.Int64Ptr(60),
+		Action:    &zoneratelimitsv1.RatelimitObjectAction{Mode: core.StringPtr("ban"), Timeout: core.Int64Ptr(120)},
+		Match: &zoneratelimitsv1.RatelimitObjectMatch{Request: &zoneratelimitsv1.RatelimitObjectMatchRequest{
+			Methods: []string{"POST"},
+			Schemes: []string{"_ALL_"},
+			URL:     core.StringPtr("*.example.com/login*"),
+		}},
+	}
+
+	It(`Ban clients over the threshold for the timeout`, func() {
+		log := "timestamp,ip,method,url,status\n"
+		for _, second := range []int{0, 10, 20, 30, 40, 200} {
+			log += start.Add(time.Duration(second)*time.Second).Format(time.RFC3339) + ",198.51.100.1,POST,https://www.example.com/login?next=/,200\n"
+		}
+		for _, second := range []int{0, 10, 20} {
+			log += start.Add(time.Duration(second)*time.Second).Format(time.RFC3339) + ",198.51.100.2,POST,https://www.example.com/login,200\n"
+			log += start.Add(time.Duration(second)*time.Second).Format(time.RFC3339) + ",198.51.100.2,GET,https://www.example.com/login,200\n"
+			log += start.Add(time.Duration(second)*time.Second).Format(time.RFC3339) + ",198.51.100.2,POST,https://example.org/login,200\n"
+		}
+		requests, err := zoneratelimitsv1.ReadRequestLog(strings.NewReader(log))
+		Expect(err).To(BeNil())
+		Expect(requests).To(HaveLen(15))
+
+		simulation, err := zoneratelimitsv1.NewRatelimitSimulator(login).Simulate(requests)
+		Expect(err).To(BeNil())
+		Expect(simulation.Requests).To(Equal(15))
+		Expect(simulation.Counted["login"]).To(Equal(int64(8)))
+		Expect(simulation.Mitigations).To(Equal([]zoneratelimitsv1.RatelimitMitigation{{
+			RatelimitID: "login",
+			IP:          "198.51.100.1",
+			Mode:        "ban",
+			Start:       start.Add(30 * time.Second),
+			End:         start.Add(150 * time.Second),
+			Requests:    2,
+		}}))
+		Expect(simulation.Mitigations[0].Duration()).To(Equal(2 * time.Minute))
+		Expect(simulation.Clients("login")).To(Equal([]string{"198.51.100.1"}))
+		Expect(simulation.String()).To(Equal("15 requests, 1 mitigations\n  login ban 198.51.100.1 from 2020-12-01T10:00:30Z for 2m0s (2 requests)\n"))
+	})
+	It(`Challenge clients while their responses keep the count over the threshold`, func() {
+		requests, err := zoneratelimitsv1.ReadRequestLog(strings.NewReader(`
+{"timestamp": 1606816800, "ip": "203.0.113.9", "method": "GET", "url": "/missing/1", "status": 404, "headers": {"CF-Cache-Status": "MISS"}}
+{"timestamp": 1606816801, "ip": "203.0.113.9", "method": "GET", "url": "/missing/2", "status": 404}
+{"timestamp": 1606816801.5, "ip": "203.0.113.9", "method": "GET", "url": "/health", "status": 404}
+{"timestamp": "2020-12-01T10:00:02Z", "ip": "203.0.113.9", "method": "GET", "url": "/missing/3", "status": 404}
+{"timestamp": "2020-12-01T10:00:02Z", "ip": "203.0.113.9", "method": "GET", "url": "/cached", "status": 404, "headers": {"cf-cache-status": "HIT"}}
+{"timestamp": "2020-12-01T10:00:03Z", "ip": "203.0.113.9", "method": "GET", "url": "/missing/4", "status": 404}
+{"timestamp": "2020-12-01T10:00:04Z", "ip": "203.0.113.9", "method": "GET", "url": "/", "status": 200}
+`))
+		Expect(err).To(BeNil())
+		Expect(requests).To(HaveLen(7))
+		Expect(requests[0].Time).To(Equal(start))
+		Expect(requests[0].Headers).To(Equal(map[string]string{"cf-cache-status": "MISS"}))
+
+		simulator := zoneratelimitsv1.NewRatelimitSimulator()
+		simulator.Ratelimits = append(simulator.Ratelimits, login)
+		simulator.Ratelimits[0].Disabled = core.BoolPtr(true)
+		err = simulator.AddInput("not-found", &zoneratelimitsv1.CreateZoneRateLimitsOptions{
+			Threshold: core.Int64Ptr(2),
+			Period:    core.Int64Ptr(10),
+			Action:    &zoneratelimitsv1.RatelimitInputAction{Mode: core.StringPtr("challenge")},
+			Bypass:    []zoneratelimitsv1.RatelimitInputBypassItem{{Name: core.StringPtr("url"), Value: core.StringPtr("example.com/health")}},
+			Match: &zoneratelimitsv1.RatelimitInputMatch{
+				Request: &zoneratelimitsv1.RatelimitInputMatchRequest{URL: core.StringPtr("*")},
+				Response: &zoneratelimitsv1.RatelimitInputMatchResponse{
+					Status:     []int64{404},
+					HeadersVar: []zoneratelimitsv1.RatelimitInputMatchResponseHeadersItem{{Name: core.StringPtr("Cf-Cache-Status"), Op: core.StringPtr("ne"), Value: core.StringPtr("HIT")}},
+				},
+			},
+		})
+		Expect(err).To(BeNil())
+		simulation, err := simulator.Simulate(requests)
+		Expect(err).To(BeNil())
+		Expect(simulation.Counted).To(Equal(map[string]int64{"not-found": 4}))
+		Expect(simulation.Mitigations).To(Equal([]zoneratelimitsv1.RatelimitMitigation{{
+			RatelimitID: "not-found",
+			IP:          "203.0.113.9",
+			Mode:        "challenge",
+			Start:       start.Add(2 * time.Second),
+			End:         start.Add(3 * time.Second),
+			Requests:    2,
+		}}))
+	})
+	It(`Reject logs and rate limits that cannot be replayed`, func() {
+		_, err := zoneratelimitsv1.ReadRequestLog(strings.NewReader("time,ip,method,url,status\n"))
+		Expect(err).To(MatchError("the request log has no timestamp column"))
+		_, err = zoneratelimitsv1.ReadRequestLog(strings.NewReader("timestamp,ip,method,url,status\nyesterday,198.51.100.1,GET,/,200\n"))
+		Expect(err).To(MatchError(`line 2: invalid timestamp "yesterday"`))
+		_, err = zoneratelimitsv1.NewRatelimitSimulator(zoneratelimitsv1.RatelimitObject{ID: core.StringPtr("empty")}).Simulate(nil)
+		Expect(err).To(MatchError("rate limit empty needs a threshold, a period and an action"))
+	})
+})
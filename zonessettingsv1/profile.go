@@ -0,0 +1,845 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonessettingsv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	common "github.com/IBM/networking-go-sdk/common"
+)
+
+// profileConcurrency is the number of settings read or updated at the same time.
+const profileConcurrency = 8
+
+// ZoneSettingsProfile : The settings of a zone as one document. A nil field stands for a setting that is not part of
+// the profile: it is left out of diffs and never updated.
+type ZoneSettingsProfile struct {
+	// The DNSSEC status: active or disabled.
+	ZoneDnssec *string `json:"dnssec,omitempty"`
+
+	// One of the UpdateZoneCnameFlatteningOptions_Value constants.
+	ZoneCnameFlattening *string `json:"cname_flattening,omitempty"`
+
+	// One of the UpdateOpportunisticEncryptionOptions_Value constants.
+	OpportunisticEncryption *string `json:"opportunistic_encryption,omitempty"`
+
+	// One of the UpdateOpportunisticOnionOptions_Value constants.
+	OpportunisticOnion *string `json:"opportunistic_onion,omitempty"`
+
+	// One of the UpdateAutomaticHttpsRewritesOptions_Value constants.
+	AutomaticHttpsRewrites *string `json:"automatic_https_rewrites,omitempty"`
+
+	// One of the UpdateTrueClientIpOptions_Value constants.
+	TrueClientIp *string `json:"true_client_ip_header,omitempty"`
+
+	// One of the UpdateAlwaysUseHttpsOptions_Value constants.
+	AlwaysUseHttps *string `json:"always_use_https,omitempty"`
+
+	// One of the UpdateImageSizeOptimizationOptions_Value constants.
+	ImageSizeOptimization *string `json:"image_size_optimization,omitempty"`
+
+	// One of the UpdateScriptLoadOptimizationOptions_Value constants.
+	ScriptLoadOptimization *string `json:"script_load_optimization,omitempty"`
+
+	// One of the UpdateImageLoadOptimizationOptions_Value constants.
+	ImageLoadOptimization *string `json:"image_load_optimization,omitempty"`
+
+	// The minimum TLS version: 1.0, 1.1, 1.2 or 1.3.
+	MinTlsVersion *string `json:"min_tls_version,omitempty"`
+
+	// One of the UpdateIpGeolocationOptions_Value constants.
+	IpGeolocation *string `json:"ip_geolocation,omitempty"`
+
+	// One of the UpdateServerSideExcludeOptions_Value constants.
+	ServerSideExclude *string `json:"server_side_exclude,omitempty"`
+
+	// One of the UpdatePrefetchPreloadOptions_Value constants.
+	PrefetchPreload *string `json:"prefetch_preload,omitempty"`
+
+	// One of the UpdateHttp2Options_Value constants.
+	Http2 *string `json:"http2,omitempty"`
+
+	// One of the UpdateHttp3Options_Value constants.
+	Http3 *string `json:"http3,omitempty"`
+
+	// One of the UpdateIpv6Options_Value constants.
+	Ipv6 *string `json:"ipv6,omitempty"`
+
+	// One of the UpdatePseudoIpv4Options_Value constants.
+	PseudoIpv4 *string `json:"pseudo_ipv4,omitempty"`
+
+	// One of the UpdateWebSocketsOptions_Value constants.
+	WebSockets *string `json:"websockets,omitempty"`
+
+	// One of the UpdateResponseBufferingOptions_Value constants.
+	ResponseBuffering *string `json:"response_buffering,omitempty"`
+
+	// One of the UpdateHotlinkProtectionOptions_Value constants.
+	HotlinkProtection *string `json:"hotlink_protection,omitempty"`
+
+	// One of the UpdateTlsClientAuthOptions_Value constants.
+	TlsClientAuth *string `json:"tls_client_auth,omitempty"`
+
+	// One of the UpdateBrotliOptions_Value constants.
+	Brotli *string `json:"brotli,omitempty"`
+
+	// One of the UpdateBrowserCheckOptions_Value constants.
+	BrowserCheck *string `json:"browser_check,omitempty"`
+
+	// One of the UpdateEnableErrorPagesOnOptions_Value constants.
+	EnableErrorPagesOn *string `json:"origin_error_page_pass_thru,omitempty"`
+
+	// One of the UpdateWebApplicationFirewallOptions_Value constants.
+	WebApplicationFirewall *string `json:"waf,omitempty"`
+
+	// The highest HTTP version used with the origin: 1 or 2.
+	OriginMaxHttpVersion *string `json:"origin_max_http_version,omitempty"`
+
+	// One of the UpdateOriginPostQuantumEncryptionOptions_Value constants.
+	OriginPostQuantumEncryption *string `json:"origin_post_quantum_encryption,omitempty"`
+
+	// The challenge TTL in seconds.
+	ChallengeTTL *int64 `json:"challenge_ttl,omitempty"`
+
+	// The maximum upload size in MB.
+	MaxUpload *int64 `json:"max_upload,omitempty"`
+
+	// The proxy read timeout in seconds.
+	ProxyReadTimeout *float64 `json:"proxy_read_timeout,omitempty"`
+
+	// The enabled ciphers, from the UpdateCiphersOptions_Value constants. An empty list stands for the default ciphers,
+	// which is why the field is written even when nil.
+	Ciphers []string `json:"ciphers"`
+
+	Minify *MinifySettingValue `json:"minify,omitempty"`
+
+	MobileRedirect *MobileRedirecSettingValue `json:"mobile_redirect,omitempty"`
+
+	SecurityHeader *SecurityHeaderSettingValue `json:"security_header,omitempty"`
+
+	// Whether the logs of the zone are retained.
+	LogRetention *bool `json:"log_retention,omitempty"`
+}
+
+// ZoneSettingChange : A zone setting that differs from a profile.
+type ZoneSettingChange struct {
+	// The name of the setting, as in the JSON form of the profile.
+	Setting string `json:"setting"`
+
+	// The current value. Nil when it could not be read.
+	From interface{} `json:"from"`
+
+	// The value of the profile.
+	To interface{} `json:"to"`
+
+	// The error of the update, when it failed.
+	Err error `json:"-"`
+}
+
+func (change ZoneSettingChange) String() string {
+	from, _ := json.Marshal(change.From)
+	to, _ := json.Marshal(change.To)
+	return fmt.Sprintf("%s: %s -> %s", change.Setting, from, to)
+}
+
+// profileSetting reads and updates one setting of a profile.
+type profileSetting struct {
+	// The JSON name of the profile field.
+	name string
+
+	// Set the field of the profile to the current value of the setting.
+	get func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error
+
+	// Set the setting to the value of the field of the profile.
+	update func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error
+}
+
+// profileFields maps the JSON names of the profile fields to their index.
+var profileFields = func() map[string]int {
+	fields := map[string]int{}
+	profileType := reflect.TypeOf(ZoneSettingsProfile{})
+	for i := 0; i < profileType.NumField(); i++ {
+		name, _, _ := strings.Cut(profileType.Field(i).Tag.Get("json"), ",")
+		fields[name] = i
+	}
+	return fields
+}()
+
+// field returns the field of a profile holding a setting.
+func (setting *profileSetting) field(profile *ZoneSettingsProfile) reflect.Value {
+	return reflect.ValueOf(profile).Elem().Field(profileFields[setting.name])
+}
+
+var profileSettings = []profileSetting{
+	{
+		name: "dnssec",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetZoneDnssecWithContext(ctx, zonesSettings.NewGetZoneDnssecOptions())
+			if err == nil && result.Result != nil {
+				profile.ZoneDnssec = result.Result.Status
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateZoneDnssecWithContext(ctx, zonesSettings.NewUpdateZoneDnssecOptions().SetStatus(*profile.ZoneDnssec))
+			return err
+		},
+	},
+	{
+		name: "cname_flattening",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetZoneCnameFlatteningWithContext(ctx, zonesSettings.NewGetZoneCnameFlatteningOptions())
+			if err == nil && result.Result != nil {
+				profile.ZoneCnameFlattening = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateZoneCnameFlatteningWithContext(ctx, zonesSettings.NewUpdateZoneCnameFlatteningOptions().SetValue(*profile.ZoneCnameFlattening))
+			return err
+		},
+	},
+	{
+		name: "opportunistic_encryption",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetOpportunisticEncryptionWithContext(ctx, zonesSettings.NewGetOpportunisticEncryptionOptions())
+			if err == nil && result.Result != nil {
+				profile.OpportunisticEncryption = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateOpportunisticEncryptionWithContext(ctx, zonesSettings.NewUpdateOpportunisticEncryptionOptions().SetValue(*profile.OpportunisticEncryption))
+			return err
+		},
+	},
+	{
+		name: "opportunistic_onion",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetOpportunisticOnionWithContext(ctx, zonesSettings.NewGetOpportunisticOnionOptions())
+			if err == nil && result.Result != nil {
+				profile.OpportunisticOnion = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateOpportunisticOnionWithContext(ctx, zonesSettings.NewUpdateOpportunisticOnionOptions().SetValue(*profile.OpportunisticOnion))
+			return err
+		},
+	},
+	{
+		name: "automatic_https_rewrites",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetAutomaticHttpsRewritesWithContext(ctx, zonesSettings.NewGetAutomaticHttpsRewritesOptions())
+			if err == nil && result.Result != nil {
+				profile.AutomaticHttpsRewrites = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateAutomaticHttpsRewritesWithContext(ctx, zonesSettings.NewUpdateAutomaticHttpsRewritesOptions().SetValue(*profile.AutomaticHttpsRewrites))
+			return err
+		},
+	},
+	{
+		name: "true_client_ip_header",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetTrueClientIpWithContext(ctx, zonesSettings.NewGetTrueClientIpOptions())
+			if err == nil && result.Result != nil {
+				profile.TrueClientIp = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateTrueClientIpWithContext(ctx, zonesSettings.NewUpdateTrueClientIpOptions().SetValue(*profile.TrueClientIp))
+			return err
+		},
+	},
+	{
+		name: "always_use_https",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetAlwaysUseHttpsWithContext(ctx, zonesSettings.NewGetAlwaysUseHttpsOptions())
+			if err == nil && result.Result != nil {
+				profile.AlwaysUseHttps = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateAlwaysUseHttpsWithContext(ctx, zonesSettings.NewUpdateAlwaysUseHttpsOptions().SetValue(*profile.AlwaysUseHttps))
+			return err
+		},
+	},
+	{
+		name: "image_size_optimization",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetImageSizeOptimizationWithContext(ctx, zonesSettings.NewGetImageSizeOptimizationOptions())
+			if err == nil && result.Result != nil {
+				profile.ImageSizeOptimization = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateImageSizeOptimizationWithContext(ctx, zonesSettings.NewUpdateImageSizeOptimizationOptions().SetValue(*profile.ImageSizeOptimization))
+			return err
+		},
+	},
+	{
+		name: "script_load_optimization",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetScriptLoadOptimizationWithContext(ctx, zonesSettings.NewGetScriptLoadOptimizationOptions())
+			if err == nil && result.Result != nil {
+				profile.ScriptLoadOptimization = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateScriptLoadOptimizationWithContext(ctx, zonesSettings.NewUpdateScriptLoadOptimizationOptions().SetValue(*profile.ScriptLoadOptimization))
+			return err
+		},
+	},
+	{
+		name: "image_load_optimization",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetImageLoadOptimizationWithContext(ctx, zonesSettings.NewGetImageLoadOptimizationOptions())
+			if err == nil && result.Result != nil {
+				profile.ImageLoadOptimization = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateImageLoadOptimizationWithContext(ctx, zonesSettings.NewUpdateImageLoadOptimizationOptions().SetValue(*profile.ImageLoadOptimization))
+			return err
+		},
+	},
+	{
+		name: "min_tls_version",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetMinTlsVersionWithContext(ctx, zonesSettings.NewGetMinTlsVersionOptions())
+			if err == nil && result.Result != nil {
+				profile.MinTlsVersion = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateMinTlsVersionWithContext(ctx, zonesSettings.NewUpdateMinTlsVersionOptions().SetValue(*profile.MinTlsVersion))
+			return err
+		},
+	},
+	{
+		name: "ip_geolocation",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetIpGeolocationWithContext(ctx, zonesSettings.NewGetIpGeolocationOptions())
+			if err == nil && result.Result != nil {
+				profile.IpGeolocation = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateIpGeolocationWithContext(ctx, zonesSettings.NewUpdateIpGeolocationOptions().SetValue(*profile.IpGeolocation))
+			return err
+		},
+	},
+	{
+		name: "server_side_exclude",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetServerSideExcludeWithContext(ctx, zonesSettings.NewGetServerSideExcludeOptions())
+			if err == nil && result.Result != nil {
+				profile.ServerSideExclude = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateServerSideExcludeWithContext(ctx, zonesSettings.NewUpdateServerSideExcludeOptions().SetValue(*profile.ServerSideExclude))
+			return err
+		},
+	},
+	{
+		name: "prefetch_preload",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetPrefetchPreloadWithContext(ctx, zonesSettings.NewGetPrefetchPreloadOptions())
+			if err == nil && result.Result != nil {
+				profile.PrefetchPreload = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdatePrefetchPreloadWithContext(ctx, zonesSettings.NewUpdatePrefetchPreloadOptions().SetValue(*profile.PrefetchPreload))
+			return err
+		},
+	},
+	{
+		name: "http2",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetHttp2WithContext(ctx, zonesSettings.NewGetHttp2Options())
+			if err == nil && result.Result != nil {
+				profile.Http2 = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateHttp2WithContext(ctx, zonesSettings.NewUpdateHttp2Options().SetValue(*profile.Http2))
+			return err
+		},
+	},
+	{
+		name: "http3",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetHttp3WithContext(ctx, zonesSettings.NewGetHttp3Options())
+			if err == nil && result.Result != nil {
+				profile.Http3 = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateHttp3WithContext(ctx, zonesSettings.NewUpdateHttp3Options().SetValue(*profile.Http3))
+			return err
+		},
+	},
+	{
+		name: "ipv6",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetIpv6WithContext(ctx, zonesSettings.NewGetIpv6Options())
+			if err == nil && result.Result != nil {
+				profile.Ipv6 = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateIpv6WithContext(ctx, zonesSettings.NewUpdateIpv6Options().SetValue(*profile.Ipv6))
+			return err
+		},
+	},
+	{
+		name: "pseudo_ipv4",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetPseudoIpv4WithContext(ctx, zonesSettings.NewGetPseudoIpv4Options())
+			if err == nil && result.Result != nil {
+				profile.PseudoIpv4 = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdatePseudoIpv4WithContext(ctx, zonesSettings.NewUpdatePseudoIpv4Options().SetValue(*profile.PseudoIpv4))
+			return err
+		},
+	},
+	{
+		name: "websockets",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetWebSocketsWithContext(ctx, zonesSettings.NewGetWebSocketsOptions())
+			if err == nil && result.Result != nil {
+				profile.WebSockets = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateWebSocketsWithContext(ctx, zonesSettings.NewUpdateWebSocketsOptions().SetValue(*profile.WebSockets))
+			return err
+		},
+	},
+	{
+		name: "response_buffering",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetResponseBufferingWithContext(ctx, zonesSettings.NewGetResponseBufferingOptions())
+			if err == nil && result.Result != nil {
+				profile.ResponseBuffering = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateResponseBufferingWithContext(ctx, zonesSettings.NewUpdateResponseBufferingOptions().SetValue(*profile.ResponseBuffering))
+			return err
+		},
+	},
+	{
+		name: "hotlink_protection",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetHotlinkProtectionWithContext(ctx, zonesSettings.NewGetHotlinkProtectionOptions())
+			if err == nil && result.Result != nil {
+				profile.HotlinkProtection = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateHotlinkProtectionWithContext(ctx, zonesSettings.NewUpdateHotlinkProtectionOptions().SetValue(*profile.HotlinkProtection))
+			return err
+		},
+	},
+	{
+		name: "tls_client_auth",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetTlsClientAuthWithContext(ctx, zonesSettings.NewGetTlsClientAuthOptions())
+			if err == nil && result.Result != nil {
+				profile.TlsClientAuth = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateTlsClientAuthWithContext(ctx, zonesSettings.NewUpdateTlsClientAuthOptions().SetValue(*profile.TlsClientAuth))
+			return err
+		},
+	},
+	{
+		name: "brotli",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetBrotliWithContext(ctx, zonesSettings.NewGetBrotliOptions())
+			if err == nil && result.Result != nil {
+				profile.Brotli = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateBrotliWithContext(ctx, zonesSettings.NewUpdateBrotliOptions().SetValue(*profile.Brotli))
+			return err
+		},
+	},
+	{
+		name: "browser_check",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetBrowserCheckWithContext(ctx, zonesSettings.NewGetBrowserCheckOptions())
+			if err == nil && result.Result != nil {
+				profile.BrowserCheck = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateBrowserCheckWithContext(ctx, zonesSettings.NewUpdateBrowserCheckOptions().SetValue(*profile.BrowserCheck))
+			return err
+		},
+	},
+	{
+		name: "origin_error_page_pass_thru",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetEnableErrorPagesOnWithContext(ctx, zonesSettings.NewGetEnableErrorPagesOnOptions())
+			if err == nil && result.Result != nil {
+				profile.EnableErrorPagesOn = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateEnableErrorPagesOnWithContext(ctx, zonesSettings.NewUpdateEnableErrorPagesOnOptions().SetValue(*profile.EnableErrorPagesOn))
+			return err
+		},
+	},
+	{
+		name: "waf",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetWebApplicationFirewallWithContext(ctx, zonesSettings.NewGetWebApplicationFirewallOptions())
+			if err == nil && result.Result != nil {
+				profile.WebApplicationFirewall = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateWebApplicationFirewallWithContext(ctx, zonesSettings.NewUpdateWebApplicationFirewallOptions().SetValue(*profile.WebApplicationFirewall))
+			return err
+		},
+	},
+	{
+		name: "origin_max_http_version",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetOriginMaxHttpVersionWithContext(ctx, zonesSettings.NewGetOriginMaxHttpVersionOptions())
+			if err == nil && result.Result != nil {
+				profile.OriginMaxHttpVersion = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateOriginMaxHttpVersionWithContext(ctx, zonesSettings.NewUpdateOriginMaxHttpVersionOptions().SetValue(*profile.OriginMaxHttpVersion))
+			return err
+		},
+	},
+	{
+		name: "origin_post_quantum_encryption",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetOriginPostQuantumEncryptionWithContext(ctx, zonesSettings.NewGetOriginPostQuantumEncryptionOptions())
+			if err == nil && result.Result != nil {
+				profile.OriginPostQuantumEncryption = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateOriginPostQuantumEncryptionWithContext(ctx, zonesSettings.NewUpdateOriginPostQuantumEncryptionOptions().SetValue(*profile.OriginPostQuantumEncryption))
+			return err
+		},
+	},
+	{
+		name: "challenge_ttl",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetChallengeTTLWithContext(ctx, zonesSettings.NewGetChallengeTtlOptions())
+			if err == nil && result.Result != nil {
+				profile.ChallengeTTL = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateChallengeTTLWithContext(ctx, zonesSettings.NewUpdateChallengeTtlOptions().SetValue(*profile.ChallengeTTL))
+			return err
+		},
+	},
+	{
+		name: "max_upload",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetMaxUploadWithContext(ctx, zonesSettings.NewGetMaxUploadOptions())
+			if err == nil && result.Result != nil {
+				profile.MaxUpload = result.Result.Value
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateMaxUploadWithContext(ctx, zonesSettings.NewUpdateMaxUploadOptions().SetValue(*profile.MaxUpload))
+			return err
+		},
+	},
+	{
+		name: "proxy_read_timeout",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetProxyReadTimeoutWithContext(ctx, zonesSettings.NewGetProxyReadTimeoutOptions())
+			if err != nil || result.Result == nil || result.Result.Value == nil {
+				return err
+			}
+			// The timeout is read as a string but updated as a number.
+			timeout, err := strconv.ParseFloat(*result.Result.Value, 64)
+			if err != nil {
+				return core.SDKErrorf(err, "", "unmarshal-resp-error", common.GetComponentInfo())
+			}
+			profile.ProxyReadTimeout = &timeout
+			return nil
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateProxyReadTimeoutWithContext(ctx, zonesSettings.NewUpdateProxyReadTimeoutOptions().SetValue(*profile.ProxyReadTimeout))
+			return err
+		},
+	},
+	{
+		name: "ciphers",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetCiphersWithContext(ctx, zonesSettings.NewGetCiphersOptions())
+			if err == nil && result.Result != nil {
+				profile.Ciphers = append([]string{}, result.Result.Value...)
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateCiphersWithContext(ctx, zonesSettings.NewUpdateCiphersOptions().SetValue(profile.Ciphers))
+			return err
+		},
+	},
+	{
+		name: "minify",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetMinifyWithContext(ctx, zonesSettings.NewGetMinifyOptions())
+			if err == nil && result.Result != nil && result.Result.Value != nil {
+				value := result.Result.Value
+				profile.Minify = &MinifySettingValue{Css: value.Css, HTML: value.HTML, Js: value.Js}
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateMinifyWithContext(ctx, zonesSettings.NewUpdateMinifyOptions().SetValue(profile.Minify))
+			return err
+		},
+	},
+	{
+		name: "mobile_redirect",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetMobileRedirectWithContext(ctx, zonesSettings.NewGetMobileRedirectOptions())
+			if err == nil && result.Result != nil && result.Result.Value != nil {
+				value := result.Result.Value
+				profile.MobileRedirect = &MobileRedirecSettingValue{Status: value.Status, MobileSubdomain: value.MobileSubdomain, StripURI: value.StripURI}
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateMobileRedirectWithContext(ctx, zonesSettings.NewUpdateMobileRedirectOptions().SetValue(profile.MobileRedirect))
+			return err
+		},
+	},
+	{
+		name: "security_header",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			result, _, err := zonesSettings.GetSecurityHeaderWithContext(ctx, zonesSettings.NewGetSecurityHeaderOptions())
+			if err == nil && result.Result != nil && result.Result.Value != nil {
+				profile.SecurityHeader = &SecurityHeaderSettingValue{}
+				if hsts := result.Result.Value.StrictTransportSecurity; hsts != nil {
+					profile.SecurityHeader.StrictTransportSecurity = &SecurityHeaderSettingValueStrictTransportSecurity{
+						Enabled:           hsts.Enabled,
+						MaxAge:            hsts.MaxAge,
+						IncludeSubdomains: hsts.IncludeSubdomains,
+						Preload:           hsts.Preload,
+						Nosniff:           hsts.Nosniff,
+					}
+				}
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			_, _, err := zonesSettings.UpdateSecurityHeaderWithContext(ctx, zonesSettings.NewUpdateSecurityHeaderOptions().SetValue(profile.SecurityHeader))
+			return err
+		},
+	},
+	{
+		name: "log_retention",
+		get: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			options := zonesSettings.NewGetLogRetentionOptions(*zonesSettings.Crn, *zonesSettings.ZoneIdentifier)
+			result, _, err := zonesSettings.GetLogRetentionWithContext(ctx, options)
+			if err == nil && result.Result != nil {
+				profile.LogRetention = result.Result.Flag
+			}
+			return err
+		},
+		update: func(ctx context.Context, zonesSettings *ZonesSettingsV1, profile *ZoneSettingsProfile) error {
+			options := zonesSettings.NewUpdateLogRetentionOptions(*zonesSettings.Crn, *zonesSettings.ZoneIdentifier).SetFlag(*profile.LogRetention)
+			_, _, err := zonesSettings.UpdateLogRetentionWithContext(ctx, options)
+			return err
+		},
+	},
+}
+
+// forEachSetting calls f for the settings that the profile sets, or for all the settings when the profile is nil, a
+// few at a time, and returns the errors by setting.
+func forEachSetting(profile *ZoneSettingsProfile, f func(setting *profileSetting) error) (errs map[string]error) {
+	errs = map[string]error{}
+	var lock sync.Mutex
+	var group sync.WaitGroup
+	semaphore := make(chan struct{}, profileConcurrency)
+	for i := range profileSettings {
+		setting := &profileSettings[i]
+		if profile != nil && setting.field(profile).IsNil() {
+			continue
+		}
+		group.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				group.Done()
+			}()
+			if err := f(setting); err != nil {
+				lock.Lock()
+				errs[setting.name] = err
+				lock.Unlock()
+			}
+		}()
+	}
+	group.Wait()
+	return
+}
+
+// settingsError returns an error listing the settings that failed, in profile order, or nil.
+func settingsError(operation string, errs map[string]error) error {
+	var failures []string
+	for _, setting := range profileSettings {
+		if err, ok := errs[setting.name]; ok {
+			failures = append(failures, fmt.Sprintf("%s: %s", setting.name, err.Error()))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("%s %d of the zone settings failed: %s", operation, len(failures), strings.Join(failures, "; "))
+	return core.SDKErrorf(nil, message, "zone-settings-profile-error", common.GetComponentInfo())
+}
+
+// SnapshotProfile : Get all the settings of the zone
+// Read every setting of the zone into a profile, a few settings at a time. When some settings cannot be read, as
+// when the plan of the zone does not include them, the profile holds the others and their fields are nil, and the
+// error lists the settings that failed.
+func (zonesSettings *ZonesSettingsV1) SnapshotProfile(ctx context.Context) (profile *ZoneSettingsProfile, err error) {
+	return zonesSettings.snapshotProfile(ctx, nil)
+}
+
+// snapshotProfile reads the settings that the filter sets, or all of them when the filter is nil.
+func (zonesSettings *ZonesSettingsV1) snapshotProfile(ctx context.Context, filter *ZoneSettingsProfile) (profile *ZoneSettingsProfile, err error) {
+	profile = &ZoneSettingsProfile{}
+	var lock sync.Mutex
+	errs := forEachSetting(filter, func(setting *profileSetting) error {
+		value := &ZoneSettingsProfile{}
+		if err := setting.get(ctx, zonesSettings, value); err != nil {
+			return err
+		}
+		lock.Lock()
+		setting.field(profile).Set(setting.field(value))
+		lock.Unlock()
+		return nil
+	})
+	err = settingsError("reading", errs)
+	return
+}
+
+// Diff returns the settings whose value in the desired profile differs from the profile, in profile order. The
+// settings that the desired profile does not set are ignored.
+func (profile *ZoneSettingsProfile) Diff(desired *ZoneSettingsProfile) (changes []ZoneSettingChange) {
+	for i := range profileSettings {
+		setting := &profileSettings[i]
+		current, wanted := setting.field(profile), setting.field(desired)
+		if wanted.IsNil() || reflect.DeepEqual(current.Interface(), wanted.Interface()) {
+			continue
+		}
+		change := ZoneSettingChange{Setting: setting.name, To: reflect.Indirect(wanted).Interface()}
+		if !current.IsNil() {
+			change.From = reflect.Indirect(current).Interface()
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+// ApplyProfile : Update the zone settings that differ from a profile
+// Read the settings that the profile sets, and update the ones that differ, a few at a time. The changes are
+// returned in profile order, with the error of each update that failed; the returned error lists the settings that
+// could not be read or updated.
+func (zonesSettings *ZonesSettingsV1) ApplyProfile(ctx context.Context, desired *ZoneSettingsProfile) (changes []ZoneSettingChange, err error) {
+	if desired == nil {
+		err = core.SDKErrorf(nil, "desired profile cannot be nil", "missing-profile", common.GetComponentInfo())
+		return
+	}
+	current, err := zonesSettings.snapshotProfile(ctx, desired)
+	if err != nil {
+		return
+	}
+	changes = current.Diff(desired)
+	changed := &ZoneSettingsProfile{}
+	for _, change := range changes {
+		setting := profileSettingNamed(change.Setting)
+		setting.field(changed).Set(setting.field(desired))
+	}
+	errs := forEachSetting(changed, func(setting *profileSetting) error {
+		return setting.update(ctx, zonesSettings, desired)
+	})
+	for i := range changes {
+		changes[i].Err = errs[changes[i].Setting]
+	}
+	err = settingsError("updating", errs)
+	return
+}
+
+func profileSettingNamed(name string) *profileSetting {
+	for i := range profileSettings {
+		if profileSettings[i].name == name {
+			return &profileSettings[i]
+		}
+	}
+	return nil
+}
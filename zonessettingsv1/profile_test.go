@@ -0,0 +1,247 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonessettingsv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/zonessettingsv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSettings keeps the settings of a zone by the last segment of their path. Log retention is updated with a POST,
+// the other settings with a PATCH.
+type fakeSettings struct {
+	sync.Mutex
+	values  map[string]interface{}
+	patches []string
+	missing map[string]bool
+}
+
+func (api *fakeSettings) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	api.Lock()
+	defer api.Unlock()
+
+	name := path.Base(req.URL.Path)
+	res.Header().Set("Content-type", "application/json")
+	if api.missing[name] {
+		res.WriteHeader(403)
+		fmt.Fprint(res, `{"success": false, "errors": [{"code": 1000, "message": "not entitled"}], "messages": []}`)
+		return
+	}
+	if req.Method == "PATCH" || req.Method == "POST" {
+		var body map[string]interface{}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		for key, value := range body {
+			if name == "proxy_read_timeout" {
+				value = fmt.Sprint(value)
+			}
+			api.values[name] = value
+			encoded, _ := json.Marshal(body)
+			api.patches = append(api.patches, fmt.Sprintf("%s %s %s", name, key, encoded))
+		}
+	}
+	result := map[string]interface{}{"id": name, "value": api.values[name], "editable": true, "modified_on": "2025-01-01T00:00:00Z"}
+	switch name {
+	case "dnssec":
+		result = map[string]interface{}{"status": api.values[name]}
+	case "retention":
+		result = map[string]interface{}{"flag": api.values[name]}
+	}
+	json.NewEncoder(res).Encode(map[string]interface{}{"success": true, "errors": []string{}, "messages": []string{}, "result": result})
+}
+
+func newFakeSettings() *fakeSettings {
+	api := &fakeSettings{values: map[string]interface{}{}, missing: map[string]bool{}}
+	for _, name := range []string{
+		"always_use_https", "automatic_https_rewrites", "brotli", "browser_check", "hotlink_protection", "http2", "http3",
+		"image_load_optimization", "ip_geolocation", "ipv6", "opportunistic_encryption", "opportunistic_onion",
+		"origin_error_page_pass_thru", "prefetch_preload", "response_buffering", "script_load_optimization",
+		"server_side_exclude", "tls_client_auth", "true_client_ip_header", "waf", "websockets",
+	} {
+		api.values[name] = "off"
+	}
+	api.values["image_size_optimization"] = "lossless"
+	api.values["pseudo_ipv4"] = "off"
+	api.values["cname_flattening"] = "flatten_at_root"
+	api.values["origin_max_http_version"] = "2"
+	api.values["origin_post_quantum_encryption"] = "supported"
+	api.values["min_tls_version"] = "1.0"
+	api.values["dnssec"] = "disabled"
+	api.values["retention"] = true
+	api.values["challenge_ttl"] = 1800
+	api.values["max_upload"] = 100
+	api.values["proxy_read_timeout"] = "100"
+	api.values["ciphers"] = []string{}
+	api.values["minify"] = map[string]interface{}{"css": "off", "html": "off", "js": "off"}
+	api.values["mobile_redirect"] = map[string]interface{}{"status": "off", "mobile_subdomain": "m", "strip_uri": false}
+	api.values["security_header"] = map[string]interface{}{"strict_transport_security": map[string]interface{}{
+		"enabled": false, "max_age": 0, "include_subdomains": false, "preload": false, "nosniff": false,
+	}}
+	return api
+}
+
+var _ = Describe(`ZoneSettingsProfile`, func() {
+	var (
+		api           *fakeSettings
+		server        *httptest.Server
+		zonesSettings *zonessettingsv1.ZonesSettingsV1
+	)
+
+	BeforeEach(func() {
+		api = newFakeSettings()
+		server = httptest.NewServer(api)
+		var err error
+		zonesSettings, err = zonessettingsv1.NewZonesSettingsV1(&zonessettingsv1.ZonesSettingsV1Options{
+			URL:            server.URL,
+			Authenticator:  &core.NoAuthAuthenticator{},
+			Crn:            core.StringPtr("crn"),
+			ZoneIdentifier: core.StringPtr("zone"),
+		})
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Reads every setting`, func() {
+		profile, err := zonesSettings.SnapshotProfile(context.Background())
+		Expect(err).To(BeNil())
+		Expect(*profile.MinTlsVersion).To(Equal("1.0"))
+		Expect(*profile.ZoneDnssec).To(Equal("disabled"))
+		Expect(*profile.LogRetention).To(BeTrue())
+		Expect(*profile.ChallengeTTL).To(Equal(int64(1800)))
+		Expect(*profile.ProxyReadTimeout).To(Equal(float64(100)))
+		Expect(profile.Ciphers).To(BeEmpty())
+		Expect(profile.Ciphers).ToNot(BeNil())
+		Expect(*profile.Minify.Css).To(Equal("off"))
+		Expect(*profile.MobileRedirect.MobileSubdomain).To(Equal("m"))
+		Expect(*profile.SecurityHeader.StrictTransportSecurity.Enabled).To(BeFalse())
+		Expect(*profile.OriginPostQuantumEncryption).To(Equal("supported"))
+
+		// Every field is set.
+		encoded, err := json.Marshal(profile)
+		Expect(err).To(BeNil())
+		var fields map[string]interface{}
+		Expect(json.Unmarshal(encoded, &fields)).To(Succeed())
+		Expect(fields).To(HaveLen(36))
+	})
+
+	It(`Reports the settings it cannot read`, func() {
+		api.missing["image_size_optimization"] = true
+		api.missing["retention"] = true
+		profile, err := zonesSettings.SnapshotProfile(context.Background())
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("reading 2 of the zone settings failed: image_size_optimization: "))
+		Expect(err.Error()).To(ContainSubstring("; log_retention: "))
+		Expect(profile.ImageSizeOptimization).To(BeNil())
+		Expect(profile.LogRetention).To(BeNil())
+		Expect(*profile.Brotli).To(Equal("off"))
+	})
+
+	It(`Diffs the settings the profile sets`, func() {
+		current, err := zonesSettings.SnapshotProfile(context.Background())
+		Expect(err).To(BeNil())
+		Expect(current.Diff(&zonessettingsv1.ZoneSettingsProfile{})).To(BeEmpty())
+
+		changes := current.Diff(&zonessettingsv1.ZoneSettingsProfile{
+			MinTlsVersion: core.StringPtr("1.2"),
+			Brotli:        core.StringPtr("off"),
+			Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+			Minify:        &zonessettingsv1.MinifySettingValue{Css: core.StringPtr("on"), HTML: core.StringPtr("off"), Js: core.StringPtr("off")},
+		})
+		Expect(changes).To(HaveLen(3))
+		Expect(changes[0].String()).To(Equal(`min_tls_version: "1.0" -> "1.2"`))
+		Expect(changes[1].String()).To(Equal(`ciphers: [] -> ["ECDHE-RSA-AES128-GCM-SHA256"]`))
+		Expect(changes[2].String()).To(Equal(`minify: {"css":"off","html":"off","js":"off"} -> {"css":"on","html":"off","js":"off"}`))
+	})
+
+	It(`Updates the settings that differ only`, func() {
+		desired := &zonessettingsv1.ZoneSettingsProfile{
+			ZoneDnssec:       core.StringPtr("active"),
+			MinTlsVersion:    core.StringPtr("1.2"),
+			Brotli:           core.StringPtr("off"),
+			AlwaysUseHttps:   core.StringPtr("on"),
+			ProxyReadTimeout: core.Float64Ptr(100),
+			MaxUpload:        core.Int64Ptr(200),
+			LogRetention:     core.BoolPtr(false),
+		}
+		changes, err := zonesSettings.ApplyProfile(context.Background(), desired)
+		Expect(err).To(BeNil())
+		Expect(changes).To(HaveLen(5))
+		for _, change := range changes {
+			Expect(change.Err).To(BeNil())
+		}
+		sort.Strings(api.patches)
+		Expect(api.patches).To(Equal([]string{
+			`always_use_https value {"value":"on"}`,
+			`dnssec status {"status":"active"}`,
+			`max_upload value {"value":200}`,
+			`min_tls_version value {"value":"1.2"}`,
+			`retention flag {"flag":false}`,
+		}))
+
+		changes, err = zonesSettings.ApplyProfile(context.Background(), desired)
+		Expect(err).To(BeNil())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It(`Reports the updates that failed`, func() {
+		current, err := zonesSettings.SnapshotProfile(context.Background())
+		Expect(err).To(BeNil())
+		current.Http3 = core.StringPtr("on")
+		current.Ipv6 = core.StringPtr("on")
+		api.missing["http3"] = true
+
+		_, err = zonesSettings.ApplyProfile(context.Background(), current)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("reading 1 of the zone settings failed: http3: "))
+
+		api.Lock()
+		api.values["http3"] = "off"
+		api.Unlock()
+		server.Config.Handler = http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if req.Method == "PATCH" && path.Base(req.URL.Path) == "http3" {
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(500)
+				fmt.Fprint(res, `{"success": false, "errors": [{"code": 1000, "message": "failed"}], "messages": []}`)
+				return
+			}
+			api.ServeHTTP(res, req)
+		})
+		delete(api.missing, "http3")
+		changes, err := zonesSettings.ApplyProfile(context.Background(), current)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("updating 1 of the zone settings failed: http3: "))
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].Setting).To(Equal("http3"))
+		Expect(changes[0].Err).ToNot(BeNil())
+		Expect(changes[1].Setting).To(Equal("ipv6"))
+		Expect(changes[1].Err).To(BeNil())
+	})
+})
@@ -0,0 +1,400 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonesv1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Defaults of the WaitForZoneActivation options.
+const (
+	DefaultZoneActivationTimeout          = 24 * time.Hour
+	DefaultZoneActivationRetryInterval    = time.Minute
+	DefaultZoneActivationMaxRetryInterval = 15 * time.Minute
+)
+
+// Constants associated with the ZoneDetails.Status property.
+const (
+	ZoneDetails_Status_Active  = "active"
+	ZoneDetails_Status_Pending = "pending"
+)
+
+// Constants associated with the ZoneOnboardingEvent.Kind property.
+const (
+	// The zone was created.
+	ZoneOnboardingEvent_Kind_Created = "created"
+
+	// The status of the zone was read.
+	ZoneOnboardingEvent_Kind_Status = "status"
+
+	// The name servers the domain is delegated to were looked up.
+	ZoneOnboardingEvent_Kind_Delegation = "delegation"
+
+	// An activation check was requested.
+	ZoneOnboardingEvent_Kind_ActivationCheck = "activation_check"
+
+	// The zone is active.
+	ZoneOnboardingEvent_Kind_Active = "active"
+)
+
+// NameServerResolver : Looks up the name servers a domain is delegated to. A *net.Resolver is a NameServerResolver.
+type NameServerResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// ZoneOnboarding : A zone and the name servers its domain must be delegated to.
+type ZoneOnboarding struct {
+	// Zone identifier.
+	ZoneID string
+
+	// The domain of the zone.
+	Name string
+
+	// The name servers assigned to the zone, that the registrar of the domain must delegate to.
+	NameServers []string
+
+	// The status of the zone.
+	Status string
+
+	// The type of the zone, one of the CreateZoneOptions_Type constants.
+	Type string
+}
+
+// ZoneOnboardingEvent : A step of the onboarding of a zone.
+type ZoneOnboardingEvent struct {
+	// One of the ZoneOnboardingEvent_Kind constants.
+	Kind string
+
+	// Zone identifier.
+	ZoneID string
+
+	// The activation attempt, from 1, or 0 for the creation of the zone.
+	Attempt int
+
+	// The status of the zone.
+	Status string
+
+	// The name servers the domain is delegated to, for delegation events.
+	Delegated []string
+
+	// The assigned name servers the domain is not delegated to yet, for delegation events.
+	Missing []string
+
+	// The error of the step, if it failed. Failed steps are tried again at the next attempt.
+	Err error
+}
+
+// OnboardZoneOptions : The OnboardZone options.
+type OnboardZoneOptions struct {
+	// The domain of the zone.
+	Name *string `validate:"required,ne="`
+
+	// zone type.
+	Type *string
+
+	// Called with the progress of the onboarding. Optional.
+	Progress func(event ZoneOnboardingEvent)
+}
+
+// NewOnboardZoneOptions : Instantiate OnboardZoneOptions
+func (*ZonesV1) NewOnboardZoneOptions(name string) *OnboardZoneOptions {
+	return &OnboardZoneOptions{
+		Name: core.StringPtr(name),
+	}
+}
+
+// SetType : Allow user to set Type
+func (_options *OnboardZoneOptions) SetType(typeVar string) *OnboardZoneOptions {
+	_options.Type = core.StringPtr(typeVar)
+	return _options
+}
+
+// SetProgress : Allow user to set Progress
+func (_options *OnboardZoneOptions) SetProgress(progress func(event ZoneOnboardingEvent)) *OnboardZoneOptions {
+	_options.Progress = progress
+	return _options
+}
+
+// OnboardZone : Create a zone to onboard
+// Create a zone and return the name servers that the registrar of its domain must delegate to. The zone stays
+// pending until then; WaitForZoneActivation follows it until it is active.
+func (zones *ZonesV1) OnboardZone(onboardZoneOptions *OnboardZoneOptions) (onboarding *ZoneOnboarding, err error) {
+	return zones.OnboardZoneWithContext(context.Background(), onboardZoneOptions)
+}
+
+// OnboardZoneWithContext is an alternate form of the OnboardZone method which supports a Context parameter
+func (zones *ZonesV1) OnboardZoneWithContext(ctx context.Context, onboardZoneOptions *OnboardZoneOptions) (onboarding *ZoneOnboarding, err error) {
+	err = core.ValidateNotNil(onboardZoneOptions, "onboardZoneOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(onboardZoneOptions, "onboardZoneOptions")
+	if err != nil {
+		return
+	}
+
+	createZoneOptions := zones.NewCreateZoneOptions().SetName(*onboardZoneOptions.Name)
+	createZoneOptions.Type = onboardZoneOptions.Type
+	result, _, err := zones.CreateZoneWithContext(ctx, createZoneOptions)
+	if err != nil {
+		return
+	}
+	if result.Result == nil || result.Result.ID == nil {
+		err = fmt.Errorf("the created zone %s has no identifier", *onboardZoneOptions.Name)
+		return
+	}
+	onboarding = newZoneOnboarding(result.Result)
+	reportOnboarding(onboardZoneOptions.Progress, ZoneOnboardingEvent{Kind: ZoneOnboardingEvent_Kind_Created, ZoneID: onboarding.ZoneID, Status: onboarding.Status})
+	return
+}
+
+// WaitForZoneActivationOptions : The WaitForZoneActivation options.
+type WaitForZoneActivationOptions struct {
+	// Zone identifier.
+	ZoneIdentifier *string `validate:"required,ne="`
+
+	// Looks up the name servers of the domain. Defaults to net.DefaultResolver.
+	Resolver NameServerResolver
+
+	// How long to wait for the zone to be active. Defaults to DefaultZoneActivationTimeout.
+	Timeout time.Duration
+
+	// The wait after the first attempt, doubled after each attempt up to MaxRetryInterval. Defaults to
+	// DefaultZoneActivationRetryInterval and DefaultZoneActivationMaxRetryInterval.
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+
+	// Called with the progress of the onboarding. Optional.
+	Progress func(event ZoneOnboardingEvent)
+}
+
+// NewWaitForZoneActivationOptions : Instantiate WaitForZoneActivationOptions
+func (*ZonesV1) NewWaitForZoneActivationOptions(zoneIdentifier string) *WaitForZoneActivationOptions {
+	return &WaitForZoneActivationOptions{
+		ZoneIdentifier: core.StringPtr(zoneIdentifier),
+	}
+}
+
+// SetResolver : Allow user to set Resolver
+func (_options *WaitForZoneActivationOptions) SetResolver(resolver NameServerResolver) *WaitForZoneActivationOptions {
+	_options.Resolver = resolver
+	return _options
+}
+
+// SetTimeout : Allow user to set Timeout
+func (_options *WaitForZoneActivationOptions) SetTimeout(timeout time.Duration) *WaitForZoneActivationOptions {
+	_options.Timeout = timeout
+	return _options
+}
+
+// SetRetryInterval : Allow user to set RetryInterval and MaxRetryInterval
+func (_options *WaitForZoneActivationOptions) SetRetryInterval(retryInterval time.Duration, maxRetryInterval time.Duration) *WaitForZoneActivationOptions {
+	_options.RetryInterval = retryInterval
+	_options.MaxRetryInterval = maxRetryInterval
+	return _options
+}
+
+// SetProgress : Allow user to set Progress
+func (_options *WaitForZoneActivationOptions) SetProgress(progress func(event ZoneOnboardingEvent)) *WaitForZoneActivationOptions {
+	_options.Progress = progress
+	return _options
+}
+
+// WaitForZoneActivation : Wait for a zone to be active
+// At each attempt, read the status of the zone and, while it is pending, look up the name servers its domain is
+// delegated to. Once the domain is delegated to all the name servers of the zone, request an activation check. A
+// partial zone is not delegated, so an activation check is requested at each attempt without looking up its name
+// servers.
+// Attempts are spaced with an exponential backoff until the zone is active or the timeout passes. Failed steps are
+// reported as progress events and tried again at the next attempt.
+func (zones *ZonesV1) WaitForZoneActivation(waitForZoneActivationOptions *WaitForZoneActivationOptions) (onboarding *ZoneOnboarding, err error) {
+	return zones.WaitForZoneActivationWithContext(context.Background(), waitForZoneActivationOptions)
+}
+
+// WaitForZoneActivationWithContext is an alternate form of the WaitForZoneActivation method which supports a Context parameter
+func (zones *ZonesV1) WaitForZoneActivationWithContext(ctx context.Context, waitForZoneActivationOptions *WaitForZoneActivationOptions) (onboarding *ZoneOnboarding, err error) {
+	err = core.ValidateNotNil(waitForZoneActivationOptions, "waitForZoneActivationOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(waitForZoneActivationOptions, "waitForZoneActivationOptions")
+	if err != nil {
+		return
+	}
+	options := *waitForZoneActivationOptions
+	if options.Timeout < 0 || options.RetryInterval < 0 || options.MaxRetryInterval < 0 {
+		err = fmt.Errorf("the timeout and retry intervals cannot be negative")
+		return
+	}
+	if options.Resolver == nil {
+		options.Resolver = net.DefaultResolver
+	}
+	if options.Timeout == 0 {
+		options.Timeout = DefaultZoneActivationTimeout
+	}
+	if options.RetryInterval == 0 {
+		options.RetryInterval = DefaultZoneActivationRetryInterval
+	}
+	if options.MaxRetryInterval == 0 {
+		options.MaxRetryInterval = DefaultZoneActivationMaxRetryInterval
+	}
+
+	deadline := time.Now().Add(options.Timeout)
+	interval := options.RetryInterval
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		var active bool
+		onboarding, active, lastErr = zones.checkZoneActivation(ctx, &options, attempt, onboarding)
+		if active {
+			return
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			status := "pending"
+			if onboarding != nil {
+				status = onboarding.Status
+			}
+			if lastErr != nil {
+				err = fmt.Errorf("the zone %s is still %s after %s, the last error: %w", *options.ZoneIdentifier, status, options.Timeout, lastErr)
+			} else {
+				err = fmt.Errorf("the zone %s is still %s after %s", *options.ZoneIdentifier, status, options.Timeout)
+			}
+			return
+		}
+		wait := interval
+		if wait > remaining {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-time.After(wait):
+		}
+		interval *= 2
+		if interval > options.MaxRetryInterval {
+			interval = options.MaxRetryInterval
+		}
+	}
+}
+
+// checkZoneActivation makes an activation attempt, and returns the zone as last read and whether it is active.
+func (zones *ZonesV1) checkZoneActivation(ctx context.Context, options *WaitForZoneActivationOptions, attempt int, previous *ZoneOnboarding) (onboarding *ZoneOnboarding, active bool, err error) {
+	onboarding = previous
+	zoneID := *options.ZoneIdentifier
+	result, _, err := zones.GetZoneWithContext(ctx, zones.NewGetZoneOptions(zoneID))
+	if err == nil && (result.Result == nil || result.Result.Name == nil) {
+		err = fmt.Errorf("the zone %s has no name", zoneID)
+	}
+	if err != nil {
+		reportOnboarding(options.Progress, ZoneOnboardingEvent{Kind: ZoneOnboardingEvent_Kind_Status, ZoneID: zoneID, Attempt: attempt, Err: err})
+		return
+	}
+	onboarding = newZoneOnboarding(result.Result)
+	if onboarding.Status == ZoneDetails_Status_Active {
+		reportOnboarding(options.Progress, ZoneOnboardingEvent{Kind: ZoneOnboardingEvent_Kind_Active, ZoneID: zoneID, Attempt: attempt, Status: onboarding.Status})
+		active = true
+		return
+	}
+	reportOnboarding(options.Progress, ZoneOnboardingEvent{Kind: ZoneOnboardingEvent_Kind_Status, ZoneID: zoneID, Attempt: attempt, Status: onboarding.Status})
+	if onboarding.Type != CreateZoneOptions_Type_Partial {
+		var delegated bool
+		delegated, err = zones.checkZoneDelegation(ctx, options, attempt, onboarding)
+		if !delegated {
+			return
+		}
+	}
+
+	_, _, err = zones.ZoneActivationCheckWithContext(ctx, zones.NewZoneActivationCheckOptions(zoneID))
+	reportOnboarding(options.Progress, ZoneOnboardingEvent{Kind: ZoneOnboardingEvent_Kind_ActivationCheck, ZoneID: zoneID, Attempt: attempt, Status: onboarding.Status, Err: err})
+	return
+}
+
+// checkZoneDelegation looks up the name servers the domain of a full zone is delegated to, and returns whether it is
+// delegated to all the name servers of the zone.
+func (zones *ZonesV1) checkZoneDelegation(ctx context.Context, options *WaitForZoneActivationOptions, attempt int, onboarding *ZoneOnboarding) (delegated bool, err error) {
+	zoneID := onboarding.ZoneID
+	records, err := options.Resolver.LookupNS(ctx, onboarding.Name)
+	var nameServers []string
+	for _, record := range records {
+		nameServers = append(nameServers, normalizeNameServer(record.Host))
+	}
+	sort.Strings(nameServers)
+	missing := missingNameServers(onboarding.NameServers, nameServers)
+	reportOnboarding(options.Progress, ZoneOnboardingEvent{Kind: ZoneOnboardingEvent_Kind_Delegation, ZoneID: zoneID, Attempt: attempt, Status: onboarding.Status,
+		Delegated: nameServers, Missing: missing, Err: err})
+	if err != nil {
+		err = fmt.Errorf("looking up the name servers of %s: %w", onboarding.Name, err)
+		return
+	}
+	delegated = len(missing) == 0 && len(onboarding.NameServers) > 0
+	return
+}
+
+// MissingNameServers returns the name servers of the zone that the domain is not delegated to, given the name
+// servers found for the domain. Names are compared without case or trailing dot.
+func (onboarding *ZoneOnboarding) MissingNameServers(delegated []string) []string {
+	return missingNameServers(onboarding.NameServers, delegated)
+}
+
+func missingNameServers(required []string, delegated []string) (missing []string) {
+	found := map[string]bool{}
+	for _, nameServer := range delegated {
+		found[normalizeNameServer(nameServer)] = true
+	}
+	for _, nameServer := range required {
+		if !found[normalizeNameServer(nameServer)] {
+			missing = append(missing, nameServer)
+		}
+	}
+	return
+}
+
+func normalizeNameServer(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
+
+func newZoneOnboarding(zone *ZoneDetails) *ZoneOnboarding {
+	onboarding := &ZoneOnboarding{NameServers: zone.NameServers}
+	if zone.ID != nil {
+		onboarding.ZoneID = *zone.ID
+	}
+	if zone.Name != nil {
+		onboarding.Name = *zone.Name
+	}
+	if zone.Status != nil {
+		onboarding.Status = *zone.Status
+	}
+	if zone.Type != nil {
+		onboarding.Type = *zone.Type
+	}
+	return onboarding
+}
+
+func reportOnboarding(progress func(event ZoneOnboardingEvent), event ZoneOnboardingEvent) {
+	if progress != nil {
+		progress(event)
+	}
+}
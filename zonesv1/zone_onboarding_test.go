@@ -0,0 +1,193 @@
+/**
+ * (C) Copyright IBM Corp. 2025.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zonesv1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/zonesv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeZones serves one zone of zoneType, which becomes active once activationChecks checks were requested.
+type fakeZones struct {
+	sync.Mutex
+	status           string
+	zoneType         string
+	checks           int
+	activationChecks int
+}
+
+func (api *fakeZones) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	api.Lock()
+	defer api.Unlock()
+
+	res.Header().Set("Content-type", "application/json")
+	var result interface{}
+	switch req.Method + " " + req.URL.Path {
+	case "POST /v1/crn/zones", "GET /v1/crn/zones/zone-1":
+		zone := map[string]interface{}{"id": "zone-1", "name": "example.com", "status": api.status, "type": api.zoneType}
+		if api.zoneType == "full" {
+			zone["name_servers"] = []string{"ns1.cis.example.net", "ns2.cis.example.net"}
+		}
+		result = zone
+	case "PUT /v1/crn/zones/zone-1/activation_check":
+		api.checks++
+		if api.checks >= api.activationChecks {
+			api.status = "active"
+		}
+		result = map[string]interface{}{"id": "zone-1"}
+	default:
+		res.WriteHeader(404)
+		return
+	}
+	body, _ := json.Marshal(map[string]interface{}{"success": true, "errors": [][]string{}, "messages": [][]string{}, "result": result})
+	res.Write(body)
+}
+
+// fakeResolver answers its name server sets in turn, repeating the last one, or err.
+type fakeResolver struct {
+	answers [][]string
+	err     error
+}
+
+func (resolver *fakeResolver) LookupNS(ctx context.Context, name string) (records []*net.NS, err error) {
+	if resolver.err != nil {
+		return nil, resolver.err
+	}
+	hosts := resolver.answers[0]
+	if len(resolver.answers) > 1 {
+		resolver.answers = resolver.answers[1:]
+	}
+	for _, host := range hosts {
+		records = append(records, &net.NS{Host: host})
+	}
+	return
+}
+
+var _ = Describe(`ZoneOnboarding`, func() {
+	var (
+		api      *fakeZones
+		server   *httptest.Server
+		service  *zonesv1.ZonesV1
+		resolver *fakeResolver
+		events   []zonesv1.ZoneOnboardingEvent
+	)
+
+	progress := func(event zonesv1.ZoneOnboardingEvent) {
+		events = append(events, event)
+	}
+	kinds := func() (kinds []string) {
+		for _, event := range events {
+			kinds = append(kinds, fmt.Sprintf("%d:%s", event.Attempt, event.Kind))
+		}
+		return
+	}
+
+	BeforeEach(func() {
+		api = &fakeZones{status: "pending", zoneType: "full", activationChecks: 1}
+		server = httptest.NewServer(api)
+		var err error
+		service, err = zonesv1.NewZonesV1(&zonesv1.ZonesV1Options{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}, Crn: core.StringPtr("crn")})
+		Expect(err).To(BeNil())
+		resolver = &fakeResolver{}
+		events = nil
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Creates the zone and returns its name servers`, func() {
+		onboarding, err := service.OnboardZone(service.NewOnboardZoneOptions("example.com").SetType(zonesv1.CreateZoneOptions_Type_Full).SetProgress(progress))
+		Expect(err).To(BeNil())
+		Expect(onboarding).To(Equal(&zonesv1.ZoneOnboarding{ZoneID: "zone-1", Name: "example.com", Status: "pending", Type: "full",
+			NameServers: []string{"ns1.cis.example.net", "ns2.cis.example.net"}}))
+		Expect(kinds()).To(Equal([]string{"0:created"}))
+		Expect(onboarding.MissingNameServers([]string{"NS2.CIS.example.net.", "ns1.registrar.example"})).To(Equal([]string{"ns1.cis.example.net"}))
+
+		_, err = service.OnboardZone(service.NewOnboardZoneOptions(""))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It(`Requests activation checks once the domain is delegated`, func() {
+		api.activationChecks = 2
+		resolver.answers = [][]string{
+			{"ns1.registrar.example.", "ns2.registrar.example."},
+			{"NS1.cis.example.net.", "ns2.cis.example.net."},
+		}
+		options := service.NewWaitForZoneActivationOptions("zone-1").SetResolver(resolver).SetProgress(progress).
+			SetRetryInterval(time.Millisecond, 2*time.Millisecond)
+		onboarding, err := service.WaitForZoneActivation(options)
+		Expect(err).To(BeNil())
+		Expect(onboarding.Status).To(Equal(zonesv1.ZoneDetails_Status_Active))
+		Expect(api.checks).To(Equal(2))
+		Expect(kinds()).To(Equal([]string{
+			"1:status", "1:delegation",
+			"2:status", "2:delegation", "2:activation_check",
+			"3:status", "3:delegation", "3:activation_check",
+			"4:active",
+		}))
+		Expect(events[1].Delegated).To(Equal([]string{"ns1.registrar.example", "ns2.registrar.example"}))
+		Expect(events[1].Missing).To(Equal([]string{"ns1.cis.example.net", "ns2.cis.example.net"}))
+		Expect(events[3].Missing).To(BeEmpty())
+	})
+
+	It(`Requests activation checks for a partial zone without looking up its name servers`, func() {
+		api.zoneType = zonesv1.CreateZoneOptions_Type_Partial
+		api.activationChecks = 2
+		resolver.err = fmt.Errorf("no such host")
+		options := service.NewWaitForZoneActivationOptions("zone-1").SetResolver(resolver).SetProgress(progress).
+			SetRetryInterval(time.Millisecond, 2*time.Millisecond)
+		onboarding, err := service.WaitForZoneActivation(options)
+		Expect(err).To(BeNil())
+		Expect(onboarding.Type).To(Equal(zonesv1.CreateZoneOptions_Type_Partial))
+		Expect(onboarding.Status).To(Equal(zonesv1.ZoneDetails_Status_Active))
+		Expect(api.checks).To(Equal(2))
+		Expect(kinds()).To(Equal([]string{
+			"1:status", "1:activation_check",
+			"2:status", "2:activation_check",
+			"3:active",
+		}))
+	})
+
+	It(`Gives up after the timeout`, func() {
+		resolver.err = fmt.Errorf("no such host")
+		options := service.NewWaitForZoneActivationOptions("zone-1").SetResolver(resolver).SetProgress(progress).
+			SetTimeout(50*time.Millisecond).SetRetryInterval(10*time.Millisecond, 20*time.Millisecond)
+		start := time.Now()
+		_, err := service.WaitForZoneActivation(options)
+		Expect(err).To(MatchError("the zone zone-1 is still pending after 50ms, the last error: looking up the name servers of example.com: no such host"))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(api.checks).To(BeZero())
+		Expect(events[1].Err).To(MatchError("no such host"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = service.WaitForZoneActivationWithContext(ctx, options.SetTimeout(time.Hour))
+		Expect(err).ToNot(BeNil())
+	})
+})